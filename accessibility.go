@@ -0,0 +1,69 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image/color"
+	"time"
+)
+
+// AccessibilityOptions represents the accessibility settings enforced by the
+// built-in widgets and renderers of an Elgato Stream Deck device.
+type AccessibilityOptions struct {
+	// HighContrast replaces the colors used by built-in widgets and
+	// renderers with a high-contrast theme.
+	HighContrast bool
+
+	// MinTextSize is the minimum font size, in pixels, used by built-in text
+	// renderers. If zero, no minimum is enforced.
+	MinTextSize float64
+
+	// AnimationScale is a factor applied to the duration of built-in
+	// animations and fades. Values greater than 1 slow animations down. If
+	// zero, animations run at their normal speed.
+	AnimationScale float64
+}
+
+var (
+	highContrastForeground color.Color = color.White
+	highContrastBackground color.Color = color.Black
+)
+
+func (o AccessibilityOptions) colors(fg color.Color, bg color.Color) (color.Color, color.Color) {
+	if o.HighContrast {
+		return highContrastForeground, highContrastBackground
+	}
+	return fg, bg
+}
+
+func (o AccessibilityOptions) textSize(s float64) float64 {
+	return max(s, o.MinTextSize)
+}
+
+func (o AccessibilityOptions) duration(d time.Duration) time.Duration {
+	if o.AnimationScale <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * o.AnimationScale)
+}
+
+// SetAccessibilityOptions sets the accessibility settings enforced by the
+// built-in widgets and renderers when drawing to the Elgato Stream Deck
+// device. It can be called at runtime, and running widgets pick the new
+// settings up on their next update.
+func (d *Device) SetAccessibilityOptions(opts AccessibilityOptions) {
+	d.mtx.Lock()
+	d.accessibility = opts
+	d.mtx.Unlock()
+}
+
+// GetAccessibilityOptions returns the accessibility settings enforced by the
+// built-in widgets and renderers when drawing to the Elgato Stream Deck
+// device.
+func (d *Device) GetAccessibilityOptions() AccessibilityOptions {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.accessibility
+}
@@ -0,0 +1,348 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package actions implements actions triggered by Elgato Stream Deck inputs,
+// like synthetic keyboard shortcuts and media keys, turning a device into a
+// macro pad.
+//
+// Keyboard events are emulated with uinput on Linux, which requires write
+// access to /dev/uinput, with Quartz event services on macOS, which requires
+// the accessibility permission, and with keybd_event on Windows. On macOS,
+// media keys are emulated as the auxiliary control buttons of the system, and
+// KEY_STOP is not supported.
+//
+// Shortcuts are described as key names joined by "+", like "ctrl+shift+t",
+// "alt+f4" or "playpause". The actions emitted by the config package can be
+// handled with Keyboard.HandleAction, using "key:" prefixed action
+// identifiers, like "key:ctrl+c".
+package actions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the actions package.
+var (
+	ErrKeyboardNotSupported = errors.New("actions: keyboard emulation is not supported on this platform")
+	ErrKeyNotSupported      = errors.New("actions: key is not supported on this platform")
+	ErrShortcutInvalid      = errors.New("actions: shortcut is not valid")
+)
+
+// Key represents a key that can be emulated by a Keyboard.
+type Key byte
+
+// Keys that can be emulated by a Keyboard.
+const (
+	KEY_A Key = iota + 1
+	KEY_B
+	KEY_C
+	KEY_D
+	KEY_E
+	KEY_F
+	KEY_G
+	KEY_H
+	KEY_I
+	KEY_J
+	KEY_K
+	KEY_L
+	KEY_M
+	KEY_N
+	KEY_O
+	KEY_P
+	KEY_Q
+	KEY_R
+	KEY_S
+	KEY_T
+	KEY_U
+	KEY_V
+	KEY_W
+	KEY_X
+	KEY_Y
+	KEY_Z
+	KEY_0
+	KEY_1
+	KEY_2
+	KEY_3
+	KEY_4
+	KEY_5
+	KEY_6
+	KEY_7
+	KEY_8
+	KEY_9
+	KEY_F1
+	KEY_F2
+	KEY_F3
+	KEY_F4
+	KEY_F5
+	KEY_F6
+	KEY_F7
+	KEY_F8
+	KEY_F9
+	KEY_F10
+	KEY_F11
+	KEY_F12
+	KEY_ENTER
+	KEY_ESC
+	KEY_TAB
+	KEY_SPACE
+	KEY_BACKSPACE
+	KEY_DELETE
+	KEY_UP
+	KEY_DOWN
+	KEY_LEFT
+	KEY_RIGHT
+	KEY_HOME
+	KEY_END
+	KEY_PAGE_UP
+	KEY_PAGE_DOWN
+	KEY_CTRL
+	KEY_SHIFT
+	KEY_ALT
+	KEY_META
+	KEY_PLAY_PAUSE
+	KEY_NEXT
+	KEY_PREVIOUS
+	KEY_STOP
+	KEY_VOLUME_UP
+	KEY_VOLUME_DOWN
+	KEY_MUTE
+)
+
+var keyNames = map[string]Key{
+	"enter":      KEY_ENTER,
+	"return":     KEY_ENTER,
+	"esc":        KEY_ESC,
+	"escape":     KEY_ESC,
+	"tab":        KEY_TAB,
+	"space":      KEY_SPACE,
+	"backspace":  KEY_BACKSPACE,
+	"delete":     KEY_DELETE,
+	"del":        KEY_DELETE,
+	"up":         KEY_UP,
+	"down":       KEY_DOWN,
+	"left":       KEY_LEFT,
+	"right":      KEY_RIGHT,
+	"home":       KEY_HOME,
+	"end":        KEY_END,
+	"pageup":     KEY_PAGE_UP,
+	"pagedown":   KEY_PAGE_DOWN,
+	"ctrl":       KEY_CTRL,
+	"control":    KEY_CTRL,
+	"shift":      KEY_SHIFT,
+	"alt":        KEY_ALT,
+	"option":     KEY_ALT,
+	"meta":       KEY_META,
+	"super":      KEY_META,
+	"win":        KEY_META,
+	"cmd":        KEY_META,
+	"command":    KEY_META,
+	"playpause":  KEY_PLAY_PAUSE,
+	"next":       KEY_NEXT,
+	"previous":   KEY_PREVIOUS,
+	"prev":       KEY_PREVIOUS,
+	"stop":       KEY_STOP,
+	"volumeup":   KEY_VOLUME_UP,
+	"volumedown": KEY_VOLUME_DOWN,
+	"mute":       KEY_MUTE,
+}
+
+func init() {
+	for i := range 26 {
+		keyNames[string(rune('a'+i))] = KEY_A + Key(i)
+	}
+	for i := range 10 {
+		keyNames[string(rune('0'+i))] = KEY_0 + Key(i)
+	}
+	for i := range 12 {
+		keyNames[fmt.Sprintf("f%d", i+1)] = KEY_F1 + Key(i)
+	}
+}
+
+// String returns a string representation of the Key.
+func (k Key) String() string {
+	switch {
+	case k >= KEY_A && k <= KEY_Z:
+		return fmt.Sprintf("KEY_%c", 'A'+rune(k-KEY_A))
+	case k >= KEY_0 && k <= KEY_9:
+		return fmt.Sprintf("KEY_%c", '0'+rune(k-KEY_0))
+	case k >= KEY_F1 && k <= KEY_F12:
+		return fmt.Sprintf("KEY_F%d", k-KEY_F1+1)
+	}
+
+	switch k {
+	case KEY_ENTER:
+		return "KEY_ENTER"
+	case KEY_ESC:
+		return "KEY_ESC"
+	case KEY_TAB:
+		return "KEY_TAB"
+	case KEY_SPACE:
+		return "KEY_SPACE"
+	case KEY_BACKSPACE:
+		return "KEY_BACKSPACE"
+	case KEY_DELETE:
+		return "KEY_DELETE"
+	case KEY_UP:
+		return "KEY_UP"
+	case KEY_DOWN:
+		return "KEY_DOWN"
+	case KEY_LEFT:
+		return "KEY_LEFT"
+	case KEY_RIGHT:
+		return "KEY_RIGHT"
+	case KEY_HOME:
+		return "KEY_HOME"
+	case KEY_END:
+		return "KEY_END"
+	case KEY_PAGE_UP:
+		return "KEY_PAGE_UP"
+	case KEY_PAGE_DOWN:
+		return "KEY_PAGE_DOWN"
+	case KEY_CTRL:
+		return "KEY_CTRL"
+	case KEY_SHIFT:
+		return "KEY_SHIFT"
+	case KEY_ALT:
+		return "KEY_ALT"
+	case KEY_META:
+		return "KEY_META"
+	case KEY_PLAY_PAUSE:
+		return "KEY_PLAY_PAUSE"
+	case KEY_NEXT:
+		return "KEY_NEXT"
+	case KEY_PREVIOUS:
+		return "KEY_PREVIOUS"
+	case KEY_STOP:
+		return "KEY_STOP"
+	case KEY_VOLUME_UP:
+		return "KEY_VOLUME_UP"
+	case KEY_VOLUME_DOWN:
+		return "KEY_VOLUME_DOWN"
+	case KEY_MUTE:
+		return "KEY_MUTE"
+	default:
+		return ""
+	}
+}
+
+// ParseShortcut parses a shortcut described as key names joined by "+", like
+// "ctrl+shift+t". Key names are case insensitive.
+func ParseShortcut(s string) ([]Key, error) {
+	rv := []Key{}
+	for _, name := range strings.Split(s, "+") {
+		k, found := keyNames[strings.ToLower(strings.TrimSpace(name))]
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrShortcutInvalid, s)
+		}
+		if slices.Contains(rv, k) {
+			return nil, fmt.Errorf("%w: %q: repeated key: %s", ErrShortcutInvalid, s, k)
+		}
+		rv = append(rv, k)
+	}
+	return rv, nil
+}
+
+type keyboardBackend interface {
+	send(k Key, down bool) error
+	close() error
+}
+
+// Keyboard emulates a keyboard, sending synthetic key events to the
+// operating system.
+type Keyboard struct {
+	mtx     sync.Mutex
+	backend keyboardBackend
+}
+
+// NewKeyboard creates a Keyboard, using the keyboard emulation available on
+// the current platform.
+func NewKeyboard() (*Keyboard, error) {
+	b, err := newKeyboardBackend()
+	if err != nil {
+		return nil, err
+	}
+	return &Keyboard{backend: b}, nil
+}
+
+// Press presses the given keys in order, and releases them in reverse
+// order, like a shortcut typed by hand.
+func (k *Keyboard) Press(keys ...Key) error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	if k.backend == nil {
+		return ErrKeyboardNotSupported
+	}
+
+	pressed := []Key{}
+	var rv error
+	for _, key := range keys {
+		if err := k.backend.send(key, true); err != nil {
+			rv = err
+			break
+		}
+		pressed = append(pressed, key)
+	}
+
+	for _, key := range slices.Backward(pressed) {
+		if err := k.backend.send(key, false); err != nil && rv == nil {
+			rv = err
+		}
+	}
+	return rv
+}
+
+// Shortcut parses a shortcut with ParseShortcut and presses it.
+func (k *Keyboard) Shortcut(s string) error {
+	keys, err := ParseShortcut(s)
+	if err != nil {
+		return err
+	}
+	return k.Press(keys...)
+}
+
+// Bind registers a handler that presses the shortcut whenever the given
+// Elgato Stream Deck key is pressed. The shortcut is validated when bound.
+func (k *Keyboard) Bind(dev *streamdeck.Device, key streamdeck.KeyID, shortcut string) (*streamdeck.HandlerRegistration, error) {
+	keys, err := ParseShortcut(shortcut)
+	if err != nil {
+		return nil, err
+	}
+
+	return dev.AddKeyPressHandler(key, func(d *streamdeck.Device, _ *streamdeck.Key) error {
+		return k.Press(keys...)
+	})
+}
+
+// HandleAction presses the shortcut of an action identifier with the "key:"
+// prefix, like "key:ctrl+c", as emitted by the config package. It returns
+// false for action identifiers without the prefix, so that they can be
+// handled elsewhere.
+func (k *Keyboard) HandleAction(action string) (bool, error) {
+	s, found := strings.CutPrefix(action, "key:")
+	if !found {
+		return false, nil
+	}
+	return true, k.Shortcut(s)
+}
+
+// Close releases the keyboard emulation resources.
+func (k *Keyboard) Close() error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+
+	if k.backend == nil {
+		return nil
+	}
+
+	err := k.backend.close()
+	k.backend = nil
+	return err
+}
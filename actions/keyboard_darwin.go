@@ -0,0 +1,188 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actions
+
+import (
+	"fmt"
+
+	"github.com/ebitengine/purego"
+	"github.com/ebitengine/purego/objc"
+)
+
+const (
+	cgEventFlagMaskShift     = 0x00020000
+	cgEventFlagMaskControl   = 0x00040000
+	cgEventFlagMaskAlternate = 0x00080000
+	cgEventFlagMaskCommand   = 0x00100000
+
+	cgHIDEventTap = 0
+
+	nsEventTypeSystemDefined   = 14
+	nxSubtypeAuxControlButtons = 8
+	nxKeyDown                  = 0x0a
+	nxKeyUp                    = 0x0b
+)
+
+// macos virtual key codes, from HIToolbox/Events.h
+var darwinKeyCodes = map[Key]uint16{
+	KEY_A: 0, KEY_S: 1, KEY_D: 2, KEY_F: 3, KEY_H: 4, KEY_G: 5, KEY_Z: 6,
+	KEY_X: 7, KEY_C: 8, KEY_V: 9, KEY_B: 11, KEY_Q: 12, KEY_W: 13, KEY_E: 14,
+	KEY_R: 15, KEY_Y: 16, KEY_T: 17, KEY_O: 31, KEY_U: 32, KEY_I: 34,
+	KEY_P: 35, KEY_L: 37, KEY_J: 38, KEY_K: 40, KEY_N: 45, KEY_M: 46,
+
+	KEY_1: 18, KEY_2: 19, KEY_3: 20, KEY_4: 21, KEY_5: 23, KEY_6: 22,
+	KEY_7: 26, KEY_8: 28, KEY_9: 25, KEY_0: 29,
+
+	KEY_F1: 122, KEY_F2: 120, KEY_F3: 99, KEY_F4: 118, KEY_F5: 96,
+	KEY_F6: 97, KEY_F7: 98, KEY_F8: 100, KEY_F9: 101, KEY_F10: 109,
+	KEY_F11: 103, KEY_F12: 111,
+
+	KEY_ENTER:     36,
+	KEY_ESC:       53,
+	KEY_TAB:       48,
+	KEY_SPACE:     49,
+	KEY_BACKSPACE: 51,
+	KEY_DELETE:    117,
+	KEY_UP:        126,
+	KEY_DOWN:      125,
+	KEY_LEFT:      123,
+	KEY_RIGHT:     124,
+	KEY_HOME:      115,
+	KEY_END:       119,
+	KEY_PAGE_UP:   116,
+	KEY_PAGE_DOWN: 121,
+
+	KEY_CTRL:  59,
+	KEY_SHIFT: 56,
+	KEY_ALT:   58,
+	KEY_META:  55,
+}
+
+// media keys are not virtual keys, but auxiliary control buttons posted as
+// system defined events, with the key types from IOKit/hidsystem/ev_keymap.h.
+// there is no key type to stop the playback.
+var darwinMediaKeyTypes = map[Key]int{
+	KEY_PLAY_PAUSE:  16, // NX_KEYTYPE_PLAY
+	KEY_NEXT:        17, // NX_KEYTYPE_NEXT
+	KEY_PREVIOUS:    18, // NX_KEYTYPE_PREVIOUS
+	KEY_VOLUME_UP:   0,  // NX_KEYTYPE_SOUND_UP
+	KEY_VOLUME_DOWN: 1,  // NX_KEYTYPE_SOUND_DOWN
+	KEY_MUTE:        7,  // NX_KEYTYPE_MUTE
+}
+
+type nsPoint struct {
+	x float64
+	y float64
+}
+
+var darwinModifierFlags = map[Key]uint64{
+	KEY_CTRL:  cgEventFlagMaskControl,
+	KEY_SHIFT: cgEventFlagMaskShift,
+	KEY_ALT:   cgEventFlagMaskAlternate,
+	KEY_META:  cgEventFlagMaskCommand,
+}
+
+type darwinKeyboard struct {
+	flags uint64
+
+	createKeyboardEvent func(source uintptr, keycode uint16, down bool) uintptr
+	setFlags            func(event uintptr, flags uint64)
+	post                func(tap uint32, event uintptr)
+	release             func(obj uintptr)
+
+	nsEvent           objc.ID
+	nsAutoreleasePool objc.ID
+	selOtherEvent     objc.SEL
+	selCGEvent        objc.SEL
+	selNew            objc.SEL
+	selDrain          objc.SEL
+}
+
+func newKeyboardBackend() (keyboardBackend, error) {
+	cg, err := purego.Dlopen("/System/Library/Frameworks/CoreGraphics.framework/CoreGraphics", purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+	cf, err := purego.Dlopen("/System/Library/Frameworks/CoreFoundation.framework/CoreFoundation", purego.RTLD_NOW|purego.RTLD_GLOBAL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+	if _, err := purego.Dlopen("/System/Library/Frameworks/AppKit.framework/AppKit", purego.RTLD_NOW|purego.RTLD_GLOBAL); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+
+	rv := &darwinKeyboard{
+		nsEvent:           objc.ID(objc.GetClass("NSEvent")),
+		nsAutoreleasePool: objc.ID(objc.GetClass("NSAutoreleasePool")),
+		selOtherEvent:     objc.RegisterName("otherEventWithType:location:modifierFlags:timestamp:windowNumber:context:subtype:data1:data2:"),
+		selCGEvent:        objc.RegisterName("CGEvent"),
+		selNew:            objc.RegisterName("new"),
+		selDrain:          objc.RegisterName("drain"),
+	}
+	purego.RegisterLibFunc(&rv.createKeyboardEvent, cg, "CGEventCreateKeyboardEvent")
+	purego.RegisterLibFunc(&rv.setFlags, cg, "CGEventSetFlags")
+	purego.RegisterLibFunc(&rv.post, cg, "CGEventPost")
+	purego.RegisterLibFunc(&rv.release, cf, "CFRelease")
+	return rv, nil
+}
+
+func (d *darwinKeyboard) send(k Key, down bool) error {
+	if kt, found := darwinMediaKeyTypes[k]; found {
+		return d.sendMediaKey(k, kt, down)
+	}
+
+	code, found := darwinKeyCodes[k]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrKeyNotSupported, k)
+	}
+
+	// synthetic events do not inherit the state of the modifier keys, that
+	// must be set explicitly for each event
+	if flag, found := darwinModifierFlags[k]; found {
+		if down {
+			d.flags |= flag
+		} else {
+			d.flags &^= flag
+		}
+	}
+
+	ev := d.createKeyboardEvent(0, code, down)
+	if ev == 0 {
+		return fmt.Errorf("%w: failed to create event: %s", ErrKeyboardNotSupported, k)
+	}
+	defer d.release(ev)
+
+	d.setFlags(ev, d.flags)
+	d.post(cgHIDEventTap, ev)
+	return nil
+}
+
+func (d *darwinKeyboard) sendMediaKey(k Key, keyType int, down bool) error {
+	// the events are autoreleased, and there is no pool in the goroutine
+	pool := d.nsAutoreleasePool.Send(d.selNew)
+	defer pool.Send(d.selDrain)
+
+	state := nxKeyUp
+	if down {
+		state = nxKeyDown
+	}
+
+	ev := d.nsEvent.Send(d.selOtherEvent, uint(nsEventTypeSystemDefined), nsPoint{}, uint(state<<8), float64(0), 0, objc.ID(0), int16(nxSubtypeAuxControlButtons), keyType<<16|state<<8, -1)
+	if ev == 0 {
+		return fmt.Errorf("%w: failed to create event: %s", ErrKeyboardNotSupported, k)
+	}
+
+	// the event is owned by the NSEvent, and must not be released
+	cgev := objc.Send[uintptr](ev, d.selCGEvent)
+	if cgev == 0 {
+		return fmt.Errorf("%w: failed to create event: %s", ErrKeyboardNotSupported, k)
+	}
+	d.post(cgHIDEventTap, cgev)
+	return nil
+}
+
+func (d *darwinKeyboard) close() error {
+	return nil
+}
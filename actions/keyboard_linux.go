@@ -0,0 +1,175 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	evSyn = 0x00
+	evKey = 0x01
+
+	uiSetEvBit   = 0x40045564
+	uiSetKeyBit  = 0x40045565
+	uiDevCreate  = 0x5501
+	uiDevDestroy = 0x5502
+)
+
+// linux input event codes, from linux/input-event-codes.h
+var linuxKeyCodes = map[Key]uint16{
+	KEY_A: 30, KEY_B: 48, KEY_C: 46, KEY_D: 32, KEY_E: 18, KEY_F: 33,
+	KEY_G: 34, KEY_H: 35, KEY_I: 23, KEY_J: 36, KEY_K: 37, KEY_L: 38,
+	KEY_M: 50, KEY_N: 49, KEY_O: 24, KEY_P: 25, KEY_Q: 16, KEY_R: 19,
+	KEY_S: 31, KEY_T: 20, KEY_U: 22, KEY_V: 47, KEY_W: 17, KEY_X: 45,
+	KEY_Y: 21, KEY_Z: 44,
+
+	KEY_1: 2, KEY_2: 3, KEY_3: 4, KEY_4: 5, KEY_5: 6, KEY_6: 7, KEY_7: 8,
+	KEY_8: 9, KEY_9: 10, KEY_0: 11,
+
+	KEY_F1: 59, KEY_F2: 60, KEY_F3: 61, KEY_F4: 62, KEY_F5: 63, KEY_F6: 64,
+	KEY_F7: 65, KEY_F8: 66, KEY_F9: 67, KEY_F10: 68, KEY_F11: 87, KEY_F12: 88,
+
+	KEY_ENTER:     28,
+	KEY_ESC:       1,
+	KEY_TAB:       15,
+	KEY_SPACE:     57,
+	KEY_BACKSPACE: 14,
+	KEY_DELETE:    111,
+	KEY_UP:        103,
+	KEY_DOWN:      108,
+	KEY_LEFT:      105,
+	KEY_RIGHT:     106,
+	KEY_HOME:      102,
+	KEY_END:       107,
+	KEY_PAGE_UP:   104,
+	KEY_PAGE_DOWN: 109,
+
+	KEY_CTRL:  29,
+	KEY_SHIFT: 42,
+	KEY_ALT:   56,
+	KEY_META:  125,
+
+	KEY_PLAY_PAUSE:  164,
+	KEY_NEXT:        163,
+	KEY_PREVIOUS:    165,
+	KEY_STOP:        166,
+	KEY_VOLUME_UP:   115,
+	KEY_VOLUME_DOWN: 114,
+	KEY_MUTE:        113,
+}
+
+// uinputUserDev mirrors struct uinput_user_dev, from linux/uinput.h.
+type uinputUserDev struct {
+	Name         [80]byte
+	BusType      uint16
+	Vendor       uint16
+	Product      uint16
+	Version      uint16
+	FFEffectsMax uint32
+	AbsMax       [64]int32
+	AbsMin       [64]int32
+	AbsFuzz      [64]int32
+	AbsFlat      [64]int32
+}
+
+type uinputKeyboard struct {
+	fp *os.File
+}
+
+func ioctl(fd uintptr, req uintptr, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func newKeyboardBackend() (keyboardBackend, error) {
+	fp, err := os.OpenFile("/dev/uinput", os.O_WRONLY|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+
+	if err := ioctl(fp.Fd(), uiSetEvBit, evKey); err != nil {
+		fp.Close()
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+	for _, code := range linuxKeyCodes {
+		if err := ioctl(fp.Fd(), uiSetKeyBit, uintptr(code)); err != nil {
+			fp.Close()
+			return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+		}
+	}
+
+	dev := uinputUserDev{
+		BusType: 0x03, // BUS_USB
+		Vendor:  0x0fd9,
+		Product: 0x0001,
+		Version: 1,
+	}
+	copy(dev.Name[:], "streamdeck actions keyboard")
+
+	buf := &bytes.Buffer{}
+	if err := binary.Write(buf, binary.NativeEndian, dev); err != nil {
+		fp.Close()
+		return nil, err
+	}
+	if _, err := fp.Write(buf.Bytes()); err != nil {
+		fp.Close()
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+
+	if err := ioctl(fp.Fd(), uiDevCreate, 0); err != nil {
+		fp.Close()
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+
+	// give the input subsystem some time to pick the device up, otherwise
+	// the first events are lost
+	time.Sleep(100 * time.Millisecond)
+
+	return &uinputKeyboard{fp: fp}, nil
+}
+
+func (u *uinputKeyboard) write(typ uint16, code uint16, value int32) error {
+	// struct input_event starts with a struct timeval, that is ignored for
+	// events written to uinput
+	var tv syscall.Timeval
+	buf := make([]byte, unsafe.Sizeof(tv)+8)
+	off := unsafe.Sizeof(tv)
+	binary.NativeEndian.PutUint16(buf[off:], typ)
+	binary.NativeEndian.PutUint16(buf[off+2:], code)
+	binary.NativeEndian.PutUint32(buf[off+4:], uint32(value))
+
+	_, err := u.fp.Write(buf)
+	return err
+}
+
+func (u *uinputKeyboard) send(k Key, down bool) error {
+	code, found := linuxKeyCodes[k]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrKeyNotSupported, k)
+	}
+
+	value := int32(0)
+	if down {
+		value = 1
+	}
+	if err := u.write(evKey, code, value); err != nil {
+		return err
+	}
+	return u.write(evSyn, 0, 0)
+}
+
+func (u *uinputKeyboard) close() error {
+	ioctl(u.fp.Fd(), uiDevDestroy, 0)
+	return u.fp.Close()
+}
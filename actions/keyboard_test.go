@@ -0,0 +1,194 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+type keyEvent struct {
+	key  Key
+	down bool
+}
+
+type fakeKeyboard struct {
+	mtx    sync.Mutex
+	events []keyEvent
+	fail   Key
+}
+
+func (f *fakeKeyboard) send(k Key, down bool) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if k == f.fail {
+		return ErrKeyNotSupported
+	}
+	f.events = append(f.events, keyEvent{key: k, down: down})
+	return nil
+}
+
+func (f *fakeKeyboard) close() error {
+	return nil
+}
+
+func (f *fakeKeyboard) get() []keyEvent {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return slices.Clone(f.events)
+}
+
+func TestParseShortcut(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want []Key
+	}{
+		{"a", []Key{KEY_A}},
+		{"ctrl+shift+t", []Key{KEY_CTRL, KEY_SHIFT, KEY_T}},
+		{"Alt + F4", []Key{KEY_ALT, KEY_F4}},
+		{"cmd+0", []Key{KEY_META, KEY_0}},
+		{"playpause", []Key{KEY_PLAY_PAUSE}},
+		{"f12", []Key{KEY_F12}},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseShortcut(tt.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unexpected keys: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, s := range []string{"", "ctrl+", "ctrl+bola", "f13", "ctrl+control+c"} {
+		if _, err := ParseShortcut(s); !errors.Is(err, ErrShortcutInvalid) {
+			t.Errorf("%q: unexpected error: %v", s, err)
+		}
+	}
+}
+
+func TestPress(t *testing.T) {
+	f := &fakeKeyboard{}
+	kb := &Keyboard{backend: f}
+
+	if err := kb.Shortcut("ctrl+shift+t"); err != nil {
+		t.Fatal(err)
+	}
+	want := []keyEvent{
+		{KEY_CTRL, true},
+		{KEY_SHIFT, true},
+		{KEY_T, true},
+		{KEY_T, false},
+		{KEY_SHIFT, false},
+		{KEY_CTRL, false},
+	}
+	if got := f.get(); !slices.Equal(got, want) {
+		t.Errorf("unexpected events: got %v, want %v", got, want)
+	}
+
+	// keys already pressed are released when a key fails
+	f.events = nil
+	f.fail = KEY_T
+	if err := kb.Press(KEY_CTRL, KEY_T); !errors.Is(err, ErrKeyNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	want = []keyEvent{
+		{KEY_CTRL, true},
+		{KEY_CTRL, false},
+	}
+	if got := f.get(); !slices.Equal(got, want) {
+		t.Errorf("unexpected events: got %v, want %v", got, want)
+	}
+
+	if err := kb.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.Press(KEY_A); !errors.Is(err, ErrKeyboardNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHandleAction(t *testing.T) {
+	f := &fakeKeyboard{}
+	kb := &Keyboard{backend: f}
+
+	handled, err := kb.HandleAction("key:alt+f4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !handled {
+		t.Error("action not handled")
+	}
+	if got := f.get(); len(got) != 4 || got[0] != (keyEvent{KEY_ALT, true}) {
+		t.Errorf("unexpected events: %v", got)
+	}
+
+	handled, err = kb.HandleAction("page:next")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handled {
+		t.Error("unexpected action handled")
+	}
+
+	if _, err := kb.HandleAction("key:bola"); !errors.Is(err, ErrShortcutInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBind(t *testing.T) {
+	f := &fakeKeyboard{}
+	kb := &Keyboard{backend: f}
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if _, err := kb.Bind(dev, streamdeck.KEY_3, "ctrl+bola"); !errors.Is(err, ErrShortcutInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	reg, err := kb.Bind(dev, streamdeck.KEY_3, "mute")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []keyEvent{
+		{KEY_MUTE, true},
+		{KEY_MUTE, false},
+	}
+	for range 100 {
+		if slices.Equal(f.get(), want) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := f.get(); !slices.Equal(got, want) {
+		t.Errorf("unexpected events: got %v, want %v", got, want)
+	}
+
+	reg.Remove()
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package actions
+
+import (
+	"fmt"
+	"syscall"
+)
+
+const (
+	keyeventfExtendedKey = 0x0001
+	keyeventfKeyUp       = 0x0002
+)
+
+// windows virtual-key codes, from winuser.h
+var windowsKeyCodes = map[Key]byte{
+	KEY_F1: 0x70, KEY_F2: 0x71, KEY_F3: 0x72, KEY_F4: 0x73, KEY_F5: 0x74,
+	KEY_F6: 0x75, KEY_F7: 0x76, KEY_F8: 0x77, KEY_F9: 0x78, KEY_F10: 0x79,
+	KEY_F11: 0x7a, KEY_F12: 0x7b,
+
+	KEY_ENTER:     0x0d,
+	KEY_ESC:       0x1b,
+	KEY_TAB:       0x09,
+	KEY_SPACE:     0x20,
+	KEY_BACKSPACE: 0x08,
+	KEY_DELETE:    0x2e,
+	KEY_UP:        0x26,
+	KEY_DOWN:      0x28,
+	KEY_LEFT:      0x25,
+	KEY_RIGHT:     0x27,
+	KEY_HOME:      0x24,
+	KEY_END:       0x23,
+	KEY_PAGE_UP:   0x21,
+	KEY_PAGE_DOWN: 0x22,
+
+	KEY_CTRL:  0x11,
+	KEY_SHIFT: 0x10,
+	KEY_ALT:   0x12,
+	KEY_META:  0x5b,
+
+	KEY_PLAY_PAUSE:  0xb3,
+	KEY_NEXT:        0xb0,
+	KEY_PREVIOUS:    0xb1,
+	KEY_STOP:        0xb2,
+	KEY_VOLUME_UP:   0xaf,
+	KEY_VOLUME_DOWN: 0xae,
+	KEY_MUTE:        0xad,
+}
+
+func init() {
+	for i := range 26 {
+		windowsKeyCodes[KEY_A+Key(i)] = 0x41 + byte(i)
+	}
+	for i := range 10 {
+		windowsKeyCodes[KEY_0+Key(i)] = 0x30 + byte(i)
+	}
+}
+
+func windowsExtendedKey(k Key) bool {
+	switch k {
+	case KEY_DELETE, KEY_UP, KEY_DOWN, KEY_LEFT, KEY_RIGHT, KEY_HOME, KEY_END,
+		KEY_PAGE_UP, KEY_PAGE_DOWN, KEY_META, KEY_PLAY_PAUSE, KEY_NEXT,
+		KEY_PREVIOUS, KEY_STOP, KEY_VOLUME_UP, KEY_VOLUME_DOWN, KEY_MUTE:
+		return true
+	default:
+		return false
+	}
+}
+
+type windowsKeyboard struct {
+	keybdEvent *syscall.LazyProc
+}
+
+func newKeyboardBackend() (keyboardBackend, error) {
+	proc := syscall.NewLazyDLL("user32.dll").NewProc("keybd_event")
+	if err := proc.Find(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyboardNotSupported, err)
+	}
+	return &windowsKeyboard{keybdEvent: proc}, nil
+}
+
+func (w *windowsKeyboard) send(k Key, down bool) error {
+	vk, found := windowsKeyCodes[k]
+	if !found {
+		return fmt.Errorf("%w: %s", ErrKeyNotSupported, k)
+	}
+
+	flags := uintptr(0)
+	if windowsExtendedKey(k) {
+		flags |= keyeventfExtendedKey
+	}
+	if !down {
+		flags |= keyeventfKeyUp
+	}
+
+	// keybd_event returns no value, and reports no errors
+	w.keybdEvent.Call(uintptr(vk), 0, flags, 0)
+	return nil
+}
+
+func (w *windowsKeyboard) close() error {
+	return nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+// FrameFunc represents a callback function that produces the frames of an
+// animation. It receives the time elapsed since the animation started, and
+// returns the image to draw. If it returns a nil image, nothing is written to
+// the device, and the previous frame is kept on the display.
+type FrameFunc func(elapsed time.Duration) (image.Image, error)
+
+// AnimationError represents an error returned by a FrameFunc, or while
+// writing its frame to the device, including the animation target.
+type AnimationError struct {
+	Target string
+	Err    error
+}
+
+// Error returns a string representation of an animation error.
+func (b AnimationError) Error() string {
+	return fmt.Sprintf("%s [%s]", b.Err, b.Target)
+}
+
+// Unwrap returns the underlying animation error.
+func (b AnimationError) Unwrap() error {
+	return b.Err
+}
+
+type animationTarget struct {
+	surface displaySurface
+	key     KeyID
+	rect    image.Rectangle
+}
+
+func (t animationTarget) String() string {
+	switch t.surface {
+	case displaySurfaceKey:
+		return t.key.String()
+	case displaySurfaceInfoBar:
+		return "INFO_BAR"
+	case displaySurfaceTouchStrip:
+		return "TOUCH_STRIP " + t.rect.String()
+	}
+	return ""
+}
+
+// Animation represents an animation registered to an Animator.
+type Animation struct {
+	animator *Animator
+	target   animationTarget
+	fn       FrameFunc
+	interval time.Duration
+	start    time.Time
+	next     time.Time
+}
+
+// Stop unregisters the animation from its Animator. The current frame is
+// kept on the display.
+func (an *Animation) Stop() {
+	if an == nil || an.animator == nil {
+		return
+	}
+
+	a := an.animator
+	a.mtx.Lock()
+	if a.animations[an.target] == an {
+		delete(a.animations, an.target)
+	}
+	a.mtx.Unlock()
+	a.notify()
+}
+
+// Animator renders animations registered for the displays of an Elgato
+// Stream Deck device from a single render loop, started with Run.
+//
+// Each animation produces frames at its own target frame rate. Frames due at
+// the same time are rendered and written together, and frames that could not
+// be written in time are dropped instead of delayed, so that slow renderers
+// or a saturated USB bus never accumulate latency.
+type Animator struct {
+	device     *Device
+	mtx        sync.Mutex
+	animations map[animationTarget]*Animation
+	wake       chan struct{}
+}
+
+// NewAnimator creates an Animator for the Elgato Stream Deck device.
+func (d *Device) NewAnimator() *Animator {
+	return &Animator{
+		device:     d,
+		animations: map[animationTarget]*Animation{},
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+func (a *Animator) notify() {
+	select {
+	case a.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (a *Animator) add(target animationTarget, fps int, fn FrameFunc) (*Animation, error) {
+	if fps <= 0 {
+		return nil, fmt.Errorf("streamdeck: %w: %d", ErrFrameRateInvalid, fps)
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrAnimationInvalid)
+	}
+
+	now := time.Now()
+	rv := &Animation{
+		animator: a,
+		target:   target,
+		fn:       fn,
+		interval: time.Second / time.Duration(fps),
+		start:    now,
+		next:     now,
+	}
+
+	a.mtx.Lock()
+	a.animations[target] = rv
+	a.mtx.Unlock()
+	a.notify()
+	return rv, nil
+}
+
+// AnimateKey registers an animation for an Elgato Stream Deck key background
+// display, replacing any animation previously registered for the key.
+func (a *Animator) AnimateKey(key KeyID, fps int, fn FrameFunc) (*Animation, error) {
+	if err := a.device.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if err := a.device.validateKeyDisplay(); err != nil {
+		return nil, err
+	}
+
+	return a.add(animationTarget{surface: displaySurfaceKey, key: key}, fps, fn)
+}
+
+// AnimateInfoBar registers an animation for the info bar display available on
+// some Elgato Stream Deck models, replacing any animation previously
+// registered for it.
+func (a *Animator) AnimateInfoBar(fps int, fn FrameFunc) (*Animation, error) {
+	if err := a.device.validateInfoBar(); err != nil {
+		return nil, err
+	}
+
+	return a.add(animationTarget{surface: displaySurfaceInfoBar}, fps, fn)
+}
+
+// AnimateTouchStrip registers an animation for the touch strip display
+// available on some Elgato Stream Deck models, replacing any animation
+// previously registered for the whole touch strip.
+func (a *Animator) AnimateTouchStrip(fps int, fn FrameFunc) (*Animation, error) {
+	return a.AnimateTouchStripWithRectangle(a.device.model.touchStripImageRect, fps, fn)
+}
+
+// AnimateTouchStripWithRectangle registers an animation for a rectangle of
+// the touch strip display available on some Elgato Stream Deck models,
+// replacing any animation previously registered for the same rectangle.
+func (a *Animator) AnimateTouchStripWithRectangle(rect image.Rectangle, fps int, fn FrameFunc) (*Animation, error) {
+	if err := a.device.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	if err := a.device.validateTouchStripRectangle(rect); err != nil {
+		return nil, err
+	}
+
+	return a.add(animationTarget{surface: displaySurfaceTouchStrip, rect: rect}, fps, fn)
+}
+
+func (d *Device) drawTarget(target animationTarget, img image.Image) error {
+	switch target.surface {
+	case displaySurfaceKey:
+		return d.SetKeyImage(target.key, img)
+	case displaySurfaceInfoBar:
+		return d.SetInfoBarImage(img)
+	case displaySurfaceTouchStrip:
+		return d.SetTouchStripImageWithRectangle(img, target.rect)
+	}
+	return nil
+}
+
+// due returns the animations with frames due at the given time, scheduling
+// their next frames, and the time when the next frame is due.
+func (a *Animator) due(now time.Time) ([]*Animation, time.Time) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	rv := []*Animation{}
+	next := time.Time{}
+	for _, an := range a.animations {
+		if !an.next.After(now) {
+			rv = append(rv, an)
+
+			an.next = an.next.Add(an.interval)
+			if !an.next.After(now) {
+				// drop the frames we are late for
+				an.next = now.Add(an.interval)
+			}
+		}
+
+		if next.IsZero() || an.next.Before(next) {
+			next = an.next
+		}
+	}
+	return rv, next
+}
+
+// Run runs the render loop of the Animator, until the context is cancelled
+// or the device is closed.
+//
+// errCh is an error channel to receive errors from the frame functions. If
+// set to a nil channel, errors are sent to the device logger. Errors are sent
+// non-blocking. Errors writing frames to the device stop the render loop and
+// are returned.
+func (a *Animator) Run(ctx context.Context, errCh chan error) error {
+	if err := a.device.validateOpen(); err != nil {
+		return err
+	}
+	done := a.device.done
+
+	for {
+		now := time.Now()
+		anims, next := a.due(now)
+
+		for _, an := range anims {
+			img, err := an.fn(now.Sub(an.start))
+			if err != nil {
+				a.device.sendHandlerError(errCh, AnimationError{
+					Target: an.target.String(),
+					Err:    err,
+				})
+				continue
+			}
+
+			if img == nil {
+				continue
+			}
+
+			if err := a.device.drawTarget(an.target, img); err != nil {
+				return AnimationError{
+					Target: an.target.String(),
+					Err:    err,
+				}
+			}
+		}
+
+		var (
+			timer *time.Timer
+			tc    <-chan time.Time
+		)
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			tc = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-done:
+			return nil
+		case <-a.wake:
+		case <-tc:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
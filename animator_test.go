@@ -0,0 +1,86 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestAnimator(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// identical frames are not written again, so alternate between two
+	frame := []image.Image{testImage(rect), image.NewRGBA(rect)}
+	n := 0
+
+	a := dev.NewAnimator()
+	if _, err := a.AnimateKey(streamdeck.KEY_1, 0, nil); !errors.Is(err, streamdeck.ErrFrameRateInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	frames := make(chan time.Duration, 100)
+	an, err := a.AnimateKey(streamdeck.KEY_1, 50, func(elapsed time.Duration) (image.Image, error) {
+		frames <- elapsed
+		n++
+		return frame[n%2], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.AnimateKey(streamdeck.KEY_2, 50, func(elapsed time.Duration) (image.Image, error) {
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- a.Run(ctx, nil)
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-frames:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for frame")
+		}
+	}
+	an.Stop()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	writes := m.Writes()
+	if len(writes) < 3 {
+		t.Fatalf("not enough frames written: %d", len(writes))
+	}
+	for _, w := range writes {
+		if w.Key != streamdeck.KEY_1 {
+			t.Errorf("unexpected write: %+v", w)
+		}
+	}
+	if len(frames) > 2 {
+		t.Errorf("animation not stopped: %d frames", len(frames))
+	}
+}
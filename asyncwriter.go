@@ -0,0 +1,247 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"slices"
+	"sync"
+)
+
+// AsyncWriteError represents an error writing an image queued to an
+// AsyncWriter, including the write target.
+type AsyncWriteError struct {
+	Target string
+	Err    error
+}
+
+// Error returns a string representation of an asynchronous write error.
+func (b AsyncWriteError) Error() string {
+	return fmt.Sprintf("%s [%s]", b.Err, b.Target)
+}
+
+// Unwrap returns the underlying asynchronous write error.
+func (b AsyncWriteError) Unwrap() error {
+	return b.Err
+}
+
+// AsyncWriter writes images to the displays of an Elgato Stream Deck device
+// from a background goroutine, so that callers do not block for the USB
+// transfers.
+//
+// Writes are queued in order, and a write to a display area with a write
+// already pending replaces the pending image instead of being queued again.
+// The queue is bounded: when it is full, writes block until the background
+// goroutine catches up.
+type AsyncWriter struct {
+	device  *Device
+	errCh   chan error
+	size    int
+	mtx     sync.Mutex
+	cond    *sync.Cond
+	queue   []animationTarget
+	pending map[animationTarget]image.Image
+	writing bool
+	closed  bool
+	err     error
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// NewAsyncWriter creates an AsyncWriter for the Elgato Stream Deck device,
+// with a queue of queueSize display areas. If queueSize is zero, the number
+// of keys plus 2 is used.
+//
+// errCh is an error channel to receive write errors. If set to a nil channel,
+// errors are sent to the device logger. Errors are sent non-blocking, and the
+// first error is also returned by Flush.
+func (d *Device) NewAsyncWriter(queueSize int, errCh chan error) (*AsyncWriter, error) {
+	if err := d.validateOpen(); err != nil {
+		return nil, err
+	}
+
+	if queueSize <= 0 {
+		queueSize = int(d.model.keyCount) + 2
+	}
+
+	rv := &AsyncWriter{
+		device:  d,
+		errCh:   errCh,
+		size:    queueSize,
+		pending: map[animationTarget]image.Image{},
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	rv.cond = sync.NewCond(&rv.mtx)
+
+	go rv.watch(d.done)
+	go rv.run()
+	return rv, nil
+}
+
+func (w *AsyncWriter) watch(done chan struct{}) {
+	select {
+	case <-done:
+	case <-w.stop:
+	}
+
+	w.mtx.Lock()
+	w.closed = true
+	w.cond.Broadcast()
+	w.mtx.Unlock()
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.stopped)
+
+	for {
+		w.mtx.Lock()
+		for len(w.queue) == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if w.closed {
+			w.mtx.Unlock()
+			return
+		}
+
+		target := w.queue[0]
+		w.queue = slices.Delete(w.queue, 0, 1)
+		img := w.pending[target]
+		delete(w.pending, target)
+		w.writing = true
+		w.cond.Broadcast()
+		w.mtx.Unlock()
+
+		err := w.device.drawTarget(target, img)
+
+		w.mtx.Lock()
+		w.writing = false
+		if err != nil {
+			e := AsyncWriteError{
+				Target: target.String(),
+				Err:    err,
+			}
+			if w.err == nil {
+				w.err = e
+			}
+			w.device.sendHandlerError(w.errCh, e)
+		}
+		w.cond.Broadcast()
+		w.mtx.Unlock()
+	}
+}
+
+func (w *AsyncWriter) enqueue(target animationTarget, img image.Image) error {
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for {
+		if w.closed {
+			return wrapErr(ErrDeviceIsClosed)
+		}
+
+		if _, found := w.pending[target]; found {
+			w.pending[target] = img
+			return nil
+		}
+
+		if len(w.queue) < w.size {
+			break
+		}
+		w.cond.Wait()
+	}
+
+	w.queue = append(w.queue, target)
+	w.pending[target] = img
+	w.cond.Broadcast()
+	return nil
+}
+
+// SetKeyImage queues a given image.Image to be drawn to an Elgato Stream
+// Deck key background display. The image is scaled as needed, and must not
+// be modified after being queued.
+func (w *AsyncWriter) SetKeyImage(key KeyID, img image.Image) error {
+	if err := w.device.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := w.device.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	return w.enqueue(animationTarget{surface: displaySurfaceKey, key: key}, img)
+}
+
+// SetInfoBarImage queues a given image.Image to be drawn to the info bar
+// display available on some Elgato Stream Deck models. The image is scaled as
+// needed, and must not be modified after being queued.
+func (w *AsyncWriter) SetInfoBarImage(img image.Image) error {
+	if err := w.device.validateInfoBar(); err != nil {
+		return err
+	}
+
+	return w.enqueue(animationTarget{surface: displaySurfaceInfoBar}, img)
+}
+
+// SetTouchStripImage queues a given image.Image to be drawn to the whole
+// touch strip display available on some Elgato Stream Deck models. The image
+// is scaled as needed, and must not be modified after being queued.
+func (w *AsyncWriter) SetTouchStripImage(img image.Image) error {
+	return w.SetTouchStripImageWithRectangle(img, w.device.model.touchStripImageRect)
+}
+
+// SetTouchStripImageWithRectangle queues a given image.Image to be drawn to a
+// rectangle of the touch strip display available on some Elgato Stream Deck
+// models. The image is scaled as needed, and must not be modified after being
+// queued.
+func (w *AsyncWriter) SetTouchStripImageWithRectangle(img image.Image, rect image.Rectangle) error {
+	if err := w.device.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	if err := w.device.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	return w.enqueue(animationTarget{surface: displaySurfaceTouchStrip, rect: rect}, img)
+}
+
+// Flush blocks until all the queued images are written, and returns the
+// first write error since the previous call to Flush, if any.
+func (w *AsyncWriter) Flush() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for (len(w.queue) > 0 || w.writing) && !w.closed {
+		w.cond.Wait()
+	}
+
+	err := w.err
+	w.err = nil
+	if err == nil && len(w.queue) > 0 {
+		err = wrapErr(ErrDeviceIsClosed)
+	}
+	return err
+}
+
+// Close stops the AsyncWriter. Queued images not written yet are discarded,
+// and should be flushed first if needed.
+func (w *AsyncWriter) Close() error {
+	w.mtx.Lock()
+	select {
+	case <-w.stop:
+	default:
+		close(w.stop)
+	}
+	w.mtx.Unlock()
+
+	<-w.stopped
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestAsyncWriter(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	w, err := dev.NewAsyncWriter(2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 20 {
+		img := image.NewRGBA(rect)
+		draw.Draw(img, rect, image.NewUniform(color.RGBA{R: byte(i), A: 0xff}), image.Point{}, draw.Src)
+		if err := w.SetKeyImage(streamdeck.KEY_1, img); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.SetKeyImage(streamdeck.KeyID(2+i%5), img); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.SetKeyImage(streamdeck.KEY_1, testImage(rect)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(m.Writes()); n == 0 || n > 41 {
+		t.Errorf("bad number of writes: %d", n)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{R: 0xff, B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_6), 2, 2, color.RGBA{R: 19})
+
+	if err := w.SetKeyImage(streamdeck.KEY_1, nil); !errors.Is(err, streamdeck.ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := w.SetInfoBarImage(testImage(rect)); !errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetKeyImage(streamdeck.KEY_1, testImage(rect)); !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+	"slices"
+)
+
+// Tx represents a batch of display updates of an Elgato Stream Deck device,
+// created by Device.Batch. Updates are queued, and only the last update of
+// each display area is written to the device when the batch is committed.
+type Tx struct {
+	device          *Device
+	keys            map[KeyID]image.Image
+	keyOrder        []KeyID
+	infoBar         image.Image
+	touchStrip      map[image.Rectangle]image.Image
+	touchStripOrder []image.Rectangle
+}
+
+// Batch calls fn with a Tx that queues display updates, and writes all of
+// them to the Elgato Stream Deck device together after fn returns. Images are
+// scaled and encoded before any write, so that the displays are updated
+// back-to-back, without visible tearing between keys, and repeated updates
+// to the same key or touch strip area only cost a single write.
+//
+// If fn returns an error, the queued updates are discarded and the error is
+// returned.
+func (d *Device) Batch(fn func(tx *Tx) error) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	tx := &Tx{
+		device:     d,
+		keys:       map[KeyID]image.Image{},
+		touchStrip: map[image.Rectangle]image.Image{},
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.commit()
+}
+
+// SetKeyImage queues a given image.Image to be drawn to an Elgato Stream Deck
+// key background display. The image is scaled as needed.
+func (tx *Tx) SetKeyImage(key KeyID, img image.Image) error {
+	d := tx.device
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	if _, found := tx.keys[key]; !found {
+		tx.keyOrder = append(tx.keyOrder, key)
+	}
+	tx.keys[key] = img
+	return nil
+}
+
+// SetKeyColor queues a color to be set to an Elgato Stream Deck key
+// background display.
+func (tx *Tx) SetKeyColor(key KeyID, c color.Color) error {
+	return tx.SetKeyImage(key, &imageColor{
+		c: c,
+		b: tx.device.model.keyImageRect,
+	})
+}
+
+// ClearKey queues an Elgato Stream Deck key background display to be
+// cleared.
+func (tx *Tx) ClearKey(key KeyID) error {
+	return tx.SetKeyColor(key, color.Black)
+}
+
+// SetInfoBarImage queues a given image.Image to be drawn to the info bar
+// display available on some Elgato Stream Deck models. The image is scaled as
+// needed.
+func (tx *Tx) SetInfoBarImage(img image.Image) error {
+	if err := tx.device.validateInfoBar(); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	tx.infoBar = img
+	return nil
+}
+
+// SetInfoBarColor queues a color to be set to the info bar display available
+// on some Elgato Stream Deck models.
+func (tx *Tx) SetInfoBarColor(c color.Color) error {
+	return tx.SetInfoBarImage(&imageColor{
+		c: c,
+		b: tx.device.model.infoBarImageRect,
+	})
+}
+
+// ClearInfoBar queues the info bar display available on some Elgato Stream
+// Deck models to be cleared.
+func (tx *Tx) ClearInfoBar() error {
+	return tx.SetInfoBarColor(color.Black)
+}
+
+// SetTouchStripImageWithRectangle queues a given image.Image to be drawn to a
+// rectangle of the touch strip display available on some Elgato Stream Deck
+// models. The image is scaled as needed. Updates queued for areas fully
+// covered by the rectangle are discarded.
+func (tx *Tx) SetTouchStripImageWithRectangle(img image.Image, rect image.Rectangle) error {
+	d := tx.device
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	tx.touchStripOrder = slices.DeleteFunc(tx.touchStripOrder, func(r image.Rectangle) bool {
+		if r.In(rect) {
+			delete(tx.touchStrip, r)
+			return true
+		}
+		return false
+	})
+	tx.touchStripOrder = append(tx.touchStripOrder, rect)
+	tx.touchStrip[rect] = img
+	return nil
+}
+
+// SetTouchStripImage queues a given image.Image to be drawn to the whole
+// touch strip display available on some Elgato Stream Deck models. The image
+// is scaled as needed.
+func (tx *Tx) SetTouchStripImage(img image.Image) error {
+	return tx.SetTouchStripImageWithRectangle(img, tx.device.model.touchStripImageRect)
+}
+
+// SetTouchStripColorWithRectangle queues a color to be set to the provided
+// rectangle of the touch strip display available on some Elgato Stream Deck
+// models.
+func (tx *Tx) SetTouchStripColorWithRectangle(c color.Color, rect image.Rectangle) error {
+	return tx.SetTouchStripImageWithRectangle(&imageColor{
+		c: c,
+		b: image.Rect(0, 0, rect.Dx(), rect.Dy()),
+	}, rect)
+}
+
+// SetTouchStripColor queues a color to be set to the whole touch strip
+// display available on some Elgato Stream Deck models.
+func (tx *Tx) SetTouchStripColor(c color.Color) error {
+	return tx.SetTouchStripColorWithRectangle(c, tx.device.model.touchStripImageRect)
+}
+
+// ClearTouchStrip queues the touch strip display available on some Elgato
+// Stream Deck models to be cleared.
+func (tx *Tx) ClearTouchStrip() error {
+	return tx.SetTouchStripColor(color.Black)
+}
+
+type txTouchStripData struct {
+	rect image.Rectangle
+	data []byte
+}
+
+func (tx *Tx) commit() error {
+	d := tx.device
+	opts := d.GetImageOptions()
+
+	keys := make([][]byte, len(tx.keyOrder))
+	for i, key := range tx.keyOrder {
+		data, err := genImage(tx.keys[key], d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform, opts)
+		if err != nil {
+			return wrapErr(err)
+		}
+		keys[i] = data
+	}
+
+	var infoBar []byte
+	if tx.infoBar != nil {
+		data, err := genImage(tx.infoBar, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform, opts)
+		if err != nil {
+			return wrapErr(err)
+		}
+		infoBar = data
+	}
+
+	touchStrip := make([]txTouchStripData, len(tx.touchStripOrder))
+	for i, rect := range tx.touchStripOrder {
+		data, err := genImage(tx.touchStrip[rect], image.Rect(0, 0, rect.Dx(), rect.Dy()), d.model.touchStripImageFormat, d.model.touchStripImageTransform, opts)
+		if err != nil {
+			return wrapErr(err)
+		}
+		touchStrip[i] = txTouchStripData{rect: rect, data: data}
+	}
+
+	for i, key := range tx.keyOrder {
+		if err := d.sendKeyImage(key, keys[i]); err != nil {
+			return err
+		}
+	}
+	if infoBar != nil {
+		if err := d.sendInfoBarImage(infoBar); err != nil {
+			return err
+		}
+	}
+	for _, ts := range touchStrip {
+		if err := d.sendTouchStripImage(ts.data, ts.rect); err != nil {
+			return err
+		}
+	}
+	return nil
+}
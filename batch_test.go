@@ -0,0 +1,77 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestBatch(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.Batch(func(tx *streamdeck.Tx) error {
+		for _, c := range []color.RGBA{{R: 0xff, A: 0xff}, {B: 0xff, A: 0xff}} {
+			if err := tx.SetKeyColor(streamdeck.KEY_1, c); err != nil {
+				return err
+			}
+		}
+		if err := tx.SetKeyColor(streamdeck.KEY_2, color.RGBA{G: 0xff, A: 0xff}); err != nil {
+			return err
+		}
+		if err := tx.SetTouchStripColorWithRectangle(color.RGBA{R: 0xff, A: 0xff}, image.Rect(0, 0, 200, 100)); err != nil {
+			return err
+		}
+		if err := tx.SetTouchStripColor(color.RGBA{G: 0xff, A: 0xff}); err != nil {
+			return err
+		}
+		return tx.SetTouchStripColorWithRectangle(color.RGBA{B: 0xff, A: 0xff}, image.Rect(600, 0, 800, 100))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	w := m.Writes()
+	if len(w) != 4 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	if w[0].Surface != mock.SURFACE_KEY || w[0].Key != streamdeck.KEY_1 || w[1].Surface != mock.SURFACE_KEY || w[1].Key != streamdeck.KEY_2 {
+		t.Errorf("bad key writes: %+v", w[:2])
+	}
+	if w[2].Rect != image.Rect(0, 0, 800, 100) || w[3].Rect != image.Rect(600, 0, 800, 100) {
+		t.Errorf("bad touch strip writes: %+v", w[2:])
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{G: 0xff})
+	assertColor(t, m.TouchStripImage(), 10, 50, color.RGBA{G: 0xff})
+	assertColor(t, m.TouchStripImage(), 700, 50, color.RGBA{B: 0xff})
+
+	m.ClearWrites()
+	errBatch := errors.New("batch failed")
+	if err := dev.Batch(func(tx *streamdeck.Tx) error {
+		if err := tx.ClearKey(streamdeck.KEY_1); err != nil {
+			return err
+		}
+		return errBatch
+	}); !errors.Is(err, errBatch) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.Batch(func(tx *streamdeck.Tx) error {
+		return tx.SetInfoBarColor(color.White)
+	}); !errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w := m.Writes(); len(w) != 0 {
+		t.Errorf("failed batches should not write: %+v", w)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"time"
+)
+
+const fadeStepInterval = 25 * time.Millisecond
+
+// FadeBrightness ramps the Elgato Stream Deck device brightness smoothly
+// from the current value to the target value, in percent, during the given
+// duration, scaled by the accessibility settings. It blocks until the fade
+// completes. Starting a new fade, or calling SetBrightness, cancels any fade
+// in progress.
+func (d *Device) FadeBrightness(target byte, duration time.Duration) error {
+	_, err := d.fadeBrightness(target, duration, false)
+	return err
+}
+
+// fadeBrightness implements FadeBrightness. If yield is true, the fade
+// stops as soon as the brightness is overridden by Sleep, by the idle monitor
+// or by the session lock monitor, recording the target as the brightness
+// restored when they end, and returns true.
+func (d *Device) fadeBrightness(target byte, duration time.Duration, yield bool) (bool, error) {
+	if err := d.validateOpen(); err != nil {
+		return false, err
+	}
+
+	if d.model.brightness == nil {
+		return false, wrapErr(ErrDeviceBrightnessNotSupported)
+	}
+
+	if target > 100 {
+		target = 100
+	}
+
+	d.mtx.Lock()
+	d.brightnessFade++
+	gen := d.brightnessFade
+	d.mtx.Unlock()
+
+	// check for cancellation and write atomically, so that a concurrent
+	// SetBrightness or a brightness override is never overwritten
+	step := func(v byte) (bool, bool, error) {
+		d.brightnessMtx.Lock()
+		defer d.brightnessMtx.Unlock()
+
+		d.mtx.Lock()
+		cancelled := d.brightnessFade != gen
+		d.mtx.Unlock()
+		if cancelled {
+			return true, false, nil
+		}
+
+		if yield && d.brightnessOverridden() {
+			d.mtx.Lock()
+			d.brightness = target
+			d.brightnessValid = true
+			d.mtx.Unlock()
+			return true, true, nil
+		}
+		return false, false, d.setBrightness(v)
+	}
+
+	from := int(d.lastBrightness())
+	steps := int(d.GetAccessibilityOptions().duration(duration) / fadeStepInterval)
+	for i := 1; i <= steps; i++ {
+		stop, held, err := step(byte(from + (int(target)-from)*i/steps))
+		if stop || err != nil {
+			return held, err
+		}
+
+		if i < steps {
+			time.Sleep(fadeStepInterval)
+		}
+	}
+
+	if steps == 0 {
+		_, held, err := step(target)
+		return held, err
+	}
+	return false, nil
+}
+
+// brightnessOverridden returns true while the brightness is overridden by
+// Sleep, by the idle monitor or by the session lock monitor.
+func (d *Device) brightnessOverridden() bool {
+	d.mtx.Lock()
+	overridden := d.sleeping || d.sessionLocked
+	m := d.idle
+	d.mtx.Unlock()
+
+	if overridden || m == nil || m.opts.Action == IDLE_ACTION_ANIMATION {
+		return overridden
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.idle
+}
+
+// BindBrightnessSource binds a brightness source function to the Elgato Stream
+// Deck device. The source function is called at the provided interval, and
+// must return the desired brightness, in percent. Brightness changes are
+// applied smoothly, fading from the previous value.
+//
+// While the brightness is overridden by Sleep, by the idle monitor or by the
+// session lock monitor, the samples are not applied, but recorded as the
+// brightness restored when the override ends.
+//
+// This is useful to drive the device brightness from external sensors, like
+// ambient light sensors. Binding a new source replaces the previous one. The
+// binding is removed when the device is closed or UnbindBrightnessSource is
+// called.
+func (d *Device) BindBrightnessSource(src func() byte, interval time.Duration) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if src == nil || interval <= 0 {
+		return wrapErr(ErrBrightnessSourceInvalid)
+	}
+
+	stop := make(chan struct{})
+
+	d.mtx.Lock()
+	if d.brightnessBind != nil {
+		close(d.brightnessBind)
+	}
+	d.brightnessBind = stop
+	done := d.done
+	d.mtx.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// a recorded sample is applied again once the override ends, in case
+		// it was recorded after the previous brightness was restored
+		held := false
+		for {
+			if v := min(src(), 100); v != d.lastBrightness() || (held && !d.brightnessOverridden()) {
+				h, err := d.fadeBrightness(v, interval/2, true)
+				if err != nil {
+					d.logError("streamdeck: brightness binding failed", err)
+					return
+				}
+				held = h
+			}
+
+			select {
+			case <-done:
+				return
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// UnbindBrightnessSource removes the brightness source function bound to the
+// Elgato Stream Deck device with BindBrightnessSource, if any. The current
+// brightness is kept.
+func (d *Device) UnbindBrightnessSource() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.brightnessBind != nil {
+		close(d.brightnessBind)
+		d.brightnessBind = nil
+	}
+}
+
+// Sleep switches the display backlight of the Elgato Stream Deck device off,
+// by writing a zero brightness to the hardware. None of the supported models
+// provide a standby command, so this is the closest to standby available:
+// the displays are dark, reducing power draw and backlight wear, but the
+// device stays powered on, keeps reporting input and keeps the display
+// contents. The brightness set before Sleep was called is kept, and restored
+// by Wake or overridden by SetBrightness. Models without brightness control
+// return ErrDeviceSleepNotSupported.
+func (d *Device) Sleep() error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if d.model.brightness == nil {
+		return wrapErr(ErrDeviceSleepNotSupported)
+	}
+
+	d.brightnessMtx.Lock()
+	defer d.brightnessMtx.Unlock()
+
+	// cancel any fade in progress
+	d.mtx.Lock()
+	d.brightnessFade++
+	d.mtx.Unlock()
+
+	if err := d.writeBrightness(0); err != nil {
+		return wrapErr(err)
+	}
+
+	d.mtx.Lock()
+	d.sleeping = true
+	d.mtx.Unlock()
+	return nil
+}
+
+// Wake switches the display backlight of the Elgato Stream Deck device back
+// on after Sleep, restoring the brightness set before Sleep was called.
+func (d *Device) Wake() error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if d.model.brightness == nil {
+		return wrapErr(ErrDeviceSleepNotSupported)
+	}
+
+	if !d.IsSleeping() {
+		return nil
+	}
+	return d.SetBrightness(d.lastBrightness())
+}
+
+// IsSleeping returns true if the display backlight of the Elgato Stream Deck
+// device was switched off with Sleep.
+func (d *Device) IsSleeping() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.sleeping
+}
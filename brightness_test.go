@@ -0,0 +1,172 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func waitBrightness(t *testing.T, m *mock.Device, want byte) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if m.Brightness() == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("unexpected brightness: got %d, want %d", m.Brightness(), want)
+}
+
+func TestBindBrightnessSourceOverride(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	go dev.Listen(nil)
+
+	if err := dev.SetBrightness(60); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Sleep(); err != nil {
+		t.Fatal(err)
+	}
+
+	sample := atomic.Uint32{}
+	sample.Store(30)
+	if err := dev.BindBrightnessSource(func() byte { return byte(sample.Load()) }, 5*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	defer dev.UnbindBrightnessSource()
+
+	// the samples do not wake the device up, but are restored by Wake
+	time.Sleep(50 * time.Millisecond)
+	if b := m.Brightness(); b != 0 || !dev.IsSleeping() {
+		t.Errorf("device woken up by the brightness source: %d", b)
+	}
+	if b, err := dev.GetBrightness(); err != nil || b != 30 {
+		t.Errorf("unexpected brightness: %d, %v", b, err)
+	}
+	if err := dev.Wake(); err != nil {
+		t.Fatal(err)
+	}
+	waitBrightness(t, m, 30)
+
+	// the samples do not override the idle dim, and are restored on the
+	// next input
+	if err := dev.SetIdleTimeout(20*time.Millisecond, streamdeck.IdleOptions{Action: streamdeck.IDLE_ACTION_DIM}); err != nil {
+		t.Fatal(err)
+	}
+	waitBrightness(t, m, 10)
+	sample.Store(80)
+	time.Sleep(50 * time.Millisecond)
+	if b := m.Brightness(); b != 10 || !dev.IsIdle() {
+		t.Errorf("idle dim overridden by the brightness source: %d", b)
+	}
+	if err := dev.SetIdleTimeout(0, streamdeck.IdleOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitBrightness(t, m, 80)
+}
+
+func TestFadeBrightness(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if b, err := dev.GetBrightness(); err != nil || b != 100 {
+		t.Errorf("bad initial brightness: %d, %v", b, err)
+	}
+
+	if err := dev.FadeBrightness(20, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if b := m.Brightness(); b != 20 {
+		t.Errorf("bad brightness: %d", b)
+	}
+	if b, err := dev.GetBrightness(); err != nil || b != 20 {
+		t.Errorf("bad brightness: %d, %v", b, err)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- dev.FadeBrightness(100, time.Second)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	if err := dev.SetBrightness(5); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if b := m.Brightness(); b != 5 {
+		t.Errorf("fade not cancelled: %d", b)
+	}
+
+	pedal, _, err := mock.Open("pedal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pedal.Close()
+
+	if _, err := pedal.GetBrightness(); !errors.Is(err, streamdeck.ErrDeviceBrightnessNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSleepWake(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetBrightness(60); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Sleep(); err != nil {
+		t.Fatal(err)
+	}
+	if !dev.IsSleeping() {
+		t.Error("device should be sleeping")
+	}
+	if b := m.Brightness(); b != 0 {
+		t.Errorf("bad brightness: %d", b)
+	}
+	if b, err := dev.GetBrightness(); err != nil || b != 60 {
+		t.Errorf("bad cached brightness: %d, %v", b, err)
+	}
+
+	if err := dev.Wake(); err != nil {
+		t.Fatal(err)
+	}
+	if dev.IsSleeping() {
+		t.Error("device should not be sleeping")
+	}
+	if b := m.Brightness(); b != 60 {
+		t.Errorf("bad brightness: %d", b)
+	}
+
+	pedal, _, err := mock.Open("pedal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pedal.Close()
+
+	if err := pedal.Sleep(); !errors.Is(err, streamdeck.ErrDeviceSleepNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
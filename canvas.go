@@ -0,0 +1,184 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"image"
+)
+
+// Canvas represents a drawing surface with the native geometry of an Elgato
+// Stream Deck display. It embeds an *image.RGBA, implementing draw.Image, and
+// can be used as a target by any 2D drawing library that accepts a draw.Image
+// or an *image.RGBA (e.g. gg.NewContextForRGBA). Nothing is sent to the
+// device until Flush is called.
+type Canvas struct {
+	*image.RGBA
+	flush func(img image.Image) error
+}
+
+// Flush sends the current canvas contents to the Elgato Stream Deck display
+// it was created for.
+func (c *Canvas) Flush() error {
+	return c.flush(c.RGBA)
+}
+
+// NewKeyCanvas creates a Canvas for an Elgato Stream Deck key background
+// display.
+func (d *Device) NewKeyCanvas(key KeyID) (*Canvas, error) {
+	if err := d.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return nil, err
+	}
+
+	return &Canvas{
+		RGBA: image.NewRGBA(d.model.keyImageRect),
+		flush: func(img image.Image) error {
+			return d.SetKeyImage(key, img)
+		},
+	}, nil
+}
+
+// NewInfoBarCanvas creates a Canvas for the info bar display available on
+// some Elgato Stream Deck models.
+func (d *Device) NewInfoBarCanvas() (*Canvas, error) {
+	if err := d.validateInfoBar(); err != nil {
+		return nil, err
+	}
+
+	return &Canvas{
+		RGBA:  image.NewRGBA(d.model.infoBarImageRect),
+		flush: d.SetInfoBarImage,
+	}, nil
+}
+
+// NewTouchStripCanvas creates a Canvas for the whole touch strip display
+// available on some Elgato Stream Deck models.
+func (d *Device) NewTouchStripCanvas() (*Canvas, error) {
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	return &Canvas{
+		RGBA:  image.NewRGBA(d.model.touchStripImageRect),
+		flush: d.SetTouchStripImage,
+	}, nil
+}
+
+// NewTouchStripCanvasWithRectangle creates a Canvas for the provided rectangle
+// of the touch strip display available on some Elgato Stream Deck models. The
+// canvas bounds start at the origin, with the rectangle dimensions.
+func (d *Device) NewTouchStripCanvasWithRectangle(rect image.Rectangle) (*Canvas, error) {
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return nil, err
+	}
+
+	return &Canvas{
+		RGBA: image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy())),
+		flush: func(img image.Image) error {
+			return d.SetTouchStripImageWithRectangle(img, rect)
+		},
+	}, nil
+}
+
+// KeyCanvas represents a double-buffered drawing surface for an Elgato Stream
+// Deck key background display. The embedded *image.RGBA is the back buffer,
+// that can be painted with standard image/draw code, and reused for every
+// frame. Commit sends the back buffer to the device only if it changed since
+// the previous commit, skipping the encoding and the USB write otherwise.
+type KeyCanvas struct {
+	*image.RGBA
+	dev       *Device
+	key       KeyID
+	front     []byte
+	committed bool
+}
+
+// NewDoubleBufferedKeyCanvas creates a KeyCanvas for an Elgato Stream Deck
+// key background display.
+func (d *Device) NewDoubleBufferedKeyCanvas(key KeyID) (*KeyCanvas, error) {
+	if err := d.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(d.model.keyImageRect)
+	return &KeyCanvas{
+		RGBA:  rgba,
+		dev:   d,
+		key:   key,
+		front: make([]byte, len(rgba.Pix)),
+	}, nil
+}
+
+// Dirty returns the smallest rectangle including all the pixels of the back
+// buffer that changed since the previous commit, or the whole canvas bounds
+// if nothing was committed yet. It returns an empty rectangle if nothing
+// changed.
+//
+// The key displays of all the supported models can only be updated with
+// whole images, so Commit always sends the full key image when anything
+// changed. The rectangle is useful to decide when to commit, or to render the
+// changed area only. Partial updates are only supported by the touch strip,
+// with SetTouchStripImageWithRectangle.
+func (c *KeyCanvas) Dirty() image.Rectangle {
+	if !c.committed {
+		return c.Rect
+	}
+
+	rv := image.Rectangle{}
+	w := c.Rect.Dx() * 4
+	for y := 0; y < c.Rect.Dy(); y++ {
+		back := c.Pix[y*c.Stride : y*c.Stride+w]
+		front := c.front[y*c.Stride : y*c.Stride+w]
+		if bytes.Equal(back, front) {
+			continue
+		}
+
+		x0 := 0
+		for back[x0] == front[x0] {
+			x0++
+		}
+		x1 := w - 1
+		for back[x1] == front[x1] {
+			x1--
+		}
+		r := image.Rect(x0/4, y, x1/4+1, y+1).Add(c.Rect.Min)
+		rv = rv.Union(r)
+	}
+	return rv
+}
+
+// Invalidate forces the next commit to send the back buffer to the device,
+// even if it did not change, e.g. after the key display was changed by other
+// means.
+func (c *KeyCanvas) Invalidate() {
+	c.committed = false
+}
+
+// Commit sends the back buffer to the Elgato Stream Deck key display it was
+// created for, if it changed since the previous commit.
+func (c *KeyCanvas) Commit() error {
+	if c.committed && bytes.Equal(c.Pix, c.front) {
+		return nil
+	}
+
+	if err := c.dev.SetKeyImage(c.key, c.RGBA); err != nil {
+		return err
+	}
+	copy(c.front, c.Pix)
+	c.committed = true
+	return nil
+}
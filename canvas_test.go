@@ -0,0 +1,68 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDoubleBufferedKeyCanvas(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	c, err := dev.NewDoubleBufferedKeyCanvas(streamdeck.KEY_1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := c.Dirty(); r != c.Bounds() {
+		t.Errorf("unexpected dirty rectangle: %s", r)
+	}
+	m.ClearWrites()
+
+	// the first commit is always sent, even if the canvas is blank
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 1 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	if r := c.Dirty(); !r.Empty() {
+		t.Errorf("unexpected dirty rectangle: %s", r)
+	}
+
+	draw.Draw(c, image.Rect(10, 20, 30, 25), image.NewUniform(color.RGBA{R: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	if r := c.Dirty(); r != image.Rect(10, 20, 30, 25) {
+		t.Errorf("unexpected dirty rectangle: %s", r)
+	}
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 15, 22, color.RGBA{R: 0xff, A: 0xff})
+
+	// invalidated canvases are encoded again, but the identical image is still
+	// skipped by the write cache
+	c.Invalidate()
+	if err := c.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 {
+		t.Errorf("bad writes: %+v", w)
+	}
+}
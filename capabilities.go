@@ -0,0 +1,117 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+)
+
+// ImageFormat represents the encoding of the images sent to an Elgato Stream
+// Deck display.
+type ImageFormat byte
+
+// String returns a string representation of the ImageFormat.
+func (f ImageFormat) String() string {
+	switch f {
+	case IMAGE_FORMAT_BMP:
+		return "IMAGE_FORMAT_BMP"
+	case IMAGE_FORMAT_JPEG:
+		return "IMAGE_FORMAT_JPEG"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck image formats.
+const (
+	IMAGE_FORMAT_BMP ImageFormat = iota + 1
+	IMAGE_FORMAT_JPEG
+)
+
+func exportImageFormat(ifmt imageFormat) ImageFormat {
+	switch ifmt {
+	case imageFormatBMP:
+		return IMAGE_FORMAT_BMP
+	case imageFormatJPEG:
+		return IMAGE_FORMAT_JPEG
+	default:
+		return 0
+	}
+}
+
+// Capabilities represents the features and the geometry of an Elgato Stream
+// Deck device model. The rectangles and formats of the displays that are not
+// supported are zero.
+type Capabilities struct {
+	ModelID string
+
+	HasKeys            bool
+	HasKeyDisplays     bool
+	HasInfoBar         bool
+	HasTouchPoints     bool
+	HasDials           bool
+	HasTouchStrip      bool
+	SupportsBrightness bool
+	SupportsStandby    bool
+
+	KeyCount        byte
+	KeyRows         int
+	KeyColumns      int
+	TouchPointCount byte
+	DialCount       byte
+
+	KeyImageRect          image.Rectangle
+	KeyImageFormat        ImageFormat
+	DeckImageRect         image.Rectangle
+	InfoBarImageRect      image.Rectangle
+	InfoBarImageFormat    ImageFormat
+	TouchStripImageRect   image.Rectangle
+	TouchStripImageFormat ImageFormat
+}
+
+// GetCapabilities returns the features and the geometry of the Elgato Stream
+// Deck device model, as reported individually by the Get*Supported, Get*Count
+// and Get*Rectangle methods.
+func (d *Device) GetCapabilities() Capabilities {
+	return d.model.capabilities()
+}
+
+func (m *model) capabilities() Capabilities {
+	rows, cols := m.keyRows(), int(m.keyColumns)
+	rv := Capabilities{
+		ModelID:            m.id,
+		HasKeys:            m.keyCount > 0,
+		HasKeyDisplays:     m.keyImageSend != nil,
+		HasInfoBar:         m.infoBarImageSend != nil,
+		HasTouchPoints:     m.touchPointCount > 0,
+		HasDials:           m.dialCount > 0,
+		HasTouchStrip:      m.touchStripImageSend != nil,
+		SupportsBrightness: m.brightness != nil,
+		SupportsStandby:    m.brightness != nil,
+		KeyCount:           m.keyCount,
+		KeyRows:            rows,
+		KeyColumns:         cols,
+		TouchPointCount:    m.touchPointCount,
+		DialCount:          m.dialCount,
+	}
+
+	if rv.HasKeyDisplays {
+		rv.KeyImageRect = m.keyImageRect
+		rv.KeyImageFormat = exportImageFormat(m.keyImageFormat)
+		rv.DeckImageRect = image.Rect(0, 0,
+			cols*m.keyImageRect.Dx()+(cols-1)*m.keyImageGap.X,
+			rows*m.keyImageRect.Dy()+(rows-1)*m.keyImageGap.Y,
+		)
+	}
+	if rv.HasInfoBar {
+		rv.InfoBarImageRect = m.infoBarImageRect
+		rv.InfoBarImageFormat = exportImageFormat(m.infoBarImageFormat)
+	}
+	if rv.HasTouchStrip {
+		rv.TouchStripImageRect = m.touchStripImageRect
+		rv.TouchStripImageFormat = exportImageFormat(m.touchStripImageFormat)
+	}
+	return rv
+}
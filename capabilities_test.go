@@ -0,0 +1,83 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"testing"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	for _, tt := range []struct {
+		pid  uint16
+		want Capabilities
+	}{
+		{0x0084, Capabilities{
+			ModelID:               "plus",
+			HasKeys:               true,
+			HasKeyDisplays:        true,
+			HasDials:              true,
+			HasTouchStrip:         true,
+			SupportsBrightness:    true,
+			SupportsStandby:       true,
+			KeyCount:              8,
+			KeyRows:               2,
+			KeyColumns:            4,
+			DialCount:             4,
+			KeyImageRect:          image.Rect(0, 0, 120, 120),
+			KeyImageFormat:        IMAGE_FORMAT_JPEG,
+			DeckImageRect:         image.Rect(0, 0, 600, 280),
+			TouchStripImageRect:   image.Rect(0, 0, 800, 100),
+			TouchStripImageFormat: IMAGE_FORMAT_JPEG,
+		}},
+		{0x009a, Capabilities{
+			ModelID:            "neo",
+			HasKeys:            true,
+			HasKeyDisplays:     true,
+			HasInfoBar:         true,
+			HasTouchPoints:     true,
+			SupportsBrightness: true,
+			SupportsStandby:    true,
+			KeyCount:           8,
+			KeyRows:            2,
+			KeyColumns:         4,
+			TouchPointCount:    2,
+			KeyImageRect:       image.Rect(0, 0, 96, 96),
+			KeyImageFormat:     IMAGE_FORMAT_JPEG,
+			DeckImageRect:      image.Rect(0, 0, 480, 224),
+			InfoBarImageRect:   image.Rect(0, 0, 248, 58),
+			InfoBarImageFormat: IMAGE_FORMAT_JPEG,
+		}},
+		{0x00aa, Capabilities{
+			ModelID:            "studio",
+			HasKeys:            true,
+			HasKeyDisplays:     true,
+			HasDials:           true,
+			SupportsBrightness: true,
+			SupportsStandby:    true,
+			KeyCount:           32,
+			KeyRows:            2,
+			KeyColumns:         16,
+			DialCount:          2,
+			KeyImageRect:       image.Rect(0, 0, 144, 112),
+			KeyImageFormat:     IMAGE_FORMAT_JPEG,
+			DeckImageRect:      image.Rect(0, 0, 2904, 264),
+		}},
+		{0x0086, Capabilities{
+			ModelID:    "pedal",
+			HasKeys:    true,
+			KeyCount:   3,
+			KeyRows:    1,
+			KeyColumns: 3,
+		}},
+	} {
+		t.Run(tt.want.ModelID, func(t *testing.T) {
+			d := &Device{model: models[tt.pid]}
+			if got := d.GetCapabilities(); got != tt.want {
+				t.Errorf("unexpected capabilities:\ngot  %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
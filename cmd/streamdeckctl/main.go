@@ -0,0 +1,507 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command streamdeckctl controls Elgato Stream Deck devices from the shell.
+//
+// Usage:
+//
+//	streamdeckctl [-serial SERIAL] COMMAND [ARGUMENTS]
+//
+// Commands:
+//
+//	list                       list the connected devices
+//	info                       print device information
+//	set-image KEY FILE         draw an image file to a key
+//	set-color KEY COLOR        fill a key with a color
+//	set-text [OPTIONS] KEY TEXT
+//	                           draw a text to a key
+//	brightness PERCENT         set the brightness
+//	clear [KEY]                clear a key, or all the displays
+//	reset                      reset the device
+//	monitor                    print input events, until interrupted
+//	test-pattern               draw a test pattern to all the displays
+//
+// Keys are numbered starting from 1 for the top left key. Colors are
+// defined in the #rgb or #rrggbb formats, or as SVG color names, like
+// "red". If no serial number is provided, the only connected device is
+// used. The displays are kept on-screen when the command exits.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/colornames"
+	"rafaelmartins.com/p/streamdeck"
+	_ "rafaelmartins.com/p/streamdeck/svg"
+)
+
+var (
+	errUsage = errors.New("invalid usage")
+
+	enumerate = streamdeck.EnumerateInfo
+	getDevice = streamdeck.GetDevice
+)
+
+type command struct {
+	usage string
+	fn    func(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error
+}
+
+var commands = map[string]command{
+	"info":         {"", cmdInfo},
+	"set-image":    {"KEY FILE", cmdSetImage},
+	"set-color":    {"KEY COLOR", cmdSetColor},
+	"set-text":     {"[-fg COLOR] [-bg COLOR] [-size PIXELS] KEY TEXT", cmdSetText},
+	"brightness":   {"PERCENT", cmdBrightness},
+	"clear":        {"[KEY]", cmdClear},
+	"reset":        {"", cmdReset},
+	"identify":     {"", cmdIdentify},
+	"monitor":      {"", cmdMonitor},
+	"test-pattern": {"", cmdTestPattern},
+}
+
+func parseKey(dev *streamdeck.Device, s string) (streamdeck.KeyID, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	if err != nil || v == 0 || v > uint64(dev.GetKeyCount()) {
+		return 0, fmt.Errorf("%w: key is not valid: %s", errUsage, s)
+	}
+	return streamdeck.KeyID(v), nil
+}
+
+func parseColor(s string) (color.Color, error) {
+	if c, found := colornames.Map[strings.ToLower(s)]; found {
+		return c, nil
+	}
+
+	h, ok := strings.CutPrefix(s, "#")
+	if ok && len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if !ok || len(h) != 6 {
+		return nil, fmt.Errorf("%w: color is not valid: %s", errUsage, s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: color is not valid: %s", errUsage, s)
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}, nil
+}
+
+func cmdList(w io.Writer) error {
+	devices, err := enumerate()
+	if err != nil {
+		return err
+	}
+	for _, dev := range devices {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", dev.SerialNumber, dev.ModelName, dev.Path)
+	}
+	return nil
+}
+
+func cmdInfo(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	fmt.Fprintf(w, "Model: %s (%s)\n", dev.GetModelName(), dev.GetModelID())
+	fmt.Fprintf(w, "Serial Number: %s\n", dev.GetSerialNumber())
+	fmt.Fprintf(w, "USB ID: %04x:%04x\n", dev.GetVendorID(), dev.GetProductID())
+	fmt.Fprintf(w, "Path: %s\n", dev.GetPath())
+	if location, err := dev.GetLocation(); err == nil {
+		fmt.Fprintf(w, "Location: %s\n", location)
+	}
+	if versions, err := dev.GetFirmwareVersions(); err == nil {
+		fmt.Fprintf(w, "Firmware Version: %s\n", versions.Application)
+		if versions.Bootloader != "" {
+			fmt.Fprintf(w, "Bootloader Version: %s\n", versions.Bootloader)
+		}
+		if versions.Secondary != "" {
+			fmt.Fprintf(w, "Secondary Firmware Version: %s\n", versions.Secondary)
+		}
+	}
+	fmt.Fprintf(w, "Keys: %d\n", dev.GetKeyCount())
+	fmt.Fprintf(w, "Touch Points: %d\n", dev.GetTouchPointCount())
+	fmt.Fprintf(w, "Dials: %d\n", dev.GetDialCount())
+	if rect, err := dev.GetKeyImageRectangle(); err == nil {
+		fmt.Fprintf(w, "Key Image Size: %dx%d\n", rect.Dx(), rect.Dy())
+	}
+	if rect, err := dev.GetInfoBarImageRectangle(); err == nil {
+		fmt.Fprintf(w, "Info Bar Image Size: %dx%d\n", rect.Dx(), rect.Dy())
+	}
+	if rect, err := dev.GetTouchStripImageRectangle(); err == nil {
+		fmt.Fprintf(w, "Touch Strip Image Size: %dx%d\n", rect.Dx(), rect.Dy())
+	}
+	return nil
+}
+
+func cmdSetImage(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	key, err := parseKey(dev, args[0])
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(args[1]), ".svg") {
+		fp, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer fp.Close()
+
+		return dev.SetKeyImageFromSVG(key, fp)
+	}
+	return dev.SetKeyImageFromFile(key, args[1])
+}
+
+func cmdSetColor(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 2 {
+		return errUsage
+	}
+
+	key, err := parseKey(dev, args[0])
+	if err != nil {
+		return err
+	}
+
+	c, err := parseColor(args[1])
+	if err != nil {
+		return err
+	}
+	return dev.SetKeyColor(key, c)
+}
+
+func cmdSetText(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	fs := flag.NewFlagSet("set-text", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fg := fs.String("fg", "", "")
+	bg := fs.String("bg", "", "")
+	size := fs.Float64("size", 0, "")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	if fs.NArg() != 2 {
+		return errUsage
+	}
+
+	key, err := parseKey(dev, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	opts := streamdeck.TextOptions{
+		Size: *size,
+	}
+	if *fg != "" {
+		if opts.Foreground, err = parseColor(*fg); err != nil {
+			return err
+		}
+	}
+	if *bg != "" {
+		if opts.Background, err = parseColor(*bg); err != nil {
+			return err
+		}
+	}
+
+	// allow multi-line labels from the shell
+	return dev.SetKeyTextWithOptions(key, strings.ReplaceAll(fs.Arg(1), `\n`, "\n"), opts)
+}
+
+func cmdBrightness(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	// the hardware does not allow reading the brightness back
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	v, err := strconv.ParseUint(strings.TrimSuffix(args[0], "%"), 10, 8)
+	if err != nil || v > 100 {
+		return fmt.Errorf("%w: brightness is not valid: %s", errUsage, args[0])
+	}
+	return dev.SetBrightness(byte(v))
+}
+
+func cmdClear(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	switch len(args) {
+	case 0:
+		if err := dev.Batch(func(tx *streamdeck.Tx) error {
+			if dev.GetKeyDisplaySupported() {
+				if err := dev.ForEachKey(tx.ClearKey); err != nil {
+					return err
+				}
+			}
+			if dev.GetInfoBarSupported() {
+				if err := tx.ClearInfoBar(); err != nil {
+					return err
+				}
+			}
+			if dev.GetTouchStripSupported() {
+				return tx.ClearTouchStrip()
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return dev.ForEachTouchPoint(dev.ClearTouchPoint)
+
+	case 1:
+		key, err := parseKey(dev, args[0])
+		if err != nil {
+			return err
+		}
+		return dev.ClearKey(key)
+	}
+	return errUsage
+}
+
+func cmdReset(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	return dev.Reset()
+}
+
+func cmdIdentify(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	return dev.Identify(ctx)
+}
+
+func cmdMonitor(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	printf := func(format string, a ...any) {
+		fmt.Fprintf(w, time.Now().Format("15:04:05.000")+" "+format+"\n", a...)
+	}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if _, err := dev.AddKeyPressHandler(k, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			printf("%s pressed", key)
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, key *streamdeck.Key, duration time.Duration) error {
+			printf("%s released after %s", key, duration.Round(time.Millisecond))
+			return nil
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		if _, err := dev.AddTouchPointPressHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint) error {
+			printf("%s pressed", t)
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := dev.AddTouchPointReleaseHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint, duration time.Duration) error {
+			printf("%s released after %s", t, duration.Round(time.Millisecond))
+			return nil
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachDial(func(di streamdeck.DialID) error {
+		if _, err := dev.AddDialPressHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial) error {
+			printf("%s pressed", dial)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if _, err := dev.AddDialReleaseHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, duration time.Duration) error {
+			printf("%s released after %s", dial, duration.Round(time.Millisecond))
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := dev.AddDialRotateHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, delta int8) error {
+			printf("%s rotated by %d", dial, delta)
+			return nil
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if dev.GetTouchStripSupported() {
+		if _, err := dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+			printf("%s at %s", t, p)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if _, err := dev.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
+			printf("TOUCH_STRIP_SWIPE from %s to %s", origin, destination)
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	errCh := make(chan error)
+	go func() {
+		for err := range errCh {
+			printf("error: %s", err)
+		}
+	}()
+	defer close(errCh)
+
+	return dev.ListenContext(ctx, errCh)
+}
+
+// hue returns the color of position i of n in a hue wheel, with full
+// saturation and value.
+func hue(i int, n int) color.Color {
+	h := float64(i) * 6 / float64(n)
+	x := byte(math.Round(255 * (1 - math.Abs(math.Mod(h, 2)-1))))
+	switch int(h) {
+	case 0:
+		return color.RGBA{R: 0xff, G: x, A: 0xff}
+	case 1:
+		return color.RGBA{R: x, G: 0xff, A: 0xff}
+	case 2:
+		return color.RGBA{G: 0xff, B: x, A: 0xff}
+	case 3:
+		return color.RGBA{G: x, B: 0xff, A: 0xff}
+	case 4:
+		return color.RGBA{R: x, B: 0xff, A: 0xff}
+	default:
+		return color.RGBA{R: 0xff, B: x, A: 0xff}
+	}
+}
+
+func cmdTestPattern(ctx context.Context, w io.Writer, dev *streamdeck.Device, args []string) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+
+	if dev.GetKeyDisplaySupported() {
+		n := int(dev.GetKeyCount())
+		if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+			return dev.SetKeyTextWithOptions(k, strconv.Itoa(int(k)), streamdeck.TextOptions{
+				Foreground: color.Black,
+				Background: hue(int(k-streamdeck.KEY_1), n),
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	if dev.GetInfoBarSupported() {
+		if err := dev.SetInfoBarText(dev.GetSerialNumber()); err != nil {
+			return err
+		}
+	}
+
+	if dev.GetTouchStripSupported() {
+		rect, err := dev.GetTouchStripImageRectangle()
+		if err != nil {
+			return err
+		}
+		img := image.NewRGBA(rect)
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c := hue(x-rect.Min.X, rect.Dx())
+			for y := rect.Min.Y; y < rect.Max.Y; y++ {
+				img.Set(x, y, c)
+			}
+		}
+		if err := dev.SetTouchStripImage(img); err != nil {
+			return err
+		}
+	}
+
+	n := int(dev.GetTouchPointCount())
+	return dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		return dev.SetTouchPointColor(tp, hue(int(tp-streamdeck.TOUCH_POINT_1), n))
+	})
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: streamdeckctl [-serial SERIAL] COMMAND [ARGUMENTS]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	fmt.Fprintln(w, "  list")
+	for _, name := range []string{"info", "set-image", "set-color", "set-text", "brightness", "clear", "reset", "monitor", "test-pattern"} {
+		fmt.Fprintf(w, "  %s %s\n", name, commands[name].usage)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("streamdeckctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { usage(stderr) }
+	serial := fs.String("serial", "", "serial number of the device")
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		usage(stderr)
+		return errUsage
+	}
+
+	name := fs.Arg(0)
+	if name == "list" {
+		if fs.NArg() != 1 {
+			return errUsage
+		}
+		return cmdList(stdout)
+	}
+
+	cmd, found := commands[name]
+	if !found {
+		usage(stderr)
+		return fmt.Errorf("%w: unknown command: %s", errUsage, name)
+	}
+
+	dev, err := getDevice(*serial)
+	if err != nil {
+		return err
+	}
+	// info only reads the device, and works while another process owns it
+	if err := dev.OpenWithOptions(streamdeck.OpenOptions{Exclusive: name != "info"}); err != nil {
+		return err
+	}
+
+	if err := cmd.fn(ctx, stdout, dev, fs.Args()[1:]); err != nil {
+		dev.Close()
+		if errors.Is(err, errUsage) {
+			return fmt.Errorf("%w\nusage: streamdeckctl %s %s", err, name, cmd.usage)
+		}
+		return err
+	}
+	return dev.Close()
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		os.Exit(1)
+	}
+}
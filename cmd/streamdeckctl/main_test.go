@@ -0,0 +1,203 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+type syncBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+func setup(t *testing.T, modelID string) *mock.Device {
+	t.Helper()
+
+	m, err := mock.New(modelID, "CTL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	getDevice = func(serial string) (*streamdeck.Device, error) {
+		if serial != "" && serial != "CTL" {
+			return nil, streamdeck.ErrNoDeviceFound
+		}
+		return streamdeck.NewDevice(m)
+	}
+	enumerate = func() ([]streamdeck.DeviceInfo, error) {
+		dev, err := streamdeck.NewDevice(m)
+		if err != nil {
+			return nil, err
+		}
+		return []streamdeck.DeviceInfo{dev.GetInfo()}, nil
+	}
+	t.Cleanup(func() {
+		getDevice = streamdeck.GetDevice
+		enumerate = streamdeck.EnumerateInfo
+	})
+	return m
+}
+
+func TestCommands(t *testing.T) {
+	m := setup(t, "mk2")
+
+	for _, args := range [][]string{
+		{"set-color", "1", "#f00"},
+		{"set-color", "2", "blue"},
+		{"set-text", "-bg", "#0f0", "3", `a\nb`},
+		{"brightness", "42"},
+	} {
+		if err := run(context.Background(), args, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+			t.Errorf("%v: %v", args, err)
+		}
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{R: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 2, 2, color.RGBA{G: 0xff})
+	if b := m.Brightness(); b != 42 {
+		t.Errorf("bad brightness: %d", b)
+	}
+
+	out := &bytes.Buffer{}
+	if err := run(context.Background(), []string{"list"}, out, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "CTL\t") {
+		t.Errorf("bad output: %q", out.String())
+	}
+
+	out.Reset()
+	if err := run(context.Background(), []string{"-serial", "CTL", "info"}, out, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Serial Number: CTL\n") || !strings.Contains(out.String(), "Keys: 15\n") {
+		t.Errorf("bad output: %q", out.String())
+	}
+
+	if err := run(context.Background(), []string{"clear", "1"}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{B: 0xff})
+
+	if err := run(context.Background(), []string{"clear"}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{})
+
+	for _, args := range [][]string{
+		{},
+		{"bola"},
+		{"set-color", "16", "#fff"},
+		{"set-color", "1", "#ffff"},
+		{"set-color", "1"},
+		{"brightness"},
+		{"brightness", "101"},
+		{"reset", "1"},
+		{"identify", "1"},
+	} {
+		if err := run(context.Background(), args, &bytes.Buffer{}, &bytes.Buffer{}); !errors.Is(err, errUsage) {
+			t.Errorf("%v: unexpected error: %v", args, err)
+		}
+	}
+
+	if err := run(context.Background(), []string{"-serial", "bola", "info"}, &bytes.Buffer{}, &bytes.Buffer{}); !errors.Is(err, streamdeck.ErrNoDeviceFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTestPattern(t *testing.T) {
+	m := setup(t, "plus")
+
+	if err := run(context.Background(), []string{"test-pattern"}, &bytes.Buffer{}, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{R: 0xff})
+	assertColor(t, m.TouchStripImage(), 0, 50, color.RGBA{R: 0xff})
+}
+
+func TestMonitor(t *testing.T) {
+	m := setup(t, "plus")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := &syncBuffer{}
+	done := make(chan error)
+	go func() {
+		done <- run(ctx, []string{"monitor"}, out, &bytes.Buffer{})
+	}()
+
+	for range 100 {
+		if err := m.PressKey(streamdeck.KEY_4); err == nil && strings.Contains(out.String(), "KEY_4 pressed") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_4); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RotateDial(streamdeck.DIAL_2, -3); err != nil {
+		t.Fatal(err)
+	}
+	for range 100 {
+		if strings.Contains(out.String(), "DIAL_2 rotated by -3") {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for monitor")
+	}
+
+	for _, want := range []string{"KEY_4 pressed", "KEY_4 released after", "DIAL_2 rotated by -3"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing %q in output: %q", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package config loads declarative Elgato Stream Deck layouts from YAML or
+// JSON documents, and applies them to devices.
+//
+// A layout describes the keys of a device, each with an optional icon,
+// label, background color and action identifier:
+//
+//	brightness: 60
+//	keys:
+//	  - key: 1
+//	    icon: icons/play.svg
+//	    label: Play
+//	    color: "#202020"
+//	    action: media.play
+//	  - key: 2
+//	    label: Build
+//	    exec:
+//	      command: [make, build]
+//	      dir: src
+//	      env: {GOFLAGS: -mod=mod}
+//	      timeout: 5m
+//
+// The action identifiers of the keys are emitted through a channel when the
+// keys are pressed, so that applications can handle layouts as data rather
+// than code. Keys may also run external commands when pressed, flashing
+// green when the command succeeds and red when it fails.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"rafaelmartins.com/p/streamdeck"
+	_ "rafaelmartins.com/p/streamdeck/svg"
+)
+
+// Errors returned by the config package.
+var (
+	ErrColorInvalid  = errors.New("config: color is not valid")
+	ErrCommandFailed = errors.New("config: command failed")
+	ErrConfigInvalid = errors.New("config: config is not valid")
+)
+
+// Key represents the definition of an Elgato Stream Deck key.
+type Key struct {
+	// Key is the key number, starting from 1 for the top left key.
+	Key streamdeck.KeyID `yaml:"key" json:"key"`
+
+	// Icon is the path of an image file displayed by the key. Relative paths
+	// are resolved from the directory of the configuration file. SVG files
+	// are rasterized at the native resolution of the key display.
+	Icon string `yaml:"icon,omitempty" json:"icon,omitempty"`
+
+	// Label is a text rendered by the key, below the icon, if any.
+	Label string `yaml:"label,omitempty" json:"label,omitempty"`
+
+	// Color is the background color of the key, in the #rgb or #rrggbb
+	// formats. If empty, defaults to black.
+	Color string `yaml:"color,omitempty" json:"color,omitempty"`
+
+	// Action is the identifier emitted when the key is pressed.
+	Action string `yaml:"action,omitempty" json:"action,omitempty"`
+
+	// Exec is an external command executed when the key is pressed.
+	Exec *Exec `yaml:"exec,omitempty" json:"exec,omitempty"`
+}
+
+// Config represents a declarative Elgato Stream Deck layout.
+type Config struct {
+	// Brightness is the device brightness, in percent. If nil, the
+	// brightness is not changed.
+	Brightness *byte `yaml:"brightness,omitempty" json:"brightness,omitempty"`
+
+	// Keys are the key definitions. Keys not defined are cleared.
+	Keys []Key `yaml:"keys" json:"keys"`
+
+	dir string
+}
+
+// Load loads a configuration from an io.Reader. The document may be YAML or
+// JSON. Relative icon paths are resolved from the current directory.
+func Load(r io.Reader) (*Config, error) {
+	rv := &Config{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(rv); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConfigInvalid, err)
+	}
+
+	seen := map[streamdeck.KeyID]bool{}
+	for _, k := range rv.Keys {
+		if seen[k.Key] {
+			return nil, fmt.Errorf("%w: duplicated key: %d", ErrConfigInvalid, k.Key)
+		}
+		seen[k.Key] = true
+
+		if _, err := parseColor(k.Color); err != nil {
+			return nil, err
+		}
+
+		if k.Exec != nil {
+			if err := k.Exec.validate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rv, nil
+}
+
+// LoadFile loads a configuration from a file. The document may be YAML or
+// JSON. Relative icon paths are resolved from the directory of the file.
+func LoadFile(name string) (*Config, error) {
+	fp, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	rv, err := Load(fp)
+	if err != nil {
+		return nil, err
+	}
+	rv.dir = filepath.Dir(name)
+	return rv, nil
+}
+
+func parseColor(s string) (color.Color, error) {
+	if s == "" {
+		return color.Black, nil
+	}
+
+	h, ok := strings.CutPrefix(s, "#")
+	if ok && len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if !ok || len(h) != 6 {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}, nil
+}
+
+func (c *Config) loadIcon(name string, size image.Point) (image.Image, error) {
+	if !filepath.IsAbs(name) && c.dir != "" {
+		name = filepath.Join(c.dir, name)
+	}
+
+	fp, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	if strings.EqualFold(filepath.Ext(name), ".svg") {
+		return streamdeck.RasterizeSVG(fp, size)
+	}
+
+	img, _, err := image.Decode(fp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrConfigInvalid, name, err)
+	}
+	return img, nil
+}
+
+func (c *Config) applyKey(dev *streamdeck.Device, k Key) error {
+	bg, err := parseColor(k.Color)
+	if err != nil {
+		return err
+	}
+
+	opts := streamdeck.TextOptions{
+		Background: bg,
+	}
+
+	if k.Icon != "" {
+		rect, err := dev.GetKeyImageRectangle()
+		if err != nil {
+			return err
+		}
+
+		icon, err := c.loadIcon(k.Icon, rect.Size())
+		if err != nil {
+			return err
+		}
+		opts.Icon = icon
+	}
+	return dev.SetKeyTextWithOptions(k.Key, k.Label, opts)
+}
+
+// Apply draws the layout to an Elgato Stream Deck device, and registers
+// handlers for the keys with actions, that send the action identifiers to
+// the actions channel when the keys are pressed. The channel should be
+// buffered or continuously read, because sends block the key handlers.
+// Handlers are also registered for the keys with commands, reporting the
+// failed commands as handler errors, with ErrCommandFailed.
+//
+// The handlers registered to the device may be removed with
+// Device.RemoveKeyHandlers.
+func (c *Config) Apply(dev *streamdeck.Device, actions chan<- string) error {
+	if dev == nil {
+		return fmt.Errorf("%w: device is nil", ErrConfigInvalid)
+	}
+
+	if c.Brightness != nil {
+		if err := dev.SetBrightness(*c.Brightness); err != nil {
+			return err
+		}
+	}
+
+	defined := map[streamdeck.KeyID]bool{}
+	for _, k := range c.Keys {
+		defined[k.Key] = true
+
+		if dev.GetKeyDisplaySupported() {
+			if err := c.applyKey(dev, k); err != nil {
+				return err
+			}
+		}
+
+		if k.Exec != nil {
+			if _, err := dev.AddKeyHandler(k.Key, c.execHandler(k)); err != nil {
+				return err
+			}
+		}
+
+		if k.Action == "" || actions == nil {
+			continue
+		}
+
+		action := k.Action
+		if _, err := dev.AddKeyHandler(k.Key, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			actions <- action
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !dev.GetKeyDisplaySupported() {
+		return nil
+	}
+	return dev.ForEachKey(func(key streamdeck.KeyID) error {
+		if defined[key] {
+			return nil
+		}
+		return dev.ClearKey(key)
+	})
+}
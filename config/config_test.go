@@ -0,0 +1,216 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(strings.NewReader(`{"brightness": 30, "keys": [{"key": 2, "color": "#f00", "action": "foo"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Brightness == nil || *cfg.Brightness != 30 {
+		t.Errorf("bad brightness: %v", cfg.Brightness)
+	}
+	if len(cfg.Keys) != 1 || cfg.Keys[0].Key != streamdeck.KEY_2 || cfg.Keys[0].Action != "foo" {
+		t.Errorf("bad keys: %+v", cfg.Keys)
+	}
+
+	for _, doc := range []string{
+		"keys: [{key: 1, color: red}]",
+		"keys: [{key: 1}, {key: 1}]",
+		"bola: 1",
+	} {
+		if _, err := Load(strings.NewReader(doc)); !errors.Is(err, ErrConfigInvalid) && !errors.Is(err, ErrColorInvalid) {
+			t.Errorf("unexpected error for %q: %v", doc, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+
+	icon := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := range icon.Pix {
+		icon.Pix[i] = 0xff
+	}
+	fp, err := os.Create(filepath.Join(dir, "icon.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(fp, icon); err != nil {
+		t.Fatal(err)
+	}
+	fp.Close()
+
+	name := filepath.Join(dir, "deck.yaml")
+	if err := os.WriteFile(name, []byte(`
+brightness: 70
+keys:
+  - key: 1
+    icon: icon.png
+    action: open
+  - key: 2
+    label: Hi
+    color: "#0000ff"
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	actions := make(chan string, 1)
+	if err := cfg.Apply(dev, actions); err != nil {
+		t.Fatal(err)
+	}
+
+	if b := m.Brightness(); b != 70 {
+		t.Errorf("bad brightness: %d", b)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{R: 0xff, G: 0xff, B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 2, 2, color.RGBA{})
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case a := <-actions:
+		if a != "open" {
+			t.Errorf("bad action: %s", a)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for action")
+	}
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands require a unix shell")
+	}
+
+	for _, doc := range []string{
+		"keys: [{key: 1, exec: {command: []}}]",
+		"keys: [{key: 1, exec: {command: [ls], timeout: -1s}}]",
+	} {
+		if _, err := Load(strings.NewReader(doc)); !errors.Is(err, ErrConfigInvalid) {
+			t.Errorf("unexpected error for %q: %v", doc, err)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "marker"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{dir: dir}
+	if err := cfg.run(&Exec{
+		Command: []string{"sh", "-c", `test "$FOO" = bar && test -f marker`},
+		Dir:     "sub",
+		Env:     map[string]string{"FOO": "bar"},
+	}); err != nil {
+		t.Error(err)
+	}
+	if err := cfg.run(&Exec{Command: []string{"false"}}); !errors.Is(err, ErrCommandFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := cfg.run(&Exec{Command: []string{"sleep", "5"}, Timeout: 100 * time.Millisecond}); !errors.Is(err, ErrCommandFailed) || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	cfg, err := Load(strings.NewReader(`
+keys:
+  - key: 1
+    color: "#0000ff"
+    exec:
+      command: ["true"]
+      timeout: 5s
+  - key: 2
+    exec:
+      command: ["false"]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Keys[0].Exec.Timeout != 5*time.Second {
+		t.Errorf("bad timeout: %s", cfg.Keys[0].Exec.Timeout)
+	}
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := cfg.Apply(dev, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+	go dev.Listen(errCh)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(execFlashDuration / 2)
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, execSuccessColor)
+	time.Sleep(execFlashDuration)
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{B: 0xff})
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(execFlashDuration / 2)
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, execFailureColor)
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrCommandFailed) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+const execFlashDuration = 300 * time.Millisecond
+
+var (
+	execSuccessColor = color.RGBA{G: 0xc0, A: 0xff}
+	execFailureColor = color.RGBA{R: 0xc0, A: 0xff}
+)
+
+// Exec represents an external command executed when a key is pressed.
+type Exec struct {
+	// Command is the command to execute, followed by its arguments. The
+	// command is not run by a shell.
+	Command []string `yaml:"command" json:"command"`
+
+	// Dir is the working directory of the command. Relative paths are
+	// resolved from the directory of the configuration file. If empty, the
+	// command runs in the current directory.
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"`
+
+	// Env are environment variables set for the command, in addition to the
+	// environment of the current process.
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Timeout is the maximum time the command is allowed to run, like "10s",
+	// before being killed. If zero, the command is not time-limited.
+	Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
+func (e *Exec) validate() error {
+	if len(e.Command) == 0 || e.Command[0] == "" {
+		return fmt.Errorf("%w: exec command is empty", ErrConfigInvalid)
+	}
+	if e.Timeout < 0 {
+		return fmt.Errorf("%w: exec timeout is negative: %s", ErrConfigInvalid, e.Timeout)
+	}
+	return nil
+}
+
+func (c *Config) run(e *Exec) error {
+	ctx := context.Background()
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, e.Command[0], e.Command[1:]...)
+
+	cmd.Dir = e.Dir
+	if cmd.Dir != "" && !filepath.IsAbs(cmd.Dir) && c.dir != "" {
+		cmd.Dir = filepath.Join(c.dir, cmd.Dir)
+	}
+
+	if len(e.Env) > 0 {
+		cmd.Env = os.Environ()
+		for _, k := range slices.Sorted(maps.Keys(e.Env)) {
+			cmd.Env = append(cmd.Env, k+"="+e.Env[k])
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%w: %s: timed out after %s", ErrCommandFailed, e.Command[0], e.Timeout)
+		}
+		return fmt.Errorf("%w: %s: %w", ErrCommandFailed, e.Command[0], err)
+	}
+	return nil
+}
+
+func (c *Config) execHandler(k Key) streamdeck.KeyHandler {
+	return func(d *streamdeck.Device, key *streamdeck.Key) error {
+		rerr := c.run(k.Exec)
+		if !d.GetKeyDisplaySupported() {
+			return rerr
+		}
+
+		// flash the key with the outcome of the command, then draw it back
+		flash := execSuccessColor
+		if rerr != nil {
+			flash = execFailureColor
+		}
+		if err := d.SetKeyColor(key.GetID(), flash); err != nil {
+			return errors.Join(rerr, err)
+		}
+		time.Sleep(execFlashDuration)
+
+		if err := c.applyKey(d, k); err != nil {
+			return errors.Join(rerr, err)
+		}
+		return rerr
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InputType represents the type of a pressable control of an Elgato Stream
+// Deck device.
+type InputType byte
+
+// String returns a string representation of the InputType.
+func (t InputType) String() string {
+	switch t {
+	case INPUT_TYPE_KEY:
+		return "INPUT_TYPE_KEY"
+	case INPUT_TYPE_TOUCH_POINT:
+		return "INPUT_TYPE_TOUCH_POINT"
+	case INPUT_TYPE_DIAL:
+		return "INPUT_TYPE_DIAL"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck input types. The pedal switches are keys without
+// displays.
+const (
+	INPUT_TYPE_KEY InputType = iota + 1
+	INPUT_TYPE_TOUCH_POINT
+	INPUT_TYPE_DIAL
+)
+
+// InputID identifies any pressable control of an Elgato Stream Deck device,
+// like a key, a touch point or a dial switch, so that applications can bind
+// actions to controls without model-specific code. InputID values are
+// comparable and may be used as map keys.
+type InputID struct {
+	Type  InputType
+	Index byte
+}
+
+// KeyInput returns the InputID of a key.
+func KeyInput(key KeyID) InputID {
+	return InputID{Type: INPUT_TYPE_KEY, Index: byte(key)}
+}
+
+// TouchPointInput returns the InputID of a touch point.
+func TouchPointInput(tp TouchPointID) InputID {
+	return InputID{Type: INPUT_TYPE_TOUCH_POINT, Index: byte(tp)}
+}
+
+// DialInput returns the InputID of the switch of a dial.
+func DialInput(di DialID) InputID {
+	return InputID{Type: INPUT_TYPE_DIAL, Index: byte(di)}
+}
+
+// ParseInputID parses the string representation of an InputID, as returned
+// by InputID.String, like "KEY_1", "TOUCH_POINT_2" or "DIAL_3".
+func ParseInputID(s string) (InputID, error) {
+	for _, t := range []InputType{INPUT_TYPE_KEY, INPUT_TYPE_TOUCH_POINT, INPUT_TYPE_DIAL} {
+		prefix := strings.TrimPrefix(t.String(), "INPUT_TYPE_") + "_"
+		if idx, ok := strings.CutPrefix(s, prefix); ok {
+			v, err := strconv.ParseUint(idx, 10, 8)
+			if err != nil || v == 0 {
+				break
+			}
+			return InputID{Type: t, Index: byte(v)}, nil
+		}
+	}
+	return InputID{}, fmt.Errorf("%w: %q", ErrInputInvalid, s)
+}
+
+// GetKeyID returns the KeyID identified by the InputID, if it identifies a
+// key.
+func (id InputID) GetKeyID() (KeyID, bool) {
+	return KeyID(id.Index), id.Type == INPUT_TYPE_KEY
+}
+
+// GetTouchPointID returns the TouchPointID identified by the InputID, if it
+// identifies a touch point.
+func (id InputID) GetTouchPointID() (TouchPointID, bool) {
+	return TouchPointID(id.Index), id.Type == INPUT_TYPE_TOUCH_POINT
+}
+
+// GetDialID returns the DialID identified by the InputID, if it identifies a
+// dial.
+func (id InputID) GetDialID() (DialID, bool) {
+	return DialID(id.Index), id.Type == INPUT_TYPE_DIAL
+}
+
+// String returns a string representation of the InputID.
+func (id InputID) String() string {
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return KeyID(id.Index).String()
+	case INPUT_TYPE_TOUCH_POINT:
+		return TouchPointID(id.Index).String()
+	case INPUT_TYPE_DIAL:
+		return DialID(id.Index).String()
+	default:
+		return fmt.Sprintf("INPUT_%d_%d", id.Type, id.Index)
+	}
+}
+
+// Control describes a pressable control of an Elgato Stream Deck device.
+type Control struct {
+	ID InputID
+
+	// Display reports if the control includes a display, that may be drawn
+	// with the functions of its type, like SetKeyImage, or with
+	// SetControlColor. Keys of models without key displays, like the
+	// pedal, and dials do not include displays.
+	Display bool
+
+	// Rotary reports if the control may also be rotated, like the dials.
+	Rotary bool
+}
+
+// InputHandler represents a callback function that is called when a control
+// is pressed. It receives the Device instance and the InputID of the control
+// as parameters.
+type InputHandler func(d *Device, id InputID) error
+
+// InputReleaseHandler represents a callback function that is called when a
+// control is released. It receives the Device instance, the InputID of the
+// control and the duration the control was held down as parameters.
+type InputReleaseHandler func(d *Device, id InputID, duration time.Duration) error
+
+// GetControls returns all the pressable controls available on the Elgato
+// Stream Deck device, in order: keys, touch points and dials.
+func (d *Device) GetControls() []Control {
+	rv := []Control{}
+	for key := range d.Keys() {
+		rv = append(rv, Control{
+			ID:      KeyInput(key),
+			Display: d.model.keyImageSend != nil,
+		})
+	}
+	if d.model.touchPointColorSend != nil {
+		for tp := range d.TouchPoints() {
+			rv = append(rv, Control{
+				ID:      TouchPointInput(tp),
+				Display: true,
+			})
+		}
+	}
+	for di := range d.Dials() {
+		rv = append(rv, Control{
+			ID:     DialInput(di),
+			Rotary: true,
+		})
+	}
+	return rv
+}
+
+func (d *Device) validateInput(id InputID) error {
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return d.validateKey(KeyID(id.Index))
+	case INPUT_TYPE_TOUCH_POINT:
+		return d.validateTouchPoint(TouchPointID(id.Index))
+	case INPUT_TYPE_DIAL:
+		return d.validateDial(DialID(id.Index))
+	default:
+		return fmt.Errorf("%w: %s", ErrInputInvalid, id)
+	}
+}
+
+// SetControlColor sets the color of a control with a display, filling the
+// key display or setting the touch point color.
+func (d *Device) SetControlColor(id InputID, c color.Color) error {
+	if err := d.validateInput(id); err != nil {
+		return err
+	}
+
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return d.SetKeyColor(KeyID(id.Index), c)
+	case INPUT_TYPE_TOUCH_POINT:
+		return d.SetTouchPointColor(TouchPointID(id.Index), c)
+	default:
+		return fmt.Errorf("%w: %s does not include a display", ErrInputInvalid, id)
+	}
+}
+
+// AddInputPressHandler registers an InputHandler callback to be called
+// whenever the given control is pressed, with the same semantics of the
+// press handlers of its type, like AddKeyPressHandler. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddInputPressHandler(id InputID, fn InputHandler) (*HandlerRegistration, error) {
+	if err := d.validateInput(id); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrInputHandlerInvalid)
+	}
+
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return d.AddKeyPressHandler(KeyID(id.Index), func(d *Device, k *Key) error {
+			return fn(d, id)
+		})
+	case INPUT_TYPE_TOUCH_POINT:
+		return d.AddTouchPointPressHandler(TouchPointID(id.Index), func(d *Device, tp *TouchPoint) error {
+			return fn(d, id)
+		})
+	default:
+		return d.AddDialPressHandler(DialID(id.Index), func(d *Device, di *Dial) error {
+			return fn(d, id)
+		})
+	}
+}
+
+// AddInputReleaseHandler registers an InputReleaseHandler callback to be
+// called whenever the given control is released, with the same semantics of
+// the release handlers of its type, like AddKeyReleaseHandler. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddInputReleaseHandler(id InputID, fn InputReleaseHandler) (*HandlerRegistration, error) {
+	if err := d.validateInput(id); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrInputHandlerInvalid)
+	}
+
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return d.AddKeyReleaseHandler(KeyID(id.Index), func(d *Device, k *Key, duration time.Duration) error {
+			return fn(d, id, duration)
+		})
+	case INPUT_TYPE_TOUCH_POINT:
+		return d.AddTouchPointReleaseHandler(TouchPointID(id.Index), func(d *Device, tp *TouchPoint, duration time.Duration) error {
+			return fn(d, id, duration)
+		})
+	default:
+		return d.AddDialReleaseHandler(DialID(id.Index), func(d *Device, di *Dial, duration time.Duration) error {
+			return fn(d, id, duration)
+		})
+	}
+}
+
+// IsInputPressed returns true if the given control is currently held down.
+func (d *Device) IsInputPressed(id InputID) (bool, error) {
+	switch id.Type {
+	case INPUT_TYPE_KEY:
+		return d.IsKeyPressed(KeyID(id.Index))
+	case INPUT_TYPE_TOUCH_POINT:
+		return d.IsTouchPointPressed(TouchPointID(id.Index))
+	case INPUT_TYPE_DIAL:
+		return d.IsDialPressed(DialID(id.Index))
+	default:
+		return false, fmt.Errorf("%w: %s", ErrInputInvalid, id)
+	}
+}
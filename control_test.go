@@ -0,0 +1,147 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestControls(t *testing.T) {
+	for _, tc := range []struct {
+		model    string
+		controls int
+		display  int
+		rotary   int
+	}{
+		{"mk2", 15, 15, 0},
+		{"plus", 12, 8, 4},
+		{"neo", 10, 10, 0},
+		{"pedal", 3, 0, 0},
+	} {
+		t.Run(tc.model, func(t *testing.T) {
+			dev, _, err := mock.Open(tc.model)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			controls := dev.GetControls()
+			if len(controls) != tc.controls {
+				t.Fatalf("unexpected number of controls: %d", len(controls))
+			}
+			display, rotary := 0, 0
+			for _, c := range controls {
+				if c.Display {
+					display++
+				}
+				if c.Rotary {
+					rotary++
+				}
+				id, err := streamdeck.ParseInputID(c.ID.String())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if id != c.ID {
+					t.Errorf("unexpected input id: got %s, want %s", id, c.ID)
+				}
+			}
+			if display != tc.display {
+				t.Errorf("unexpected number of controls with display: %d", display)
+			}
+			if rotary != tc.rotary {
+				t.Errorf("unexpected number of rotary controls: %d", rotary)
+			}
+		})
+	}
+
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	for _, c := range dev.GetControls() {
+		if _, err := dev.AddInputPressHandler(c.ID, func(d *streamdeck.Device, id streamdeck.InputID) error {
+			events <- "press " + id.String()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dev.AddInputReleaseHandler(c.ID, func(d *streamdeck.Device, id streamdeck.InputID, duration time.Duration) error {
+			events <- "release " + id.String()
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	go dev.Listen(nil)
+
+	wait := func(want string) {
+		t.Helper()
+
+		select {
+		case ev := <-events:
+			if ev != want {
+				t.Errorf("unexpected event: %q", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for event: %q", want)
+		}
+	}
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	wait("press KEY_2")
+	if pressed, err := dev.IsInputPressed(streamdeck.KeyInput(streamdeck.KEY_2)); err != nil || !pressed {
+		t.Errorf("unexpected pressed state: %t, %v", pressed, err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	wait("release KEY_2")
+
+	if err := m.PressDial(streamdeck.DIAL_3); err != nil {
+		t.Fatal(err)
+	}
+	wait("press DIAL_3")
+	if err := m.ReleaseDial(streamdeck.DIAL_3); err != nil {
+		t.Fatal(err)
+	}
+	wait("release DIAL_3")
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	if err := dev.SetControlColor(streamdeck.KeyInput(streamdeck.KEY_1), red); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, red)
+	if err := dev.SetControlColor(streamdeck.DialInput(streamdeck.DIAL_1), red); !errors.Is(err, streamdeck.ErrInputInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := dev.AddInputPressHandler(streamdeck.TouchPointInput(streamdeck.TOUCH_POINT_1), func(d *streamdeck.Device, id streamdeck.InputID) error {
+		return nil
+	}); !errors.Is(err, streamdeck.ErrDeviceTouchPointNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := dev.AddInputPressHandler(streamdeck.KeyInput(streamdeck.KEY_1), nil); !errors.Is(err, streamdeck.ErrInputHandlerInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := dev.IsInputPressed(streamdeck.InputID{}); !errors.Is(err, streamdeck.ErrInputInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, s := range []string{"", "KEY_", "KEY_0", "KEY_256", "BOLA_1"} {
+		if _, err := streamdeck.ParseInputID(s); !errors.Is(err, streamdeck.ErrInputInvalid) {
+			t.Errorf("%q: unexpected error: %v", s, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"time"
+)
+
+type debounce struct {
+	pressed bool
+	t       time.Time
+	timer   *time.Timer
+}
+
+// SetDebounceInterval sets the time the keys, touch points and dial switches
+// of the Elgato Stream Deck device must settle for after a press or release,
+// before another state change is reported. Changes within the interval are
+// deferred to its end, when only the latest state is reported, if different,
+// so that noisy switches report a single press, and taps shorter than the
+// interval are still reported. If zero or negative, the default, every
+// change received from the device is reported.
+func (d *Device) SetDebounceInterval(interval time.Duration) {
+	d.debounce.Store(int64(max(interval, 0)))
+}
+
+// GetDebounceInterval returns the time the switches of the Elgato Stream Deck
+// device must settle for after a press or release.
+func (d *Device) GetDebounceInterval() time.Duration {
+	return time.Duration(d.debounce.Load())
+}
+
+// transition reports a press or release of the input, unless it happened
+// within the debounce interval of the previous one. It must be called with
+// the input mutex held.
+func (in *input) transition(t time.Time, pressed bool, errCh chan error) {
+	db := &in.debounce
+	db.pressed = pressed
+	db.t = t
+
+	if interval := in.device.GetDebounceInterval(); interval > 0 && !in.changed.IsZero() {
+		if wait := interval - t.Sub(in.changed); wait > 0 {
+			if db.timer == nil {
+				db.timer = time.AfterFunc(wait, func() {
+					in.mtx.Lock()
+					defer in.mtx.Unlock()
+
+					db.timer = nil
+					if db.pressed == !in.pressed.IsZero() {
+						return
+					}
+					if db.pressed {
+						in.applyPress(db.t, errCh)
+					} else {
+						in.applyRelease(db.t, errCh)
+					}
+				})
+			}
+			return
+		}
+	}
+
+	if pressed {
+		in.applyPress(t, errCh)
+	} else {
+		in.applyRelease(t, errCh)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDebounce(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	dev.SetDebounceInterval(-time.Second)
+	if iv := dev.GetDebounceInterval(); iv != 0 {
+		t.Errorf("unexpected debounce interval: %s", iv)
+	}
+	dev.SetDebounceInterval(50 * time.Millisecond)
+
+	events := make(chan string, 10)
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "press"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyReleaseHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+		events <- "release"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	// a bouncing press and release, followed by a tap shorter than the
+	// interval, that is still reported
+	for _, seq := range [][]func(streamdeck.KeyID) error{
+		{m.PressKey, m.ReleaseKey, m.PressKey},
+		{m.ReleaseKey, m.PressKey, m.ReleaseKey},
+		{m.PressKey, m.ReleaseKey},
+	} {
+		for _, fn := range seq {
+			if err := fn(streamdeck.KEY_1); err != nil {
+				t.Fatal(err)
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	for _, want := range []string{"press", "release", "press", "release"} {
+		select {
+		case e := <-events:
+			if e != want {
+				t.Fatalf("unexpected event: got %q, want %q", e, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %q", want)
+		}
+	}
+
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event: %q", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"image"
+	"image/color"
+)
+
+// Deck represents the core interface of an Elgato Stream Deck device,
+// implemented by *Device. Applications may depend on it instead of *Device to
+// make their own abstractions easier to test.
+type Deck interface {
+	Open() error
+	OpenWithOptions(opts OpenOptions) error
+	IsOpen() bool
+	Close() error
+	CloseWithoutClear() error
+	Listen(errCh chan error) error
+	ListenContext(ctx context.Context, errCh chan error) error
+	Reset() error
+
+	GetModelName() string
+	GetModelID() string
+	GetSerialNumber() string
+	GetHardwareSerialNumber() (string, error)
+	GetVendorID() uint16
+	GetProductID() uint16
+	GetPath() string
+	GetLocation() (string, error)
+	GetFirmwareVersion() (string, error)
+	GetFirmwareVersions() (FirmwareVersions, error)
+	GetKeyCount() byte
+	GetKeyLayout() (int, int)
+	GetTouchPointCount() byte
+	GetDialCount() byte
+	GetKeyDisplaySupported() bool
+	GetInfoBarSupported() bool
+	GetTouchStripSupported() bool
+	GetCapabilities() Capabilities
+
+	ForEachKey(cb func(k KeyID) error) error
+	ForEachTouchPoint(cb func(tp TouchPointID) error) error
+	ForEachDial(cb func(di DialID) error) error
+
+	AddKeyHandler(key KeyID, fn KeyHandler) (*HandlerRegistration, error)
+	AddKeyContextHandler(key KeyID, fn KeyContextHandler) (*HandlerRegistration, error)
+	AddKeyPressHandler(key KeyID, fn KeyHandler) (*HandlerRegistration, error)
+	AddKeyReleaseHandler(key KeyID, fn KeyReleaseHandler) (*HandlerRegistration, error)
+	AddTouchPointHandler(tp TouchPointID, fn TouchPointHandler) (*HandlerRegistration, error)
+	AddTouchPointContextHandler(tp TouchPointID, fn TouchPointContextHandler) (*HandlerRegistration, error)
+	AddTouchPointPressHandler(tp TouchPointID, fn TouchPointHandler) (*HandlerRegistration, error)
+	AddTouchPointReleaseHandler(tp TouchPointID, fn TouchPointReleaseHandler) (*HandlerRegistration, error)
+	AddDialSwitchHandler(di DialID, fn DialSwitchHandler) (*HandlerRegistration, error)
+	AddDialSwitchContextHandler(di DialID, fn DialSwitchContextHandler) (*HandlerRegistration, error)
+	AddDialPressHandler(di DialID, fn DialSwitchHandler) (*HandlerRegistration, error)
+	AddDialReleaseHandler(di DialID, fn DialReleaseHandler) (*HandlerRegistration, error)
+	AddDialRotateHandler(di DialID, fn DialRotateHandler) (*HandlerRegistration, error)
+	AddDialRotationHandler(di DialID, fn DialRotationHandler) (*HandlerRegistration, error)
+	AddTouchStripTouchHandler(fn TouchStripTouchHandler) (*HandlerRegistration, error)
+	AddTouchStripSwipeHandler(fn TouchStripSwipeHandler) (*HandlerRegistration, error)
+	AddTouchStripDragHandler(fn TouchStripDragHandler) (*HandlerRegistration, error)
+
+	SetBrightness(perc byte) error
+	GetBrightness() (byte, error)
+
+	SetKeyImage(key KeyID, img image.Image) error
+	SetKeyColor(key KeyID, c color.Color) error
+	ClearKey(key KeyID) error
+	GetKeyImageRectangle() (image.Rectangle, error)
+
+	SetInfoBarImage(img image.Image) error
+	SetInfoBarColor(c color.Color) error
+	ClearInfoBar() error
+	GetInfoBarImageRectangle() (image.Rectangle, error)
+
+	SetTouchPointColor(tp TouchPointID, c color.Color) error
+	ClearTouchPoint(tp TouchPointID) error
+
+	SetTouchStripImage(img image.Image) error
+	SetTouchStripImageWithRectangle(img image.Image, rect image.Rectangle) error
+	SetTouchStripColor(c color.Color) error
+	SetTouchStripColorWithRectangle(c color.Color, rect image.Rectangle) error
+	ClearTouchStrip() error
+	ClearTouchStripWithRectangle(rect image.Rectangle) error
+	GetTouchStripImageRectangle() (image.Rectangle, error)
+}
+
+var _ Deck = (*Device)(nil)
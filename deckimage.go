@@ -0,0 +1,80 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+)
+
+// deckKeyRect returns the rectangle covered by a key display in the
+// full-deck canvas.
+func (m *model) deckKeyRect(key KeyID) image.Rectangle {
+	row, col := m.keyPosition(key)
+	pt := image.Pt(
+		col*(m.keyImageRect.Dx()+m.keyImageGap.X),
+		row*(m.keyImageRect.Dy()+m.keyImageGap.Y),
+	)
+	return image.Rectangle{Min: pt, Max: pt.Add(m.keyImageRect.Size())}
+}
+
+// GetDeckImageRectangle returns an image.Rectangle representing the geometry
+// of a virtual canvas covering all the Elgato Stream Deck key background
+// displays, including the physical gaps between the keys. The gaps are
+// approximated, in display pixels, from the hardware of each model.
+func (d *Device) GetDeckImageRectangle() (image.Rectangle, error) {
+	if err := d.validateKeyDisplay(); err != nil {
+		return image.Rectangle{}, err
+	}
+
+	cols := int(d.model.keyColumns)
+	rows := d.model.keyRows()
+	return image.Rect(0, 0,
+		cols*d.model.keyImageRect.Dx()+(cols-1)*d.model.keyImageGap.X,
+		rows*d.model.keyImageRect.Dy()+(rows-1)*d.model.keyImageGap.Y,
+	), nil
+}
+
+// GetDeckKeyRectangle returns an image.Rectangle representing the area of
+// the canvas returned by GetDeckImageRectangle that is displayed by a given
+// Elgato Stream Deck key.
+func (d *Device) GetDeckKeyRectangle(key KeyID) (image.Rectangle, error) {
+	if err := d.validateKey(key); err != nil {
+		return image.Rectangle{}, err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return image.Rectangle{}, err
+	}
+
+	return d.model.deckKeyRect(key), nil
+}
+
+// SetDeckImage draws a given image.Image across all the Elgato Stream Deck
+// key background displays, as if they were a single display. The image is
+// scaled as needed to fit the canvas returned by GetDeckImageRectangle, and
+// the areas behind the physical gaps between the keys are not displayed.
+func (d *Device) SetDeckImage(img image.Image) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	rect, err := d.GetDeckImageRectangle()
+	if err != nil {
+		return err
+	}
+	canvas := scaleImage(img, rect, d.GetImageOptions())
+
+	return d.ForEachKey(func(key KeyID) error {
+		return d.setKeyImage(key, canvas.SubImage(d.model.deckKeyRect(key)))
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"image/color"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDeckImage(t *testing.T) {
+	for _, id := range []string{"original", "mk2", "plus"} {
+		t.Run(id, func(t *testing.T) {
+			dev, m, err := mock.Open(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			rect, err := dev.GetDeckImageRectangle()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := dev.SetDeckImage(testImage(rect)); err != nil {
+				t.Fatal(err)
+			}
+
+			kr, err := dev.GetKeyImageRectangle()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first := streamdeck.KEY_1
+			last := streamdeck.KEY_1 + streamdeck.KeyID(dev.GetKeyCount()) - 1
+			if r, err := dev.GetDeckKeyRectangle(last); err != nil || r.Max != rect.Max {
+				t.Errorf("bad deck key rectangle: %s, %v", r, err)
+			}
+
+			assertColor(t, m.KeyImage(first), kr.Dx()/2, kr.Dy()/2, color.RGBA{R: 0xff, B: 0xff})
+			assertColor(t, m.KeyImage(last), kr.Dx()/2, kr.Dy()/2, color.RGBA{})
+			if w := m.Writes(); len(w) != int(dev.GetKeyCount()) {
+				t.Errorf("bad writes: %d", len(w))
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+)
+
+type deckArea struct {
+	cells image.Rectangle
+	img   image.Image
+	text  string
+	opts  TextOptions
+	color color.Color
+}
+
+// DeckLayout is a logical layout of the Elgato Stream Deck keys, made of
+// named rectangular areas spanning one or more keys, rendered to the
+// full-deck canvas and sliced across the keys by Device.SetDeckLayout.
+type DeckLayout struct {
+	rows  int
+	cols  int
+	areas map[string]*deckArea
+}
+
+// NewDeckLayout creates a DeckLayout from a template, with a string per row
+// of keys and a whitespace separated area name per key, similar to the grid
+// template areas of CSS. Each area must be a rectangle. Keys named "." are
+// not part of any area. For example, a title bar across the top row of a
+// Stream Deck MK.2, with an image block of 2x2 keys and buttons beside it:
+//
+//	l, err := streamdeck.NewDeckLayout(
+//		"title title title title title",
+//		"img   img   .     .     .",
+//		"img   img   prev  play  next",
+//	)
+func NewDeckLayout(template ...string) (*DeckLayout, error) {
+	rv := &DeckLayout{
+		rows:  len(template),
+		areas: map[string]*deckArea{},
+	}
+	if rv.rows == 0 {
+		return nil, fmt.Errorf("streamdeck: %w: template is empty", ErrDeckLayoutInvalid)
+	}
+
+	cells := map[string]int{}
+	for row, line := range template {
+		names := strings.Fields(line)
+		if row == 0 {
+			rv.cols = len(names)
+		}
+		if len(names) == 0 || len(names) != rv.cols {
+			return nil, fmt.Errorf("streamdeck: %w: row %d has %d keys", ErrDeckLayoutInvalid, row, len(names))
+		}
+
+		for col, name := range names {
+			if name == "." {
+				continue
+			}
+
+			cell := image.Rect(col, row, col+1, row+1)
+			if a, found := rv.areas[name]; found {
+				a.cells = a.cells.Union(cell)
+			} else {
+				rv.areas[name] = &deckArea{cells: cell}
+			}
+			cells[name]++
+		}
+	}
+
+	for name, a := range rv.areas {
+		if a.cells.Dx()*a.cells.Dy() != cells[name] {
+			return nil, fmt.Errorf("streamdeck: %w: area is not rectangular: %s", ErrDeckLayoutInvalid, name)
+		}
+	}
+	return rv, nil
+}
+
+func (l *DeckLayout) area(name string) (*deckArea, error) {
+	a, found := l.areas[name]
+	if !found {
+		return nil, fmt.Errorf("streamdeck: %w: area not found: %s", ErrDeckLayoutInvalid, name)
+	}
+	return a, nil
+}
+
+// SetAreaImage sets the image.Image displayed by an area of the layout,
+// scaled to fit the area. If the area also has a text, the image is rendered
+// above it, as the icon of the text.
+func (l *DeckLayout) SetAreaImage(name string, img image.Image) error {
+	a, err := l.area(name)
+	if err != nil {
+		return err
+	}
+	a.img = img
+	return nil
+}
+
+// SetAreaText sets the text rendered by an area of the layout, using the
+// given TextOptions. The Icon text option is replaced by the image of the
+// area, if any.
+func (l *DeckLayout) SetAreaText(name string, text string, opts TextOptions) error {
+	a, err := l.area(name)
+	if err != nil {
+		return err
+	}
+	a.text = text
+	a.opts = opts
+	return nil
+}
+
+// SetAreaColor sets the background color of an area of the layout. If nil,
+// the background of the text options is used, if the area has a text, and
+// black otherwise.
+func (l *DeckLayout) SetAreaColor(name string, c color.Color) error {
+	a, err := l.area(name)
+	if err != nil {
+		return err
+	}
+	a.color = c
+	return nil
+}
+
+// deckAreaRect returns the rectangle covered by an area in the full-deck
+// canvas, including the gaps between its keys, so that the contents of the
+// area are continuous across the physical keys.
+func (d *Device) deckAreaRect(cells image.Rectangle) image.Rectangle {
+	keyW := d.model.keyImageRect.Dx() + d.model.keyImageGap.X
+	keyH := d.model.keyImageRect.Dy() + d.model.keyImageGap.Y
+	return image.Rect(
+		cells.Min.X*keyW,
+		cells.Min.Y*keyH,
+		cells.Max.X*keyW-d.model.keyImageGap.X,
+		cells.Max.Y*keyH-d.model.keyImageGap.Y,
+	)
+}
+
+func (d *Device) renderDeckArea(a *deckArea, rect image.Rectangle) (*image.RGBA, error) {
+	if a.text != "" {
+		opts := d.textOptions(a.opts)
+		opts.Icon = a.img
+		if a.color != nil {
+			opts.Background = a.color
+		}
+		return renderText(rect, a.text, opts, d.GetAccessibilityOptions())
+	}
+
+	rv := image.NewRGBA(rect)
+	if a.color != nil {
+		draw.Draw(rv, rect, &imageColor{c: a.color, b: rect}, rect.Min, draw.Src)
+	}
+	if a.img != nil {
+		img := scaleImage(a.img, rect, d.GetImageOptions())
+		draw.Draw(rv, rect, img, rect.Min, draw.Over)
+	}
+	return rv, nil
+}
+
+// SetDeckLayout renders a DeckLayout to the full-deck canvas returned by
+// GetDeckImageRectangle, and draws it across all the Elgato Stream Deck key
+// background displays. Areas spanning several keys are rendered as a single
+// image that includes the physical gaps between the keys, so that images and
+// text are not distorted, but the parts behind the gaps are not displayed.
+// Keys outside of the layout areas are cleared. The layout must not have
+// more rows or columns than the key grid of the device.
+func (d *Device) SetDeckLayout(l *DeckLayout) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if l == nil {
+		return wrapErr(ErrDeckLayoutInvalid)
+	}
+
+	if l.rows > d.model.keyRows() || l.cols > int(d.model.keyColumns) {
+		return fmt.Errorf("streamdeck: %w: layout has %dx%d keys, device has %dx%d", ErrDeckLayoutInvalid, l.rows, l.cols, d.model.keyRows(), d.model.keyColumns)
+	}
+
+	rect, err := d.GetDeckImageRectangle()
+	if err != nil {
+		return err
+	}
+
+	canvas := image.NewRGBA(rect)
+	draw.Draw(canvas, rect, image.Black, image.Point{}, draw.Src)
+	for _, a := range l.areas {
+		area := d.deckAreaRect(a.cells)
+		img, err := d.renderDeckArea(a, area.Sub(area.Min))
+		if err != nil {
+			return wrapErr(err)
+		}
+		draw.Draw(canvas, area, img, image.Point{}, draw.Src)
+	}
+
+	return d.ForEachKey(func(key KeyID) error {
+		return d.setKeyImage(key, canvas.SubImage(d.model.deckKeyRect(key)))
+	})
+}
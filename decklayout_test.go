@@ -0,0 +1,84 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDeckLayout(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	l, err := streamdeck.NewDeckLayout(
+		"title title title title title",
+		"img   img   .     .     .",
+		"img   img   prev  play  next",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	draw.Draw(img, image.Rect(0, 0, 50, 100), &image.Uniform{color.RGBA{R: 0xff, A: 0xff}}, image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(50, 0, 100, 100), &image.Uniform{color.RGBA{B: 0xff, A: 0xff}}, image.Point{}, draw.Src)
+	if err := l.SetAreaImage("img", img); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetAreaText("title", "Title", streamdeck.TextOptions{Background: color.RGBA{G: 0xff, A: 0xff}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetAreaColor("play", color.White); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetAreaText("bola", "", streamdeck.TextOptions{}); !errors.Is(err, streamdeck.ErrDeckLayoutInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := dev.SetKeyColor(streamdeck.KEY_8, color.White); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetDeckLayout(l); err != nil {
+		t.Fatal(err)
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{G: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_5), 70, 70, color.RGBA{G: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_6), 36, 36, color.RGBA{R: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_7), 36, 36, color.RGBA{B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_12), 36, 36, color.RGBA{B: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_8), 36, 36, color.RGBA{})
+	assertColor(t, m.KeyImage(streamdeck.KEY_14), 36, 36, color.RGBA{R: 0xff, G: 0xff, B: 0xff})
+
+	// the image block is rendered across the gap between its keys
+	assertColor(t, m.KeyImage(streamdeck.KEY_6), 70, 36, color.RGBA{R: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_7), 2, 36, color.RGBA{B: 0xff})
+
+	for _, template := range [][]string{
+		{},
+		{"a a", "a"},
+		{"a b a"},
+		{"a a", "a ."},
+		{"a . . . . ."},
+	} {
+		l, err := streamdeck.NewDeckLayout(template...)
+		if err == nil {
+			err = dev.SetDeckLayout(l)
+		}
+		if !errors.Is(err, streamdeck.ErrDeckLayoutInvalid) {
+			t.Errorf("%q: unexpected error: %v", template, err)
+		}
+	}
+}
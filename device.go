@@ -9,56 +9,150 @@
 package streamdeck
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"image"
+	"iter"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/image/font/opentype"
 	"rafaelmartins.com/p/usbhid"
 )
 
 // Errors returned from streamdeck package may be tested against these errors
 // with errors.Is.
 var (
+	ErrAnimationInvalid             = errors.New("animation is not valid")
+	ErrBrightnessSourceInvalid      = errors.New("brightness source is not valid")
+	ErrDeckLayoutInvalid            = errors.New("deck layout is not valid")
+	ErrDeviceBrightnessNotSupported = errors.New("device hardware does not supports brightness control")
 	ErrDeviceEnumerationFailed      = usbhid.ErrDeviceEnumerationFailed
+	ErrDeviceEventHandlerInvalid    = errors.New("device event handler is not valid")
 	ErrDeviceFailedToClose          = usbhid.ErrDeviceFailedToClose
 	ErrDeviceFailedToOpen           = usbhid.ErrDeviceFailedToOpen
 	ErrDeviceInfoBarNotSupported    = errors.New("device hardware does not includes an info bar")
 	ErrDeviceIsClosed               = usbhid.ErrDeviceIsClosed
 	ErrDeviceIsOpen                 = usbhid.ErrDeviceIsOpen
+	ErrDeviceKeyDisplayNotSupported = errors.New("device hardware does not includes key displays")
+	ErrDeviceLocationNotSupported   = errors.New("device location is not supported")
 	ErrDeviceLocked                 = usbhid.ErrDeviceLocked
+	ErrDeviceSerialNotSupported     = errors.New("device hardware does not support reading the serial number")
+	ErrDeviceSleepNotSupported      = errors.New("device hardware does not support sleeping")
 	ErrDeviceTouchPointNotSupported = errors.New("device hardware does not includes touch points")
 	ErrDeviceTouchStripNotSupported = errors.New("device hardware does not includes a touch strip")
 	ErrDialHandlerInvalid           = errors.New("dial handler is not valid")
 	ErrDialInvalid                  = errors.New("dial is not valid")
+	ErrDialValueInvalid             = errors.New("dial value is not valid")
+	ErrDispatchModeInvalid          = errors.New("dispatch mode is not valid")
+	ErrFontInvalid                  = errors.New("font is not valid")
+	ErrFrameRateInvalid             = errors.New("frame rate is not valid")
+	ErrFrameSinkInvalid             = errors.New("frame sink is not valid")
 	ErrGetFeatureReportFailed       = usbhid.ErrGetFeatureReportFailed
 	ErrGetInputReportFailed         = usbhid.ErrGetInputReportFailed
+	ErrIconInvalid                  = errors.New("icon is not valid")
+	ErrIdleActionInvalid            = errors.New("idle action is not valid")
 	ErrImageInvalid                 = errors.New("image is not valid")
+	ErrInputHandlerInvalid          = errors.New("input handler is not valid")
+	ErrInputInvalid                 = errors.New("input is not valid")
+	ErrInputRecordingInvalid        = errors.New("input recording is not valid")
+	ErrInputReleased                = errors.New("input was released")
 	ErrKeyHandlerInvalid            = errors.New("key handler is not valid")
 	ErrKeyInvalid                   = errors.New("key is not valid")
+	ErrKeyPositionInvalid           = errors.New("key position is not valid")
+	ErrModelInvalid                 = errors.New("model is not valid")
 	ErrMoreThanOneDeviceFound       = usbhid.ErrMoreThanOneDeviceFound
 	ErrNoDeviceFound                = usbhid.ErrNoDeviceFound
+	ErrPageInvalid                  = errors.New("page is not valid")
+	ErrPreparedImageInvalid         = errors.New("prepared image is not valid")
 	ErrReportBufferOverflow         = usbhid.ErrReportBufferOverflow
+	ErrSelectorInvalid              = errors.New("selector is not valid")
+	ErrSessionLockNotSupported      = errors.New("session lock monitoring is not supported on this platform")
 	ErrSetFeatureReportFailed       = usbhid.ErrSetFeatureReportFailed
 	ErrSetOutputReportFailed        = usbhid.ErrSetOutputReportFailed
+	ErrStateInvalid                 = errors.New("state is not valid")
+	ErrSVGNotSupported              = errors.New("svg rasterizer not registered")
 	ErrTouchPointHandlerInvalid     = errors.New("touch point handler is not valid")
 	ErrTouchPointInvalid            = errors.New("touch point is not valid")
 	ErrTouchStripHandlerInvalid     = errors.New("touch strip handler is not valid")
 )
 
+// HIDDevice represents the USB HID device used to communicate with an Elgato
+// Stream Deck device. It is implemented by *usbhid.Device, and may be
+// implemented by fake devices, to test applications without physical
+// hardware. Implementations must not retain the data passed to
+// SetOutputReport after returning, as the buffer is reused.
+type HIDDevice interface {
+	Open(lock bool) error
+	IsOpen() bool
+	Close() error
+	GetInputReport() (byte, []byte, error)
+	SetOutputReport(reportId byte, data []byte) error
+	GetFeatureReport(reportId byte) ([]byte, error)
+	SetFeatureReport(reportId byte, data []byte) error
+	GetInputReportLength() uint16
+	GetOutputReportLength() uint16
+	GetFeatureReportLength() uint16
+	Path() string
+	VendorId() uint16
+	ProductId() uint16
+	Product() string
+	SerialNumber() string
+}
+
 // Device represents an Elgato Stream Deck device and provides methods to
 // interact with it, including setting key images, handling input events, and
 // controlling device settings.
+//
+// A Device is safe for concurrent use by multiple goroutines once opened.
+// Writes to the device are serialized internally, so that the multi-report
+// transfers of images to different displays are never interleaved. Open and
+// Close must not be called concurrently with other methods.
 type Device struct {
-	dev             *usbhid.Device
+	dev             HIDDevice
 	model           *model
+	inputsOnce      sync.Once
 	inputs          []*input
 	dialInputs      []*input
 	touchStripInput *input
-	keyStates       []byte
-	dialStates      []byte
 	listen          chan struct{}
+	done            chan struct{}
+	reports         chan inputReport
 	open            bool
+	clearOnClose    bool
+	brightnessMtx   sync.Mutex
+	writeMtx        sync.Mutex
+	logger          atomic.Pointer[slog.Logger]
+	dispatchMode    atomic.Uint32
+	dispatchQueue   dispatchQueue
+	debounce        atomic.Int64
+	errorSink       atomic.Pointer[errorSinkValue]
+	errorQueue      errorQueue
+	frames          frameGovernor
+
+	mtx             sync.Mutex
+	keyStates       []byte
+	dialStates      []byte
+	brightness      byte
+	brightnessValid bool
+	sleeping        bool
+	sessionLocked   bool
+	brightnessFade  uint64
+	brightnessBind  chan struct{}
+	accessibility   AccessibilityOptions
+	imageOptions    ImageOptions
+	fonts           []*opentype.Font
+	pages           *Pages
+	idle            *idleMonitor
+	state           displayState
+	writeCache      writeCache
+	overlays        keyOverlays
+	journal         *stateJournal
+	recorder        *inputRecorder
 }
 
 func wrapErr(err error) error {
@@ -90,10 +184,30 @@ func Enumerate() ([]*Device, error) {
 	return rv, nil
 }
 
+// NewDevice creates an Elgato Stream Deck device from a HIDDevice. This is
+// useful to use the package with fake devices, like the ones provided by the
+// mock package. Devices connected to the computer should be obtained with
+// Enumerate or GetDevice instead.
+func NewDevice(dev HIDDevice) (*Device, error) {
+	if dev == nil {
+		return nil, wrapErr(ErrNoDeviceFound)
+	}
+
+	model, err := getModel(dev)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return &Device{
+		dev:   dev,
+		model: model,
+	}, nil
+}
+
 // GetDevice returns an Elgato Stream Deck device found connected to the
 // machine that matches the provided serial number. If serial number is empty
 // and only one device is connected, this device is returned, otherwise an
-// error is returned.
+// error is returned. Devices may be selected by other criteria with
+// GetDeviceWith.
 func GetDevice(serialNumber string) (*Device, error) {
 	devices, err := usbhid.Enumerate(enumerateFunc)
 	if err != nil {
@@ -144,18 +258,120 @@ func (d *Device) IsOpen() bool {
 	return d.open && d.dev.IsOpen()
 }
 
+// OpenOptions represents the settings used to open an Elgato Stream Deck
+// device with Device.OpenWithOptions. Device.Open uses Exclusive and
+// ClearOnClose set to true, without retries, and Device.OpenShared uses all
+// the settings disabled.
+type OpenOptions struct {
+	// Exclusive locks the USB HID device, so that other processes can not
+	// open it while it is open.
+	Exclusive bool
+
+	// ClearOnClose clears all the displays when the device is closed. If
+	// false, the current content is kept on-screen after closing, for
+	// example to keep a layout visible while a daemon restarts.
+	ClearOnClose bool
+
+	// RetryCount is the number of times opening the USB HID device is
+	// retried after failing, for example while another process still holds
+	// its lock.
+	RetryCount int
+
+	// RetryInterval is the time waited between retries. If zero, defaults to
+	// 100 milliseconds.
+	RetryInterval time.Duration
+}
+
+// DeviceLockedError represents a failure to open an Elgato Stream Deck
+// device exclusively, because another process holds its lock. It wraps
+// ErrDeviceLocked. The PID and the name of the process holding the lock are
+// only reported on Linux, and are zero and empty if not known.
+type DeviceLockedError struct {
+	Path    string
+	PID     int
+	Process string
+	Err     error
+}
+
+// Error returns a string representation of a device locked error.
+func (e DeviceLockedError) Error() string {
+	if e.PID == 0 {
+		return e.Err.Error()
+	}
+	if e.Process == "" {
+		return fmt.Sprintf("%s (pid %d)", e.Err, e.PID)
+	}
+	return fmt.Sprintf("%s (pid %d: %s)", e.Err, e.PID, e.Process)
+}
+
+// Unwrap returns the underlying device locked error.
+func (e DeviceLockedError) Unwrap() error {
+	return e.Err
+}
+
+func lockedError(path string, err error) error {
+	rv := DeviceLockedError{
+		Path: path,
+		Err:  err,
+	}
+	if pid, process, err := deviceLockOwner(path); err == nil {
+		rv.PID = pid
+		rv.Process = process
+	}
+	return rv
+}
+
 // Open opens the Elgato Stream Deck device for usage.
 func (d *Device) Open() error {
+	return d.OpenWithOptions(OpenOptions{
+		Exclusive:    true,
+		ClearOnClose: true,
+	})
+}
+
+// OpenShared opens the Elgato Stream Deck device without locking it, and
+// without clearing the displays when closed, so that monitoring tools can
+// read the device information while another process owns the displays.
+// Devices opened exclusively by other processes can still be opened with
+// OpenShared, but writing to the displays of a device used by another
+// process results in mixed content.
+func (d *Device) OpenShared() error {
+	return d.OpenWithOptions(OpenOptions{})
+}
+
+// OpenWithOptions opens the Elgato Stream Deck device for usage, with the
+// provided settings. If the device is exclusively opened by another process,
+// the error returned is a DeviceLockedError.
+func (d *Device) OpenWithOptions(opts OpenOptions) error {
 	if d.IsOpen() {
 		return wrapErr(ErrDeviceIsOpen)
 	}
 
-	if err := d.dev.Open(true); err != nil {
-		return wrapErr(err)
+	interval := opts.RetryInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+
+	for i := 0; ; i++ {
+		err := d.dev.Open(opts.Exclusive)
+		if err == nil {
+			break
+		}
+		if i >= opts.RetryCount {
+			if opts.Exclusive && errors.Is(err, ErrDeviceLocked) {
+				return wrapErr(lockedError(d.dev.Path(), err))
+			}
+			return wrapErr(err)
+		}
+		time.Sleep(interval)
 	}
 
+	d.writeCache.reset()
+	d.frames.start()
+	d.clearOnClose = opts.ClearOnClose
 	d.open = true
 	d.listen = make(chan struct{})
+	d.done = make(chan struct{})
 	return nil
 }
 
@@ -166,14 +382,36 @@ func (d *Device) validateOpen() error {
 	return nil
 }
 
-// Close closes the Elgato Stream Deck device.
+// Close closes the Elgato Stream Deck device. All the displays are cleared,
+// unless the device was opened with the ClearOnClose option disabled.
 func (d *Device) Close() error {
+	return d.close(d.clearOnClose)
+}
+
+// CloseWithoutClear closes the Elgato Stream Deck device, keeping the current
+// content on-screen, regardless of the options used to open it. This is
+// useful for supervising processes that restart without the displays
+// flashing to black.
+func (d *Device) CloseWithoutClear() error {
+	return d.close(false)
+}
+
+func (d *Device) close(clearDisplays bool) error {
 	if err := d.validateOpen(); err != nil {
 		return err
 	}
 
-	if err := d.closeDisplays(); err != nil {
-		return wrapErr(err)
+	d.stopIdle(false)
+	d.frames.stop()
+
+	if clearDisplays {
+		if err := d.closeDisplays(); err != nil {
+			return wrapErr(err)
+		}
+	}
+
+	if err := d.stopJournal(); err != nil {
+		return err
 	}
 
 	if d.listen != nil {
@@ -181,6 +419,15 @@ func (d *Device) Close() error {
 		d.listen = nil
 	}
 
+	if d.done != nil {
+		close(d.done)
+		d.done = nil
+	}
+
+	d.mtx.Lock()
+	d.reports = nil
+	d.mtx.Unlock()
+
 	if err := d.dev.Close(); err != nil {
 		return err
 	}
@@ -207,6 +454,13 @@ func (d *Device) validateTouchPoint(tp TouchPointID) error {
 	return nil
 }
 
+func (d *Device) validateKeyDisplay() error {
+	if d.model.keyImageSend == nil {
+		return wrapErr(ErrDeviceKeyDisplayNotSupported)
+	}
+	return nil
+}
+
 func (d *Device) validateInfoBar() error {
 	if d.model.infoBarImageSend == nil {
 		return wrapErr(ErrDeviceInfoBarNotSupported)
@@ -229,177 +483,586 @@ func (d *Device) validateTouchStrip() error {
 }
 
 // AddKeyHandler registers a KeyHandler callback to be called whenever the
-// given key is pressed.
-func (d *Device) AddKeyHandler(key KeyID, fn KeyHandler) error {
+// given key is pressed. The returned HandlerRegistration can be used to
+// unregister the callback.
+func (d *Device) AddKeyHandler(key KeyID, fn KeyHandler) (*HandlerRegistration, error) {
 	if err := d.validateKey(key); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrKeyHandlerInvalid)
+		return nil, wrapErr(ErrKeyHandlerInvalid)
 	}
 
-	if d.inputs == nil {
-		d.inputs = newInputs(d, d.model.keyCount, d.model.touchPointCount)
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			return in.key.addHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrKeyInvalid, key)
+}
+
+// AddKeyContextHandler registers a KeyContextHandler callback to be called
+// whenever the given key is pressed. The context passed to the callback is
+// cancelled when the key is released, with ErrInputReleased as cause, or when
+// the device is closed, with ErrDeviceIsClosed as cause. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddKeyContextHandler(key KeyID, fn KeyContextHandler) (*HandlerRegistration, error) {
+	if err := d.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrKeyHandlerInvalid)
 	}
 
+	d.initInputs()
+
 	for _, in := range d.inputs {
 		if in.key != nil && in.key.id == key {
-			in.key.addHandler(fn)
-			return nil
+			return in.key.addContextHandler(fn), nil
 		}
 	}
-	return fmt.Errorf("%w: %s", ErrKeyInvalid, key)
+	return nil, fmt.Errorf("%w: %s", ErrKeyInvalid, key)
 }
 
 // AddTouchPointHandler registers a TouchPointHandler callback to be called
-// whenever the given touch point is pressed.
-func (d *Device) AddTouchPointHandler(tp TouchPointID, fn TouchPointHandler) error {
+// whenever the given touch point is pressed. The returned HandlerRegistration
+// can be used to unregister the callback.
+func (d *Device) AddTouchPointHandler(tp TouchPointID, fn TouchPointHandler) (*HandlerRegistration, error) {
 	if err := d.validateTouchPoint(tp); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrTouchPointHandlerInvalid)
+		return nil, wrapErr(ErrTouchPointHandlerInvalid)
 	}
 
-	if d.inputs == nil {
-		d.inputs = newInputs(d, d.model.keyCount, d.model.touchPointCount)
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.tp != nil && in.tp.id == tp {
+			return in.tp.addHandler(fn), nil
+		}
 	}
+	return nil, fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
+}
+
+// AddTouchPointContextHandler registers a TouchPointContextHandler callback
+// to be called whenever the given touch point is pressed. The context passed
+// to the callback is cancelled when the touch point is released or the device
+// is closed, as done by AddKeyContextHandler. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddTouchPointContextHandler(tp TouchPointID, fn TouchPointContextHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchPoint(tp); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchPointHandlerInvalid)
+	}
+
+	d.initInputs()
 
 	for _, in := range d.inputs {
 		if in.tp != nil && in.tp.id == tp {
-			in.tp.addHandler(fn)
-			return nil
+			return in.tp.addContextHandler(fn), nil
 		}
 	}
-	return fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
+	return nil, fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
 }
 
 // AddDialSwitchHandler registers a DialSwitchHandler callback to be called
-// whenever the given dial is pressed.
-func (d *Device) AddDialSwitchHandler(di DialID, fn DialSwitchHandler) error {
+// whenever the given dial is pressed. The returned HandlerRegistration can be
+// used to unregister the callback.
+func (d *Device) AddDialSwitchHandler(di DialID, fn DialSwitchHandler) (*HandlerRegistration, error) {
 	if err := d.validateDial(di); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrDialHandlerInvalid)
+		return nil, wrapErr(ErrDialHandlerInvalid)
 	}
 
-	if d.dialInputs == nil {
-		d.dialInputs = newDialInputs(d, d.model.dialCount)
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			return in.dial.addSwitchHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
+}
+
+// AddDialSwitchContextHandler registers a DialSwitchContextHandler callback
+// to be called whenever the given dial is pressed. The context passed to the
+// callback is cancelled when the dial switch is released or the device is
+// closed, as done by AddKeyContextHandler. The returned HandlerRegistration
+// can be used to unregister the callback.
+func (d *Device) AddDialSwitchContextHandler(di DialID, fn DialSwitchContextHandler) (*HandlerRegistration, error) {
+	if err := d.validateDial(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrDialHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			return in.dial.addContextHandler(fn), nil
+		}
 	}
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
+}
+
+// AddDialRotateHandler registers a DialRotateHandler callback to be called
+// whenever the given dial is rotated. The returned HandlerRegistration can be
+// used to unregister the callback.
+func (d *Device) AddDialRotateHandler(di DialID, fn DialRotateHandler) (*HandlerRegistration, error) {
+	if err := d.validateDial(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrDialHandlerInvalid)
+	}
+
+	d.initInputs()
 
 	for _, in := range d.dialInputs {
 		if in.dial != nil && in.dial.id == di {
-			in.dial.addSwitchHandler(fn)
-			return nil
+			return in.dial.addRotateHandler(fn), nil
 		}
 	}
-	return fmt.Errorf("%w: %s", ErrDialInvalid, di)
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
 }
 
-// AddDialRotateHandler registers a DialSwitchHandler callback to be called
-// whenever the given dial is rotated.
-func (d *Device) AddDialRotateHandler(di DialID, fn DialRotateHandler) error {
+// AddDialRotationHandler registers a DialRotationHandler callback to be called
+// whenever the given dial is rotated, with the rotation reports coalesced and
+// accelerated as defined by SetDialRotationOptions. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddDialRotationHandler(di DialID, fn DialRotationHandler) (*HandlerRegistration, error) {
 	if err := d.validateDial(di); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrDialHandlerInvalid)
+		return nil, wrapErr(ErrDialHandlerInvalid)
 	}
 
-	if d.dialInputs == nil {
-		d.dialInputs = newDialInputs(d, d.model.dialCount)
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			return in.dial.addRotationHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
+}
+
+// AddKeyPressHandler registers a KeyHandler callback to be called whenever the
+// given key is pressed. Unlike the callbacks registered with AddKeyHandler,
+// press and release callbacks are called in order, one at a time for each
+// key, and must not block waiting for the key to be released. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddKeyPressHandler(key KeyID, fn KeyHandler) (*HandlerRegistration, error) {
+	if err := d.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrKeyHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			return in.key.addPressHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrKeyInvalid, key)
+}
+
+// AddKeyReleaseHandler registers a KeyReleaseHandler callback to be called
+// whenever the given key is released. The returned HandlerRegistration can be
+// used to unregister the callback.
+func (d *Device) AddKeyReleaseHandler(key KeyID, fn KeyReleaseHandler) (*HandlerRegistration, error) {
+	if err := d.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrKeyHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			return in.key.addReleaseHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrKeyInvalid, key)
+}
+
+// AddTouchPointPressHandler registers a TouchPointHandler callback to be
+// called whenever the given touch point is pressed. Press and release
+// callbacks are called in order, one at a time for each touch point. The
+// returned HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddTouchPointPressHandler(tp TouchPointID, fn TouchPointHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchPoint(tp); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchPointHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.tp != nil && in.tp.id == tp {
+			return in.tp.addPressHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
+}
+
+// AddTouchPointReleaseHandler registers a TouchPointReleaseHandler callback to
+// be called whenever the given touch point is released. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddTouchPointReleaseHandler(tp TouchPointID, fn TouchPointReleaseHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchPoint(tp); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchPointHandlerInvalid)
 	}
 
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.tp != nil && in.tp.id == tp {
+			return in.tp.addReleaseHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
+}
+
+// AddDialPressHandler registers a DialSwitchHandler callback to be called
+// whenever the given dial is pressed. Press and release callbacks are called
+// in order, one at a time for each dial. The returned HandlerRegistration can
+// be used to unregister the callback.
+func (d *Device) AddDialPressHandler(di DialID, fn DialSwitchHandler) (*HandlerRegistration, error) {
+	if err := d.validateDial(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrDialHandlerInvalid)
+	}
+
+	d.initInputs()
+
 	for _, in := range d.dialInputs {
 		if in.dial != nil && in.dial.id == di {
-			in.dial.addRotateHandler(fn)
-			return nil
+			return in.dial.addPressHandler(fn), nil
 		}
 	}
-	return fmt.Errorf("%w: %s", ErrDialInvalid, di)
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
+}
+
+// AddDialReleaseHandler registers a DialReleaseHandler callback to be called
+// whenever the given dial is released. The returned HandlerRegistration can be
+// used to unregister the callback.
+func (d *Device) AddDialReleaseHandler(di DialID, fn DialReleaseHandler) (*HandlerRegistration, error) {
+	if err := d.validateDial(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrDialHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			return in.dial.addReleaseHandler(fn), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrDialInvalid, di)
 }
 
 // AddTouchStripTouchHandler registers a TouchStripTouchHandler callback to be
-// called whenever the touch strip is touched.
-func (d *Device) AddTouchStripTouchHandler(fn TouchStripTouchHandler) error {
+// called whenever the touch strip is touched. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddTouchStripTouchHandler(fn TouchStripTouchHandler) (*HandlerRegistration, error) {
 	if err := d.validateTouchStrip(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrTouchStripHandlerInvalid)
+		return nil, wrapErr(ErrTouchStripHandlerInvalid)
 	}
 
-	if d.touchStripInput == nil {
-		d.touchStripInput = newTouchStripInput(d)
-	}
+	d.initInputs()
 
-	d.touchStripInput.touchStrip.addTouchHandler(fn)
-	return nil
+	return d.touchStripInput.touchStrip.addTouchHandler(fn), nil
 }
 
 // AddTouchStripSwipeHandler registers a TouchStripSwipeHandler callback to be
-// called whenever the touch strip is swiped.
-func (d *Device) AddTouchStripSwipeHandler(fn TouchStripSwipeHandler) error {
+// called whenever the touch strip is swiped. The returned
+// HandlerRegistration can be used to unregister the callback.
+func (d *Device) AddTouchStripSwipeHandler(fn TouchStripSwipeHandler) (*HandlerRegistration, error) {
 	if err := d.validateTouchStrip(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if fn == nil {
-		return wrapErr(ErrTouchStripHandlerInvalid)
+		return nil, wrapErr(ErrTouchStripHandlerInvalid)
 	}
 
-	if d.touchStripInput == nil {
-		d.touchStripInput = newTouchStripInput(d)
+	d.initInputs()
+
+	return d.touchStripInput.touchStrip.addSwipeHandler(fn), nil
+}
+
+// RemoveKeyHandlers unregisters all the KeyHandler, KeyContextHandler and
+// KeyReleaseHandler callbacks registered for the given key.
+func (d *Device) RemoveKeyHandlers(key KeyID) error {
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			in.mtx.Lock()
+			in.key.handlers = nil
+			in.key.contextHandlers = nil
+			in.key.pressHandlers = nil
+			in.key.releaseHandlers = nil
+			in.mtx.Unlock()
+		}
+	}
+	return nil
+}
+
+// RemoveTouchPointHandlers unregisters all the TouchPointHandler,
+// TouchPointContextHandler and TouchPointReleaseHandler callbacks registered
+// for the given touch point.
+func (d *Device) RemoveTouchPointHandlers(tp TouchPointID) error {
+	if err := d.validateTouchPoint(tp); err != nil {
+		return err
 	}
 
-	d.touchStripInput.touchStrip.addSwipeHandler(fn)
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.tp != nil && in.tp.id == tp {
+			in.mtx.Lock()
+			in.tp.handlers = nil
+			in.tp.contextHandlers = nil
+			in.tp.pressHandlers = nil
+			in.tp.releaseHandlers = nil
+			in.mtx.Unlock()
+		}
+	}
 	return nil
 }
 
+// RemoveDialHandlers unregisters all the DialSwitchHandler,
+// DialSwitchContextHandler, DialReleaseHandler, DialRotateHandler and
+// DialRotationHandler callbacks registered for the given dial.
+func (d *Device) RemoveDialHandlers(di DialID) error {
+	if err := d.validateDial(di); err != nil {
+		return err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			in.mtx.Lock()
+			in.dial.switchHandlers = nil
+			in.dial.contextHandlers = nil
+			in.dial.pressHandlers = nil
+			in.dial.releaseHandlers = nil
+			in.dial.rotateHandlers = nil
+			in.dial.rotationHandlers = nil
+			in.mtx.Unlock()
+		}
+	}
+	return nil
+}
+
+// RemoveTouchStripHandlers unregisters all the TouchStripTouchHandler,
+// TouchStripSwipeHandler and TouchStripDragHandler callbacks.
+func (d *Device) RemoveTouchStripHandlers() error {
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	d.initInputs()
+	in := d.touchStripInput
+	in.mtx.Lock()
+	in.touchStrip.touchHandlers = nil
+	in.touchStrip.swipeHandlers = nil
+	in.touchStrip.dragHandlers = nil
+	in.mtx.Unlock()
+	return nil
+}
+
+type inputReport struct {
+	id  byte
+	buf []byte
+	err error
+}
+
+// inputReports returns a channel delivering the input reports read from the
+// device. Reports are read by a single goroutine, that outlives individual
+// Listen calls, so that cancelling a listener never loses a report that was
+// already read from the device.
+// initInputs creates the inputs of the device, that hold their handlers, on
+// first use. They are never replaced, so that handlers may be registered
+// concurrently with Listen.
+func (d *Device) initInputs() {
+	d.inputsOnce.Do(func() {
+		d.inputs = newInputs(d, d.model.keyCount, d.model.touchPointCount)
+		d.dialInputs = newDialInputs(d, d.model.dialCount)
+		d.touchStripInput = newTouchStripInput(d)
+	})
+}
+
+func (d *Device) inputReports() chan inputReport {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.reports != nil {
+		return d.reports
+	}
+
+	reports := make(chan inputReport)
+	done := d.done
+	d.reports = reports
+
+	go func() {
+		defer func() {
+			d.mtx.Lock()
+			if d.reports == reports {
+				d.reports = nil
+			}
+			d.mtx.Unlock()
+		}()
+
+		for {
+			id, buf, err := d.dev.GetInputReport()
+
+			select {
+			case reports <- inputReport{id: id, buf: buf, err: err}:
+			case <-done:
+				return
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return reports
+}
+
 // Listen listens to input events from the Elgato Stream Deck device and calls
 // handler callbacks as required.
 //
 // errCh is an error channel to receive errors from the input handlers. If set
-// to a nil channel, errors are sent to standard logger. Errors are sent
-// non-blocking.
+// to a nil channel, errors are sent to the device logger. Errors are sent
+// non-blocking, and dropped if the channel is not ready to receive them, so
+// SetErrorSink is preferred to receive every error. Panics in the input
+// handlers are recovered and reported as errors wrapping a HandlerPanicError.
 func (d *Device) Listen(errCh chan error) error {
+	return d.ListenContext(context.Background(), errCh)
+}
+
+// ListenContext listens to input events from the Elgato Stream Deck device
+// and calls handler callbacks as required, until the context is cancelled or
+// the device is closed.
+//
+// errCh is an error channel to receive errors from the input handlers. If set
+// to a nil channel, errors are sent to the device logger. Errors are sent
+// non-blocking, and dropped if the channel is not ready to receive them, so
+// SetErrorSink is preferred to receive every error. Panics in the input
+// handlers are recovered and reported as errors wrapping a HandlerPanicError.
+func (d *Device) ListenContext(ctx context.Context, errCh chan error) error {
 	if err := d.validateOpen(); err != nil {
 		return err
 	}
 
+	// input states are only written by the listener, with the mutex held,
+	// so that they can be read by GetInputState
+	d.mtx.Lock()
 	if i := int(d.model.keyCount + d.model.touchPointCount); len(d.keyStates) != i {
 		d.keyStates = make([]byte, i)
 	}
 	if len(d.dialStates) != int(d.model.dialCount) {
 		d.dialStates = make([]byte, d.model.dialCount)
 	}
+	d.mtx.Unlock()
+	d.initInputs()
+
+	listen := d.listen
+	if listen == nil {
+		return nil
+	}
+	reports := d.inputReports()
 
 	for {
+		var rep inputReport
 		select {
-		case <-d.listen:
+		case <-ctx.Done():
 			return nil
-		default:
-			if d.listen == nil {
+		case <-listen:
+			return nil
+		case rep = <-reports:
+		}
+
+		if rep.err != nil {
+			select {
+			case <-listen:
 				return nil
+			default:
 			}
+			return wrapErr(rep.err)
 		}
-
-		id, buf, err := d.dev.GetInputReport()
-		if err != nil {
-			return wrapErr(err)
+		if rep.id != 1 {
+			return fmt.Errorf("streamdeck: got unexpected report id: %d", rep.id)
 		}
-		if id != 1 {
-			return fmt.Errorf("streamdeck: got unexpected report id: %d", id)
+		buf := rep.buf
+		if len(buf) == 0 {
+			d.logWarn("streamdeck: empty input report")
+			continue
 		}
+		d.recordInput(buf)
+
+		// the input waking the device up may be swallowed, but the input
+		// states are still tracked, to not dispatch an orphan release later
+		swallow := d.idleActivity()
 
 		if buf[0] == 2 && d.model.touchStripImageSend != nil {
-			if d.touchStripInput == nil {
+			if swallow {
+				continue
+			}
+			if len(buf) < 4 {
+				d.logWarn("streamdeck: truncated touch strip report", "length", len(buf))
 				continue
 			}
 
@@ -416,6 +1079,7 @@ func (d *Device) Listen(errCh chan error) error {
 				}
 
 				if len(buf) < 9 {
+					d.logWarn("streamdeck: truncated touch strip report", "length", len(buf))
 					continue
 				}
 
@@ -426,6 +1090,7 @@ func (d *Device) Listen(errCh chan error) error {
 
 			case 3:
 				if len(buf) < 13 {
+					d.logWarn("streamdeck: truncated touch strip report", "length", len(buf))
 					continue
 				}
 
@@ -436,12 +1101,22 @@ func (d *Device) Listen(errCh chan error) error {
 					X: int(buf[10])<<8 | int(buf[9]),
 					Y: int(buf[12])<<8 | int(buf[11]),
 				}, errCh)
+
+			default:
+				d.logWarn("streamdeck: unknown touch strip event", "type", buf[3])
 			}
 			continue
 		}
 
 		if buf[0] == 3 && d.model.dialCount > 0 {
-			states := buf[d.model.dialStart : d.model.dialStart+d.model.dialCount]
+			end := int(d.model.dialStart) + int(d.model.dialCount)
+			if len(buf) < max(4, end) {
+				d.logWarn("streamdeck: truncated dial report", "length", len(buf))
+				continue
+			}
+
+			// copied, as the states are kept after the report is handled
+			states := append([]byte{}, buf[d.model.dialStart:end]...)
 			switch buf[3] {
 			case 0:
 				t := time.Now()
@@ -455,30 +1130,53 @@ func (d *Device) Listen(errCh chan error) error {
 
 					inp := d.dialInputs[i]
 					if st > 0 {
-						inp.press(t, errCh)
+						if !swallow {
+							inp.press(t, errCh)
+						}
 					} else {
-						inp.release(t)
+						inp.release(t, errCh)
 					}
 				}
+				d.mtx.Lock()
 				d.dialStates = states
+				d.mtx.Unlock()
 				continue
 
 			case 1:
+				t := time.Now()
 				for i, st := range states {
 					if i >= len(d.dialInputs) {
 						continue
 					}
-					if st != 0 {
-						d.dialInputs[i].rotate(int8(st), errCh)
+					if st != 0 && !swallow {
+						d.dialInputs[i].rotate(t, int8(st), errCh)
 					}
 				}
+
+			default:
+				d.logWarn("streamdeck: unknown dial event", "type", buf[3])
 			}
 			continue
 		}
 
-		states := buf[d.model.keyStart : d.model.keyStart+d.model.keyCount]
+		end := int(d.model.keyStart) + int(d.model.keyCount)
+		tpEnd := int(d.model.touchPointStart) + int(d.model.touchPointCount)
+		if len(buf) < end || len(buf) < tpEnd {
+			d.logWarn("streamdeck: truncated key report", "length", len(buf))
+			continue
+		}
+
+		// copied, as the states are kept after the report is handled
+		states := append([]byte{}, buf[d.model.keyStart:end]...)
+		if d.model.keyMirrored {
+			hw := states
+			states = make([]byte, len(hw))
+			for i := range hw {
+				states[i] = hw[d.model.keyIndex(byte(i))]
+			}
+		}
 		if d.model.touchPointCount > 0 {
-			states = append(states, buf[d.model.touchPointStart:d.model.touchPointStart+d.model.touchPointCount]...)
+			states = append(states, buf[d.model.touchPointStart:tpEnd]...)
 		}
 
 		t := time.Now()
@@ -492,12 +1190,16 @@ func (d *Device) Listen(errCh chan error) error {
 
 			inp := d.inputs[i]
 			if st > 0 {
-				inp.press(t, errCh)
+				if !swallow {
+					inp.press(t, errCh)
+				}
 			} else {
-				inp.release(t)
+				inp.release(t, errCh)
 			}
 		}
+		d.mtx.Lock()
 		d.keyStates = states
+		d.mtx.Unlock()
 	}
 }
 
@@ -512,9 +1214,78 @@ func (d *Device) GetModelID() string {
 	return d.model.id
 }
 
-// GetSerialNumber returns the serial number of the Elgato Stream Deck device.
+// GetSerialNumber returns the serial number of the Elgato Stream Deck device,
+// as reported by the USB string descriptor. If the descriptor is blank and
+// the device is open, the serial number is read from the device hardware, as
+// returned by GetHardwareSerialNumber.
 func (d *Device) GetSerialNumber() string {
-	return d.dev.SerialNumber()
+	rv := d.dev.SerialNumber()
+	if strings.TrimSpace(rv) != "" || !d.IsOpen() {
+		return rv
+	}
+
+	if hw, err := d.GetHardwareSerialNumber(); err == nil {
+		return hw
+	}
+	return rv
+}
+
+// GetHardwareSerialNumber returns the serial number of the Elgato Stream Deck
+// device, read from the device hardware with a feature report instead of the
+// USB string descriptor, that is empty or wrong on some platforms.
+func (d *Device) GetHardwareSerialNumber() (string, error) {
+	if err := d.validateOpen(); err != nil {
+		return "", err
+	}
+
+	if d.model.serialNumber == nil {
+		return "", wrapErr(ErrDeviceSerialNotSupported)
+	}
+
+	d.writeMtx.Lock()
+	rv, err := d.model.serialNumber(d.dev)
+	d.writeMtx.Unlock()
+	if err != nil {
+		return "", wrapErr(err)
+	}
+	return strings.TrimSpace(rv), nil
+}
+
+// GetVendorID returns the USB vendor identifier of the Elgato Stream Deck
+// device.
+func (d *Device) GetVendorID() uint16 {
+	return d.dev.VendorId()
+}
+
+// GetProductID returns the USB product identifier of the Elgato Stream Deck
+// device.
+func (d *Device) GetProductID() uint16 {
+	return d.dev.ProductId()
+}
+
+// GetPath returns the operating system path of the Elgato Stream Deck USB HID
+// device. The path is unique among the connected devices, but may change when
+// the device is reconnected.
+func (d *Device) GetPath() string {
+	return d.dev.Path()
+}
+
+// GetLocation returns the physical location of the Elgato Stream Deck device,
+// identifying the USB port it is connected to, like "1-2.3" for port 3 of a
+// hub connected to port 2 of bus 1. The location is kept when the device is
+// reconnected to the same port, and can be used to distinguish identical
+// devices or persist bindings by port. It is only supported on Linux, unless
+// the HIDDevice implements a Location method returning it.
+func (d *Device) GetLocation() (string, error) {
+	if l, ok := d.dev.(interface{ Location() string }); ok {
+		return l.Location(), nil
+	}
+
+	rv, err := deviceLocation(d.dev.Path())
+	if err != nil {
+		return "", wrapErr(err)
+	}
+	return rv, nil
 }
 
 // GetKeyCount returns the number of keys available on the Elgato Stream Deck
@@ -535,6 +1306,12 @@ func (d *Device) GetDialCount() byte {
 	return d.model.dialCount
 }
 
+// GetKeyDisplaySupported returns a boolean reporting if the Elgato Stream Deck
+// device keys include background displays.
+func (d *Device) GetKeyDisplaySupported() bool {
+	return d.model.keyImageSend != nil
+}
+
 // GetInfoBarSupported returns a boolean reporting if the Elgato Stream Deck
 // device includes an info bar display.
 func (d *Device) GetInfoBarSupported() bool {
@@ -554,13 +1331,55 @@ func (d *Device) GetFirmwareVersion() (string, error) {
 		return "", err
 	}
 
+	d.writeMtx.Lock()
 	rv, err := d.model.firmwareVersion(d.dev)
+	d.writeMtx.Unlock()
 	if err != nil {
 		return "", wrapErr(err)
 	}
 	return rv, nil
 }
 
+// FirmwareVersions represents the versions of the firmware components of an
+// Elgato Stream Deck device. Components not reported by the device are empty.
+type FirmwareVersions struct {
+	// Application is the version of the main firmware, as returned by
+	// GetFirmwareVersion.
+	Application string
+
+	// Bootloader is the version of the bootloader (LD) firmware.
+	Bootloader string
+
+	// Secondary is the version of the secondary application (AP1) firmware,
+	// running on the display controller of some models.
+	Secondary string
+}
+
+// GetFirmwareVersions returns the versions of all the firmware components
+// reported by the Elgato Stream Deck device. Older models report the main
+// firmware version only.
+func (d *Device) GetFirmwareVersions() (FirmwareVersions, error) {
+	if err := d.validateOpen(); err != nil {
+		return FirmwareVersions{}, err
+	}
+
+	if d.model.firmwareVersions == nil {
+		v, err := d.GetFirmwareVersion()
+		if err != nil {
+			return FirmwareVersions{}, err
+		}
+		return FirmwareVersions{Application: v}, nil
+	}
+
+	d.writeMtx.Lock()
+	rv, err := d.model.firmwareVersions(d.dev)
+	d.writeMtx.Unlock()
+	if err != nil {
+		return FirmwareVersions{}, wrapErr(err)
+	}
+	return rv, nil
+}
+
 // Reset resets the Elgato Stream Deck device.
 //
 // Please note that this will close the connection, because this is similar to
@@ -570,23 +1389,150 @@ func (d *Device) Reset() error {
 		return err
 	}
 
-	if err := d.model.reset(d.dev); err != nil {
+	d.writeMtx.Lock()
+	err := d.model.reset(d.dev)
+	d.writeMtx.Unlock()
+	if err != nil {
 		return wrapErr(err)
 	}
+	d.writeCache.reset()
 
 	return d.dev.Close()
 }
 
+// Reinitialize brings the Elgato Stream Deck device back to a known state
+// without closing the connection, to recover from garbled displays. It checks
+// that the device still responds by querying its firmware version, discards
+// the deferred frames, clears all the displays and the touch points, even if
+// they were already cleared, and writes the last brightness set again.
+func (d *Device) Reinitialize() error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if _, err := d.GetFirmwareVersion(); err != nil {
+		return err
+	}
+
+	d.frames.mtx.Lock()
+	d.frames.cancel()
+	d.frames.mtx.Unlock()
+
+	d.writeCache.reset()
+	if err := d.closeDisplays(); err != nil {
+		return wrapErr(err)
+	}
+
+	if d.model.brightness == nil {
+		return nil
+	}
+
+	d.brightnessMtx.Lock()
+	defer d.brightnessMtx.Unlock()
+
+	// cancel any fade in progress
+	d.mtx.Lock()
+	d.brightnessFade++
+	perc, valid, sleeping := d.brightness, d.brightnessValid, d.sleeping
+	d.mtx.Unlock()
+
+	if sleeping {
+		perc = 0
+	} else if !valid {
+		return nil
+	}
+	return wrapErr(d.writeBrightness(perc))
+}
+
 // SetBrightness sets the Elgato Stream Deck device brightness, in percent.
+// The brightness applies to all the displays and the touch point LEDs
+// together, as none of the supported models provide a command to control
+// them separately.
 func (d *Device) SetBrightness(perc byte) error {
 	if err := d.validateOpen(); err != nil {
 		return err
 	}
 
+	d.brightnessMtx.Lock()
+	defer d.brightnessMtx.Unlock()
+
+	// cancel any fade in progress
+	d.mtx.Lock()
+	d.brightnessFade++
+	d.mtx.Unlock()
+
+	return d.setBrightness(perc)
+}
+
+// writeBrightness writes the brightness to the device hardware.
+func (d *Device) writeBrightness(perc byte) error {
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+	return d.model.brightness(d.dev, perc)
+}
+
+func (d *Device) setBrightness(perc byte) error {
+	if d.model.brightness == nil {
+		return wrapErr(ErrDeviceBrightnessNotSupported)
+	}
+
+	if perc > 100 {
+		perc = 100
+	}
+	if err := d.writeBrightness(perc); err != nil {
+		return wrapErr(err)
+	}
+
+	d.mtx.Lock()
+	d.brightness = perc
+	d.brightnessValid = true
+	d.sleeping = false
+	d.mtx.Unlock()
+	return nil
+}
+
+// GetBrightness returns the last brightness set to the Elgato Stream Deck
+// device, in percent, including the intermediate values of fades in
+// progress. As the hardware does not allow reading the brightness back, 100
+// is returned if it was never set.
+func (d *Device) GetBrightness() (byte, error) {
+	if d.model.brightness == nil {
+		return 0, wrapErr(ErrDeviceBrightnessNotSupported)
+	}
+	return d.lastBrightness(), nil
+}
+
+// lastBrightness returns the last brightness set with SetBrightness, or 100
+// if it was never set, as the hardware does not allow reading it back.
+func (d *Device) lastBrightness() byte {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.brightnessValid {
+		return 100
+	}
+	return d.brightness
+}
+
+// applyBrightness sets the device brightness without touching the value
+// cached by SetBrightness, to be used by subsystems that change the
+// brightness temporarily.
+func (d *Device) applyBrightness(perc byte) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if d.model.brightness == nil {
+		return wrapErr(ErrDeviceBrightnessNotSupported)
+	}
+
 	if perc > 100 {
 		perc = 100
 	}
-	return wrapErr(d.model.brightness(d.dev, perc))
+
+	d.brightnessMtx.Lock()
+	defer d.brightnessMtx.Unlock()
+	return wrapErr(d.writeBrightness(perc))
 }
 
 // ForEachKey calls the provided callback function for each key available on
@@ -635,3 +1581,43 @@ func (d *Device) ForEachDial(cb func(di DialID) error) error {
 	}
 	return nil
 }
+
+// Keys returns an iterator over the keys available on the Elgato Stream Deck
+// device, to be used with range loops:
+//
+//	for key := range device.Keys() {
+//		device.SetKeyColor(key, color.White)
+//	}
+func (d *Device) Keys() iter.Seq[KeyID] {
+	return func(yield func(KeyID) bool) {
+		for key := KEY_1; key < KEY_1+KeyID(d.model.keyCount); key++ {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// TouchPoints returns an iterator over the touch points available on the
+// Elgato Stream Deck device, to be used with range loops.
+func (d *Device) TouchPoints() iter.Seq[TouchPointID] {
+	return func(yield func(TouchPointID) bool) {
+		for tp := TOUCH_POINT_1; tp < TOUCH_POINT_1+TouchPointID(d.model.touchPointCount); tp++ {
+			if !yield(tp) {
+				return
+			}
+		}
+	}
+}
+
+// Dials returns an iterator over the dials available on the Elgato Stream
+// Deck device, to be used with range loops.
+func (d *Device) Dials() iter.Seq[DialID] {
+	return func(yield func(DialID) bool) {
+		for di := DIAL_1; di < DIAL_1+DialID(d.model.dialCount); di++ {
+			if !yield(di) {
+				return
+			}
+		}
+	}
+}
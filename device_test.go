@@ -0,0 +1,598 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func testImage(rect image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c := color.RGBA{A: 0xff}
+			if x < rect.Min.X+rect.Dx()/2 {
+				c.R = 0xff
+			}
+			if y < rect.Min.Y+rect.Dy()/2 {
+				c.B = 0xff
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func TestConcurrentWrites(t *testing.T) {
+	dev, m, err := mock.Open("xl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a noisy image, so that it is split into several reports
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 37), G: uint8(x * y), B: uint8(x ^ y*13), A: 0xff})
+		}
+	}
+
+	errCh := make(chan error, 32)
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		go func() {
+			for range 5 {
+				if err := dev.SetKeyImage(k, img); err != nil {
+					errCh <- err
+					return
+				}
+				dev.InvalidateImageCache()
+			}
+			errCh <- nil
+		}()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for range dev.GetKeyCount() {
+		if err := <-errCh; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if m.KeyImage(k) == nil {
+			t.Errorf("image not written to %s", k)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListenContext(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	pressed := make(chan struct{}, 1)
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_3, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		pressed <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- dev.ListenContext(ctx, nil)
+	}()
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("listen did not return after context cancellation")
+	}
+
+	// reports received while not listening are delivered to the next listener
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	go dev.Listen(nil)
+
+	select {
+	case <-pressed:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for key press")
+	}
+}
+
+func TestHandlerRegistration(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	reg, err := dev.AddKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "removed"
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "kept"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	reg.Remove()
+	reg.Remove()
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		if e != "kept" {
+			t.Fatalf("unexpected handler called: %s", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for key press")
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.RemoveKeyHandlers(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected handler called: %s", e)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestPressReleaseHandlers(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_2, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		time.Sleep(10 * time.Millisecond)
+		events <- "key press"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyReleaseHandler(streamdeck.KEY_2, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+		events <- "key release"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialPressHandler(streamdeck.DIAL_1, func(d *streamdeck.Device, di *streamdeck.Dial) error {
+		events <- "dial press"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialReleaseHandler(streamdeck.DIAL_1, func(d *streamdeck.Device, di *streamdeck.Dial, duration time.Duration) error {
+		events <- "dial release"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"key press", "key release"} {
+		select {
+		case e := <-events:
+			if e != want {
+				t.Fatalf("unexpected event: got %q, want %q", e, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %q", want)
+		}
+	}
+
+	if err := m.PressDial(streamdeck.DIAL_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseDial(streamdeck.DIAL_1); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"dial press", "dial release"} {
+		select {
+		case e := <-events:
+			if e != want {
+				t.Fatalf("unexpected event: got %q, want %q", e, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %q", want)
+		}
+	}
+}
+
+func TestContextHandlers(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	causes := make(chan error, 10)
+	if _, err := dev.AddKeyContextHandler(streamdeck.KEY_1, func(ctx context.Context, d *streamdeck.Device, k *streamdeck.Key) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialSwitchContextHandler(streamdeck.DIAL_3, func(ctx context.Context, d *streamdeck.Device, di *streamdeck.Dial) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyContextHandler(streamdeck.KEY_1, nil); !errors.Is(err, streamdeck.ErrKeyHandlerInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := dev.AddTouchPointContextHandler(streamdeck.TOUCH_POINT_1, func(ctx context.Context, d *streamdeck.Device, tp *streamdeck.TouchPoint) error {
+		return nil
+	}); !errors.Is(err, streamdeck.ErrDeviceTouchPointNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-causes:
+		t.Fatalf("context cancelled before release: %v", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-causes:
+		if !errors.Is(err, streamdeck.ErrInputReleased) {
+			t.Errorf("unexpected cause: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for release")
+	}
+
+	if err := m.PressDial(streamdeck.DIAL_3); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-causes:
+		if !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+			t.Errorf("unexpected cause: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for close")
+	}
+}
+
+func TestOpenWithOptions(t *testing.T) {
+	m, err := mock.New("mk2", "MOCK1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := streamdeck.NewDevice(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsLocked() {
+		t.Error("device not locked")
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 10, 10, color.RGBA{A: 0xff})
+
+	m.FailOpen(2)
+	if err := dev.OpenWithOptions(streamdeck.OpenOptions{RetryCount: 1, RetryInterval: time.Millisecond}); !errors.Is(err, streamdeck.ErrDeviceLocked) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	m.FailOpen(2)
+	if err := dev.OpenWithOptions(streamdeck.OpenOptions{RetryCount: 2, RetryInterval: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	if m.IsLocked() {
+		t.Error("device locked")
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 10, 10, color.RGBA{R: 0xff, A: 0xff})
+
+	if err := dev.OpenShared(); err != nil {
+		t.Fatal(err)
+	}
+	if m.IsLocked() {
+		t.Error("device locked")
+	}
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 10, 10, color.RGBA{R: 0xff, A: 0xff})
+
+	m.FailOpen(1)
+	err = dev.Open()
+	lerr := streamdeck.DeviceLockedError{}
+	if !errors.As(err, &lerr) || !errors.Is(err, streamdeck.ErrDeviceLocked) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lerr.Path != dev.GetPath() || lerr.PID != 0 || lerr.Process != "" {
+		t.Errorf("unexpected locked error: %+v", lerr)
+	}
+
+	m.FailOpen(1)
+	if err := dev.OpenShared(); errors.As(err, &lerr) || !errors.Is(err, streamdeck.ErrDeviceLocked) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCloseWithoutClear(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetTouchStripColor(color.RGBA{G: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	m.ClearWrites()
+
+	if err := dev.CloseWithoutClear(); err != nil {
+		t.Fatal(err)
+	}
+	if dev.IsOpen() {
+		t.Error("device not closed")
+	}
+	if w := m.Writes(); len(w) != 0 {
+		t.Errorf("unexpected writes: %d", len(w))
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 10, 10, color.RGBA{R: 0xff, A: 0xff})
+	assertColor(t, m.TouchStripImage(), 10, 10, color.RGBA{G: 0xff, A: 0xff})
+
+	if err := dev.CloseWithoutClear(); !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUSBMetadata(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if v := dev.GetVendorID(); v != 0x0fd9 {
+		t.Errorf("unexpected vendor id: %04x", v)
+	}
+	if p := dev.GetProductID(); p != m.ProductId() {
+		t.Errorf("unexpected product id: %04x", p)
+	}
+	if p := dev.GetPath(); p != m.Path() {
+		t.Errorf("unexpected path: %q", p)
+	}
+
+	m.SetLocation("1-2.3")
+	if l, err := dev.GetLocation(); err != nil || l != "1-2.3" {
+		t.Errorf("unexpected location: %q, %v", l, err)
+	}
+}
+
+func TestReinitialize(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetBrightness(40); err != nil {
+		t.Fatal(err)
+	}
+	m.ClearWrites()
+
+	// keys already cleared are written again
+	if err := dev.Reinitialize(); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != int(dev.GetKeyCount()) {
+		t.Errorf("bad writes: %d", len(w))
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{})
+	if b := m.Brightness(); b != 40 {
+		t.Errorf("unexpected brightness: %d", b)
+	}
+	if m.Resets() != 0 || !dev.IsOpen() {
+		t.Error("device reset or closed")
+	}
+
+	if err := dev.Sleep(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.Reinitialize(); err != nil {
+		t.Fatal(err)
+	}
+	if b := m.Brightness(); b != 0 {
+		t.Errorf("unexpected brightness: %d", b)
+	}
+}
+
+func TestSerialNumberFallback(t *testing.T) {
+	for _, id := range []string{"mini", "mk2"} {
+		t.Run(id, func(t *testing.T) {
+			m, err := mock.New(id, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			m.SetHardwareSerialNumber("HW0123")
+
+			dev, err := streamdeck.NewDevice(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := dev.GetSerialNumber(); s != "" {
+				t.Errorf("unexpected serial number before open: %q", s)
+			}
+			if _, err := dev.GetHardwareSerialNumber(); !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if err := dev.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			if s := dev.GetSerialNumber(); s != "HW0123" {
+				t.Errorf("unexpected serial number: %q", s)
+			}
+			if s, err := dev.GetHardwareSerialNumber(); err != nil || s != "HW0123" {
+				t.Errorf("unexpected hardware serial number: %q, %v", s, err)
+			}
+		})
+	}
+
+	// the USB string descriptor is preferred
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	m.SetHardwareSerialNumber("HW0123")
+	if s := dev.GetSerialNumber(); s != m.SerialNumber() {
+		t.Errorf("unexpected serial number: %q", s)
+	}
+}
+
+func TestIterators(t *testing.T) {
+	dev, _, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	keys := slices.Collect(dev.Keys())
+	if len(keys) != 8 || keys[0] != streamdeck.KEY_1 || keys[7] != streamdeck.KEY_8 {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+	if dials := slices.Collect(dev.Dials()); !slices.Equal(dials, []streamdeck.DialID{streamdeck.DIAL_1, streamdeck.DIAL_2, streamdeck.DIAL_3, streamdeck.DIAL_4}) {
+		t.Errorf("unexpected dials: %v", dials)
+	}
+	if tps := slices.Collect(dev.TouchPoints()); len(tps) != 0 {
+		t.Errorf("unexpected touch points: %v", tps)
+	}
+
+	// breaking out of the loop stops the iteration
+	n := 0
+	for key := range dev.Keys() {
+		if key == streamdeck.KEY_3 {
+			break
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("unexpected number of iterations: %d", n)
+	}
+
+	neo, _, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer neo.Close()
+
+	if tps := slices.Collect(neo.TouchPoints()); !slices.Equal(tps, []streamdeck.TouchPointID{streamdeck.TOUCH_POINT_1, streamdeck.TOUCH_POINT_2}) {
+		t.Errorf("unexpected touch points: %v", tps)
+	}
+	if dials := slices.Collect(neo.Dials()); len(dials) != 0 {
+		t.Errorf("unexpected dials: %v", dials)
+	}
+}
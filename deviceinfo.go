@@ -0,0 +1,65 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"rafaelmartins.com/p/usbhid"
+)
+
+// DeviceInfo represents the identification and the capabilities of an
+// Elgato Stream Deck device, as reported by the USB descriptors, without
+// opening the device. The serial number may be blank on some platforms, see
+// Device.GetSerialNumber.
+type DeviceInfo struct {
+	Path         string
+	SerialNumber string
+	ModelID      string
+	ModelName    string
+	VendorID     uint16
+	ProductID    uint16
+	Capabilities Capabilities
+}
+
+func newDeviceInfo(dev HIDDevice, model *model) DeviceInfo {
+	return DeviceInfo{
+		Path:         dev.Path(),
+		SerialNumber: dev.SerialNumber(),
+		ModelID:      model.id,
+		ModelName:    dev.Product(),
+		VendorID:     dev.VendorId(),
+		ProductID:    dev.ProductId(),
+		Capabilities: model.capabilities(),
+	}
+}
+
+// EnumerateInfo lists the supported Elgato Stream Deck devices connected to
+// the computer, like Enumerate, but returns their identification and
+// capabilities only, for applications that just list the hardware. Devices
+// may be obtained later with GetDeviceWith and WithPath.
+func EnumerateInfo() ([]DeviceInfo, error) {
+	devices, err := usbhid.Enumerate(enumerateFunc)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	rv := []DeviceInfo{}
+	for _, dev := range devices {
+		model, err := getModel(dev)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+		rv = append(rv, newDeviceInfo(dev, model))
+	}
+	return rv, nil
+}
+
+// GetInfo returns the identification and the capabilities of the Elgato
+// Stream Deck device, as returned by EnumerateInfo, with the serial number
+// returned by GetSerialNumber.
+func (d *Device) GetInfo() DeviceInfo {
+	rv := newDeviceInfo(d.dev, d.model)
+	rv.SerialNumber = d.GetSerialNumber()
+	return rv
+}
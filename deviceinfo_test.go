@@ -0,0 +1,46 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDeviceInfo(t *testing.T) {
+	for _, id := range []string{"mk2", "plus", "pedal"} {
+		t.Run(id, func(t *testing.T) {
+			dev, _, err := mock.Open(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			info := dev.GetInfo()
+			if info.ModelID != id || info.ModelID != dev.GetModelID() {
+				t.Errorf("unexpected model id: %q", info.ModelID)
+			}
+			if info.ModelName != dev.GetModelName() {
+				t.Errorf("unexpected model name: %q", info.ModelName)
+			}
+			if info.SerialNumber != dev.GetSerialNumber() {
+				t.Errorf("unexpected serial number: %q", info.SerialNumber)
+			}
+			if info.Path != dev.GetPath() {
+				t.Errorf("unexpected path: %q", info.Path)
+			}
+			if info.VendorID != dev.GetVendorID() || info.ProductID != dev.GetProductID() {
+				t.Errorf("unexpected usb id: %04x:%04x", info.VendorID, info.ProductID)
+			}
+			if info.Capabilities != dev.GetCapabilities() {
+				t.Errorf("unexpected capabilities: %+v", info.Capabilities)
+			}
+			if rect, err := dev.GetDeckImageRectangle(); err == nil && rect != info.Capabilities.DeckImageRect {
+				t.Errorf("unexpected deck image rectangle: %s", info.Capabilities.DeckImageRect)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"math"
+	"time"
+)
+
+// rotations further apart than this are considered separate gestures, and
+// the velocity of the first report of a gesture is measured over it.
+const dialRotationGestureInterval = 250 * time.Millisecond
+
+// DialRotation represents the rotation of a dial reported to a
+// DialRotationHandler, possibly accumulated from several reports of the
+// device.
+type DialRotation struct {
+	// Delta is the logical rotation delta, after acceleration is applied.
+	Delta int
+
+	// RawDelta is the accumulated rotation delta reported by the device.
+	RawDelta int
+
+	// Velocity is the rotation speed, in steps per second. It is always
+	// positive.
+	Velocity float64
+}
+
+// DialRotationHandler represents a callback function that is called when a
+// dial is rotated. It receives the Device, the Dial instance and the
+// DialRotation as parameters.
+type DialRotationHandler func(d *Device, di *Dial, r DialRotation) error
+
+// DialRotationOptions represents the settings used to report dial rotations
+// to the DialRotationHandler callbacks. The zero value reports every rotation
+// as received from the device, without acceleration.
+type DialRotationOptions struct {
+	// CoalesceInterval is the time rotation reports are accumulated for,
+	// starting from the first report, before the callbacks are called once
+	// with the accumulated delta. If zero, callbacks are called for every
+	// report.
+	CoalesceInterval time.Duration
+
+	// Acceleration is the gain applied to fast rotations. Deltas are
+	// multiplied by 1 + Acceleration * Velocity / 10, so that rotations
+	// slower than a few steps per second are barely affected. If zero,
+	// deltas are not accelerated.
+	Acceleration float64
+}
+
+func (o DialRotationOptions) delta(raw int, velocity float64) int {
+	if o.Acceleration <= 0 || raw == 0 {
+		return raw
+	}
+
+	rv := int(math.Round(float64(raw) * (1 + o.Acceleration*velocity/10)))
+	if raw > 0 {
+		return max(rv, raw)
+	}
+	return min(rv, raw)
+}
+
+type dialRotation struct {
+	opts    DialRotationOptions
+	pending int
+	reports int
+	first   time.Time
+	last    time.Time
+	prev    time.Time
+	timer   *time.Timer
+}
+
+// SetDialRotationOptions sets the settings used to report the rotations of
+// the given dial to its DialRotationHandler callbacks. DialRotateHandler
+// callbacks always receive the deltas as reported by the device.
+func (d *Device) SetDialRotationOptions(di DialID, opts DialRotationOptions) error {
+	if err := d.validateDial(di); err != nil {
+		return err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			in.mtx.Lock()
+			in.dial.rotation.opts = opts
+			in.mtx.Unlock()
+		}
+	}
+	return nil
+}
+
+// GetDialRotationOptions returns the settings used to report the rotations
+// of the given dial to its DialRotationHandler callbacks.
+func (d *Device) GetDialRotationOptions(di DialID) (DialRotationOptions, error) {
+	if err := d.validateDial(di); err != nil {
+		return DialRotationOptions{}, err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.dialInputs {
+		if in.dial != nil && in.dial.id == di {
+			in.mtx.Lock()
+			defer in.mtx.Unlock()
+			return in.dial.rotation.opts, nil
+		}
+	}
+	return DialRotationOptions{}, nil
+}
+
+// accumulateRotation adds a rotation report to the pending rotation of the
+// dial, and flushes it right away if not coalescing. It must be called with
+// the input mutex held.
+func (in *input) accumulateRotation(t time.Time, delta int8, errCh chan error) {
+	if len(in.dial.rotationHandlers) == 0 {
+		return
+	}
+
+	r := &in.dial.rotation
+	if r.pending == 0 && r.timer == nil {
+		r.first = t
+	}
+	r.pending += int(delta)
+	r.reports++
+	r.last = t
+
+	if r.opts.CoalesceInterval <= 0 {
+		in.flushRotation(errCh)
+		return
+	}
+
+	if r.timer == nil {
+		r.timer = time.AfterFunc(r.opts.CoalesceInterval, func() {
+			in.mtx.Lock()
+			defer in.mtx.Unlock()
+
+			in.dial.rotation.timer = nil
+			in.flushRotation(errCh)
+		})
+	}
+}
+
+// flushRotation dispatches the pending rotation of the dial to the
+// DialRotationHandler callbacks. It must be called with the input mutex held.
+func (in *input) flushRotation(errCh chan error) {
+	r := &in.dial.rotation
+	raw, reports := r.pending, r.reports
+	r.pending, r.reports = 0, 0
+
+	// measure the velocity since the previous report, if part of the same
+	// gesture, or estimate it from the interval between the pending reports.
+	elapsed := dialRotationGestureInterval
+	if !r.prev.IsZero() && r.first.Sub(r.prev) < dialRotationGestureInterval {
+		elapsed = r.last.Sub(r.prev)
+	} else if reports > 1 {
+		elapsed = r.last.Sub(r.first) * time.Duration(reports) / time.Duration(reports-1)
+	}
+	elapsed = max(elapsed, time.Millisecond)
+	r.prev = r.last
+
+	if raw == 0 || len(in.dial.rotationHandlers) == 0 {
+		return
+	}
+
+	velocity := math.Abs(float64(raw)) / elapsed.Seconds()
+	rot := DialRotation{
+		Delta:    r.opts.delta(raw, velocity),
+		RawDelta: raw,
+		Velocity: velocity,
+	}
+
+	hnds := append([]handler[DialRotationHandler]{}, in.dial.rotationHandlers...)
+	in.dispatch(func() {
+		for _, h := range hnds {
+			if err := callHandler(func() error { return h.fn(in.device, in.dial, rot) }); err != nil {
+				in.device.sendHandlerError(errCh, DialHandlerError{DialID: in.dial.id, Err: err})
+			}
+		}
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"testing"
+)
+
+func TestDialRotationOptions_Delta(t *testing.T) {
+	for _, tc := range []struct {
+		opts     DialRotationOptions
+		raw      int
+		velocity float64
+		want     int
+	}{
+		{DialRotationOptions{}, 3, 100, 3},
+		{DialRotationOptions{}, -3, 100, -3},
+		{DialRotationOptions{Acceleration: 1}, 0, 100, 0},
+		{DialRotationOptions{Acceleration: 1}, 1, 1, 1},
+		{DialRotationOptions{Acceleration: 1}, -1, 1, -1},
+		{DialRotationOptions{Acceleration: 1}, 2, 40, 10},
+		{DialRotationOptions{Acceleration: 1}, -2, 40, -10},
+		{DialRotationOptions{Acceleration: 0.5}, 4, 20, 8},
+	} {
+		if got := tc.opts.delta(tc.raw, tc.velocity); got != tc.want {
+			t.Errorf("%+v.delta(%d, %f): got %d, want %d", tc.opts, tc.raw, tc.velocity, got, tc.want)
+		}
+	}
+}
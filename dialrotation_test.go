@@ -0,0 +1,102 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDialRotation(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetDialRotationOptions(streamdeck.DIAL_1, streamdeck.DialRotationOptions{
+		CoalesceInterval: 100 * time.Millisecond,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := make(chan int8, 10)
+	if _, err := dev.AddDialRotateHandler(streamdeck.DIAL_1, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+		raw <- delta
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rotations := make(chan streamdeck.DialRotation, 10)
+	if _, err := dev.AddDialRotationHandler(streamdeck.DIAL_1, func(d *streamdeck.Device, di *streamdeck.Dial, r streamdeck.DialRotation) error {
+		rotations <- r
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	for _, delta := range []int8{1, 2, 3} {
+		if err := m.RotateDial(streamdeck.DIAL_1, delta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for range 3 {
+		select {
+		case <-raw:
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for raw rotation")
+		}
+	}
+
+	select {
+	case r := <-rotations:
+		if r.Delta != 6 || r.RawDelta != 6 {
+			t.Errorf("rotations not coalesced: %+v", r)
+		}
+		if r.Velocity <= 0 {
+			t.Errorf("bad velocity: %f", r.Velocity)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for rotation")
+	}
+
+	select {
+	case r := <-rotations:
+		t.Fatalf("unexpected rotation: %+v", r)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := dev.SetDialRotationOptions(streamdeck.DIAL_1, streamdeck.DialRotationOptions{
+		Acceleration: 1,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, delta := range []int8{-1, -1} {
+		if err := m.RotateDial(streamdeck.DIAL_1, delta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, check := range []func(r streamdeck.DialRotation) bool{
+		func(r streamdeck.DialRotation) bool { return r.Delta <= -1 && r.RawDelta == -1 },
+		func(r streamdeck.DialRotation) bool { return r.Delta < -1 && r.RawDelta == -1 },
+	} {
+		select {
+		case r := <-rotations:
+			if !check(r) {
+				t.Errorf("bad accelerated rotation: %+v", r)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for rotation")
+		}
+	}
+}
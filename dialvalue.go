@@ -0,0 +1,198 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+)
+
+// DialValueHandler represents a callback function that is called when the
+// value bound to a dial changes. It receives the Device, the Dial instance
+// and the new value as parameters.
+type DialValueHandler func(d *Device, di *Dial, value float64) error
+
+// DialValueOptions represents the range and behavior of a value bound to a
+// dial with Device.BindDialValue.
+type DialValueOptions struct {
+	// Min and Max are the bounds of the value. If both are zero, defaults to
+	// the [0, 100] range.
+	Min float64
+	Max float64
+
+	// Step is the change of the value for each logical rotation step of the
+	// dial. If zero, defaults to 1.
+	Step float64
+
+	// Wrap makes the value wrap around when rotating past its bounds,
+	// instead of being clamped.
+	Wrap bool
+
+	// Value is the initial value. It is clamped to the bounds.
+	Value float64
+
+	// Render is an optional function that renders the value to the area of
+	// the touch strip display above the dial, called after every change.
+	// The LevelMeter.Render method of a LevelMeter using the DialValue as
+	// its LevelFeed can be used to draw a bar. The device must be open
+	// when the value is bound.
+	Render func(v *DialValue, rect image.Rectangle) image.Image
+}
+
+// DialValue represents a ranged value bound to an Elgato Stream Deck dial,
+// changed by rotating it. The rotations are reported as defined by
+// Device.SetDialRotationOptions, so the value changes faster with fast spins
+// if acceleration is enabled.
+type DialValue struct {
+	device *Device
+	dial   DialID
+	opts   DialValueOptions
+	fn     DialValueHandler
+	reg    *HandlerRegistration
+
+	mtx   sync.Mutex
+	value float64
+}
+
+// BindDialValue binds a ranged value to the given dial. The callback is
+// called with the new value whenever a rotation changes it, and can be nil if
+// only Render is needed.
+func (d *Device) BindDialValue(di DialID, opts DialValueOptions, fn DialValueHandler) (*DialValue, error) {
+	if err := d.validateDial(di); err != nil {
+		return nil, err
+	}
+
+	if opts.Min == 0 && opts.Max == 0 {
+		opts.Max = 100
+	}
+	if opts.Step == 0 {
+		opts.Step = 1
+	}
+	if opts.Max < opts.Min || opts.Step < 0 || math.IsNaN(opts.Min) || math.IsNaN(opts.Max) || math.IsNaN(opts.Step) {
+		return nil, fmt.Errorf("streamdeck: %w: [%g, %g], step %g", ErrDialValueInvalid, opts.Min, opts.Max, opts.Step)
+	}
+
+	if opts.Render != nil {
+		if err := d.validateTouchStrip(); err != nil {
+			return nil, err
+		}
+	}
+
+	rv := &DialValue{
+		device: d,
+		dial:   di,
+		opts:   opts,
+		fn:     fn,
+	}
+	rv.value = rv.clamp(opts.Value)
+
+	reg, err := d.AddDialRotationHandler(di, func(d *Device, dial *Dial, r DialRotation) error {
+		return rv.rotate(dial, r.Delta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rv.reg = reg
+
+	if err := rv.render(); err != nil {
+		reg.Remove()
+		return nil, err
+	}
+	return rv, nil
+}
+
+// clamp snaps the value to the step grid and limits it to the bounds.
+func (v *DialValue) clamp(value float64) float64 {
+	if math.IsNaN(value) {
+		value = v.opts.Min
+	}
+	if v.opts.Step > 0 {
+		value = v.opts.Min + math.Round((value-v.opts.Min)/v.opts.Step)*v.opts.Step
+	}
+	return min(max(value, v.opts.Min), v.opts.Max)
+}
+
+func (v *DialValue) rotate(dial *Dial, steps int) error {
+	v.mtx.Lock()
+	value := v.value + float64(steps)*v.opts.Step
+	if v.opts.Wrap && (value < v.opts.Min || value > v.opts.Max) {
+		span := v.opts.Max - v.opts.Min + v.opts.Step
+		value = v.opts.Min + math.Mod(math.Mod(value-v.opts.Min, span)+span, span)
+	}
+	value = v.clamp(value)
+
+	changed := value != v.value
+	v.value = value
+	v.mtx.Unlock()
+
+	if !changed {
+		return nil
+	}
+
+	if err := v.render(); err != nil {
+		return err
+	}
+
+	if v.fn != nil {
+		return v.fn(v.device, dial, value)
+	}
+	return nil
+}
+
+func (v *DialValue) render() error {
+	if v.opts.Render == nil {
+		return nil
+	}
+
+	rect := v.device.touchStripDialRect(v.dial)
+	img := v.opts.Render(v, image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	if img == nil {
+		return nil
+	}
+	return v.device.SetTouchStripImageWithRectangle(img, rect)
+}
+
+// Get returns the current value.
+func (v *DialValue) Get() float64 {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return v.value
+}
+
+// Set sets the current value, clamped to the bounds, and renders it. The
+// DialValueHandler callback is not called.
+func (v *DialValue) Set(value float64) error {
+	v.mtx.Lock()
+	v.value = v.clamp(value)
+	v.mtx.Unlock()
+	return v.render()
+}
+
+// Level returns the current value normalized to the [0, 1] range, so that the
+// DialValue can be used as the LevelFeed of a LevelMeter.
+func (v *DialValue) Level() float64 {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+
+	if v.opts.Max == v.opts.Min {
+		return 0
+	}
+	return (v.value - v.opts.Min) / (v.opts.Max - v.opts.Min)
+}
+
+// GetDialID returns the identifier of the dial the value is bound to.
+func (v *DialValue) GetDialID() DialID {
+	return v.dial
+}
+
+// Remove unbinds the value from its dial. The value can still be read and
+// set, but is no longer changed by rotating the dial.
+func (v *DialValue) Remove() {
+	v.reg.Remove()
+}
+
+var _ LevelFeed = (*DialValue)(nil)
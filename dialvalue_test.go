@@ -0,0 +1,109 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDialValue(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	values := make(chan float64, 10)
+	v, err := dev.BindDialValue(streamdeck.DIAL_2, streamdeck.DialValueOptions{
+		Min:   0,
+		Max:   10,
+		Step:  2,
+		Value: 7,
+		Render: func(v *streamdeck.DialValue, rect image.Rectangle) image.Image {
+			return (&streamdeck.LevelMeter{Feed: v, Foreground: color.White}).Render(rect)
+		},
+	}, func(d *streamdeck.Device, di *streamdeck.Dial, value float64) error {
+		values <- value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Get() != 8 {
+		t.Errorf("initial value not snapped to step: %f", v.Get())
+	}
+	assertColor(t, m.TouchStripImage(), 200+150, 50, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	assertColor(t, m.TouchStripImage(), 200+170, 50, color.RGBA{0, 0, 0, 0xff})
+
+	w, err := dev.BindDialValue(streamdeck.DIAL_3, streamdeck.DialValueOptions{
+		Min:  1,
+		Max:  3,
+		Wrap: true,
+	}, func(d *streamdeck.Device, di *streamdeck.Dial, value float64) error {
+		values <- 100 + value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	for _, step := range []struct {
+		di    streamdeck.DialID
+		delta int8
+		want  float64
+	}{
+		{streamdeck.DIAL_2, 1, 10},
+		{streamdeck.DIAL_2, -20, 0},
+		{streamdeck.DIAL_3, -1, 103},
+		{streamdeck.DIAL_3, 2, 102},
+	} {
+		if err := m.RotateDial(step.di, step.delta); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case got := <-values:
+			if got != step.want {
+				t.Errorf("unexpected value: got %f, want %f", got, step.want)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for value")
+		}
+	}
+
+	if err := m.RotateDial(streamdeck.DIAL_2, 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-values:
+		if got != 2 {
+			t.Errorf("unexpected value: got %f, want 2", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for value")
+	}
+	assertColor(t, m.TouchStripImage(), 200+30, 50, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	assertColor(t, m.TouchStripImage(), 200+50, 50, color.RGBA{0, 0, 0, 0xff})
+
+	w.Remove()
+	if err := w.Set(5); err != nil {
+		t.Fatal(err)
+	}
+	if w.Get() != 3 {
+		t.Errorf("value not clamped: %f", w.Get())
+	}
+
+	if _, err := dev.BindDialValue(streamdeck.DIAL_1, streamdeck.DialValueOptions{Min: 5, Max: 1}, nil); !errors.Is(err, streamdeck.ErrDialValueInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"sync"
+)
+
+// DispatchMode represents how the input handler callbacks of an Elgato
+// Stream Deck device are called.
+type DispatchMode byte
+
+// String returns a string representation of the DispatchMode.
+func (m DispatchMode) String() string {
+	switch m {
+	case DISPATCH_MODE_CONCURRENT:
+		return "DISPATCH_MODE_CONCURRENT"
+	case DISPATCH_MODE_SERIAL:
+		return "DISPATCH_MODE_SERIAL"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck dispatch modes. These constants represent how the input
+// handler callbacks are called.
+//
+// With DISPATCH_MODE_CONCURRENT, the default, each handler callback is called
+// from its own goroutine. Press and release handlers of the same input are
+// called in order, but handlers of different inputs may run in any order.
+//
+// With DISPATCH_MODE_SERIAL, all the handler callbacks are called one at a
+// time from a single goroutine, in the order the input events were received.
+// A handler that blocks delays all the following ones, but WaitForRelease
+// and the contexts of the context handlers are still released, because
+// releases are tracked by the listener.
+const (
+	DISPATCH_MODE_CONCURRENT DispatchMode = iota + 1
+	DISPATCH_MODE_SERIAL
+)
+
+// dispatchQueue calls functions one at a time, in order, from a goroutine
+// that runs while the queue is not empty.
+type dispatchQueue struct {
+	mtx     sync.Mutex
+	queue   []func()
+	running bool
+}
+
+func (q *dispatchQueue) push(fn func()) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.queue = append(q.queue, fn)
+	if !q.running {
+		q.running = true
+		go q.run()
+	}
+}
+
+func (q *dispatchQueue) run() {
+	for {
+		q.mtx.Lock()
+		if len(q.queue) == 0 {
+			q.running = false
+			q.mtx.Unlock()
+			return
+		}
+		fn := q.queue[0]
+		q.queue[0] = nil
+		q.queue = q.queue[1:]
+		q.mtx.Unlock()
+
+		fn()
+	}
+}
+
+// SetDispatchMode sets how the input handler callbacks of the Elgato Stream
+// Deck device are called. It affects the input events received after it
+// returns.
+func (d *Device) SetDispatchMode(mode DispatchMode) error {
+	if mode != DISPATCH_MODE_CONCURRENT && mode != DISPATCH_MODE_SERIAL {
+		return wrapErr(ErrDispatchModeInvalid)
+	}
+	d.dispatchMode.Store(uint32(mode))
+	return nil
+}
+
+// GetDispatchMode returns how the input handler callbacks of the Elgato
+// Stream Deck device are called.
+func (d *Device) GetDispatchMode() DispatchMode {
+	if m := DispatchMode(d.dispatchMode.Load()); m != 0 {
+		return m
+	}
+	return DISPATCH_MODE_CONCURRENT
+}
+
+// goHandler calls a handler callback as defined by the dispatch mode.
+func (d *Device) goHandler(fn func()) {
+	if d.GetDispatchMode() == DISPATCH_MODE_SERIAL {
+		d.dispatchQueue.push(fn)
+		return
+	}
+	go fn()
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestDispatchMode(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if mode := dev.GetDispatchMode(); mode != streamdeck.DISPATCH_MODE_CONCURRENT {
+		t.Errorf("unexpected dispatch mode: %s", mode)
+	}
+	if err := dev.SetDispatchMode(0); !errors.Is(err, streamdeck.ErrDispatchModeInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetDispatchMode(streamdeck.DISPATCH_MODE_SERIAL); err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(chan string, 20)
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if _, err := dev.AddKeyHandler(k, func(d *streamdeck.Device, k *streamdeck.Key) error {
+			// earlier keys take longer, to be overtaken if run concurrently
+			time.Sleep(time.Duration(5-k.GetID()) * 5 * time.Millisecond)
+			events <- "handler " + k.String()
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err := dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+			events <- "release " + k.String()
+			return nil
+		})
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	want := []string{}
+	for k := streamdeck.KEY_1; k <= streamdeck.KEY_4; k++ {
+		if err := m.PressKey(k); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.ReleaseKey(k); err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, "handler "+k.String(), "release "+k.String())
+	}
+
+	for _, w := range want {
+		select {
+		case e := <-events:
+			if e != w {
+				t.Fatalf("unexpected event: got %q, want %q", e, w)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %q", w)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elgatosdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"net/url"
+	"strings"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	_ "rafaelmartins.com/p/streamdeck/svg"
+)
+
+var (
+	okColor    = color.RGBA{G: 0xc0, A: 0xff}
+	alertColor = color.RGBA{R: 0xc0, A: 0xff}
+)
+
+// decodeImage decodes the data URLs sent by the plugins, with base64 encoded
+// images in any of the formats supported by the image package, or SVG
+// documents, that may also be URL encoded.
+func decodeImage(s string, size image.Point) (image.Image, error) {
+	hdr, data, found := strings.Cut(s, ",")
+	mime, ok := strings.CutPrefix(hdr, "data:")
+	if !found || !ok {
+		return nil, fmt.Errorf("%w: image is not a data url", ErrActionInvalid)
+	}
+
+	var buf []byte
+	if strings.HasSuffix(mime, ";base64") {
+		b, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		buf = b
+	} else {
+		d, err := url.PathUnescape(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		buf = []byte(d)
+	}
+
+	if strings.HasPrefix(mime, "image/svg+xml") {
+		return streamdeck.RasterizeSVG(bytes.NewReader(buf), size)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(buf))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrActionInvalid, err)
+	}
+	return img, nil
+}
+
+// renderLocked draws the image and the title of an action to its key. The
+// title is drawn over the image as a key overlay.
+func (h *Host) renderLocked(ka *keyAction) error {
+	if ka.image != nil {
+		if err := h.device.SetKeyImage(ka.key, ka.image); err != nil {
+			return err
+		}
+	} else if err := h.device.ClearKey(ka.key); err != nil {
+		return err
+	}
+
+	if ka.title == "" {
+		return nil
+	}
+	return h.device.SetKeyOverlayText(ka.key, ka.title, streamdeck.TextOptions{
+		VerticalAlignment: streamdeck.TEXT_VERTICAL_ALIGNMENT_BOTTOM,
+	})
+}
+
+func (h *Host) flash(ka *keyAction, c color.Color) error {
+	if err := h.device.SetKeyColor(ka.key, c); err != nil {
+		return err
+	}
+
+	time.AfterFunc(flashDuration, func() {
+		h.mtx.Lock()
+		defer h.mtx.Unlock()
+
+		if h.keys[ka.key] != ka {
+			return
+		}
+		if err := h.renderLocked(ka); err != nil {
+			h.logError("elgatosdk: failed to render key", err)
+		}
+	})
+	return nil
+}
+
+func (h *Host) exec(c *conn, cmd *command) error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	switch cmd.Event {
+	case "setGlobalSettings":
+		settings := map[string]any{}
+		if err := json.Unmarshal(cmd.Payload, &settings); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		h.global[c.uuid] = settings
+		return nil
+
+	case "getGlobalSettings":
+		settings := h.global[c.uuid]
+		if settings == nil {
+			settings = map[string]any{}
+		}
+		h.sendLocked(c, event{
+			Event:   "didReceiveGlobalSettings",
+			Payload: map[string]any{"settings": settings},
+		})
+		return nil
+
+	case "logMessage":
+		msg := struct {
+			Message string `json:"message"`
+		}{}
+		if err := json.Unmarshal(cmd.Payload, &msg); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		if l := h.device.GetLogger(); l != nil {
+			l.Info("elgatosdk: "+msg.Message, "serial", h.device.GetSerialNumber(), "plugin", c.uuid)
+		}
+		return nil
+	}
+
+	var ka *keyAction
+	for _, k := range h.keys {
+		if k.context == cmd.Context {
+			ka = k
+			break
+		}
+	}
+	if ka == nil || !c.owns(ka.action) {
+		return fmt.Errorf("%w: context not found: %q", ErrActionInvalid, cmd.Context)
+	}
+
+	switch cmd.Event {
+	case "setImage":
+		p := struct {
+			Image string `json:"image"`
+		}{}
+		if err := json.Unmarshal(cmd.Payload, &p); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+
+		ka.image = nil
+		if p.Image != "" {
+			rect, err := h.device.GetKeyImageRectangle()
+			if err != nil {
+				return err
+			}
+			img, err := decodeImage(p.Image, rect.Size())
+			if err != nil {
+				return err
+			}
+			ka.image = img
+		}
+		return h.renderLocked(ka)
+
+	case "setTitle":
+		p := struct {
+			Title string `json:"title"`
+		}{}
+		if err := json.Unmarshal(cmd.Payload, &p); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		ka.title = p.Title
+		return h.renderLocked(ka)
+
+	case "setSettings":
+		settings := map[string]any{}
+		if err := json.Unmarshal(cmd.Payload, &settings); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		ka.settings = settings
+		return nil
+
+	case "getSettings":
+		h.keyEventLocked(ka, "didReceiveSettings")
+		return nil
+
+	case "setState":
+		p := struct {
+			State int `json:"state"`
+		}{}
+		if err := json.Unmarshal(cmd.Payload, &p); err != nil {
+			return fmt.Errorf("%w: %w", ErrActionInvalid, err)
+		}
+		ka.state = p.State
+		return nil
+
+	case "showOk":
+		return h.flash(ka, okColor)
+
+	case "showAlert":
+		return h.flash(ka, alertColor)
+	}
+	return nil
+}
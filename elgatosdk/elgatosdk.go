@@ -0,0 +1,428 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package elgatosdk runs plugins written for the official Elgato Stream Deck
+// SDK against devices driven by this module, by implementing the WebSocket
+// protocol the plugins use to talk to the official software.
+//
+// The Host implements http.Handler, and must be served on the loopback
+// interface. Plugins are started with Host.Launch, pointing to the port the
+// Host is served on, and actions of the plugins are assigned to keys with
+// Host.SetKeyAction:
+//
+//	h, err := elgatosdk.New(dev)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer h.Close()
+//
+//	ln, err := net.Listen("tcp", "127.0.0.1:0")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	go http.Serve(ln, h)
+//
+//	p, err := h.Launch("com.example.counter.sdPlugin", ln.Addr().(*net.TCPAddr).Port)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer p.Stop()
+//
+//	h.SetKeyAction(streamdeck.KEY_1, "com.example.counter.increment", nil)
+//
+// Only a subset of the protocol is supported: the willAppear,
+// willDisappear, keyDown, keyUp, deviceDidConnect, didReceiveSettings and
+// didReceiveGlobalSettings events, and the setImage, setTitle, setSettings,
+// getSettings, setGlobalSettings, getGlobalSettings, setState, showOk,
+// showAlert and logMessage commands. Other commands, like openUrl, are
+// ignored. Property inspectors, dials and multi-actions are not supported.
+//
+// The Host does not listen to the device input. Events are only reported
+// while the application calls Device.Listen.
+package elgatosdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the elgatosdk package.
+var (
+	ErrActionInvalid      = errors.New("elgatosdk: action is not valid")
+	ErrPluginInvalid      = errors.New("elgatosdk: plugin is not valid")
+	ErrPluginNotSupported = errors.New("elgatosdk: plugin is not supported")
+)
+
+const (
+	// connBuffer is the number of events buffered for each plugin
+	// connection. Events are dropped for plugins that do not keep up.
+	connBuffer = 64
+
+	// flashDuration is how long the feedback of showOk and showAlert is
+	// displayed.
+	flashDuration = 300 * time.Millisecond
+)
+
+type coordinates struct {
+	Column int `json:"column"`
+	Row    int `json:"row"`
+}
+
+type size struct {
+	Columns int `json:"columns"`
+	Rows    int `json:"rows"`
+}
+
+type deviceInfo struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	Size size   `json:"size"`
+	Type int    `json:"type"`
+}
+
+type payload struct {
+	Settings        map[string]any `json:"settings"`
+	Coordinates     *coordinates   `json:"coordinates,omitempty"`
+	Controller      string         `json:"controller,omitempty"`
+	State           int            `json:"state"`
+	IsInMultiAction bool           `json:"isInMultiAction"`
+}
+
+// event is a message sent to the plugins.
+type event struct {
+	Action     string      `json:"action,omitempty"`
+	Event      string      `json:"event"`
+	Context    string      `json:"context,omitempty"`
+	Device     string      `json:"device,omitempty"`
+	DeviceInfo *deviceInfo `json:"deviceInfo,omitempty"`
+	Payload    any         `json:"payload,omitempty"`
+}
+
+// command is a message received from the plugins.
+type command struct {
+	Event   string          `json:"event"`
+	UUID    string          `json:"uuid"`
+	Context string          `json:"context"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// keyAction is an action assigned to a key.
+type keyAction struct {
+	key      streamdeck.KeyID
+	action   string
+	context  string
+	settings map[string]any
+	state    int
+	image    image.Image
+	title    string
+}
+
+// conn is a registered plugin connection.
+type conn struct {
+	uuid    string
+	actions map[string]bool
+	out     chan event
+}
+
+func (c *conn) owns(action string) bool {
+	if c.actions != nil {
+		return c.actions[action]
+	}
+	return len(action) > len(c.uuid) && action[:len(c.uuid)+1] == c.uuid+"."
+}
+
+// Host is an http.Handler implementing the WebSocket protocol of the Elgato
+// Stream Deck SDK plugins, for an Elgato Stream Deck device. Errors of the
+// plugin connections are reported with Device.ReportError, and the messages
+// logged by the plugins are logged to the logger set with Device.SetLogger,
+// if any.
+type Host struct {
+	device *streamdeck.Device
+	server websocket.Server
+	regs   []*streamdeck.HandlerRegistration
+	done   chan struct{}
+	once   sync.Once
+
+	mtx     sync.Mutex
+	keys    map[streamdeck.KeyID]*keyAction
+	conns   map[*conn]struct{}
+	pending map[string]*Plugin
+	global  map[string]map[string]any
+}
+
+// New creates a Host bound to an open Elgato Stream Deck device, and
+// registers the key handlers used to report events to the plugins.
+func New(dev *streamdeck.Device) (*Host, error) {
+	if dev == nil || !dev.IsOpen() {
+		return nil, fmt.Errorf("elgatosdk: %w", streamdeck.ErrDeviceIsClosed)
+	}
+
+	rv := &Host{
+		device:  dev,
+		done:    make(chan struct{}),
+		keys:    map[streamdeck.KeyID]*keyAction{},
+		conns:   map[*conn]struct{}{},
+		pending: map[string]*Plugin{},
+		global:  map[string]map[string]any{},
+	}
+	rv.server = websocket.Server{Handler: rv.serve}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		reg, err := dev.AddKeyPressHandler(k, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			rv.keyEvent(key.GetID(), "keyDown")
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		rv.regs = append(rv.regs, reg)
+
+		reg, err = dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, key *streamdeck.Key, duration time.Duration) error {
+			rv.keyEvent(key.GetID(), "keyUp")
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		rv.regs = append(rv.regs, reg)
+		return nil
+	}); err != nil {
+		rv.Close()
+		return nil, err
+	}
+	return rv, nil
+}
+
+// ServeHTTP handles the WebSocket connections of the plugins.
+func (h *Host) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeHTTP(w, r)
+}
+
+// Close unregisters the key handlers of the Host and closes the plugin
+// connections. The device is not closed, and the plugins are not stopped.
+func (h *Host) Close() error {
+	h.once.Do(func() {
+		for _, reg := range h.regs {
+			reg.Remove()
+		}
+		close(h.done)
+	})
+	return nil
+}
+
+func (h *Host) deviceID() string {
+	return h.device.GetSerialNumber()
+}
+
+// deviceType returns the device type identifier of the SDK for the model of
+// the device.
+func (h *Host) deviceType() int {
+	switch h.device.GetModelID() {
+	case "mini":
+		return 1
+	case "xl":
+		return 2
+	case "pedal":
+		return 5
+	case "plus":
+		return 7
+	case "neo":
+		return 9
+	case "studio":
+		return 10
+	default:
+		return 0
+	}
+}
+
+func (h *Host) deviceInfo() *deviceInfo {
+	rows, cols := h.device.GetKeyLayout()
+	return &deviceInfo{
+		Name: h.device.GetModelName(),
+		Size: size{Columns: cols, Rows: rows},
+		Type: h.deviceType(),
+	}
+}
+
+func (h *Host) logError(msg string, err error) {
+	h.device.ReportError(msg, err)
+}
+
+func (h *Host) keyPayload(ka *keyAction) *payload {
+	row, col, _ := h.device.KeyPosition(ka.key)
+	return &payload{
+		Settings:    ka.settings,
+		Coordinates: &coordinates{Column: col, Row: row},
+		Controller:  "Keypad",
+		State:       ka.state,
+	}
+}
+
+func (h *Host) keyEventLocked(ka *keyAction, name string) {
+	ev := event{
+		Action:  ka.action,
+		Event:   name,
+		Context: ka.context,
+		Device:  h.deviceID(),
+		Payload: h.keyPayload(ka),
+	}
+	for c := range h.conns {
+		if c.owns(ka.action) {
+			h.sendLocked(c, ev)
+		}
+	}
+}
+
+func (h *Host) sendLocked(c *conn, ev event) {
+	select {
+	case c.out <- ev:
+	default:
+	}
+}
+
+func (h *Host) keyEvent(key streamdeck.KeyID, name string) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if ka, found := h.keys[key]; found {
+		h.keyEventLocked(ka, name)
+	}
+}
+
+// SetKeyAction assigns an action of a plugin, identified by its UUID, to an
+// Elgato Stream Deck key, with the given settings, replacing any action
+// previously assigned to the key. The plugin owning the action receives a
+// willAppear event, and draws the key. If the action is empty, the action of
+// the key is removed, and the key is cleared.
+func (h *Host) SetKeyAction(key streamdeck.KeyID, action string, settings map[string]any) error {
+	if _, _, err := h.device.KeyPosition(key); err != nil {
+		return err
+	}
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	if ka, found := h.keys[key]; found {
+		h.keyEventLocked(ka, "willDisappear")
+		delete(h.keys, key)
+	}
+
+	if action == "" {
+		return h.device.ClearKey(key)
+	}
+
+	if settings == nil {
+		settings = map[string]any{}
+	}
+	ka := &keyAction{
+		key:      key,
+		action:   action,
+		context:  h.deviceID() + "." + strconv.Itoa(int(key)),
+		settings: settings,
+	}
+	h.keys[key] = ka
+
+	if err := h.device.ClearKey(key); err != nil {
+		return err
+	}
+	h.keyEventLocked(ka, "willAppear")
+	return nil
+}
+
+// GetKeySettings returns the settings of the action assigned to an Elgato
+// Stream Deck key, as last set by the application or the plugin, e.g. to
+// persist them.
+func (h *Host) GetKeySettings(key streamdeck.KeyID) (map[string]any, error) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	ka, found := h.keys[key]
+	if !found {
+		return nil, fmt.Errorf("%w: no action assigned to key: %s", ErrActionInvalid, key)
+	}
+	return ka.settings, nil
+}
+
+func (h *Host) serve(ws *websocket.Conn) {
+	reg := command{}
+	if err := websocket.JSON.Receive(ws, &reg); err != nil {
+		return
+	}
+	if reg.Event != "registerPlugin" || reg.UUID == "" {
+		h.logError("elgatosdk: plugin registration failed", fmt.Errorf("%w: unexpected event: %q", ErrPluginInvalid, reg.Event))
+		return
+	}
+
+	c := &conn{
+		uuid: reg.UUID,
+		out:  make(chan event, connBuffer),
+	}
+
+	h.mtx.Lock()
+	if p, found := h.pending[reg.UUID]; found {
+		c.uuid = p.UUID
+		c.actions = p.actions
+	}
+	h.conns[c] = struct{}{}
+
+	info := h.deviceInfo()
+	h.sendLocked(c, event{Event: "deviceDidConnect", Device: h.deviceID(), DeviceInfo: info})
+	for _, ka := range h.keys {
+		if c.owns(ka.action) {
+			h.sendLocked(c, event{
+				Action:  ka.action,
+				Event:   "willAppear",
+				Context: ka.context,
+				Device:  h.deviceID(),
+				Payload: h.keyPayload(ka),
+			})
+		}
+	}
+	h.mtx.Unlock()
+
+	defer func() {
+		h.mtx.Lock()
+		delete(h.conns, c)
+		h.mtx.Unlock()
+	}()
+
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+
+		for {
+			cmd := command{}
+			if err := websocket.JSON.Receive(ws, &cmd); err != nil {
+				return
+			}
+			if err := h.exec(c, &cmd); err != nil {
+				h.logError("elgatosdk: plugin command failed", fmt.Errorf("%s: %s: %w", c.uuid, cmd.Event, err))
+			}
+		}
+	}()
+
+	for {
+		select {
+		case ev := <-c.out:
+			if err := websocket.JSON.Send(ws, ev); err != nil {
+				return
+			}
+
+		case <-received:
+			return
+
+		case <-h.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elgatosdk
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func near(v uint32, want byte) bool {
+	d := int(v) - int(want)
+	return d >= -16 && d <= 16
+}
+
+func waitColor(t *testing.T, m *mock.Device, key streamdeck.KeyID, want color.RGBA) {
+	t.Helper()
+
+	var r, g, b uint32
+	for range 100 {
+		if img := m.KeyImage(key); img != nil {
+			r, g, b, _ = img.At(36, 20).RGBA()
+			if near(r>>8, want.R) && near(g>>8, want.G) && near(b>>8, want.B) {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("bad color: got (%d, %d, %d), want %v", r>>8, g>>8, b>>8, want)
+}
+
+type testEvent struct {
+	Action  string         `json:"action"`
+	Event   string         `json:"event"`
+	Context string         `json:"context"`
+	Device  string         `json:"device"`
+	Payload map[string]any `json:"payload"`
+}
+
+func receive(t *testing.T, ws *websocket.Conn, want string) *testEvent {
+	t.Helper()
+
+	ws.SetReadDeadline(time.Now().Add(time.Second))
+	ev := &testEvent{}
+	if err := websocket.JSON.Receive(ws, ev); err != nil {
+		t.Fatalf("failed to receive %q: %s", want, err)
+	}
+	if ev.Event != want {
+		t.Fatalf("unexpected event: got %q, want %q", ev.Event, want)
+	}
+	return ev
+}
+
+func send(t *testing.T, ws *websocket.Conn, v any) {
+	t.Helper()
+
+	if err := websocket.JSON.Send(ws, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHost(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	h, err := New(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	reported := make(chan error, 10)
+	dev.SetErrorSink(streamdeck.ErrorSinkFunc(func(e streamdeck.ErrorEvent) {
+		reported <- e.Err
+	}))
+
+	go dev.Listen(nil)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	send(t, ws, map[string]any{"event": "registerPlugin", "uuid": "com.example.test"})
+	receive(t, ws, "deviceDidConnect")
+
+	if err := h.SetKeyAction(streamdeck.KEY_3, "com.example.test.action", map[string]any{"count": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.SetKeyAction(streamdeck.KEY_4, "com.example.other.action", nil); err != nil {
+		t.Fatal(err)
+	}
+	ev := receive(t, ws, "willAppear")
+	if ev.Action != "com.example.test.action" {
+		t.Errorf("unexpected action: %q", ev.Action)
+	}
+	if ev.Device != dev.GetSerialNumber() {
+		t.Errorf("unexpected device: %q", ev.Device)
+	}
+	ctx := ev.Context
+
+	img := image.NewRGBA(image.Rect(0, 0, 72, 72))
+	for i := range img.Pix {
+		if i%4 == 0 || i%4 == 3 {
+			img.Pix[i] = 0xff
+		}
+	}
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	send(t, ws, map[string]any{
+		"event":   "setImage",
+		"context": ctx,
+		"payload": map[string]any{"image": "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())},
+	})
+	send(t, ws, map[string]any{
+		"event":   "setTitle",
+		"context": ctx,
+		"payload": map[string]any{"title": "bola"},
+	})
+	waitColor(t, m, streamdeck.KEY_3, color.RGBA{R: 0xff})
+
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, ws, "keyDown")
+	if err := m.ReleaseKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, ws, "keyUp")
+
+	send(t, ws, map[string]any{
+		"event":   "setSettings",
+		"context": ctx,
+		"payload": map[string]any{"count": 2},
+	})
+	send(t, ws, map[string]any{"event": "getSettings", "context": ctx})
+	ev = receive(t, ws, "didReceiveSettings")
+	if s, ok := ev.Payload["settings"].(map[string]any); !ok || s["count"] != float64(2) {
+		t.Errorf("unexpected settings: %v", ev.Payload["settings"])
+	}
+	settings, err := h.GetKeySettings(streamdeck.KEY_3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings["count"] != float64(2) {
+		t.Errorf("unexpected settings: %v", settings)
+	}
+
+	send(t, ws, map[string]any{"event": "setGlobalSettings", "context": "bola", "payload": map[string]any{"foo": "bar"}})
+	send(t, ws, map[string]any{"event": "getGlobalSettings", "context": "bola"})
+	ev = receive(t, ws, "didReceiveGlobalSettings")
+	if s, ok := ev.Payload["settings"].(map[string]any); !ok || s["foo"] != "bar" {
+		t.Errorf("unexpected global settings: %v", ev.Payload["settings"])
+	}
+
+	send(t, ws, map[string]any{"event": "setTitle", "context": "bola"})
+	select {
+	case err := <-reported:
+		if !errors.Is(err, ErrActionInvalid) || !strings.Contains(err.Error(), "com.example.test") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("invalid command not reported")
+	}
+
+	if err := h.SetKeyAction(streamdeck.KEY_3, "", nil); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, ws, "willDisappear")
+	if _, err := h.GetKeySettings(streamdeck.KEY_3); !errors.Is(err, ErrActionInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := h.SetKeyAction(0, "com.example.test.action", nil); !errors.Is(err, streamdeck.ErrKeyInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDecodeImage(t *testing.T) {
+	img, err := decodeImage(`data:image/svg+xml;charset=utf8,%3Csvg%20xmlns%3D%22http%3A%2F%2Fwww.w3.org%2F2000%2Fsvg%22%20width%3D%2272%22%20height%3D%2272%22%3E%3Crect%20width%3D%2272%22%20height%3D%2272%22%20fill%3D%22%230000ff%22%2F%3E%3C%2Fsvg%3E`, image.Pt(72, 72))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := img.At(36, 36).RGBA(); r != 0 || g != 0 || b>>8 != 0xff {
+		t.Errorf("bad color: (%d, %d, %d)", r>>8, g>>8, b>>8)
+	}
+
+	for _, s := range []string{"bola", "data:image/png;base64,bola", "data:image/png;base64,Ym9sYQ=="} {
+		if _, err := decodeImage(s, image.Pt(72, 72)); !errors.Is(err, ErrActionInvalid) {
+			t.Errorf("%q: unexpected error: %v", s, err)
+		}
+	}
+}
+
+func TestLaunch(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	dev, _, err := mock.Open("mini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	h, err := New(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer h.Close()
+
+	dir := filepath.Join(t.TempDir(), "com.example.test.sdPlugin")
+	if err := os.Mkdir(dir, 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.Launch(dir, 1234); !errors.Is(err, ErrPluginInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"CodePath": "index.html"}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Launch(dir, 1234); !errors.Is(err, ErrPluginNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "plugin"), []byte("#!/bin/sh\necho \"$@\" > args.txt\nexec sleep 10\n"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"CodePath": "plugin", "Actions": [{"UUID": "com.example.test.action"}]}`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	p, err := h.Launch(dir, 1234)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.UUID != "com.example.test" {
+		t.Errorf("unexpected uuid: %q", p.UUID)
+	}
+
+	var args []byte
+	for range 100 {
+		args, err = os.ReadFile(filepath.Join(dir, "args.txt"))
+		if err == nil && len(args) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := p.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"-port 1234 ", "-pluginUUID " + p.token + " ", "-registerEvent registerPlugin ", `"type":1`} {
+		if !bytes.Contains(args, []byte(want)) {
+			t.Errorf("missing argument %q: %s", want, args)
+		}
+	}
+	if _, found := h.pending[p.token]; found {
+		t.Errorf("plugin still pending")
+	}
+}
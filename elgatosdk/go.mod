@@ -0,0 +1,23 @@
+module rafaelmartins.com/p/streamdeck/elgatosdk
+
+go 1.24.0
+
+require (
+	golang.org/x/net v0.50.0
+	rafaelmartins.com/p/streamdeck v0.0.0-00010101000000-000000000000
+	rafaelmartins.com/p/streamdeck/svg v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/ebitengine/purego v0.8.4 // indirect
+	github.com/srwiley/oksvg v0.0.0-20221011165216-be6e8873101c // indirect
+	github.com/srwiley/rasterx v0.0.0-20220730225603-2ab79fcdd4ef // indirect
+	golang.org/x/image v0.30.0 // indirect
+	golang.org/x/text v0.34.0 // indirect
+	rafaelmartins.com/p/usbhid v0.0.0-20250616003425-c818f1cb579e // indirect
+)
+
+replace (
+	rafaelmartins.com/p/streamdeck => ../
+	rafaelmartins.com/p/streamdeck/svg => ../svg
+)
@@ -0,0 +1,212 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elgatosdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// manifest is the subset of the manifest.json file of the plugins used to
+// start them.
+type manifest struct {
+	UUID        string
+	Name        string
+	Version     string
+	CodePath    string
+	CodePathMac string
+	CodePathWin string
+	Actions     []struct {
+		UUID string
+	}
+}
+
+// Plugin represents an Elgato Stream Deck SDK plugin started by a Host.
+type Plugin struct {
+	// UUID is the unique identifier of the plugin, like
+	// "com.elgato.cpu".
+	UUID string
+
+	// Name is the display name of the plugin.
+	Name string
+
+	host    *Host
+	token   string
+	actions map[string]bool
+	cmd     *exec.Cmd
+}
+
+func readManifest(dir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPluginInvalid, err)
+	}
+
+	rv := &manifest{}
+	if err := json.Unmarshal(data, rv); err != nil {
+		return nil, fmt.Errorf("%w: manifest.json: %w", ErrPluginInvalid, err)
+	}
+
+	// plugins without an UUID in the manifest are identified by the name of
+	// their directory.
+	if rv.UUID == "" {
+		rv.UUID = strings.TrimSuffix(filepath.Base(dir), ".sdPlugin")
+	}
+	return rv, nil
+}
+
+func (m *manifest) command() ([]string, error) {
+	code := m.CodePath
+	switch runtime.GOOS {
+	case "darwin":
+		if m.CodePathMac != "" {
+			code = m.CodePathMac
+		}
+	case "windows":
+		if m.CodePathWin != "" {
+			code = m.CodePathWin
+		}
+	}
+	if code == "" {
+		return nil, fmt.Errorf("%w: no code path", ErrPluginInvalid)
+	}
+
+	switch strings.ToLower(filepath.Ext(code)) {
+	case ".js", ".mjs", ".cjs":
+		return []string{"node", code}, nil
+	case ".html", ".htm":
+		return nil, fmt.Errorf("%w: html plugins require a browser runtime: %s", ErrPluginNotSupported, code)
+	}
+	return []string{filepath.FromSlash(code)}, nil
+}
+
+func (h *Host) info(m *manifest) (string, error) {
+	platform := "mac"
+	if runtime.GOOS == "windows" {
+		platform = "windows"
+	}
+
+	rect, err := h.device.GetKeyImageRectangle()
+	if err != nil {
+		return "", err
+	}
+	ratio := 1
+	if rect.Dx() > 96 {
+		ratio = 2
+	}
+
+	dev := h.deviceInfo()
+	dev.ID = h.deviceID()
+	data, err := json.Marshal(map[string]any{
+		"application": map[string]any{
+			"language": "en",
+			"platform": platform,
+			"version":  "6.0.0",
+		},
+		"plugin": map[string]any{
+			"uuid":    m.UUID,
+			"version": m.Version,
+		},
+		"devicePixelRatio": ratio,
+		"colors":           map[string]any{},
+		"devices":          []*deviceInfo{dev},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Launch starts the Elgato Stream Deck SDK plugin installed in the given
+// directory, e.g. "com.elgato.cpu.sdPlugin", pointing it to the Host served
+// on the given port of the loopback interface. The plugin is started with
+// the executable of the manifest for the current platform, or with Node.js
+// for JavaScript plugins, that must be available in the PATH. Plugins built
+// for macOS or Windows only usually can not run on other platforms.
+// Plugins written in HTML are not supported.
+func (h *Host) Launch(dir string, port int) (*Plugin, error) {
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	command, err := m.command()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := h.info(m)
+	if err != nil {
+		return nil, err
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	rv := &Plugin{
+		UUID:    m.UUID,
+		Name:    m.Name,
+		host:    h,
+		token:   hex.EncodeToString(token),
+		actions: map[string]bool{},
+	}
+	for _, a := range m.Actions {
+		rv.actions[a.UUID] = true
+	}
+
+	args := append(command[1:],
+		"-port", strconv.Itoa(port),
+		"-pluginUUID", rv.token,
+		"-registerEvent", "registerPlugin",
+		"-info", info,
+	)
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	name := command[0]
+	if len(command) == 1 && !filepath.IsAbs(name) {
+		name = filepath.Join(abs, name)
+	}
+	rv.cmd = exec.Command(name, args...)
+	rv.cmd.Dir = abs
+	rv.cmd.Stdout = os.Stderr
+	rv.cmd.Stderr = os.Stderr
+
+	h.mtx.Lock()
+	h.pending[rv.token] = rv
+	h.mtx.Unlock()
+
+	if err := rv.cmd.Start(); err != nil {
+		h.mtx.Lock()
+		delete(h.pending, rv.token)
+		h.mtx.Unlock()
+		return nil, fmt.Errorf("%w: %s: %w", ErrPluginInvalid, m.UUID, err)
+	}
+	return rv, nil
+}
+
+// Stop kills the plugin process and waits for it to exit.
+func (p *Plugin) Stop() error {
+	p.host.mtx.Lock()
+	delete(p.host.pending, p.token)
+	p.host.mtx.Unlock()
+
+	if err := p.cmd.Process.Kill(); err != nil {
+		return err
+	}
+	p.cmd.Wait()
+	return nil
+}
@@ -0,0 +1,375 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package emulator emulates Elgato Stream Deck devices in a web browser, to
+// run applications without hardware.
+//
+// An Emulator is backed by a fake device from the mock package, exposed as a
+// regular streamdeck.Device. The Emulator implements http.Handler, serving a
+// page that renders the key, info bar and touch strip displays, and
+// converts mouse clicks into key, touch point and dial presses, mouse wheel
+// scrolls over dials into rotations, and clicks and drags over the touch
+// strip into touches and swipes:
+//
+//	emu, err := emulator.New("plus")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	go http.ListenAndServe("localhost:8080", emu)
+//
+//	dev := emu.GetDevice()
+//	dev.SetKeyColor(streamdeck.KEY_1, colornames.Red)
+//	dev.Listen(nil)
+//
+// The displays are also served as PNG images, and inputs can be injected
+// with HTTP requests, for scripted tests:
+//
+//	GET  /layout                               device layout, as JSON
+//	GET  /keys/{key}/image                     key display
+//	GET  /info-bar/image                       info bar display
+//	GET  /touch-strip/image                    touch strip display
+//	POST /keys/{key}/{press,release}           key input
+//	POST /touch-points/{tp}/{press,release}    touch point input
+//	POST /dials/{dial}/{press,release}         dial switch input
+//	POST /dials/{dial}/rotate?delta=-1         dial rotation
+//	POST /touch-strip/touch?x=10&y=20&long=1   touch strip touch
+//	POST /touch-strip/swipe?x=10&y=20&x2=90&y2=20
+//	                                           touch strip swipe
+//
+// Keys, touch points and dials are numbered starting from 1. Input events
+// are only handled while the application calls Device.Listen.
+package emulator
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"net/http"
+	"strconv"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+// Errors returned by the emulator package.
+var (
+	ErrRequestInvalid = errors.New("emulator: request is not valid")
+)
+
+//go:embed index.html
+var index []byte
+
+// Layout represents the layout of the emulated Elgato Stream Deck device.
+type Layout struct {
+	ModelName       string `json:"model_name"`
+	ModelID         string `json:"model_id"`
+	SerialNumber    string `json:"serial_number"`
+	KeyCount        byte   `json:"key_count"`
+	KeyColumns      int    `json:"key_columns"`
+	KeyRows         int    `json:"key_rows"`
+	KeyDisplay      bool   `json:"key_display"`
+	TouchPointCount byte   `json:"touch_point_count"`
+	DialCount       byte   `json:"dial_count"`
+	InfoBar         bool   `json:"info_bar"`
+	TouchStrip      bool   `json:"touch_strip"`
+
+	// TouchPointColors are the colors set to the touch points, in the
+	// #rrggbb format.
+	TouchPointColors []string `json:"touch_point_colors,omitempty"`
+}
+
+// Emulator is an emulated Elgato Stream Deck device, rendered by a web
+// browser.
+type Emulator struct {
+	mock   *mock.Device
+	device *streamdeck.Device
+	mux    *http.ServeMux
+}
+
+// New creates an Emulator for the given model identifier, as returned by
+// streamdeck.Device.GetModelID. The device returned by Emulator.GetDevice is
+// already open.
+func New(modelID string) (*Emulator, error) {
+	dev, m, err := mock.Open(modelID)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := &Emulator{
+		mock:   m,
+		device: dev,
+		mux:    http.NewServeMux(),
+	}
+	rv.mux.HandleFunc("GET /{$}", rv.handleIndex)
+	rv.mux.HandleFunc("GET /layout", rv.handleLayout)
+	rv.mux.HandleFunc("GET /keys/{key}/image", rv.handleKeyImage)
+	rv.mux.HandleFunc("GET /info-bar/image", rv.handleInfoBarImage)
+	rv.mux.HandleFunc("GET /touch-strip/image", rv.handleTouchStripImage)
+	rv.mux.HandleFunc("POST /keys/{key}/{action}", rv.handleKey)
+	rv.mux.HandleFunc("POST /touch-points/{tp}/{action}", rv.handleTouchPoint)
+	rv.mux.HandleFunc("POST /dials/{dial}/{action}", rv.handleDial)
+	rv.mux.HandleFunc("POST /touch-strip/{action}", rv.handleTouchStrip)
+	return rv, nil
+}
+
+// GetDevice returns the emulated Elgato Stream Deck device.
+func (e *Emulator) GetDevice() *streamdeck.Device {
+	return e.device
+}
+
+// GetMock returns the fake USB HID device backing the Emulator, that can be
+// used to inspect the displays and inject inputs directly.
+func (e *Emulator) GetMock() *mock.Device {
+	return e.mock
+}
+
+// ServeHTTP serves the emulator page and endpoints.
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mux.ServeHTTP(w, r)
+}
+
+// Close closes the emulated device.
+func (e *Emulator) Close() error {
+	return e.device.Close()
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrRequestInvalid),
+		errors.Is(err, streamdeck.ErrKeyInvalid),
+		errors.Is(err, mock.ErrKeyInvalid),
+		errors.Is(err, mock.ErrTouchPointInvalid),
+		errors.Is(err, mock.ErrDialInvalid):
+		status = http.StatusBadRequest
+
+	case errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported),
+		errors.Is(err, streamdeck.ErrDeviceTouchStripNotSupported):
+		status = http.StatusNotImplemented
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func writeImage(w http.ResponseWriter, img image.Image, rect image.Rectangle) {
+	// displays that were never drawn to are rendered black
+	rv := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(rv, rv.Rect, image.Black, image.Point{}, draw.Src)
+	if img != nil {
+		draw.Draw(rv, rv.Rect, img, img.Bounds().Min, draw.Src)
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	png.Encode(w, rv)
+}
+
+func parseID(r *http.Request, name string) (byte, error) {
+	v, err := strconv.ParseUint(r.PathValue(name), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %s", ErrRequestInvalid, name, r.PathValue(name))
+	}
+	return byte(v), nil
+}
+
+func parseInt(r *http.Request, name string) (int, error) {
+	v, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: %q", ErrRequestInvalid, name, r.URL.Query().Get(name))
+	}
+	return v, nil
+}
+
+func (e *Emulator) handleIndex(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(index)
+}
+
+func (e *Emulator) handleLayout(w http.ResponseWriter, r *http.Request) {
+	dev := e.device
+	rows, cols := dev.GetKeyLayout()
+	rv := Layout{
+		ModelName:       dev.GetModelName(),
+		ModelID:         dev.GetModelID(),
+		SerialNumber:    dev.GetSerialNumber(),
+		KeyCount:        dev.GetKeyCount(),
+		KeyColumns:      cols,
+		KeyRows:         rows,
+		KeyDisplay:      dev.GetKeyDisplaySupported(),
+		TouchPointCount: dev.GetTouchPointCount(),
+		DialCount:       dev.GetDialCount(),
+		InfoBar:         dev.GetInfoBarSupported(),
+		TouchStrip:      dev.GetTouchStripSupported(),
+	}
+	dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		c := color.RGBAModel.Convert(color.Black).(color.RGBA)
+		if tc := e.mock.TouchPointColor(tp); tc != nil {
+			c = color.RGBAModel.Convert(tc).(color.RGBA)
+		}
+		rv.TouchPointColors = append(rv.TouchPointColors, fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+		return nil
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(rv)
+}
+
+func (e *Emulator) handleKeyImage(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "key")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	key := streamdeck.KeyID(id)
+	if key < streamdeck.KEY_1 || id >= byte(streamdeck.KEY_1)+e.device.GetKeyCount() {
+		writeError(w, fmt.Errorf("%w: %s", streamdeck.ErrKeyInvalid, key))
+		return
+	}
+
+	rect, err := e.device.GetKeyImageRectangle()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeImage(w, e.mock.KeyImage(key), rect)
+}
+
+func (e *Emulator) handleInfoBarImage(w http.ResponseWriter, r *http.Request) {
+	rect, err := e.device.GetInfoBarImageRectangle()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeImage(w, e.mock.InfoBarImage(), rect)
+}
+
+func (e *Emulator) handleTouchStripImage(w http.ResponseWriter, r *http.Request) {
+	rect, err := e.device.GetTouchStripImageRectangle()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeImage(w, e.mock.TouchStripImage(), rect)
+}
+
+func (e *Emulator) handleKey(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "key")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "press":
+		err = e.mock.PressKey(streamdeck.KeyID(id))
+	case "release":
+		err = e.mock.ReleaseKey(streamdeck.KeyID(id))
+	default:
+		err = fmt.Errorf("%w: action: %s", ErrRequestInvalid, r.PathValue("action"))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (e *Emulator) handleTouchPoint(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "tp")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "press":
+		err = e.mock.PressTouchPoint(streamdeck.TouchPointID(id))
+	case "release":
+		err = e.mock.ReleaseTouchPoint(streamdeck.TouchPointID(id))
+	default:
+		err = fmt.Errorf("%w: action: %s", ErrRequestInvalid, r.PathValue("action"))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (e *Emulator) handleDial(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(r, "dial")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "press":
+		err = e.mock.PressDial(streamdeck.DialID(id))
+	case "release":
+		err = e.mock.ReleaseDial(streamdeck.DialID(id))
+	case "rotate":
+		delta := 0
+		delta, err = parseInt(r, "delta")
+		if err == nil {
+			err = e.mock.RotateDial(streamdeck.DialID(id), int8(max(min(delta, 127), -128)))
+		}
+	default:
+		err = fmt.Errorf("%w: action: %s", ErrRequestInvalid, r.PathValue("action"))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (e *Emulator) handleTouchStrip(w http.ResponseWriter, r *http.Request) {
+	if !e.device.GetTouchStripSupported() {
+		writeError(w, streamdeck.ErrDeviceTouchStripNotSupported)
+		return
+	}
+
+	x, err := parseInt(r, "x")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	y, err := parseInt(r, "y")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch r.PathValue("action") {
+	case "touch":
+		t := streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT
+		if r.URL.Query().Get("long") != "" {
+			t = streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG
+		}
+		err = e.mock.TouchStrip(t, image.Pt(x, y))
+
+	case "swipe":
+		x2, y2 := 0, 0
+		if x2, err = parseInt(r, "x2"); err == nil {
+			if y2, err = parseInt(r, "y2"); err == nil {
+				err = e.mock.SwipeTouchStrip(image.Pt(x, y), image.Pt(x2, y2))
+			}
+		}
+
+	default:
+		err = fmt.Errorf("%w: action: %s", ErrRequestInvalid, r.PathValue("action"))
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func request(t *testing.T, ts *httptest.Server, method string, path string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, ts.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, buf.Bytes()
+}
+
+func setup(t *testing.T, modelID string) (*Emulator, *httptest.Server) {
+	t.Helper()
+
+	emu, err := New(modelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(emu)
+	t.Cleanup(func() {
+		ts.Close()
+		emu.Close()
+	})
+	return emu, ts
+}
+
+func TestDisplays(t *testing.T) {
+	emu, ts := setup(t, "neo")
+	dev := emu.GetDevice()
+
+	status, body := request(t, ts, http.MethodGet, "/")
+	if status != http.StatusOK || !bytes.Contains(body, []byte("Stream Deck Emulator")) {
+		t.Errorf("bad index: %d", status)
+	}
+
+	if err := dev.SetKeyColor(streamdeck.KEY_2, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetTouchPointColor(streamdeck.TOUCH_POINT_2, color.RGBA{B: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body = request(t, ts, http.MethodGet, "/layout")
+	if status != http.StatusOK {
+		t.Fatalf("bad status: %d", status)
+	}
+	layout := Layout{}
+	if err := json.Unmarshal(body, &layout); err != nil {
+		t.Fatal(err)
+	}
+	if layout.ModelID != "neo" || layout.KeyCount != 8 || layout.KeyColumns != 4 || layout.KeyRows != 2 || !layout.InfoBar || layout.TouchStrip {
+		t.Errorf("bad layout: %+v", layout)
+	}
+	if len(layout.TouchPointColors) != 2 || layout.TouchPointColors[0] != "#000000" || layout.TouchPointColors[1] != "#0000ff" {
+		t.Errorf("bad touch point colors: %v", layout.TouchPointColors)
+	}
+
+	for _, tt := range []struct {
+		path string
+		want color.RGBA
+	}{
+		{"/keys/1/image", color.RGBA{}},
+		{"/keys/2/image", color.RGBA{R: 0xff}},
+		{"/info-bar/image", color.RGBA{}},
+	} {
+		status, body := request(t, ts, http.MethodGet, tt.path)
+		if status != http.StatusOK {
+			t.Errorf("%s: bad status: %d", tt.path, status)
+			continue
+		}
+		img, err := png.Decode(bytes.NewReader(body))
+		if err != nil {
+			t.Errorf("%s: %s", tt.path, err)
+			continue
+		}
+		if b := img.Bounds(); b.Dx() == 0 || b.Dy() == 0 {
+			t.Errorf("%s: bad bounds: %s", tt.path, b)
+		}
+		assertColor(t, img, 10, 10, tt.want)
+	}
+
+	for path, want := range map[string]int{
+		"/keys/9/image":        http.StatusBadRequest,
+		"/keys/bola/image":     http.StatusBadRequest,
+		"/touch-strip/image":   http.StatusNotImplemented,
+		"/touch-strip/touch":   http.StatusMethodNotAllowed,
+		"/dials/1/bola/action": http.StatusNotFound,
+	} {
+		if status, _ := request(t, ts, http.MethodGet, path); status != want {
+			t.Errorf("%s: bad status: got %d, want %d", path, status, want)
+		}
+	}
+}
+
+func TestInputs(t *testing.T) {
+	emu, ts := setup(t, "plus")
+	dev := emu.GetDevice()
+
+	events := make(chan string, 10)
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_3, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "press " + k.String()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyReleaseHandler(streamdeck.KEY_3, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+		events <- "release " + k.String()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialRotateHandler(streamdeck.DIAL_2, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+		events <- fmt.Sprintf("rotate %s %d", di, delta)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+		events <- "touch " + p.String()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dev.ListenContext(ctx, nil)
+
+	for _, path := range []string{
+		"/keys/3/press",
+		"/keys/3/release",
+		"/dials/2/rotate?delta=3",
+		"/touch-strip/touch?x=10&y=20",
+	} {
+		if status, body := request(t, ts, http.MethodPost, path); status != http.StatusNoContent {
+			t.Errorf("%s: bad status: %d: %s", path, status, body)
+		}
+	}
+
+	for _, want := range []string{"press KEY_3", "release KEY_3", "rotate DIAL_2 3", "touch (10,20)"} {
+		select {
+		case e := <-events:
+			if e != want {
+				t.Errorf("bad event: got %q, want %q", e, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %q", want)
+		}
+	}
+
+	for path, want := range map[string]int{
+		"/keys/9/press":                 http.StatusBadRequest,
+		"/keys/1/bola":                  http.StatusBadRequest,
+		"/touch-points/1/press":         http.StatusBadRequest,
+		"/dials/5/press":                http.StatusBadRequest,
+		"/dials/1/rotate?delta=bola":    http.StatusBadRequest,
+		"/touch-strip/swipe?x=1&y=1":    http.StatusBadRequest,
+		"/touch-strip/bola?x=1&y=1":     http.StatusBadRequest,
+		"/touch-strip/touch?x=1":        http.StatusBadRequest,
+		"/touch-strip/touch?x=1&y=1&z=": http.StatusNoContent,
+	} {
+		if status, body := request(t, ts, http.MethodPost, path); status != want {
+			t.Errorf("%s: bad status: got %d, want %d: %s", path, status, want, strings.TrimSpace(string(body)))
+		}
+	}
+}
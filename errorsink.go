@@ -0,0 +1,215 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// errorQueueSize is the maximum number of error events waiting to be
+// delivered to the error sink. Further events are dropped, and counted.
+const errorQueueSize = 64
+
+// ErrorSeverity represents the severity of an error reported by an Elgato
+// Stream Deck device to its ErrorSink.
+type ErrorSeverity byte
+
+// String returns a string representation of the ErrorSeverity.
+func (s ErrorSeverity) String() string {
+	switch s {
+	case ERROR_SEVERITY_WARNING:
+		return "ERROR_SEVERITY_WARNING"
+	case ERROR_SEVERITY_ERROR:
+		return "ERROR_SEVERITY_ERROR"
+	case ERROR_SEVERITY_CRITICAL:
+		return "ERROR_SEVERITY_CRITICAL"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck error severities.
+//
+// ERROR_SEVERITY_WARNING is used for unexpected conditions that were
+// ignored, like malformed input reports. ERROR_SEVERITY_ERROR is used for
+// errors returned by callbacks and background tasks. ERROR_SEVERITY_CRITICAL
+// is used for panics recovered from callbacks.
+const (
+	ERROR_SEVERITY_WARNING ErrorSeverity = iota + 1
+	ERROR_SEVERITY_ERROR
+	ERROR_SEVERITY_CRITICAL
+)
+
+// ErrorEvent represents an error reported by an Elgato Stream Deck device to
+// its ErrorSink.
+type ErrorEvent struct {
+	// Err is the error, usually of one of the error types of the package,
+	// like KeyHandlerError, AnimationError or ProtocolWarning.
+	Err error
+
+	// Severity is the severity of the error.
+	Severity ErrorSeverity
+
+	// Key, TouchPoint and Dial identify the input that originated the error,
+	// and are zero if not originated by an input of their type. TouchStrip
+	// is true if the error was originated by the touch strip.
+	Key        KeyID
+	TouchPoint TouchPointID
+	Dial       DialID
+	TouchStrip bool
+
+	// Dropped is the number of error events dropped before this one, since
+	// the previous event delivered, because the sink was not keeping up.
+	Dropped int
+}
+
+// ErrorSink is the interface implemented by the receivers of the errors
+// reported by an Elgato Stream Deck device.
+type ErrorSink interface {
+	HandleError(e ErrorEvent)
+}
+
+// ErrorSinkFunc is an adapter to use ordinary functions as ErrorSink.
+type ErrorSinkFunc func(e ErrorEvent)
+
+// HandleError calls f(e).
+func (f ErrorSinkFunc) HandleError(e ErrorEvent) {
+	f(e)
+}
+
+// ProtocolWarning represents an unexpected condition in the communication
+// with the device that was ignored, like a malformed input report.
+type ProtocolWarning struct {
+	Message string
+	Attrs   []any
+}
+
+// Error returns a string representation of a protocol warning.
+func (w ProtocolWarning) Error() string {
+	b := strings.Builder{}
+	b.WriteString(w.Message)
+	for i := 0; i+1 < len(w.Attrs); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", w.Attrs[i], w.Attrs[i+1])
+	}
+	return b.String()
+}
+
+type errorSinkValue struct {
+	sink ErrorSink
+}
+
+type errorQueue struct {
+	mtx     sync.Mutex
+	queue   []ErrorEvent
+	dropped int
+	running bool
+}
+
+// SetErrorSink sets the receiver of all the errors reported by the Elgato
+// Stream Deck device that can not be returned to the caller: errors from the
+// input handlers, also sent to the error channels of Listen and
+// ListenContext, if any, errors from background tasks, like animators and
+// asynchronous writers, and protocol warnings.
+//
+// Errors are delivered in order, one at a time, from a separate goroutine,
+// so that the sink never blocks the device. If the sink does not keep up,
+// errors are dropped and counted in the next ErrorEvent delivered.
+//
+// If set, errors are not sent to the device logger. If set to nil, the
+// default, errors are reported as described by SetLogger.
+func (d *Device) SetErrorSink(sink ErrorSink) {
+	if sink == nil {
+		d.errorSink.Store(nil)
+		return
+	}
+	d.errorSink.Store(&errorSinkValue{sink: sink})
+}
+
+// GetErrorSink returns the receiver of the errors reported by the Elgato
+// Stream Deck device, or nil if not set.
+func (d *Device) GetErrorSink() ErrorSink {
+	if v := d.errorSink.Load(); v != nil {
+		return v.sink
+	}
+	return nil
+}
+
+// reportError delivers an error to the error sink, if set, returning false
+// otherwise.
+func (d *Device) reportError(severity ErrorSeverity, err error) bool {
+	if d.errorSink.Load() == nil {
+		return false
+	}
+
+	e := ErrorEvent{
+		Err:      err,
+		Severity: severity,
+	}
+	if errors.As(err, &HandlerPanicError{}) {
+		e.Severity = ERROR_SEVERITY_CRITICAL
+	}
+
+	var (
+		keyErr   KeyHandlerError
+		tpErr    TouchPointHandlerError
+		dialErr  DialHandlerError
+		touchErr TouchStripTouchHandlerError
+		swipeErr TouchStripSwipeHandlerError
+		dragErr  TouchStripDragHandlerError
+	)
+	switch {
+	case errors.As(err, &keyErr):
+		e.Key = keyErr.KeyID
+	case errors.As(err, &tpErr):
+		e.TouchPoint = tpErr.TouchPointID
+	case errors.As(err, &dialErr):
+		e.Dial = dialErr.DialID
+	case errors.As(err, &touchErr), errors.As(err, &swipeErr), errors.As(err, &dragErr):
+		e.TouchStrip = true
+	}
+
+	d.errorQueue.push(d, e)
+	return true
+}
+
+func (q *errorQueue) push(d *Device, e ErrorEvent) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if len(q.queue) >= errorQueueSize {
+		q.dropped++
+		return
+	}
+
+	e.Dropped = q.dropped
+	q.dropped = 0
+	q.queue = append(q.queue, e)
+	if !q.running {
+		q.running = true
+		go q.run(d)
+	}
+}
+
+func (q *errorQueue) run(d *Device) {
+	for {
+		q.mtx.Lock()
+		if len(q.queue) == 0 {
+			q.running = false
+			q.mtx.Unlock()
+			return
+		}
+		e := q.queue[0]
+		q.queue[0] = ErrorEvent{}
+		q.queue = q.queue[1:]
+		q.mtx.Unlock()
+
+		if sink := d.GetErrorSink(); sink != nil {
+			sink.HandleError(e)
+		}
+	}
+}
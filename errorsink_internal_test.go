@@ -0,0 +1,123 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProtocolWarning_Error(t *testing.T) {
+	w := ProtocolWarning{Message: "streamdeck: unknown dial event", Attrs: []any{"type", 5}}
+	if s := w.Error(); s != "streamdeck: unknown dial event type=5" {
+		t.Errorf("unexpected string: %q", s)
+	}
+}
+
+func TestReportError(t *testing.T) {
+	d := &Device{}
+	if d.reportError(ERROR_SEVERITY_ERROR, errors.New("foo")) {
+		t.Fatal("reported without sink")
+	}
+
+	release := make(chan struct{})
+	events := make(chan ErrorEvent, errorQueueSize+10)
+	d.SetErrorSink(ErrorSinkFunc(func(e ErrorEvent) {
+		<-release
+		events <- e
+	}))
+
+	boom := errors.New("boom")
+	for _, err := range []error{
+		KeyHandlerError{KeyID: KEY_3, Err: boom},
+		DialHandlerError{DialID: DIAL_2, Err: HandlerPanicError{Value: boom}},
+		TouchStripSwipeHandlerError{Err: boom},
+	} {
+		if !d.reportError(ERROR_SEVERITY_ERROR, err) {
+			t.Fatal("not reported")
+		}
+	}
+
+	// the queue is full after 61 warnings, or 62 if the first event was
+	// already taken by the sink
+	for range errorQueueSize + 3 {
+		d.reportError(ERROR_SEVERITY_WARNING, boom)
+	}
+	close(release)
+
+	get := func() ErrorEvent {
+		t.Helper()
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return ErrorEvent{}
+	}
+
+	if e := get(); e.Key != KEY_3 || e.Severity != ERROR_SEVERITY_ERROR || !errors.Is(e.Err, boom) {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	if e := get(); e.Dial != DIAL_2 || e.Severity != ERROR_SEVERITY_CRITICAL {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	if e := get(); !e.TouchStrip || e.Key != 0 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	for range errorQueueSize - 3 {
+		if e := get(); e.Dropped != 0 || e.Severity != ERROR_SEVERITY_WARNING {
+			t.Fatalf("unexpected event: %+v", e)
+		}
+	}
+
+	d.reportError(ERROR_SEVERITY_WARNING, boom)
+	e := get()
+	for e.Dropped == 0 {
+		e = get()
+	}
+	if e.Dropped < 5 || e.Dropped > 6 {
+		t.Errorf("unexpected dropped count: %d", e.Dropped)
+	}
+}
+
+func TestDevice_ReportError(t *testing.T) {
+	d, err := NewDevice(&selectorDevice{productID: 0x0080, serial: "ABC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	d.SetLogger(slog.New(slog.NewTextHandler(buf, nil)))
+
+	boom := errors.New("boom")
+	d.ReportError("pkg: failed", boom)
+	if s := buf.String(); !strings.Contains(s, `msg="pkg: failed" serial=ABC error=boom`) {
+		t.Errorf("unexpected log: %q", s)
+	}
+
+	events := make(chan ErrorEvent, 1)
+	d.SetErrorSink(ErrorSinkFunc(func(e ErrorEvent) {
+		events <- e
+	}))
+	buf.Reset()
+	d.ReportError("pkg: failed", boom)
+
+	select {
+	case e := <-events:
+		if e.Err != boom || e.Severity != ERROR_SEVERITY_ERROR {
+			t.Errorf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected log: %q", buf.String())
+	}
+}
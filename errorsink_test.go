@@ -0,0 +1,75 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestErrorSink(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan streamdeck.ErrorEvent, 10)
+	dev.SetErrorSink(streamdeck.ErrorSinkFunc(func(e streamdeck.ErrorEvent) {
+		events <- e
+	}))
+	if dev.GetErrorSink() == nil {
+		t.Fatal("error sink not set")
+	}
+
+	if _, err := dev.AddDialSwitchHandler(streamdeck.DIAL_3, func(d *streamdeck.Device, di *streamdeck.Dial) error {
+		return errors.New("boom")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// errors are delivered to the sink even if nobody reads the channel
+	go dev.ListenContext(ctx, make(chan error))
+
+	if err := m.InjectInputReport([]byte{2, 0, 0, 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PressDial(streamdeck.DIAL_3); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, check := range []func(e streamdeck.ErrorEvent) bool{
+		func(e streamdeck.ErrorEvent) bool {
+			w := streamdeck.ProtocolWarning{}
+			return e.Severity == streamdeck.ERROR_SEVERITY_WARNING && errors.As(e.Err, &w) && w.Message == "streamdeck: unknown touch strip event"
+		},
+		func(e streamdeck.ErrorEvent) bool {
+			return e.Severity == streamdeck.ERROR_SEVERITY_ERROR && e.Dial == streamdeck.DIAL_3 && e.Err.Error() == "boom [DIAL_3]"
+		},
+	} {
+		select {
+		case e := <-events:
+			if !check(e) {
+				t.Errorf("unexpected event: %+v", e)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+	}
+}
@@ -79,14 +79,14 @@ func main() {
 			log.Println("Set touch strip gradient")
 		}
 
-		if err := device.AddTouchStripTouchHandler(func(d *streamdeck.Device, typ streamdeck.TouchStripTouchType, p image.Point) error {
+		if _, err := device.AddTouchStripTouchHandler(func(d *streamdeck.Device, typ streamdeck.TouchStripTouchType, p image.Point) error {
 			log.Printf("Touch strip activated: (%s: %s)", typ, p)
 			return nil
 		}); err != nil {
 			log.Printf("error: %v", err)
 		}
 
-		if err := device.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
+		if _, err := device.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
 			log.Printf("Touch strip swiped: (%s -> %s)", origin, destination)
 			return nil
 		}); err != nil {
@@ -113,7 +113,7 @@ func main() {
 				log.Printf("Set touch point %s color", tp)
 			}
 
-			return device.AddTouchPointHandler(tp, func(d *streamdeck.Device, tp *streamdeck.TouchPoint) error {
+			_, err := device.AddTouchPointHandler(tp, func(d *streamdeck.Device, tp *streamdeck.TouchPoint) error {
 				touch := tp.GetID()
 				log.Printf("Touch point %s activated!", touch)
 
@@ -128,6 +128,7 @@ func main() {
 				// restore original color
 				return d.SetTouchPointColor(touch, colors[int(touch-streamdeck.TOUCH_POINT_1)%len(colors)])
 			})
+			return err
 		}); err != nil {
 			log.Printf("error: %v", err)
 		}
@@ -139,19 +140,20 @@ func main() {
 		log.Println("Setting up dials...")
 
 		if err := device.ForEachDial(func(d streamdeck.DialID) error {
-			if err := device.AddDialRotateHandler(d, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+			if _, err := device.AddDialRotateHandler(d, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
 				log.Printf("Dial %s rotated: %d", di, delta)
 				return nil
 			}); err != nil {
 				return err
 			}
 
-			return device.AddDialSwitchHandler(d, func(d *streamdeck.Device, di *streamdeck.Dial) error {
+			_, err := device.AddDialSwitchHandler(d, func(d *streamdeck.Device, di *streamdeck.Dial) error {
 				log.Printf("Dial %s pressed!", di)
 				duration := di.WaitForRelease()
 				log.Printf("Dial %s was held for %v", di, duration)
 				return nil
 			})
+			return err
 		}); err != nil {
 			log.Printf("error: %v", err)
 		}
@@ -213,7 +215,7 @@ func setupKeys(device *streamdeck.Device) {
 			return fmt.Errorf("failed to set %s color: %w", key, err)
 		}
 
-		return device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		_, err := device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
 			key := k.GetID()
 			log.Printf("Key %s pressed!", key)
 
@@ -235,6 +237,7 @@ func setupKeys(device *streamdeck.Device) {
 				return flashKey(d, key, 500*time.Millisecond)
 			}
 		})
+		return err
 	}); err != nil {
 		log.Printf("error: %v", err)
 	}
@@ -62,7 +62,7 @@ func main() {
 
 		log.Printf("Set key %s to color", key)
 
-		return device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		_, err := device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
 			key := k.GetID()
 			log.Printf("Key %s pressed!", key)
 
@@ -77,6 +77,7 @@ func main() {
 			// restore original color
 			return d.SetKeyColor(key, colors[key-streamdeck.KEY_1])
 		})
+		return err
 	}); err != nil {
 		log.Printf("error: %v", err)
 	}
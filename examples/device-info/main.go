@@ -36,6 +36,14 @@ func main() {
 		fmt.Printf("  Model Name: %s\n", device.GetModelName())
 		fmt.Printf("  Model ID: %s\n", device.GetModelID())
 		fmt.Printf("  Serial Number: %s\n", device.GetSerialNumber())
+		fmt.Printf("  USB ID: %04x:%04x\n", device.GetVendorID(), device.GetProductID())
+		fmt.Printf("  Path: %s\n", device.GetPath())
+
+		if location, err := device.GetLocation(); err != nil {
+			fmt.Printf("  Location: Error - %v\n", err)
+		} else {
+			fmt.Printf("  Location: %s\n", location)
+		}
 
 		if firmwareVersion, err := device.GetFirmwareVersion(); err != nil {
 			fmt.Printf("  Firmware Version: Error - %v\n", err)
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"image/color"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/image/colornames"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/emulator"
+)
+
+func main() {
+	model := flag.String("model", "plus", "emulated model identifier")
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	flag.Parse()
+
+	emu, err := emulator.New(*model)
+	if err != nil {
+		log.Fatalf("error: failed to create emulator: %v", err)
+	}
+	defer emu.Close()
+
+	device := emu.GetDevice()
+	log.Printf("Emulating: %s", device.GetModelName())
+
+	colors := []color.Color{
+		colornames.Red,
+		colornames.Green,
+		colornames.Blue,
+		colornames.Yellow,
+	}
+
+	if err := device.ForEachKey(func(key streamdeck.KeyID) error {
+		c := colors[int(key-streamdeck.KEY_1)%len(colors)]
+		if err := device.SetKeyColor(key, c); err != nil {
+			return err
+		}
+
+		_, err := device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+			log.Printf("Key %s pressed!", k)
+
+			// flash the key by setting it to white briefly
+			if err := d.SetKeyColor(key, color.White); err != nil {
+				return err
+			}
+
+			duration := k.WaitForRelease()
+			log.Printf("Key %s was held for %v", k, duration)
+
+			// restore original color
+			return d.SetKeyColor(key, c)
+		})
+		return err
+	}); err != nil {
+		log.Printf("error: %v", err)
+	}
+
+	if err := device.ForEachDial(func(di streamdeck.DialID) error {
+		_, err := device.AddDialRotateHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, delta int8) error {
+			log.Printf("Dial %s rotated by %d", dial, delta)
+			return nil
+		})
+		return err
+	}); err != nil {
+		log.Printf("error: %v", err)
+	}
+
+	go func() {
+		log.Printf("Open http://%s in a web browser", *addr)
+		if err := http.ListenAndServe(*addr, emu); err != nil {
+			log.Fatalf("error: %v", err)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := device.ListenContext(ctx, nil); err != nil {
+		log.Printf("error: input error: %v", err)
+	}
+}
@@ -53,7 +53,7 @@ func main() {
 	}
 
 	if err := device.ForEachKey(func(key streamdeck.KeyID) error {
-		return device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		_, err := device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
 			key := k.GetID()
 			log.Printf("Key %s pressed!", key)
 
@@ -68,6 +68,7 @@ func main() {
 			// restore original image
 			return restoreKeyImage(d, key)
 		})
+		return err
 	}); err != nil {
 		log.Printf("error: %v", err)
 	}
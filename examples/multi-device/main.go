@@ -2,12 +2,10 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"image/color"
 	"log"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
@@ -58,224 +56,105 @@ var baseColors = [][]color.Color{
 	},
 }
 
-type DeviceManager struct {
-	devices []*streamdeck.Device
-	ctx     context.Context
-	cancel  context.CancelFunc
-	wg      sync.WaitGroup
-}
-
-func NewDeviceManager() *DeviceManager {
-	ctx, cancel := context.WithCancel(context.Background())
-	return &DeviceManager{
-		ctx:    ctx,
-		cancel: cancel,
+func restoreKey(m *streamdeck.Manager, dev *streamdeck.Device, key streamdeck.KeyID) error {
+	for i, d := range m.GetDevices() {
+		if d == dev {
+			colors := baseColors[i%len(baseColors)]
+			return dev.SetKeyColor(key, colors[int(key-streamdeck.KEY_1)%len(colors)])
+		}
 	}
+	return nil
 }
 
-func (dm *DeviceManager) Initialize() error {
-	devices, err := streamdeck.Enumerate()
-	if err != nil {
-		return fmt.Errorf("failed to enumerate devices: %w", err)
-	}
-
-	if len(devices) == 0 {
-		return fmt.Errorf("no Stream Deck devices found")
+func setupDevices(m *streamdeck.Manager) error {
+	if err := m.SetBrightnessAll(75); err != nil {
+		return err
 	}
 
-	log.Printf("Found %d Stream Deck device(s)", len(devices))
-
+	devices := m.GetDevices()
 	for i, device := range devices {
-		if err := device.Open(); err != nil {
-			log.Printf("Failed to open device %d: %v", i, err)
-			continue
-		}
-
 		log.Printf("Device %d: %s (%s) with %d keys", i, device.GetModelName(), device.GetSerialNumber(), device.GetKeyCount())
-		dm.devices = append(dm.devices, device)
-	}
-
-	if len(dm.devices) == 0 {
-		return fmt.Errorf("failed to open any devices")
 	}
-	return nil
-}
 
-func (dm *DeviceManager) SetupDevices() error {
-	for i, device := range dm.devices {
-		if err := dm.setupDevice(device, i); err != nil {
-			return fmt.Errorf("failed to setup device %d: %v", i, err)
-		}
-	}
-	return nil
+	return m.ForEach(func(device *streamdeck.Device) error {
+		return device.ForEachKey(func(key streamdeck.KeyID) error {
+			return restoreKey(m, device, key)
+		})
+	})
 }
 
-func (dm *DeviceManager) setupDevice(device *streamdeck.Device, deviceIndex int) error {
-	if err := device.SetBrightness(75); err != nil {
-		return fmt.Errorf("failed to set brightness: %w", err)
-	}
-
-	colors := baseColors[deviceIndex%len(baseColors)]
-
-	return device.ForEachKey(func(key streamdeck.KeyID) error {
-		keyColor := colors[int(key-streamdeck.KEY_1)%(len(colors))]
+func handleEvent(m *streamdeck.Manager, e streamdeck.ManagerEvent) error {
+	switch e.Type {
+	case streamdeck.MANAGER_EVENT_TYPE_KEY_PRESS:
+		log.Printf("Device %s, Key %s pressed!", e.Serial, e.Key)
 
-		if err := device.SetKeyColor(key, keyColor); err != nil {
-			return fmt.Errorf("failed to set color for key %s on device %d: %v", key, deviceIndex, err)
+		// flash the key white
+		if err := e.Device.SetKeyColor(e.Key, color.White); err != nil {
+			return err
 		}
 
-		if err := device.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
-			key := k.GetID()
-			log.Printf("Device %d, Key %s pressed!", deviceIndex, key)
-
-			// flash the key white
-			if err := d.SetKeyColor(key, color.White); err != nil {
-				return err
-			}
-
-			// also flash a key on other devices for synchronization effect
-			dm.flashOtherDevices(deviceIndex, key)
+		// also flash a key on other devices for synchronization effect
+		flashOtherDevices(m, e.Device, e.Key)
 
-			duration := k.WaitForRelease()
-			log.Printf("Device %d, Key %s held for %v", deviceIndex, key, duration)
+	case streamdeck.MANAGER_EVENT_TYPE_KEY_RELEASE:
+		log.Printf("Device %s, Key %s held for %v", e.Serial, e.Key, e.Duration)
 
-			// restore original color
-			return d.SetKeyColor(key, colors[int(key-1)%len(colors)])
-		}); err != nil {
-			return fmt.Errorf("failed to add handler for key %s on device %d: %v", key, deviceIndex, err)
-		}
-		return nil
-	})
+		// restore original color
+		return restoreKey(m, e.Device, e.Key)
+	}
+	return nil
 }
 
-func (dm *DeviceManager) flashOtherDevices(devSrc int, key streamdeck.KeyID) {
-	for i, device := range dm.devices {
-		if i == devSrc {
+func flashOtherDevices(m *streamdeck.Manager, src *streamdeck.Device, key streamdeck.KeyID) {
+	for _, device := range m.GetDevices() {
+		if device == src || byte(key) > device.GetKeyCount() {
 			continue
 		}
 
-		go func(dev *streamdeck.Device, idx int) {
-			if byte(key) <= dev.GetKeyCount() {
-				// flash briefly in white
-				if err := dev.SetKeyColor(key, color.White); err != nil {
-					log.Printf("error: failed to flash key %s on device %d: %v", key, idx, err)
-					return
-				}
-
-				time.Sleep(300 * time.Millisecond)
-
-				// restore original color based on device theme
-				colors := baseColors[idx%len(baseColors)]
-				if err := dev.SetKeyColor(key, colors[int(key-1)%len(colors)]); err != nil {
-					log.Printf("error: failed to restore key %s color on device %d: %v", key, idx, err)
-				}
-			}
-		}(device, i)
-	}
-}
-
-func (dm *DeviceManager) Listen() {
-	for i, device := range dm.devices {
-		dm.wg.Add(1)
-
-		go func(dev *streamdeck.Device, deviceIndex int) {
-			defer dm.wg.Done()
-
-			errCh := make(chan error, 1)
-			go func() {
-				if err := dev.Listen(errCh); err != nil {
-					select {
-					case errCh <- err:
-					default:
-					}
-				}
-			}()
-
-			for {
-				select {
-				case <-dm.ctx.Done():
-					log.Printf("Device %d listener stopping", deviceIndex)
-					return
-
-				case err := <-errCh:
-					if err != nil {
-						log.Printf("error: device %d: %v", deviceIndex, err)
-					}
-				}
+		go func() {
+			// flash briefly in white
+			if err := device.SetKeyColor(key, color.White); err != nil {
+				log.Printf("error: failed to flash key %s on device %s: %v", key, device.GetSerialNumber(), err)
+				return
 			}
-		}(device, i)
-	}
-}
 
-func (dm *DeviceManager) CreateSynchronizedEffect() {
-	dm.wg.Add(1)
+			time.Sleep(300 * time.Millisecond)
 
-	go func() {
-		defer dm.wg.Done()
-
-		ticker := time.NewTicker(2 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-dm.ctx.Done():
-				return
-
-			case <-ticker.C:
-				dm.runWaveEffect()
+			// restore original color based on device theme
+			if err := restoreKey(m, device, key); err != nil {
+				log.Printf("error: failed to restore key %s color on device %s: %v", key, device.GetSerialNumber(), err)
 			}
-		}
-	}()
+		}()
+	}
 }
 
-func (dm *DeviceManager) runWaveEffect() {
+func runWaveEffect(m *streamdeck.Manager) {
 	log.Println("Running synchronized wave effect...")
 
-	var dev *streamdeck.Device
-	for _, device := range dm.devices {
-		if dev == nil || device.GetKeyCount() > dev.GetKeyCount() {
-			dev = device
-		}
-	}
-	if dev == nil {
-		log.Println("error: no devices available for wave effect")
-		return
+	var keys byte
+	for _, device := range m.GetDevices() {
+		keys = max(keys, device.GetKeyCount())
 	}
 
-	dev.ForEachKey(func(key streamdeck.KeyID) error {
-		for _, device := range dm.devices {
-			if byte(key) <= device.GetKeyCount() {
-				if err := device.SetKeyColor(key, colornames.Yellow); err != nil {
-					log.Printf("error: failed to set yellow color for wave effect: %v", err)
-				}
+	for key := streamdeck.KEY_1; byte(key-streamdeck.KEY_1) < keys; key++ {
+		if err := m.ForEach(func(device *streamdeck.Device) error {
+			if byte(key) > device.GetKeyCount() {
+				return nil
 			}
+			return device.SetKeyColor(key, colornames.Yellow)
+		}); err != nil {
+			log.Printf("error: failed to set yellow color for wave effect: %v", err)
 		}
 
 		time.Sleep(100 * time.Millisecond)
 
-		for i, device := range dm.devices {
-			if byte(key) <= device.GetKeyCount() {
-				colors := baseColors[i%len(baseColors)]
-				if err := device.SetKeyColor(key, colors[int(key-1)%len(colors)]); err != nil {
-					log.Printf("error: failed to restore color after wave effect: %v", err)
-				}
+		if err := m.ForEach(func(device *streamdeck.Device) error {
+			if byte(key) > device.GetKeyCount() {
+				return nil
 			}
-		}
-		return nil
-	})
-}
-
-func (dm *DeviceManager) Shutdown() {
-	log.Println("Shutting down device manager...")
-
-	dm.cancel()
-	dm.wg.Wait()
-
-	for i, device := range dm.devices {
-		if err := device.Close(); err != nil {
-			log.Printf("error: failed to close device %d: %v", i, err)
-		} else {
-			log.Printf("Device %d closed successfully", i)
+			return restoreKey(m, device, key)
+		}); err != nil {
+			log.Printf("error: failed to restore color after wave effect: %v", err)
 		}
 	}
 }
@@ -286,26 +165,54 @@ func main() {
 	log.Println("Press any key on any device to see synchronized effects")
 	log.Println("Press Ctrl+C to exit")
 
-	dm := NewDeviceManager()
-	if err := dm.Initialize(); err != nil {
-		log.Fatalf("error: failed to initialize devices: %v", err)
+	m, err := streamdeck.OpenManager()
+	if m == nil {
+		log.Fatalf("error: failed to open devices: %v", err)
 	}
-	defer dm.Shutdown()
+	if err != nil {
+		log.Printf("error: some devices failed to open: %v", err)
+	}
+	defer func() {
+		log.Println("Shutting down device manager...")
+		if err := m.ClearAll(); err != nil {
+			log.Printf("error: failed to clear devices: %v", err)
+		}
+		if err := m.Close(); err != nil {
+			log.Printf("error: failed to close devices: %v", err)
+		}
+	}()
 
-	if err := dm.SetupDevices(); err != nil {
+	if err := setupDevices(m); err != nil {
 		log.Fatalf("error: failed to setup devices: %v", err)
 	}
 
-	dm.Listen()
+	if _, err := m.AddEventHandler(handleEvent); err != nil {
+		log.Fatalf("error: failed to add event handler: %v", err)
+	}
 
-	dm.CreateSynchronizedEffect()
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		ticker := time.NewTicker(2 * time.Second)
+		defer ticker.Stop()
 
-	log.Printf("Multi-device setup complete with %d devices!", len(dm.devices))
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-ticker.C:
+				runWaveEffect(m)
+			}
+		}
+	}()
+
+	log.Printf("Multi-device setup complete with %d devices!", len(m.GetDevices()))
 	log.Println("Try pressing keys to see cross-device synchronization!")
 
-	<-sigChan
+	if err := m.Listen(ctx, nil); err != nil {
+		log.Printf("error: %v", err)
+	}
 	log.Println("Received interrupt signal, shutting down...")
 }
@@ -0,0 +1,174 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"io/fs"
+	"os"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+var defaultFont *opentype.Font
+
+func init() {
+	f, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		panic(err)
+	}
+	defaultFont = f
+}
+
+// LoadFont parses a TrueType or OpenType font, to be used by the text
+// renderer.
+func LoadFont(data []byte) (*opentype.Font, error) {
+	f, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFontInvalid, err)
+	}
+	return f, nil
+}
+
+// LoadFontFromReader reads and parses a TrueType or OpenType font from an
+// io.Reader, to be used by the text renderer.
+func LoadFontFromReader(r io.Reader) (*opentype.Font, error) {
+	if r == nil {
+		return nil, wrapErr(ErrFontInvalid)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return LoadFont(data)
+}
+
+// LoadFontFromFile reads and parses a TrueType or OpenType font from a file,
+// to be used by the text renderer.
+func LoadFontFromFile(name string) (*opentype.Font, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return LoadFont(data)
+}
+
+// LoadFontFromFS reads and parses a TrueType or OpenType font from a
+// filesystem, to be used by the text renderer.
+func LoadFontFromFS(ffs fs.FS, name string) (*opentype.Font, error) {
+	data, err := fs.ReadFile(ffs, name)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return LoadFont(data)
+}
+
+// SetFonts sets the fonts used by the text renderer when TextOptions.Fonts
+// is empty, in order of preference. The bundled default font is always used
+// as the last fallback, for glyphs missing from all the fonts. Calling
+// SetFonts without arguments restores the default font.
+func (d *Device) SetFonts(fonts ...*opentype.Font) {
+	rv := []*opentype.Font{}
+	for _, f := range fonts {
+		if f != nil {
+			rv = append(rv, f)
+		}
+	}
+
+	d.mtx.Lock()
+	d.fonts = rv
+	d.mtx.Unlock()
+}
+
+func (d *Device) textOptions(opts TextOptions) TextOptions {
+	if len(opts.Fonts) == 0 {
+		d.mtx.Lock()
+		opts.Fonts = d.fonts
+		d.mtx.Unlock()
+	}
+	return opts
+}
+
+type fallbackFace struct {
+	faces []font.Face
+}
+
+// NewFallbackFace returns a font.Face that renders each glyph using the first
+// of the provided faces that includes it, allowing mixed-script text (e.g.
+// Latin, CJK and symbols) to be rendered completely. Glyphs missing from all
+// the faces are rendered using the first face.
+//
+// The metrics of the returned face are the maximum metrics of all the
+// provided faces, so that lines are tall enough for any glyph.
+func NewFallbackFace(faces ...font.Face) (font.Face, error) {
+	rv := &fallbackFace{}
+	for _, f := range faces {
+		if f != nil {
+			rv.faces = append(rv.faces, f)
+		}
+	}
+
+	if len(rv.faces) == 0 {
+		return nil, wrapErr(ErrFontInvalid)
+	}
+	return rv, nil
+}
+
+func (f *fallbackFace) face(r rune) font.Face {
+	for _, face := range f.faces {
+		if _, ok := face.GlyphAdvance(r); ok {
+			return face
+		}
+	}
+	return f.faces[0]
+}
+
+func (f *fallbackFace) Close() error {
+	var rv error
+	for _, face := range f.faces {
+		if err := face.Close(); err != nil && rv == nil {
+			rv = err
+		}
+	}
+	return rv
+}
+
+func (f *fallbackFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	return f.face(r).Glyph(dot, r)
+}
+
+func (f *fallbackFace) GlyphBounds(r rune) (fixed.Rectangle26_6, fixed.Int26_6, bool) {
+	return f.face(r).GlyphBounds(r)
+}
+
+func (f *fallbackFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	return f.face(r).GlyphAdvance(r)
+}
+
+func (f *fallbackFace) Kern(r0 rune, r1 rune) fixed.Int26_6 {
+	if face := f.face(r0); face == f.face(r1) {
+		return face.Kern(r0, r1)
+	}
+	return 0
+}
+
+func (f *fallbackFace) Metrics() font.Metrics {
+	rv := f.faces[0].Metrics()
+	for _, face := range f.faces[1:] {
+		m := face.Metrics()
+		rv.Height = max(rv.Height, m.Height)
+		rv.Ascent = max(rv.Ascent, m.Ascent)
+		rv.Descent = max(rv.Descent, m.Descent)
+		rv.CapHeight = max(rv.CapHeight, m.CapHeight)
+		rv.XHeight = max(rv.XHeight, m.XHeight)
+	}
+	return rv
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+type testFace struct {
+	font.Face
+	runes   string
+	advance fixed.Int26_6
+	height  fixed.Int26_6
+}
+
+func (f *testFace) GlyphAdvance(r rune) (fixed.Int26_6, bool) {
+	for _, rr := range f.runes {
+		if rr == r {
+			return f.advance, true
+		}
+	}
+	return 0, false
+}
+
+func (f *testFace) Glyph(dot fixed.Point26_6, r rune) (image.Rectangle, image.Image, image.Point, fixed.Int26_6, bool) {
+	a, ok := f.GlyphAdvance(r)
+	return image.Rectangle{}, nil, image.Point{}, a, ok
+}
+
+func (f *testFace) Metrics() font.Metrics {
+	return font.Metrics{Height: f.height}
+}
+
+func TestFallbackFace(t *testing.T) {
+	latin := &testFace{Face: basicfont.Face7x13, runes: "abc", advance: fixed.I(1), height: fixed.I(10)}
+	cjk := &testFace{Face: basicfont.Face7x13, runes: "漢字", advance: fixed.I(2), height: fixed.I(12)}
+	symbols := &testFace{Face: basicfont.Face7x13, runes: "★a", advance: fixed.I(3), height: fixed.I(8)}
+
+	f, err := NewFallbackFace(latin, nil, cjk, symbols)
+	if err != nil {
+		t.Fatalf("NewFallbackFace failed: %v", err)
+	}
+
+	tests := []struct {
+		r       rune
+		advance fixed.Int26_6
+		ok      bool
+	}{
+		{'a', fixed.I(1), true},
+		{'漢', fixed.I(2), true},
+		{'★', fixed.I(3), true},
+		{'?', 0, false},
+	}
+
+	for _, tt := range tests {
+		_, _, _, a, ok := f.Glyph(fixed.Point26_6{}, tt.r)
+		if a != tt.advance || ok != tt.ok {
+			t.Errorf("Glyph(%q) = %v, %t, want %v, %t", tt.r, a, ok, tt.advance, tt.ok)
+		}
+	}
+
+	if h := f.Metrics().Height; h != fixed.I(12) {
+		t.Errorf("expected height %v, got %v", fixed.I(12), h)
+	}
+}
+
+func TestFallbackFace_NoFaces(t *testing.T) {
+	if _, err := NewFallbackFace(nil); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestLoadFont(t *testing.T) {
+	if _, err := LoadFont([]byte("bola")); !errors.Is(err, ErrFontInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	f, err := LoadFontFromFS(fstest.MapFS{
+		"mono.ttf": &fstest.MapFile{Data: gomono.TTF},
+	}, "mono.ttf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := TextOptions{Fonts: []*opentype.Font{f}}
+	face, err := opts.newFace(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer face.Close()
+
+	// monospace font is used, with the default font as fallback
+	if _, ok := face.(*fallbackFace); !ok {
+		t.Fatalf("default font not used as fallback: %T", face)
+	}
+	i, _ := face.GlyphAdvance('i')
+	m, _ := face.GlyphAdvance('m')
+	if i != m {
+		t.Errorf("custom font not used: %s != %s", i, m)
+	}
+}
+
+func TestSetFonts(t *testing.T) {
+	f, err := LoadFont(gomono.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Device{}
+	if opts := d.textOptions(TextOptions{}); len(opts.Fonts) != 0 {
+		t.Errorf("unexpected fonts: %v", opts.Fonts)
+	}
+
+	d.SetFonts(f, nil)
+	if opts := d.textOptions(TextOptions{}); len(opts.Fonts) != 1 || opts.Fonts[0] != f {
+		t.Errorf("device fonts not used: %v", opts.Fonts)
+	}
+	if opts := d.textOptions(TextOptions{Fonts: []*opentype.Font{defaultFont}}); len(opts.Fonts) != 1 || opts.Fonts[0] != defaultFont {
+		t.Errorf("explicit fonts not used: %v", opts.Fonts)
+	}
+}
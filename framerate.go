@@ -0,0 +1,228 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// frameGovernor limits the rate of the writes to each display target. Writes
+// arriving too early are deferred to the end of the interval, and replaced by
+// any later write to the same target, so that only the latest frame is sent.
+// Areas of the touch strip are separate targets, but a deferred write to an
+// area is written before any later write to an overlapping area, so that
+// frames are never written out of order.
+type frameGovernor struct {
+	mtx      sync.Mutex
+	interval time.Duration
+	slots    map[animationTarget]*frameSlot
+	dropped  uint64
+	stopped  bool
+}
+
+type frameSlot struct {
+	last    time.Time
+	pending func() error
+	timer   *time.Timer
+	err     error
+}
+
+// frameWrite is a deferred write removed from its slot, to be written right
+// away.
+type frameWrite struct {
+	slot *frameSlot
+	fn   func() error
+}
+
+// write writes a frame to a target right away, returning the error of the
+// write, or defers it. The deferred writes that fail are reported to the
+// device logger, and their errors are returned by the next write to the same
+// target.
+func (g *frameGovernor) write(d *Device, target animationTarget, fn func() error) error {
+	deferred, flush, err := g.throttle(d, target, fn)
+	for _, w := range flush {
+		g.run(d, w.slot, w.fn)
+	}
+	if deferred {
+		return err
+	}
+	if werr := fn(); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// run calls a deferred write, already removed from its slot.
+func (g *frameGovernor) run(d *Device, slot *frameSlot, fn func() error) {
+	if fn == nil {
+		return
+	}
+	if err := fn(); err != nil {
+		d.logError("streamdeck: deferred frame write failed", err)
+
+		g.mtx.Lock()
+		slot.err = err
+		g.mtx.Unlock()
+	}
+}
+
+// throttle returns false if the write must be done right away, or defers it
+// and returns true, with the deferred writes to overlapping touch strip areas
+// that must be written first, and the error of the previous deferred write to the
+// target, if it failed.
+func (g *frameGovernor) throttle(d *Device, target animationTarget, fn func() error) (bool, []frameWrite, error) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.interval <= 0 || g.stopped {
+		return false, nil, nil
+	}
+
+	if g.slots == nil {
+		g.slots = map[animationTarget]*frameSlot{}
+	}
+	slot, found := g.slots[target]
+	if !found {
+		slot = &frameSlot{}
+		g.slots[target] = slot
+	}
+	err := slot.err
+	slot.err = nil
+
+	flush := []frameWrite{}
+	if target.surface == displaySurfaceTouchStrip {
+		for t, s := range g.slots {
+			if s == slot || t.surface != displaySurfaceTouchStrip || s.timer == nil || !t.rect.Overlaps(target.rect) {
+				continue
+			}
+			s.timer.Stop()
+			s.timer = nil
+			s.last = time.Now()
+			if s.pending != nil {
+				flush = append(flush, frameWrite{slot: s, fn: s.pending})
+				s.pending = nil
+			}
+		}
+	}
+
+	now := time.Now()
+	wait := slot.last.Add(g.interval).Sub(now)
+	if slot.timer == nil && wait <= 0 {
+		slot.last = now
+		return false, flush, err
+	}
+
+	if slot.pending != nil {
+		g.dropped++
+	}
+	slot.pending = fn
+
+	if slot.timer == nil {
+		slot.timer = time.AfterFunc(wait, func() {
+			g.mtx.Lock()
+			if slot.timer == nil {
+				g.mtx.Unlock()
+				return
+			}
+			fn := slot.pending
+			slot.pending = nil
+			slot.timer = nil
+			slot.last = time.Now()
+			g.mtx.Unlock()
+
+			g.run(d, slot, fn)
+		})
+	}
+	return true, flush, err
+}
+
+func (g *frameGovernor) cancel() {
+	for _, slot := range g.slots {
+		if slot.timer != nil {
+			slot.timer.Stop()
+			slot.timer = nil
+			if slot.pending != nil {
+				slot.pending = nil
+				g.dropped++
+			}
+		}
+	}
+	g.slots = nil
+}
+
+// reset cancels the deferred writes, and sets the interval between writes.
+func (g *frameGovernor) reset(interval time.Duration) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.cancel()
+	g.interval = interval
+}
+
+// stop cancels the deferred writes, and disables the limit until start is
+// called, so that the displays can be cleared right away when closing the
+// device.
+func (g *frameGovernor) stop() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.cancel()
+	g.stopped = true
+}
+
+func (g *frameGovernor) start() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.stopped = false
+}
+
+// SetFrameRateLimit sets the maximum number of frames per second written to
+// each display target of the Elgato Stream Deck device: each key, the info
+// bar and each area of the touch strip. Frames written too early are deferred
+// to the end of the interval, and dropped if replaced by a later frame for
+// the same target, so that runaway render loops degrade to the limit showing
+// the latest frames, instead of queueing stale frames. Deferred frames that
+// fail to be written are reported to the ErrorSink or to the logger of the
+// device, and their errors are also returned by the next write to the same
+// target. Deferred frames are discarded when the device is closed.
+//
+// If set to zero, the default, frames are not limited.
+func (d *Device) SetFrameRateLimit(fps int) error {
+	if fps < 0 {
+		return fmt.Errorf("streamdeck: %w: %d", ErrFrameRateInvalid, fps)
+	}
+
+	interval := time.Duration(0)
+	if fps > 0 {
+		interval = time.Second / time.Duration(fps)
+	}
+	d.frames.reset(interval)
+	return nil
+}
+
+// GetFrameRateLimit returns the maximum number of frames per second written
+// to each display target of the Elgato Stream Deck device, or zero if not
+// limited.
+func (d *Device) GetFrameRateLimit() int {
+	d.frames.mtx.Lock()
+	defer d.frames.mtx.Unlock()
+
+	if d.frames.interval <= 0 {
+		return 0
+	}
+	return int(time.Second / d.frames.interval)
+}
+
+// GetDroppedFrames returns the number of frames dropped by the frame rate
+// limit of the Elgato Stream Deck device, because they were replaced by
+// later frames before being written.
+func (d *Device) GetDroppedFrames() uint64 {
+	d.frames.mtx.Lock()
+	defer d.frames.mtx.Unlock()
+	return d.frames.dropped
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestFrameRateLimitTouchStripOrder(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetFrameRateLimit(10); err != nil {
+		t.Fatal(err)
+	}
+	m.ClearWrites()
+
+	left := image.Rect(0, 0, 400, 100)
+	middle := image.Rect(200, 0, 600, 100)
+	for _, w := range []struct {
+		c    color.RGBA
+		rect image.Rectangle
+	}{
+		{color.RGBA{R: 0xff, A: 0xff}, left},
+		{color.RGBA{G: 0xff, A: 0xff}, left},
+		{color.RGBA{B: 0xff, A: 0xff}, middle},
+	} {
+		img := image.NewRGBA(image.Rect(0, 0, w.rect.Dx(), w.rect.Dy()))
+		draw.Draw(img, img.Bounds(), image.NewUniform(w.c), image.Point{}, draw.Src)
+		if err := dev.SetTouchStripImageWithRectangle(img, w.rect); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the deferred write to the left area is written before the overlapping
+	// write, instead of overwriting it later
+	w := m.Writes()
+	if len(w) != 3 || w[0].Rect != left || w[1].Rect != left || w[2].Rect != middle {
+		t.Fatalf("bad writes: %+v", w)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if w := m.Writes(); len(w) != 3 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	assertColor(t, m.TouchStripImage(), 100, 50, color.RGBA{G: 0xff, A: 0xff})
+	assertColor(t, m.TouchStripImage(), 300, 50, color.RGBA{B: 0xff, A: 0xff})
+	assertColor(t, m.TouchStripImage(), 700, 50, color.RGBA{A: 0xff})
+}
+
+func TestFrameRateLimitError(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	reported := make(chan error, 10)
+	dev.SetErrorSink(streamdeck.ErrorSinkFunc(func(e streamdeck.ErrorEvent) {
+		reported <- e.Err
+	}))
+
+	if err := dev.SetFrameRateLimit(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{G: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the deferred write fails while the fake device is closed
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-reported:
+		if !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("deferred write error not reported")
+	}
+	if err := m.Open(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{B: 0xff, A: 0xff}); !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_2, color.RGBA{B: 0xff, A: 0xff}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{B: 0xff, A: 0xff})
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFrameRateLimit(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetFrameRateLimit(-1); !errors.Is(err, streamdeck.ErrFrameRateInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetFrameRateLimit(10); err != nil {
+		t.Fatal(err)
+	}
+	if fps := dev.GetFrameRateLimit(); fps != 10 {
+		t.Errorf("unexpected frame rate limit: %d", fps)
+	}
+	m.ClearWrites()
+
+	// the first frame is written right away, the following ones are deferred
+	// and replaced, and the other key is not limited by the first one
+	colors := []color.RGBA{
+		{R: 0xff, A: 0xff},
+		{G: 0xff, A: 0xff},
+		{B: 0xff, A: 0xff},
+		{R: 0xff, G: 0xff, A: 0xff},
+		{R: 0xff, B: 0xff, A: 0xff},
+	}
+	for _, c := range colors {
+		if err := dev.SetKeyColor(streamdeck.KEY_1, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_2, colors[0]); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 || w[0].Key != streamdeck.KEY_1 || w[1].Key != streamdeck.KEY_2 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	w := m.Writes()
+	if len(w) != 3 || w[2].Key != streamdeck.KEY_1 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, colors[len(colors)-1])
+	if n := dev.GetDroppedFrames(); n != 3 {
+		t.Errorf("unexpected dropped frames: %d", n)
+	}
+
+	if err := dev.SetFrameRateLimit(0); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range colors {
+		if err := dev.SetKeyColor(streamdeck.KEY_1, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := m.Writes(); len(w) != 3+len(colors) {
+		t.Errorf("bad writes: %d", len(w))
+	}
+}
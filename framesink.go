@@ -0,0 +1,244 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+// FrameSink streams video frames to the touch strip display available on
+// some Elgato Stream Deck models, at a target frame rate.
+//
+// Writing frames never blocks: frames are sent to the device by a background
+// goroutine, and if a new frame is written while the previous one is still
+// pending, the previous frame is dropped. This allows producers running
+// faster than the display to degrade gracefully instead of accumulating
+// latency.
+type FrameSink struct {
+	device   *Device
+	rect     *image.Rectangle
+	interval time.Duration
+	mtx      sync.Mutex
+	frame    image.Image
+	err      error
+	dropped  uint64
+	notify   chan struct{}
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// NewTouchStripFrameSink creates a FrameSink that streams frames to the whole
+// touch strip display available on some Elgato Stream Deck models, at most at
+// the provided frame rate.
+func (d *Device) NewTouchStripFrameSink(fps int) (*FrameSink, error) {
+	if err := d.validateOpen(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	return d.newFrameSink(fps, nil)
+}
+
+// NewTouchStripFrameSinkWithRectangle creates a FrameSink that streams frames
+// to the provided rectangle of the touch strip display available on some
+// Elgato Stream Deck models, at most at the provided frame rate.
+func (d *Device) NewTouchStripFrameSinkWithRectangle(fps int, rect image.Rectangle) (*FrameSink, error) {
+	if err := d.validateOpen(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return nil, err
+	}
+
+	return d.newFrameSink(fps, &rect)
+}
+
+func (d *Device) newFrameSink(fps int, rect *image.Rectangle) (*FrameSink, error) {
+	if fps <= 0 {
+		return nil, fmt.Errorf("streamdeck: %w: %d", ErrFrameRateInvalid, fps)
+	}
+
+	rv := &FrameSink{
+		device:   d,
+		rect:     rect,
+		interval: time.Second / time.Duration(fps),
+		notify:   make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go rv.run(d.done)
+	return rv, nil
+}
+
+func (s *FrameSink) run(done chan struct{}) {
+	defer close(s.stopped)
+
+	last := time.Time{}
+	for {
+		select {
+		case <-done:
+			s.setErr(wrapErr(ErrDeviceIsClosed))
+			return
+		case <-s.stop:
+			return
+		case <-s.notify:
+		}
+
+		if w := s.interval - time.Since(last); w > 0 {
+			select {
+			case <-done:
+				s.setErr(wrapErr(ErrDeviceIsClosed))
+				return
+			case <-s.stop:
+				return
+			case <-time.After(w):
+			}
+		}
+
+		s.mtx.Lock()
+		frame := s.frame
+		s.frame = nil
+		s.mtx.Unlock()
+		if frame == nil {
+			continue
+		}
+
+		last = time.Now()
+		if err := s.device.setTouchStripImage(frame, s.rect); err != nil {
+			s.setErr(err)
+			return
+		}
+	}
+}
+
+func (s *FrameSink) setErr(err error) {
+	s.mtx.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mtx.Unlock()
+}
+
+// WriteFrame queues an image.Image to be displayed as the next frame. The
+// image must not be modified after being written. If the background sender
+// failed, its error is returned.
+func (s *FrameSink) WriteFrame(img image.Image) error {
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	s.mtx.Lock()
+	if err := s.err; err != nil {
+		s.mtx.Unlock()
+		return err
+	}
+	if s.frame != nil {
+		s.dropped++
+	}
+	s.frame = img
+	s.mtx.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// WriteRGB queues a raw frame of packed 24-bit RGB pixels, with the provided
+// dimensions, to be displayed as the next frame. The data is copied, and may
+// be reused by the caller.
+func (s *FrameSink) WriteRGB(data []byte, width int, height int) error {
+	img, err := rgbToImage(data, width, height)
+	if err != nil {
+		return wrapErr(err)
+	}
+	return s.WriteFrame(img)
+}
+
+// WriteYUV420 queues a raw frame of planar YUV 4:2:0 (I420) pixels, with the
+// provided dimensions, to be displayed as the next frame. The data is copied,
+// and may be reused by the caller.
+func (s *FrameSink) WriteYUV420(data []byte, width int, height int) error {
+	img, err := yuv420ToImage(data, width, height)
+	if err != nil {
+		return wrapErr(err)
+	}
+	return s.WriteFrame(img)
+}
+
+// Dropped returns the number of frames dropped because they were replaced by
+// newer frames before being sent to the device.
+func (s *FrameSink) Dropped() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.dropped
+}
+
+// Close stops the FrameSink. Pending frames are discarded. If the background
+// sender failed, its error is returned.
+func (s *FrameSink) Close() error {
+	s.mtx.Lock()
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	s.mtx.Unlock()
+
+	<-s.stopped
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if errors.Is(s.err, ErrDeviceIsClosed) {
+		return nil
+	}
+	return s.err
+}
+
+func rgbToImage(data []byte, width int, height int) (*image.RGBA, error) {
+	if width <= 0 || height <= 0 || len(data) != width*height*3 {
+		return nil, fmt.Errorf("%w: invalid RGB frame size", ErrImageInvalid)
+	}
+
+	rv := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i, j := 0, 0; i < len(data); i, j = i+3, j+4 {
+		rv.Pix[j] = data[i]
+		rv.Pix[j+1] = data[i+1]
+		rv.Pix[j+2] = data[i+2]
+		rv.Pix[j+3] = 0xff
+	}
+	return rv, nil
+}
+
+func yuv420ToImage(data []byte, width int, height int) (*image.YCbCr, error) {
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("%w: invalid YUV frame size", ErrImageInvalid)
+	}
+
+	rv := image.NewYCbCr(image.Rect(0, 0, width, height), image.YCbCrSubsampleRatio420)
+	ySize := len(rv.Y)
+	cSize := len(rv.Cb)
+	if len(data) != ySize+2*cSize {
+		return nil, fmt.Errorf("%w: invalid YUV frame size", ErrImageInvalid)
+	}
+
+	copy(rv.Y, data[:ySize])
+	copy(rv.Cb, data[ySize:ySize+cSize])
+	copy(rv.Cr, data[ySize+cSize:])
+	return rv, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+)
+
+func TestRGBToImage(t *testing.T) {
+	data := []byte{
+		255, 0, 0, 0, 255, 0,
+		0, 0, 255, 255, 255, 255,
+	}
+
+	img, err := rgbToImage(data, 2, 2)
+	if err != nil {
+		t.Fatalf("rgbToImage failed: %v", err)
+	}
+
+	if img.At(0, 0) != (color.RGBA{255, 0, 0, 255}) {
+		t.Error("top-left pixel doesn't match")
+	}
+	if img.At(1, 0) != (color.RGBA{0, 255, 0, 255}) {
+		t.Error("top-right pixel doesn't match")
+	}
+	if img.At(0, 1) != (color.RGBA{0, 0, 255, 255}) {
+		t.Error("bottom-left pixel doesn't match")
+	}
+	if img.At(1, 1) != (color.RGBA{255, 255, 255, 255}) {
+		t.Error("bottom-right pixel doesn't match")
+	}
+}
+
+func TestRGBToImage_InvalidSize(t *testing.T) {
+	if _, err := rgbToImage(make([]byte, 11), 2, 2); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("expected ErrImageInvalid, got %v", err)
+	}
+}
+
+func TestYUV420ToImage(t *testing.T) {
+	// 3x3 frame: 9 luma samples, 4 samples per chroma plane
+	data := make([]byte, 9+4+4)
+	for i := range 9 {
+		data[i] = 255
+	}
+	for i := 9; i < len(data); i++ {
+		data[i] = 128
+	}
+
+	img, err := yuv420ToImage(data, 3, 3)
+	if err != nil {
+		t.Fatalf("yuv420ToImage failed: %v", err)
+	}
+
+	r, g, b, _ := img.At(2, 2).RGBA()
+	if r>>8 != 255 || g>>8 != 255 || b>>8 != 255 {
+		t.Errorf("expected white pixel, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+
+	if _, err := yuv420ToImage(data[:16], 3, 3); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("expected ErrImageInvalid, got %v", err)
+	}
+}
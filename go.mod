@@ -1,10 +1,11 @@
 module rafaelmartins.com/p/streamdeck
 
-go 1.24
+go 1.24.0
 
 require (
+	github.com/ebitengine/purego v0.8.4
 	golang.org/x/image v0.30.0
 	rafaelmartins.com/p/usbhid v0.0.0-20250616003425-c818f1cb579e
 )
 
-require github.com/ebitengine/purego v0.8.4 // indirect
+require golang.org/x/text v0.34.0 // indirect
@@ -0,0 +1,578 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package grpcserver exposes an Elgato Stream Deck device through a gRPC
+// service, to control a device attached to a headless machine from services
+// elsewhere on the network.
+//
+// The service is defined in streamdeck.proto, and the generated code lives in
+// the streamdeckpb package. The Server implements the service, and must be
+// registered to a grpc.Server:
+//
+//	srv, err := grpcserver.New(dev)
+//	if err != nil {
+//		return err
+//	}
+//	defer srv.Close()
+//
+//	gs := grpc.NewServer()
+//	streamdeckpb.RegisterStreamDeckServer(gs, srv)
+//	return gs.Serve(listener)
+//
+// The Server does not listen to the device input. Events are only streamed
+// while the application calls Device.Listen.
+//
+// The service covers the device information, the brightness, resetting the
+// device, the images, colors and texts of the displays, SVG images included,
+// and the input events. The rest of the Device API is not exposed:
+//
+//   - brightness fades, sleep and wake, and idle timeouts;
+//   - image transitions, animations, scrolling text, icons and overlays;
+//   - prepared and raw images, and full-deck images and layouts;
+//   - saving and loading the display state, and pages;
+//   - image, text and frame rate options;
+//   - input options, like debouncing, key repeat and dial rotation
+//     acceleration;
+//   - input recording and replay.
+//
+// Applications needing them should wrap the Server with their own service.
+// This package lives in its own module, to keep the gRPC dependencies out of
+// the streamdeck module.
+package grpcserver
+
+//go:generate protoc --go_out=. --go_opt=module=rafaelmartins.com/p/streamdeck/grpcserver --go-grpc_out=. --go-grpc_opt=module=rafaelmartins.com/p/streamdeck/grpcserver streamdeck.proto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/grpcserver/streamdeckpb"
+	_ "rafaelmartins.com/p/streamdeck/svg"
+)
+
+// Errors returned by the grpcserver package.
+var (
+	ErrColorInvalid   = errors.New("grpcserver: color is not valid")
+	ErrRequestInvalid = errors.New("grpcserver: request is not valid")
+)
+
+// subscriberBuffer is the number of events buffered for each event stream.
+// Events are dropped for streams that do not keep up.
+const subscriberBuffer = 16
+
+// Server implements the StreamDeck gRPC service for an Elgato Stream Deck
+// device.
+type Server struct {
+	streamdeckpb.UnimplementedStreamDeckServer
+
+	device *streamdeck.Device
+	regs   []*streamdeck.HandlerRegistration
+	done   chan struct{}
+	once   sync.Once
+
+	mtx         sync.Mutex
+	subscribers map[chan *streamdeckpb.Event]struct{}
+}
+
+// New creates a Server bound to an open Elgato Stream Deck device, and
+// registers the input handlers used to stream events.
+func New(dev *streamdeck.Device) (*Server, error) {
+	if dev == nil || !dev.IsOpen() {
+		return nil, fmt.Errorf("%w: %w", ErrRequestInvalid, streamdeck.ErrDeviceIsClosed)
+	}
+
+	rv := &Server{
+		device:      dev,
+		done:        make(chan struct{}),
+		subscribers: map[chan *streamdeckpb.Event]struct{}{},
+	}
+
+	if err := rv.register(); err != nil {
+		rv.Close()
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (s *Server) register() error {
+	dev := s.device
+	add := func(reg *streamdeck.HandlerRegistration, err error) error {
+		if err != nil {
+			return err
+		}
+		s.regs = append(s.regs, reg)
+		return nil
+	}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if err := add(dev.AddKeyPressHandler(k, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_KeyPress{KeyPress: &streamdeckpb.KeyEvent{
+				Key: uint32(key.GetID()),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, key *streamdeck.Key, duration time.Duration) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_KeyRelease{KeyRelease: &streamdeckpb.KeyEvent{
+				Key:        uint32(key.GetID()),
+				DurationMs: duration.Milliseconds(),
+			}}})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		if err := add(dev.AddTouchPointPressHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_TouchPointPress{TouchPointPress: &streamdeckpb.TouchPointEvent{
+				TouchPoint: uint32(t.GetID()),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddTouchPointReleaseHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint, duration time.Duration) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_TouchPointRelease{TouchPointRelease: &streamdeckpb.TouchPointEvent{
+				TouchPoint: uint32(t.GetID()),
+				DurationMs: duration.Milliseconds(),
+			}}})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachDial(func(di streamdeck.DialID) error {
+		if err := add(dev.AddDialPressHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_DialPress{DialPress: &streamdeckpb.DialEvent{
+				Dial: uint32(dial.GetID()),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddDialReleaseHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, duration time.Duration) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_DialRelease{DialRelease: &streamdeckpb.DialEvent{
+				Dial:       uint32(dial.GetID()),
+				DurationMs: duration.Milliseconds(),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddDialRotateHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, delta int8) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_DialRotate{DialRotate: &streamdeckpb.DialEvent{
+				Dial:  uint32(dial.GetID()),
+				Delta: int32(delta),
+			}}})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if dev.GetTouchStripSupported() {
+		if err := add(dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_TouchStripTouch{TouchStripTouch: &streamdeckpb.TouchStripTouchEvent{
+				Long:  t == streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG,
+				Point: toPoint(p),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
+			s.publish(&streamdeckpb.Event{Event: &streamdeckpb.Event_TouchStripSwipe{TouchStripSwipe: &streamdeckpb.TouchStripSwipeEvent{
+				Origin:      toPoint(origin),
+				Destination: toPoint(destination),
+			}}})
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) publish(e *streamdeckpb.Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribe() chan *streamdeckpb.Event {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	rv := make(chan *streamdeckpb.Event, subscriberBuffer)
+	s.subscribers[rv] = struct{}{}
+	return rv
+}
+
+func (s *Server) unsubscribe(ch chan *streamdeckpb.Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.subscribers, ch)
+}
+
+// Close unregisters the input handlers of the Server and ends the event
+// streams of the connected clients. The device is not closed.
+func (s *Server) Close() error {
+	s.once.Do(func() {
+		for _, reg := range s.regs {
+			reg.Remove()
+		}
+		close(s.done)
+	})
+	return nil
+}
+
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	code := codes.Internal
+	switch {
+	case errors.Is(err, ErrRequestInvalid),
+		errors.Is(err, ErrColorInvalid),
+		errors.Is(err, streamdeck.ErrKeyInvalid),
+		errors.Is(err, streamdeck.ErrTouchPointInvalid),
+		errors.Is(err, streamdeck.ErrImageInvalid),
+		errors.Is(err, image.ErrFormat):
+		code = codes.InvalidArgument
+
+	case errors.Is(err, streamdeck.ErrDeviceKeyDisplayNotSupported),
+		errors.Is(err, streamdeck.ErrDeviceBrightnessNotSupported),
+		errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported),
+		errors.Is(err, streamdeck.ErrDeviceTouchStripNotSupported):
+		code = codes.Unimplemented
+
+	case errors.Is(err, streamdeck.ErrDeviceIsClosed):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
+
+func toPoint(p image.Point) *streamdeckpb.Point {
+	return &streamdeckpb.Point{
+		X: int32(p.X),
+		Y: int32(p.Y),
+	}
+}
+
+func toRectangle(r image.Rectangle, err error) *streamdeckpb.Rectangle {
+	if err != nil {
+		return nil
+	}
+	return &streamdeckpb.Rectangle{
+		MinX: int32(r.Min.X),
+		MinY: int32(r.Min.Y),
+		MaxX: int32(r.Max.X),
+		MaxY: int32(r.Max.Y),
+	}
+}
+
+func fromRectangle(r *streamdeckpb.Rectangle) image.Rectangle {
+	return image.Rect(int(r.GetMinX()), int(r.GetMinY()), int(r.GetMaxX()), int(r.GetMaxY()))
+}
+
+func fromColor(c *streamdeckpb.Color, def color.Color) (color.Color, error) {
+	if c == nil {
+		return def, nil
+	}
+	if c.GetR() > 0xff || c.GetG() > 0xff || c.GetB() > 0xff {
+		return nil, fmt.Errorf("%w: (%d, %d, %d)", ErrColorInvalid, c.GetR(), c.GetG(), c.GetB())
+	}
+	return color.RGBA{R: byte(c.GetR()), G: byte(c.GetG()), B: byte(c.GetB()), A: 0xff}, nil
+}
+
+func fromTextOptions(o *streamdeckpb.TextOptions) (streamdeck.TextOptions, error) {
+	fg, err := fromColor(o.GetForeground(), color.White)
+	if err != nil {
+		return streamdeck.TextOptions{}, err
+	}
+	bg, err := fromColor(o.GetBackground(), color.Black)
+	if err != nil {
+		return streamdeck.TextOptions{}, err
+	}
+	return streamdeck.TextOptions{
+		Foreground: fg,
+		Background: bg,
+		Size:       o.GetSize(),
+	}, nil
+}
+
+// isSVG detects SVG documents, that are not supported by image.Decode.
+func isSVG(data []byte) bool {
+	return bytes.Contains(data[:min(len(data), 1024)], []byte("<svg"))
+}
+
+func (s *Server) setBrightness(req *streamdeckpb.SetBrightnessRequest) error {
+	if req.GetBrightness() > 100 {
+		return fmt.Errorf("%w: brightness out of range: %d", ErrRequestInvalid, req.GetBrightness())
+	}
+	return s.device.SetBrightness(byte(req.GetBrightness()))
+}
+
+func (s *Server) setKeyImage(req *streamdeckpb.SetKeyImageRequest) error {
+	key := streamdeck.KeyID(req.GetKey())
+	if isSVG(req.GetImage()) {
+		return s.device.SetKeyImageFromSVG(key, bytes.NewReader(req.GetImage()))
+	}
+	return s.device.SetKeyImageFromReader(key, bytes.NewReader(req.GetImage()))
+}
+
+func (s *Server) setKeyColor(req *streamdeckpb.SetKeyColorRequest) error {
+	c, err := fromColor(req.GetColor(), color.Black)
+	if err != nil {
+		return err
+	}
+	return s.device.SetKeyColor(streamdeck.KeyID(req.GetKey()), c)
+}
+
+func (s *Server) setKeyText(req *streamdeckpb.SetKeyTextRequest) error {
+	opts, err := fromTextOptions(req.GetOptions())
+	if err != nil {
+		return err
+	}
+	return s.device.SetKeyTextWithOptions(streamdeck.KeyID(req.GetKey()), req.GetText(), opts)
+}
+
+func (s *Server) setInfoBarImage(req *streamdeckpb.SetInfoBarImageRequest) error {
+	if isSVG(req.GetImage()) {
+		return s.device.SetInfoBarImageFromSVG(bytes.NewReader(req.GetImage()))
+	}
+	return s.device.SetInfoBarImageFromReader(bytes.NewReader(req.GetImage()))
+}
+
+func (s *Server) setTouchPointColor(req *streamdeckpb.SetTouchPointColorRequest) error {
+	c, err := fromColor(req.GetColor(), color.Black)
+	if err != nil {
+		return err
+	}
+	return s.device.SetTouchPointColor(streamdeck.TouchPointID(req.GetTouchPoint()), c)
+}
+
+func (s *Server) setTouchStripImage(req *streamdeckpb.SetTouchStripImageRequest) error {
+	rect, err := s.device.GetTouchStripImageRectangle()
+	if err != nil {
+		return err
+	}
+	if req.GetRectangle() != nil {
+		rect = fromRectangle(req.GetRectangle())
+	}
+
+	if isSVG(req.GetImage()) {
+		return s.device.SetTouchStripImageFromSVGWithRectangle(bytes.NewReader(req.GetImage()), rect)
+	}
+	return s.device.SetTouchStripImageFromReaderWithRectangle(bytes.NewReader(req.GetImage()), rect)
+}
+
+// GetDevice returns the device information.
+func (s *Server) GetDevice(ctx context.Context, req *streamdeckpb.GetDeviceRequest) (*streamdeckpb.Device, error) {
+	dev := s.device
+	rows, cols := dev.GetKeyLayout()
+	rv := &streamdeckpb.Device{
+		ModelName:                dev.GetModelName(),
+		ModelId:                  dev.GetModelID(),
+		SerialNumber:             dev.GetSerialNumber(),
+		KeyCount:                 uint32(dev.GetKeyCount()),
+		KeyColumns:               uint32(cols),
+		KeyRows:                  uint32(rows),
+		TouchPointCount:          uint32(dev.GetTouchPointCount()),
+		DialCount:                uint32(dev.GetDialCount()),
+		KeyDisplay:               dev.GetKeyDisplaySupported(),
+		InfoBar:                  dev.GetInfoBarSupported(),
+		TouchStrip:               dev.GetTouchStripSupported(),
+		KeyImageRectangle:        toRectangle(dev.GetKeyImageRectangle()),
+		InfoBarImageRectangle:    toRectangle(dev.GetInfoBarImageRectangle()),
+		TouchStripImageRectangle: toRectangle(dev.GetTouchStripImageRectangle()),
+	}
+	if v, err := dev.GetFirmwareVersion(); err == nil {
+		rv.FirmwareVersion = v
+	}
+	return rv, nil
+}
+
+// SetBrightness sets the brightness of the device displays.
+func (s *Server) SetBrightness(ctx context.Context, req *streamdeckpb.SetBrightnessRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setBrightness(req))
+}
+
+// GetBrightness returns the brightness of the device displays.
+func (s *Server) GetBrightness(ctx context.Context, req *streamdeckpb.GetBrightnessRequest) (*streamdeckpb.Brightness, error) {
+	b, err := s.device.GetBrightness()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &streamdeckpb.Brightness{Brightness: uint32(b)}, nil
+}
+
+// Reset resets the device, clearing all the displays.
+func (s *Server) Reset(ctx context.Context, req *streamdeckpb.ResetRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.device.Reset())
+}
+
+// SetKeyImage draws an encoded image to a key.
+func (s *Server) SetKeyImage(ctx context.Context, req *streamdeckpb.SetKeyImageRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setKeyImage(req))
+}
+
+// SetKeyColor fills a key with a color.
+func (s *Server) SetKeyColor(ctx context.Context, req *streamdeckpb.SetKeyColorRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setKeyColor(req))
+}
+
+// SetKeyText draws a text to a key.
+func (s *Server) SetKeyText(ctx context.Context, req *streamdeckpb.SetKeyTextRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setKeyText(req))
+}
+
+// ClearKey clears a key.
+func (s *Server) ClearKey(ctx context.Context, req *streamdeckpb.ClearKeyRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.device.ClearKey(streamdeck.KeyID(req.GetKey())))
+}
+
+// SetInfoBarImage draws an encoded image to the info bar.
+func (s *Server) SetInfoBarImage(ctx context.Context, req *streamdeckpb.SetInfoBarImageRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setInfoBarImage(req))
+}
+
+// SetInfoBarText draws a text to the info bar.
+func (s *Server) SetInfoBarText(ctx context.Context, req *streamdeckpb.SetInfoBarTextRequest) (*streamdeckpb.Empty, error) {
+	opts, err := fromTextOptions(req.GetOptions())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &streamdeckpb.Empty{}, toStatus(s.device.SetInfoBarTextWithOptions(req.GetText(), opts))
+}
+
+// ClearInfoBar clears the info bar.
+func (s *Server) ClearInfoBar(ctx context.Context, req *streamdeckpb.ClearInfoBarRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.device.ClearInfoBar())
+}
+
+// SetTouchPointColor sets the color of a touch point.
+func (s *Server) SetTouchPointColor(ctx context.Context, req *streamdeckpb.SetTouchPointColorRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setTouchPointColor(req))
+}
+
+// ClearTouchPoint clears a touch point.
+func (s *Server) ClearTouchPoint(ctx context.Context, req *streamdeckpb.ClearTouchPointRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.device.ClearTouchPoint(streamdeck.TouchPointID(req.GetTouchPoint())))
+}
+
+// SetTouchStripImage draws an encoded image to the touch strip, or to an
+// area of it.
+func (s *Server) SetTouchStripImage(ctx context.Context, req *streamdeckpb.SetTouchStripImageRequest) (*streamdeckpb.Empty, error) {
+	return &streamdeckpb.Empty{}, toStatus(s.setTouchStripImage(req))
+}
+
+// ClearTouchStrip clears the touch strip, or an area of it.
+func (s *Server) ClearTouchStrip(ctx context.Context, req *streamdeckpb.ClearTouchStripRequest) (*streamdeckpb.Empty, error) {
+	if req.GetRectangle() == nil {
+		return &streamdeckpb.Empty{}, toStatus(s.device.ClearTouchStrip())
+	}
+	return &streamdeckpb.Empty{}, toStatus(s.device.ClearTouchStripWithRectangle(fromRectangle(req.GetRectangle())))
+}
+
+func (s *Server) command(cmd *streamdeckpb.Command) error {
+	switch c := cmd.GetCommand().(type) {
+	case *streamdeckpb.Command_SetBrightness:
+		return s.setBrightness(c.SetBrightness)
+	case *streamdeckpb.Command_SetKeyImage:
+		return s.setKeyImage(c.SetKeyImage)
+	case *streamdeckpb.Command_SetKeyColor:
+		return s.setKeyColor(c.SetKeyColor)
+	case *streamdeckpb.Command_SetKeyText:
+		return s.setKeyText(c.SetKeyText)
+	case *streamdeckpb.Command_ClearKey:
+		return s.device.ClearKey(streamdeck.KeyID(c.ClearKey.GetKey()))
+	case *streamdeckpb.Command_SetInfoBarImage:
+		return s.setInfoBarImage(c.SetInfoBarImage)
+	case *streamdeckpb.Command_SetTouchPointColor:
+		return s.setTouchPointColor(c.SetTouchPointColor)
+	case *streamdeckpb.Command_SetTouchStripImage:
+		return s.setTouchStripImage(c.SetTouchStripImage)
+	}
+	return fmt.Errorf("%w: command is empty", ErrRequestInvalid)
+}
+
+// Events streams the input events of the device, and applies the commands
+// sent by the client, answering each one of them with a result event.
+func (s *Server) Events(stream grpc.BidiStreamingServer[streamdeckpb.Command, streamdeckpb.Event]) error {
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	results := make(chan *streamdeckpb.Event)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			cmd, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			res := &streamdeckpb.CommandResult{Id: cmd.GetId()}
+			if err := s.command(cmd); err != nil {
+				res.Error = err.Error()
+			}
+
+			select {
+			case results <- &streamdeckpb.Event{Event: &streamdeckpb.Event_Result{Result: res}}:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		var e *streamdeckpb.Event
+		select {
+		case <-stream.Context().Done():
+			return nil
+
+		case <-s.done:
+			return nil
+
+		case err := <-recvErr:
+			// the client closing its side of the stream stops the commands,
+			// but not the events.
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			recvErr = nil
+			continue
+
+		case e = <-ch:
+		case e = <-results:
+		}
+
+		if err := stream.Send(e); err != nil {
+			return err
+		}
+	}
+}
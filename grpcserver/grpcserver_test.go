@@ -0,0 +1,290 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/grpcserver/streamdeckpb"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func assertCode(t *testing.T, err error, want codes.Code) {
+	t.Helper()
+
+	if c := status.Code(err); c != want {
+		t.Errorf("unexpected status code: got %s, want %s: %v", c, want, err)
+	}
+}
+
+func newClient(t *testing.T, modelID string) (*streamdeck.Device, *mock.Device, streamdeckpb.StreamDeckClient) {
+	t.Helper()
+
+	dev, m, err := mock.Open(modelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dev.Close() })
+
+	srv, err := New(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	streamdeckpb.RegisterStreamDeckServer(gs, srv)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return dev, m, streamdeckpb.NewStreamDeckClient(conn)
+}
+
+func encodePNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for y := range 20 {
+		for x := range 20 {
+			img.Set(x, y, c)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Error("expected error for nil device")
+	}
+}
+
+func TestDeviceAndBrightness(t *testing.T) {
+	_, m, c := newClient(t, "plus")
+	ctx := context.Background()
+
+	info, err := c.GetDevice(ctx, &streamdeckpb.GetDeviceRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.GetModelId() != "plus" || info.GetSerialNumber() != "MOCKplus" || info.GetKeyCount() != 8 || info.GetKeyColumns() != 4 || info.GetKeyRows() != 2 || info.GetDialCount() != 4 || !info.GetTouchStrip() || info.GetInfoBar() {
+		t.Errorf("unexpected device: %v", info)
+	}
+	if r := info.GetTouchStripImageRectangle(); r.GetMaxX() != 800 || r.GetMaxY() != 100 {
+		t.Errorf("unexpected touch strip rectangle: %v", r)
+	}
+	if info.GetInfoBarImageRectangle() != nil {
+		t.Errorf("unexpected info bar rectangle: %v", info.GetInfoBarImageRectangle())
+	}
+
+	if _, err := c.SetBrightness(ctx, &streamdeckpb.SetBrightnessRequest{Brightness: 42}); err != nil {
+		t.Fatal(err)
+	}
+	if m.Brightness() != 42 {
+		t.Errorf("unexpected brightness: %d", m.Brightness())
+	}
+
+	b, err := c.GetBrightness(ctx, &streamdeckpb.GetBrightnessRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.GetBrightness() != 42 {
+		t.Errorf("unexpected brightness: %d", b.GetBrightness())
+	}
+
+	_, err = c.SetBrightness(ctx, &streamdeckpb.SetBrightnessRequest{Brightness: 420})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = c.SetInfoBarText(ctx, &streamdeckpb.SetInfoBarTextRequest{Text: "bola"})
+	assertCode(t, err, codes.Unimplemented)
+}
+
+func TestKeys(t *testing.T) {
+	_, m, c := newClient(t, "mk2")
+	ctx := context.Background()
+
+	if _, err := c.SetKeyImage(ctx, &streamdeckpb.SetKeyImageRequest{Key: 3, Image: encodePNG(t, color.RGBA{R: 0xff, A: 0xff})}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 36, 36, color.RGBA{R: 0xff, A: 0xff})
+
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><rect width="10" height="10" fill="#00ff00"/></svg>`
+	if _, err := c.SetKeyImage(ctx, &streamdeckpb.SetKeyImageRequest{Key: 5, Image: []byte(svg)}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_5), 36, 36, color.RGBA{G: 0xff, A: 0xff})
+
+	if _, err := c.SetKeyColor(ctx, &streamdeckpb.SetKeyColorRequest{Key: 1, Color: &streamdeckpb.Color{B: 0xff}}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{B: 0xff, A: 0xff})
+
+	if _, err := c.SetKeyText(ctx, &streamdeckpb.SetKeyTextRequest{
+		Key:     4,
+		Text:    "Hi",
+		Options: &streamdeckpb.TextOptions{Background: &streamdeckpb.Color{B: 0xff}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_4), 1, 1, color.RGBA{B: 0xff, A: 0xff})
+
+	if _, err := c.ClearKey(ctx, &streamdeckpb.ClearKeyRequest{Key: 3}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 36, 36, color.RGBA{A: 0xff})
+
+	_, err := c.ClearKey(ctx, &streamdeckpb.ClearKeyRequest{Key: 16})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = c.SetKeyColor(ctx, &streamdeckpb.SetKeyColorRequest{Key: 1, Color: &streamdeckpb.Color{R: 256}})
+	assertCode(t, err, codes.InvalidArgument)
+
+	_, err = c.SetKeyImage(ctx, &streamdeckpb.SetKeyImageRequest{Key: 1, Image: []byte("bola")})
+	assertCode(t, err, codes.InvalidArgument)
+}
+
+func TestTouchStrip(t *testing.T) {
+	_, m, c := newClient(t, "plus")
+	ctx := context.Background()
+
+	if _, err := c.SetTouchStripImage(ctx, &streamdeckpb.SetTouchStripImageRequest{
+		Image:     encodePNG(t, color.RGBA{R: 0xff, A: 0xff}),
+		Rectangle: &streamdeckpb.Rectangle{MinX: 200, MaxX: 400, MaxY: 100},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.TouchStripImage(), 300, 50, color.RGBA{R: 0xff, A: 0xff})
+	assertColor(t, m.TouchStripImage(), 100, 50, color.RGBA{A: 0xff})
+
+	if _, err := c.ClearTouchStrip(ctx, &streamdeckpb.ClearTouchStripRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.TouchStripImage(), 300, 50, color.RGBA{A: 0xff})
+}
+
+func TestEvents(t *testing.T) {
+	dev, m, c := newClient(t, "plus")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	stream, err := c.Events(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := make(chan *streamdeckpb.Event)
+	go func() {
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			events <- e
+		}
+	}()
+
+	next := func() *streamdeckpb.Event {
+		t.Helper()
+
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+		return nil
+	}
+
+	// the result of the command also ensures that the stream is subscribed.
+	if err := stream.Send(&streamdeckpb.Command{
+		Id:      1,
+		Command: &streamdeckpb.Command_SetKeyColor{SetKeyColor: &streamdeckpb.SetKeyColorRequest{Key: 2, Color: &streamdeckpb.Color{R: 0xff}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if r := next().GetResult(); r.GetId() != 1 || r.GetError() != "" {
+		t.Errorf("unexpected result: %v", r)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{R: 0xff, A: 0xff})
+
+	if err := stream.Send(&streamdeckpb.Command{
+		Id:      2,
+		Command: &streamdeckpb.Command_ClearKey{ClearKey: &streamdeckpb.ClearKeyRequest{Key: 20}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if r := next().GetResult(); r.GetId() != 2 || r.GetError() == "" {
+		t.Errorf("unexpected result: %v", r)
+	}
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	if e := next().GetKeyPress(); e.GetKey() != 2 {
+		t.Errorf("unexpected event: %v", e)
+	}
+
+	if err := m.RotateDial(streamdeck.DIAL_3, -2); err != nil {
+		t.Fatal(err)
+	}
+	if e := next().GetDialRotate(); e.GetDial() != 3 || e.GetDelta() != -2 {
+		t.Errorf("unexpected event: %v", e)
+	}
+
+	// events keep being streamed after the client is done sending commands.
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, image.Pt(12, 34)); err != nil {
+		t.Fatal(err)
+	}
+	if e := next().GetTouchStripTouch(); !e.GetLong() || e.GetPoint().GetX() != 12 || e.GetPoint().GetY() != 34 {
+		t.Errorf("unexpected event: %v", e)
+	}
+}
@@ -0,0 +1,2239 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Service definition for remote control of an Elgato Stream Deck device.
+//
+// Keys, touch points and dials are numbered starting from 1. Images are
+// encoded in any of the formats supported by the streamdeck package (BMP,
+// GIF, JPEG, PNG, WebP or SVG), and scaled as needed.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: streamdeck.proto
+
+package streamdeckpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_streamdeck_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{0}
+}
+
+type Color struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	R             uint32                 `protobuf:"varint,1,opt,name=r,proto3" json:"r,omitempty"`
+	G             uint32                 `protobuf:"varint,2,opt,name=g,proto3" json:"g,omitempty"`
+	B             uint32                 `protobuf:"varint,3,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Color) Reset() {
+	*x = Color{}
+	mi := &file_streamdeck_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Color) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Color) ProtoMessage() {}
+
+func (x *Color) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Color.ProtoReflect.Descriptor instead.
+func (*Color) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Color) GetR() uint32 {
+	if x != nil {
+		return x.R
+	}
+	return 0
+}
+
+func (x *Color) GetG() uint32 {
+	if x != nil {
+		return x.G
+	}
+	return 0
+}
+
+func (x *Color) GetB() uint32 {
+	if x != nil {
+		return x.B
+	}
+	return 0
+}
+
+type Rectangle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinX          int32                  `protobuf:"varint,1,opt,name=min_x,json=minX,proto3" json:"min_x,omitempty"`
+	MinY          int32                  `protobuf:"varint,2,opt,name=min_y,json=minY,proto3" json:"min_y,omitempty"`
+	MaxX          int32                  `protobuf:"varint,3,opt,name=max_x,json=maxX,proto3" json:"max_x,omitempty"`
+	MaxY          int32                  `protobuf:"varint,4,opt,name=max_y,json=maxY,proto3" json:"max_y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rectangle) Reset() {
+	*x = Rectangle{}
+	mi := &file_streamdeck_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rectangle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rectangle) ProtoMessage() {}
+
+func (x *Rectangle) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rectangle.ProtoReflect.Descriptor instead.
+func (*Rectangle) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Rectangle) GetMinX() int32 {
+	if x != nil {
+		return x.MinX
+	}
+	return 0
+}
+
+func (x *Rectangle) GetMinY() int32 {
+	if x != nil {
+		return x.MinY
+	}
+	return 0
+}
+
+func (x *Rectangle) GetMaxX() int32 {
+	if x != nil {
+		return x.MaxX
+	}
+	return 0
+}
+
+func (x *Rectangle) GetMaxY() int32 {
+	if x != nil {
+		return x.MaxY
+	}
+	return 0
+}
+
+type Point struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Point) Reset() {
+	*x = Point{}
+	mi := &file_streamdeck_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Point) ProtoMessage() {}
+
+func (x *Point) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Point.ProtoReflect.Descriptor instead.
+func (*Point) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Point) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Point) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type GetDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeviceRequest) Reset() {
+	*x = GetDeviceRequest{}
+	mi := &file_streamdeck_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeviceRequest) ProtoMessage() {}
+
+func (x *GetDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeviceRequest.ProtoReflect.Descriptor instead.
+func (*GetDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{4}
+}
+
+type Device struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ModelName                string                 `protobuf:"bytes,1,opt,name=model_name,json=modelName,proto3" json:"model_name,omitempty"`
+	ModelId                  string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	SerialNumber             string                 `protobuf:"bytes,3,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	FirmwareVersion          string                 `protobuf:"bytes,4,opt,name=firmware_version,json=firmwareVersion,proto3" json:"firmware_version,omitempty"`
+	KeyCount                 uint32                 `protobuf:"varint,5,opt,name=key_count,json=keyCount,proto3" json:"key_count,omitempty"`
+	KeyColumns               uint32                 `protobuf:"varint,6,opt,name=key_columns,json=keyColumns,proto3" json:"key_columns,omitempty"`
+	KeyRows                  uint32                 `protobuf:"varint,7,opt,name=key_rows,json=keyRows,proto3" json:"key_rows,omitempty"`
+	TouchPointCount          uint32                 `protobuf:"varint,8,opt,name=touch_point_count,json=touchPointCount,proto3" json:"touch_point_count,omitempty"`
+	DialCount                uint32                 `protobuf:"varint,9,opt,name=dial_count,json=dialCount,proto3" json:"dial_count,omitempty"`
+	KeyDisplay               bool                   `protobuf:"varint,10,opt,name=key_display,json=keyDisplay,proto3" json:"key_display,omitempty"`
+	InfoBar                  bool                   `protobuf:"varint,11,opt,name=info_bar,json=infoBar,proto3" json:"info_bar,omitempty"`
+	TouchStrip               bool                   `protobuf:"varint,12,opt,name=touch_strip,json=touchStrip,proto3" json:"touch_strip,omitempty"`
+	KeyImageRectangle        *Rectangle             `protobuf:"bytes,13,opt,name=key_image_rectangle,json=keyImageRectangle,proto3" json:"key_image_rectangle,omitempty"`
+	InfoBarImageRectangle    *Rectangle             `protobuf:"bytes,14,opt,name=info_bar_image_rectangle,json=infoBarImageRectangle,proto3" json:"info_bar_image_rectangle,omitempty"`
+	TouchStripImageRectangle *Rectangle             `protobuf:"bytes,15,opt,name=touch_strip_image_rectangle,json=touchStripImageRectangle,proto3" json:"touch_strip_image_rectangle,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_streamdeck_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Device) GetModelName() string {
+	if x != nil {
+		return x.ModelName
+	}
+	return ""
+}
+
+func (x *Device) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *Device) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Device) GetFirmwareVersion() string {
+	if x != nil {
+		return x.FirmwareVersion
+	}
+	return ""
+}
+
+func (x *Device) GetKeyCount() uint32 {
+	if x != nil {
+		return x.KeyCount
+	}
+	return 0
+}
+
+func (x *Device) GetKeyColumns() uint32 {
+	if x != nil {
+		return x.KeyColumns
+	}
+	return 0
+}
+
+func (x *Device) GetKeyRows() uint32 {
+	if x != nil {
+		return x.KeyRows
+	}
+	return 0
+}
+
+func (x *Device) GetTouchPointCount() uint32 {
+	if x != nil {
+		return x.TouchPointCount
+	}
+	return 0
+}
+
+func (x *Device) GetDialCount() uint32 {
+	if x != nil {
+		return x.DialCount
+	}
+	return 0
+}
+
+func (x *Device) GetKeyDisplay() bool {
+	if x != nil {
+		return x.KeyDisplay
+	}
+	return false
+}
+
+func (x *Device) GetInfoBar() bool {
+	if x != nil {
+		return x.InfoBar
+	}
+	return false
+}
+
+func (x *Device) GetTouchStrip() bool {
+	if x != nil {
+		return x.TouchStrip
+	}
+	return false
+}
+
+func (x *Device) GetKeyImageRectangle() *Rectangle {
+	if x != nil {
+		return x.KeyImageRectangle
+	}
+	return nil
+}
+
+func (x *Device) GetInfoBarImageRectangle() *Rectangle {
+	if x != nil {
+		return x.InfoBarImageRectangle
+	}
+	return nil
+}
+
+func (x *Device) GetTouchStripImageRectangle() *Rectangle {
+	if x != nil {
+		return x.TouchStripImageRectangle
+	}
+	return nil
+}
+
+type SetBrightnessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brightness    uint32                 `protobuf:"varint,1,opt,name=brightness,proto3" json:"brightness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetBrightnessRequest) Reset() {
+	*x = SetBrightnessRequest{}
+	mi := &file_streamdeck_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetBrightnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetBrightnessRequest) ProtoMessage() {}
+
+func (x *SetBrightnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetBrightnessRequest.ProtoReflect.Descriptor instead.
+func (*SetBrightnessRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetBrightnessRequest) GetBrightness() uint32 {
+	if x != nil {
+		return x.Brightness
+	}
+	return 0
+}
+
+type GetBrightnessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBrightnessRequest) Reset() {
+	*x = GetBrightnessRequest{}
+	mi := &file_streamdeck_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBrightnessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBrightnessRequest) ProtoMessage() {}
+
+func (x *GetBrightnessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBrightnessRequest.ProtoReflect.Descriptor instead.
+func (*GetBrightnessRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{7}
+}
+
+type Brightness struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Brightness    uint32                 `protobuf:"varint,1,opt,name=brightness,proto3" json:"brightness,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Brightness) Reset() {
+	*x = Brightness{}
+	mi := &file_streamdeck_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Brightness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Brightness) ProtoMessage() {}
+
+func (x *Brightness) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Brightness.ProtoReflect.Descriptor instead.
+func (*Brightness) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Brightness) GetBrightness() uint32 {
+	if x != nil {
+		return x.Brightness
+	}
+	return 0
+}
+
+type ResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetRequest) Reset() {
+	*x = ResetRequest{}
+	mi := &file_streamdeck_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetRequest) ProtoMessage() {}
+
+func (x *ResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetRequest.ProtoReflect.Descriptor instead.
+func (*ResetRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{9}
+}
+
+type SetKeyImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	Image         []byte                 `protobuf:"bytes,2,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyImageRequest) Reset() {
+	*x = SetKeyImageRequest{}
+	mi := &file_streamdeck_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyImageRequest) ProtoMessage() {}
+
+func (x *SetKeyImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyImageRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyImageRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetKeyImageRequest) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *SetKeyImageRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type SetKeyColorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	Color         *Color                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyColorRequest) Reset() {
+	*x = SetKeyColorRequest{}
+	mi := &file_streamdeck_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyColorRequest) ProtoMessage() {}
+
+func (x *SetKeyColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyColorRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyColorRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetKeyColorRequest) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *SetKeyColorRequest) GetColor() *Color {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+type TextOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Foreground    *Color                 `protobuf:"bytes,1,opt,name=foreground,proto3" json:"foreground,omitempty"`
+	Background    *Color                 `protobuf:"bytes,2,opt,name=background,proto3" json:"background,omitempty"`
+	Size          float64                `protobuf:"fixed64,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextOptions) Reset() {
+	*x = TextOptions{}
+	mi := &file_streamdeck_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextOptions) ProtoMessage() {}
+
+func (x *TextOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextOptions.ProtoReflect.Descriptor instead.
+func (*TextOptions) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TextOptions) GetForeground() *Color {
+	if x != nil {
+		return x.Foreground
+	}
+	return nil
+}
+
+func (x *TextOptions) GetBackground() *Color {
+	if x != nil {
+		return x.Background
+	}
+	return nil
+}
+
+func (x *TextOptions) GetSize() float64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type SetKeyTextRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Options       *TextOptions           `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetKeyTextRequest) Reset() {
+	*x = SetKeyTextRequest{}
+	mi := &file_streamdeck_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetKeyTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetKeyTextRequest) ProtoMessage() {}
+
+func (x *SetKeyTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetKeyTextRequest.ProtoReflect.Descriptor instead.
+func (*SetKeyTextRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SetKeyTextRequest) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *SetKeyTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SetKeyTextRequest) GetOptions() *TextOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ClearKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearKeyRequest) Reset() {
+	*x = ClearKeyRequest{}
+	mi := &file_streamdeck_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearKeyRequest) ProtoMessage() {}
+
+func (x *ClearKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearKeyRequest.ProtoReflect.Descriptor instead.
+func (*ClearKeyRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ClearKeyRequest) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+type SetInfoBarImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         []byte                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInfoBarImageRequest) Reset() {
+	*x = SetInfoBarImageRequest{}
+	mi := &file_streamdeck_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInfoBarImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInfoBarImageRequest) ProtoMessage() {}
+
+func (x *SetInfoBarImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInfoBarImageRequest.ProtoReflect.Descriptor instead.
+func (*SetInfoBarImageRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SetInfoBarImageRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type SetInfoBarTextRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Options       *TextOptions           `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetInfoBarTextRequest) Reset() {
+	*x = SetInfoBarTextRequest{}
+	mi := &file_streamdeck_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetInfoBarTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetInfoBarTextRequest) ProtoMessage() {}
+
+func (x *SetInfoBarTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetInfoBarTextRequest.ProtoReflect.Descriptor instead.
+func (*SetInfoBarTextRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetInfoBarTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SetInfoBarTextRequest) GetOptions() *TextOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type ClearInfoBarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearInfoBarRequest) Reset() {
+	*x = ClearInfoBarRequest{}
+	mi := &file_streamdeck_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearInfoBarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearInfoBarRequest) ProtoMessage() {}
+
+func (x *ClearInfoBarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearInfoBarRequest.ProtoReflect.Descriptor instead.
+func (*ClearInfoBarRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{17}
+}
+
+type SetTouchPointColorRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouchPoint    uint32                 `protobuf:"varint,1,opt,name=touch_point,json=touchPoint,proto3" json:"touch_point,omitempty"`
+	Color         *Color                 `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTouchPointColorRequest) Reset() {
+	*x = SetTouchPointColorRequest{}
+	mi := &file_streamdeck_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTouchPointColorRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTouchPointColorRequest) ProtoMessage() {}
+
+func (x *SetTouchPointColorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTouchPointColorRequest.ProtoReflect.Descriptor instead.
+func (*SetTouchPointColorRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetTouchPointColorRequest) GetTouchPoint() uint32 {
+	if x != nil {
+		return x.TouchPoint
+	}
+	return 0
+}
+
+func (x *SetTouchPointColorRequest) GetColor() *Color {
+	if x != nil {
+		return x.Color
+	}
+	return nil
+}
+
+type ClearTouchPointRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TouchPoint    uint32                 `protobuf:"varint,1,opt,name=touch_point,json=touchPoint,proto3" json:"touch_point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearTouchPointRequest) Reset() {
+	*x = ClearTouchPointRequest{}
+	mi := &file_streamdeck_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearTouchPointRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearTouchPointRequest) ProtoMessage() {}
+
+func (x *ClearTouchPointRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearTouchPointRequest.ProtoReflect.Descriptor instead.
+func (*ClearTouchPointRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ClearTouchPointRequest) GetTouchPoint() uint32 {
+	if x != nil {
+		return x.TouchPoint
+	}
+	return 0
+}
+
+type SetTouchStripImageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Image []byte                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// rectangle is the area of the touch strip to draw to. If unset, the image
+	// is drawn to the whole touch strip.
+	Rectangle     *Rectangle `protobuf:"bytes,2,opt,name=rectangle,proto3" json:"rectangle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTouchStripImageRequest) Reset() {
+	*x = SetTouchStripImageRequest{}
+	mi := &file_streamdeck_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTouchStripImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTouchStripImageRequest) ProtoMessage() {}
+
+func (x *SetTouchStripImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTouchStripImageRequest.ProtoReflect.Descriptor instead.
+func (*SetTouchStripImageRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetTouchStripImageRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *SetTouchStripImageRequest) GetRectangle() *Rectangle {
+	if x != nil {
+		return x.Rectangle
+	}
+	return nil
+}
+
+type ClearTouchStripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rectangle     *Rectangle             `protobuf:"bytes,1,opt,name=rectangle,proto3" json:"rectangle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearTouchStripRequest) Reset() {
+	*x = ClearTouchStripRequest{}
+	mi := &file_streamdeck_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearTouchStripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearTouchStripRequest) ProtoMessage() {}
+
+func (x *ClearTouchStripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearTouchStripRequest.ProtoReflect.Descriptor instead.
+func (*ClearTouchStripRequest) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ClearTouchStripRequest) GetRectangle() *Rectangle {
+	if x != nil {
+		return x.Rectangle
+	}
+	return nil
+}
+
+type Command struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is chosen by the client, and echoed in the result event.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*Command_SetBrightness
+	//	*Command_SetKeyImage
+	//	*Command_SetKeyColor
+	//	*Command_SetKeyText
+	//	*Command_ClearKey
+	//	*Command_SetInfoBarImage
+	//	*Command_SetTouchPointColor
+	//	*Command_SetTouchStripImage
+	Command       isCommand_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Command) Reset() {
+	*x = Command{}
+	mi := &file_streamdeck_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Command) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Command) ProtoMessage() {}
+
+func (x *Command) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Command.ProtoReflect.Descriptor instead.
+func (*Command) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Command) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Command) GetCommand() isCommand_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *Command) GetSetBrightness() *SetBrightnessRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetBrightness); ok {
+			return x.SetBrightness
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetKeyImage() *SetKeyImageRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetKeyImage); ok {
+			return x.SetKeyImage
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetKeyColor() *SetKeyColorRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetKeyColor); ok {
+			return x.SetKeyColor
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetKeyText() *SetKeyTextRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetKeyText); ok {
+			return x.SetKeyText
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetClearKey() *ClearKeyRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_ClearKey); ok {
+			return x.ClearKey
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetInfoBarImage() *SetInfoBarImageRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetInfoBarImage); ok {
+			return x.SetInfoBarImage
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetTouchPointColor() *SetTouchPointColorRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetTouchPointColor); ok {
+			return x.SetTouchPointColor
+		}
+	}
+	return nil
+}
+
+func (x *Command) GetSetTouchStripImage() *SetTouchStripImageRequest {
+	if x != nil {
+		if x, ok := x.Command.(*Command_SetTouchStripImage); ok {
+			return x.SetTouchStripImage
+		}
+	}
+	return nil
+}
+
+type isCommand_Command interface {
+	isCommand_Command()
+}
+
+type Command_SetBrightness struct {
+	SetBrightness *SetBrightnessRequest `protobuf:"bytes,2,opt,name=set_brightness,json=setBrightness,proto3,oneof"`
+}
+
+type Command_SetKeyImage struct {
+	SetKeyImage *SetKeyImageRequest `protobuf:"bytes,3,opt,name=set_key_image,json=setKeyImage,proto3,oneof"`
+}
+
+type Command_SetKeyColor struct {
+	SetKeyColor *SetKeyColorRequest `protobuf:"bytes,4,opt,name=set_key_color,json=setKeyColor,proto3,oneof"`
+}
+
+type Command_SetKeyText struct {
+	SetKeyText *SetKeyTextRequest `protobuf:"bytes,5,opt,name=set_key_text,json=setKeyText,proto3,oneof"`
+}
+
+type Command_ClearKey struct {
+	ClearKey *ClearKeyRequest `protobuf:"bytes,6,opt,name=clear_key,json=clearKey,proto3,oneof"`
+}
+
+type Command_SetInfoBarImage struct {
+	SetInfoBarImage *SetInfoBarImageRequest `protobuf:"bytes,7,opt,name=set_info_bar_image,json=setInfoBarImage,proto3,oneof"`
+}
+
+type Command_SetTouchPointColor struct {
+	SetTouchPointColor *SetTouchPointColorRequest `protobuf:"bytes,8,opt,name=set_touch_point_color,json=setTouchPointColor,proto3,oneof"`
+}
+
+type Command_SetTouchStripImage struct {
+	SetTouchStripImage *SetTouchStripImageRequest `protobuf:"bytes,9,opt,name=set_touch_strip_image,json=setTouchStripImage,proto3,oneof"`
+}
+
+func (*Command_SetBrightness) isCommand_Command() {}
+
+func (*Command_SetKeyImage) isCommand_Command() {}
+
+func (*Command_SetKeyColor) isCommand_Command() {}
+
+func (*Command_SetKeyText) isCommand_Command() {}
+
+func (*Command_ClearKey) isCommand_Command() {}
+
+func (*Command_SetInfoBarImage) isCommand_Command() {}
+
+func (*Command_SetTouchPointColor) isCommand_Command() {}
+
+func (*Command_SetTouchStripImage) isCommand_Command() {}
+
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*Event_KeyPress
+	//	*Event_KeyRelease
+	//	*Event_TouchPointPress
+	//	*Event_TouchPointRelease
+	//	*Event_DialPress
+	//	*Event_DialRelease
+	//	*Event_DialRotate
+	//	*Event_TouchStripTouch
+	//	*Event_TouchStripSwipe
+	//	*Event_Result
+	Event         isEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_streamdeck_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Event) GetEvent() isEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *Event) GetKeyPress() *KeyEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_KeyPress); ok {
+			return x.KeyPress
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetKeyRelease() *KeyEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_KeyRelease); ok {
+			return x.KeyRelease
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTouchPointPress() *TouchPointEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_TouchPointPress); ok {
+			return x.TouchPointPress
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTouchPointRelease() *TouchPointEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_TouchPointRelease); ok {
+			return x.TouchPointRelease
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDialPress() *DialEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_DialPress); ok {
+			return x.DialPress
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDialRelease() *DialEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_DialRelease); ok {
+			return x.DialRelease
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetDialRotate() *DialEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_DialRotate); ok {
+			return x.DialRotate
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTouchStripTouch() *TouchStripTouchEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_TouchStripTouch); ok {
+			return x.TouchStripTouch
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetTouchStripSwipe() *TouchStripSwipeEvent {
+	if x != nil {
+		if x, ok := x.Event.(*Event_TouchStripSwipe); ok {
+			return x.TouchStripSwipe
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetResult() *CommandResult {
+	if x != nil {
+		if x, ok := x.Event.(*Event_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+type isEvent_Event interface {
+	isEvent_Event()
+}
+
+type Event_KeyPress struct {
+	KeyPress *KeyEvent `protobuf:"bytes,1,opt,name=key_press,json=keyPress,proto3,oneof"`
+}
+
+type Event_KeyRelease struct {
+	KeyRelease *KeyEvent `protobuf:"bytes,2,opt,name=key_release,json=keyRelease,proto3,oneof"`
+}
+
+type Event_TouchPointPress struct {
+	TouchPointPress *TouchPointEvent `protobuf:"bytes,3,opt,name=touch_point_press,json=touchPointPress,proto3,oneof"`
+}
+
+type Event_TouchPointRelease struct {
+	TouchPointRelease *TouchPointEvent `protobuf:"bytes,4,opt,name=touch_point_release,json=touchPointRelease,proto3,oneof"`
+}
+
+type Event_DialPress struct {
+	DialPress *DialEvent `protobuf:"bytes,5,opt,name=dial_press,json=dialPress,proto3,oneof"`
+}
+
+type Event_DialRelease struct {
+	DialRelease *DialEvent `protobuf:"bytes,6,opt,name=dial_release,json=dialRelease,proto3,oneof"`
+}
+
+type Event_DialRotate struct {
+	DialRotate *DialEvent `protobuf:"bytes,7,opt,name=dial_rotate,json=dialRotate,proto3,oneof"`
+}
+
+type Event_TouchStripTouch struct {
+	TouchStripTouch *TouchStripTouchEvent `protobuf:"bytes,8,opt,name=touch_strip_touch,json=touchStripTouch,proto3,oneof"`
+}
+
+type Event_TouchStripSwipe struct {
+	TouchStripSwipe *TouchStripSwipeEvent `protobuf:"bytes,9,opt,name=touch_strip_swipe,json=touchStripSwipe,proto3,oneof"`
+}
+
+type Event_Result struct {
+	Result *CommandResult `protobuf:"bytes,10,opt,name=result,proto3,oneof"`
+}
+
+func (*Event_KeyPress) isEvent_Event() {}
+
+func (*Event_KeyRelease) isEvent_Event() {}
+
+func (*Event_TouchPointPress) isEvent_Event() {}
+
+func (*Event_TouchPointRelease) isEvent_Event() {}
+
+func (*Event_DialPress) isEvent_Event() {}
+
+func (*Event_DialRelease) isEvent_Event() {}
+
+func (*Event_DialRotate) isEvent_Event() {}
+
+func (*Event_TouchStripTouch) isEvent_Event() {}
+
+func (*Event_TouchStripSwipe) isEvent_Event() {}
+
+func (*Event_Result) isEvent_Event() {}
+
+type KeyEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   uint32                 `protobuf:"varint,1,opt,name=key,proto3" json:"key,omitempty"`
+	// duration_ms is the time the key was held, for release events.
+	DurationMs    int64 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyEvent) Reset() {
+	*x = KeyEvent{}
+	mi := &file_streamdeck_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyEvent) ProtoMessage() {}
+
+func (x *KeyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyEvent.ProtoReflect.Descriptor instead.
+func (*KeyEvent) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *KeyEvent) GetKey() uint32 {
+	if x != nil {
+		return x.Key
+	}
+	return 0
+}
+
+func (x *KeyEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type TouchPointEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TouchPoint uint32                 `protobuf:"varint,1,opt,name=touch_point,json=touchPoint,proto3" json:"touch_point,omitempty"`
+	// duration_ms is the time the touch point was held, for release events.
+	DurationMs    int64 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TouchPointEvent) Reset() {
+	*x = TouchPointEvent{}
+	mi := &file_streamdeck_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchPointEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchPointEvent) ProtoMessage() {}
+
+func (x *TouchPointEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchPointEvent.ProtoReflect.Descriptor instead.
+func (*TouchPointEvent) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TouchPointEvent) GetTouchPoint() uint32 {
+	if x != nil {
+		return x.TouchPoint
+	}
+	return 0
+}
+
+func (x *TouchPointEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type DialEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Dial  uint32                 `protobuf:"varint,1,opt,name=dial,proto3" json:"dial,omitempty"`
+	// duration_ms is the time the dial was held, for release events.
+	DurationMs int64 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// delta is the rotation delta, for rotate events.
+	Delta         int32 `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DialEvent) Reset() {
+	*x = DialEvent{}
+	mi := &file_streamdeck_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DialEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DialEvent) ProtoMessage() {}
+
+func (x *DialEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DialEvent.ProtoReflect.Descriptor instead.
+func (*DialEvent) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DialEvent) GetDial() uint32 {
+	if x != nil {
+		return x.Dial
+	}
+	return 0
+}
+
+func (x *DialEvent) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *DialEvent) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type TouchStripTouchEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Long          bool                   `protobuf:"varint,1,opt,name=long,proto3" json:"long,omitempty"`
+	Point         *Point                 `protobuf:"bytes,2,opt,name=point,proto3" json:"point,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TouchStripTouchEvent) Reset() {
+	*x = TouchStripTouchEvent{}
+	mi := &file_streamdeck_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchStripTouchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchStripTouchEvent) ProtoMessage() {}
+
+func (x *TouchStripTouchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchStripTouchEvent.ProtoReflect.Descriptor instead.
+func (*TouchStripTouchEvent) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TouchStripTouchEvent) GetLong() bool {
+	if x != nil {
+		return x.Long
+	}
+	return false
+}
+
+func (x *TouchStripTouchEvent) GetPoint() *Point {
+	if x != nil {
+		return x.Point
+	}
+	return nil
+}
+
+type TouchStripSwipeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Origin        *Point                 `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination   *Point                 `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TouchStripSwipeEvent) Reset() {
+	*x = TouchStripSwipeEvent{}
+	mi := &file_streamdeck_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TouchStripSwipeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchStripSwipeEvent) ProtoMessage() {}
+
+func (x *TouchStripSwipeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchStripSwipeEvent.ProtoReflect.Descriptor instead.
+func (*TouchStripSwipeEvent) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TouchStripSwipeEvent) GetOrigin() *Point {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *TouchStripSwipeEvent) GetDestination() *Point {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+type CommandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommandResult) Reset() {
+	*x = CommandResult{}
+	mi := &file_streamdeck_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResult) ProtoMessage() {}
+
+func (x *CommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_streamdeck_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return file_streamdeck_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CommandResult) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CommandResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_streamdeck_proto protoreflect.FileDescriptor
+
+const file_streamdeck_proto_rawDesc = "" +
+	"\n" +
+	"\x10streamdeck.proto\x12\rstreamdeck.v1\"\a\n" +
+	"\x05Empty\"1\n" +
+	"\x05Color\x12\f\n" +
+	"\x01r\x18\x01 \x01(\rR\x01r\x12\f\n" +
+	"\x01g\x18\x02 \x01(\rR\x01g\x12\f\n" +
+	"\x01b\x18\x03 \x01(\rR\x01b\"_\n" +
+	"\tRectangle\x12\x13\n" +
+	"\x05min_x\x18\x01 \x01(\x05R\x04minX\x12\x13\n" +
+	"\x05min_y\x18\x02 \x01(\x05R\x04minY\x12\x13\n" +
+	"\x05max_x\x18\x03 \x01(\x05R\x04maxX\x12\x13\n" +
+	"\x05max_y\x18\x04 \x01(\x05R\x04maxY\"#\n" +
+	"\x05Point\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\"\x12\n" +
+	"\x10GetDeviceRequest\"\x89\x05\n" +
+	"\x06Device\x12\x1d\n" +
+	"\n" +
+	"model_name\x18\x01 \x01(\tR\tmodelName\x12\x19\n" +
+	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x12#\n" +
+	"\rserial_number\x18\x03 \x01(\tR\fserialNumber\x12)\n" +
+	"\x10firmware_version\x18\x04 \x01(\tR\x0ffirmwareVersion\x12\x1b\n" +
+	"\tkey_count\x18\x05 \x01(\rR\bkeyCount\x12\x1f\n" +
+	"\vkey_columns\x18\x06 \x01(\rR\n" +
+	"keyColumns\x12\x19\n" +
+	"\bkey_rows\x18\a \x01(\rR\akeyRows\x12*\n" +
+	"\x11touch_point_count\x18\b \x01(\rR\x0ftouchPointCount\x12\x1d\n" +
+	"\n" +
+	"dial_count\x18\t \x01(\rR\tdialCount\x12\x1f\n" +
+	"\vkey_display\x18\n" +
+	" \x01(\bR\n" +
+	"keyDisplay\x12\x19\n" +
+	"\binfo_bar\x18\v \x01(\bR\ainfoBar\x12\x1f\n" +
+	"\vtouch_strip\x18\f \x01(\bR\n" +
+	"touchStrip\x12H\n" +
+	"\x13key_image_rectangle\x18\r \x01(\v2\x18.streamdeck.v1.RectangleR\x11keyImageRectangle\x12Q\n" +
+	"\x18info_bar_image_rectangle\x18\x0e \x01(\v2\x18.streamdeck.v1.RectangleR\x15infoBarImageRectangle\x12W\n" +
+	"\x1btouch_strip_image_rectangle\x18\x0f \x01(\v2\x18.streamdeck.v1.RectangleR\x18touchStripImageRectangle\"6\n" +
+	"\x14SetBrightnessRequest\x12\x1e\n" +
+	"\n" +
+	"brightness\x18\x01 \x01(\rR\n" +
+	"brightness\"\x16\n" +
+	"\x14GetBrightnessRequest\",\n" +
+	"\n" +
+	"Brightness\x12\x1e\n" +
+	"\n" +
+	"brightness\x18\x01 \x01(\rR\n" +
+	"brightness\"\x0e\n" +
+	"\fResetRequest\"<\n" +
+	"\x12SetKeyImageRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x14\n" +
+	"\x05image\x18\x02 \x01(\fR\x05image\"R\n" +
+	"\x12SetKeyColorRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12*\n" +
+	"\x05color\x18\x02 \x01(\v2\x14.streamdeck.v1.ColorR\x05color\"\x8d\x01\n" +
+	"\vTextOptions\x124\n" +
+	"\n" +
+	"foreground\x18\x01 \x01(\v2\x14.streamdeck.v1.ColorR\n" +
+	"foreground\x124\n" +
+	"\n" +
+	"background\x18\x02 \x01(\v2\x14.streamdeck.v1.ColorR\n" +
+	"background\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x01R\x04size\"o\n" +
+	"\x11SetKeyTextRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x124\n" +
+	"\aoptions\x18\x03 \x01(\v2\x1a.streamdeck.v1.TextOptionsR\aoptions\"#\n" +
+	"\x0fClearKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\".\n" +
+	"\x16SetInfoBarImageRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\"a\n" +
+	"\x15SetInfoBarTextRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x124\n" +
+	"\aoptions\x18\x02 \x01(\v2\x1a.streamdeck.v1.TextOptionsR\aoptions\"\x15\n" +
+	"\x13ClearInfoBarRequest\"h\n" +
+	"\x19SetTouchPointColorRequest\x12\x1f\n" +
+	"\vtouch_point\x18\x01 \x01(\rR\n" +
+	"touchPoint\x12*\n" +
+	"\x05color\x18\x02 \x01(\v2\x14.streamdeck.v1.ColorR\x05color\"9\n" +
+	"\x16ClearTouchPointRequest\x12\x1f\n" +
+	"\vtouch_point\x18\x01 \x01(\rR\n" +
+	"touchPoint\"i\n" +
+	"\x19SetTouchStripImageRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\x126\n" +
+	"\trectangle\x18\x02 \x01(\v2\x18.streamdeck.v1.RectangleR\trectangle\"P\n" +
+	"\x16ClearTouchStripRequest\x126\n" +
+	"\trectangle\x18\x01 \x01(\v2\x18.streamdeck.v1.RectangleR\trectangle\"\x9d\x05\n" +
+	"\aCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12L\n" +
+	"\x0eset_brightness\x18\x02 \x01(\v2#.streamdeck.v1.SetBrightnessRequestH\x00R\rsetBrightness\x12G\n" +
+	"\rset_key_image\x18\x03 \x01(\v2!.streamdeck.v1.SetKeyImageRequestH\x00R\vsetKeyImage\x12G\n" +
+	"\rset_key_color\x18\x04 \x01(\v2!.streamdeck.v1.SetKeyColorRequestH\x00R\vsetKeyColor\x12D\n" +
+	"\fset_key_text\x18\x05 \x01(\v2 .streamdeck.v1.SetKeyTextRequestH\x00R\n" +
+	"setKeyText\x12=\n" +
+	"\tclear_key\x18\x06 \x01(\v2\x1e.streamdeck.v1.ClearKeyRequestH\x00R\bclearKey\x12T\n" +
+	"\x12set_info_bar_image\x18\a \x01(\v2%.streamdeck.v1.SetInfoBarImageRequestH\x00R\x0fsetInfoBarImage\x12]\n" +
+	"\x15set_touch_point_color\x18\b \x01(\v2(.streamdeck.v1.SetTouchPointColorRequestH\x00R\x12setTouchPointColor\x12]\n" +
+	"\x15set_touch_strip_image\x18\t \x01(\v2(.streamdeck.v1.SetTouchStripImageRequestH\x00R\x12setTouchStripImageB\t\n" +
+	"\acommand\"\xb9\x05\n" +
+	"\x05Event\x126\n" +
+	"\tkey_press\x18\x01 \x01(\v2\x17.streamdeck.v1.KeyEventH\x00R\bkeyPress\x12:\n" +
+	"\vkey_release\x18\x02 \x01(\v2\x17.streamdeck.v1.KeyEventH\x00R\n" +
+	"keyRelease\x12L\n" +
+	"\x11touch_point_press\x18\x03 \x01(\v2\x1e.streamdeck.v1.TouchPointEventH\x00R\x0ftouchPointPress\x12P\n" +
+	"\x13touch_point_release\x18\x04 \x01(\v2\x1e.streamdeck.v1.TouchPointEventH\x00R\x11touchPointRelease\x129\n" +
+	"\n" +
+	"dial_press\x18\x05 \x01(\v2\x18.streamdeck.v1.DialEventH\x00R\tdialPress\x12=\n" +
+	"\fdial_release\x18\x06 \x01(\v2\x18.streamdeck.v1.DialEventH\x00R\vdialRelease\x12;\n" +
+	"\vdial_rotate\x18\a \x01(\v2\x18.streamdeck.v1.DialEventH\x00R\n" +
+	"dialRotate\x12Q\n" +
+	"\x11touch_strip_touch\x18\b \x01(\v2#.streamdeck.v1.TouchStripTouchEventH\x00R\x0ftouchStripTouch\x12Q\n" +
+	"\x11touch_strip_swipe\x18\t \x01(\v2#.streamdeck.v1.TouchStripSwipeEventH\x00R\x0ftouchStripSwipe\x126\n" +
+	"\x06result\x18\n" +
+	" \x01(\v2\x1c.streamdeck.v1.CommandResultH\x00R\x06resultB\a\n" +
+	"\x05event\"=\n" +
+	"\bKeyEvent\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\rR\x03key\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\"S\n" +
+	"\x0fTouchPointEvent\x12\x1f\n" +
+	"\vtouch_point\x18\x01 \x01(\rR\n" +
+	"touchPoint\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\"V\n" +
+	"\tDialEvent\x12\x12\n" +
+	"\x04dial\x18\x01 \x01(\rR\x04dial\x12\x1f\n" +
+	"\vduration_ms\x18\x02 \x01(\x03R\n" +
+	"durationMs\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\"V\n" +
+	"\x14TouchStripTouchEvent\x12\x12\n" +
+	"\x04long\x18\x01 \x01(\bR\x04long\x12*\n" +
+	"\x05point\x18\x02 \x01(\v2\x14.streamdeck.v1.PointR\x05point\"|\n" +
+	"\x14TouchStripSwipeEvent\x12,\n" +
+	"\x06origin\x18\x01 \x01(\v2\x14.streamdeck.v1.PointR\x06origin\x126\n" +
+	"\vdestination\x18\x02 \x01(\v2\x14.streamdeck.v1.PointR\vdestination\"5\n" +
+	"\rCommandResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\xb2\t\n" +
+	"\n" +
+	"StreamDeck\x12C\n" +
+	"\tGetDevice\x12\x1f.streamdeck.v1.GetDeviceRequest\x1a\x15.streamdeck.v1.Device\x12J\n" +
+	"\rSetBrightness\x12#.streamdeck.v1.SetBrightnessRequest\x1a\x14.streamdeck.v1.Empty\x12O\n" +
+	"\rGetBrightness\x12#.streamdeck.v1.GetBrightnessRequest\x1a\x19.streamdeck.v1.Brightness\x12:\n" +
+	"\x05Reset\x12\x1b.streamdeck.v1.ResetRequest\x1a\x14.streamdeck.v1.Empty\x12F\n" +
+	"\vSetKeyImage\x12!.streamdeck.v1.SetKeyImageRequest\x1a\x14.streamdeck.v1.Empty\x12F\n" +
+	"\vSetKeyColor\x12!.streamdeck.v1.SetKeyColorRequest\x1a\x14.streamdeck.v1.Empty\x12D\n" +
+	"\n" +
+	"SetKeyText\x12 .streamdeck.v1.SetKeyTextRequest\x1a\x14.streamdeck.v1.Empty\x12@\n" +
+	"\bClearKey\x12\x1e.streamdeck.v1.ClearKeyRequest\x1a\x14.streamdeck.v1.Empty\x12N\n" +
+	"\x0fSetInfoBarImage\x12%.streamdeck.v1.SetInfoBarImageRequest\x1a\x14.streamdeck.v1.Empty\x12L\n" +
+	"\x0eSetInfoBarText\x12$.streamdeck.v1.SetInfoBarTextRequest\x1a\x14.streamdeck.v1.Empty\x12H\n" +
+	"\fClearInfoBar\x12\".streamdeck.v1.ClearInfoBarRequest\x1a\x14.streamdeck.v1.Empty\x12T\n" +
+	"\x12SetTouchPointColor\x12(.streamdeck.v1.SetTouchPointColorRequest\x1a\x14.streamdeck.v1.Empty\x12N\n" +
+	"\x0fClearTouchPoint\x12%.streamdeck.v1.ClearTouchPointRequest\x1a\x14.streamdeck.v1.Empty\x12T\n" +
+	"\x12SetTouchStripImage\x12(.streamdeck.v1.SetTouchStripImageRequest\x1a\x14.streamdeck.v1.Empty\x12N\n" +
+	"\x0fClearTouchStrip\x12%.streamdeck.v1.ClearTouchStripRequest\x1a\x14.streamdeck.v1.Empty\x12:\n" +
+	"\x06Events\x12\x16.streamdeck.v1.Command\x1a\x14.streamdeck.v1.Event(\x010\x01B8Z6rafaelmartins.com/p/streamdeck/grpcserver/streamdeckpbb\x06proto3"
+
+var (
+	file_streamdeck_proto_rawDescOnce sync.Once
+	file_streamdeck_proto_rawDescData []byte
+)
+
+func file_streamdeck_proto_rawDescGZIP() []byte {
+	file_streamdeck_proto_rawDescOnce.Do(func() {
+		file_streamdeck_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_streamdeck_proto_rawDesc), len(file_streamdeck_proto_rawDesc)))
+	})
+	return file_streamdeck_proto_rawDescData
+}
+
+var file_streamdeck_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_streamdeck_proto_goTypes = []any{
+	(*Empty)(nil),                     // 0: streamdeck.v1.Empty
+	(*Color)(nil),                     // 1: streamdeck.v1.Color
+	(*Rectangle)(nil),                 // 2: streamdeck.v1.Rectangle
+	(*Point)(nil),                     // 3: streamdeck.v1.Point
+	(*GetDeviceRequest)(nil),          // 4: streamdeck.v1.GetDeviceRequest
+	(*Device)(nil),                    // 5: streamdeck.v1.Device
+	(*SetBrightnessRequest)(nil),      // 6: streamdeck.v1.SetBrightnessRequest
+	(*GetBrightnessRequest)(nil),      // 7: streamdeck.v1.GetBrightnessRequest
+	(*Brightness)(nil),                // 8: streamdeck.v1.Brightness
+	(*ResetRequest)(nil),              // 9: streamdeck.v1.ResetRequest
+	(*SetKeyImageRequest)(nil),        // 10: streamdeck.v1.SetKeyImageRequest
+	(*SetKeyColorRequest)(nil),        // 11: streamdeck.v1.SetKeyColorRequest
+	(*TextOptions)(nil),               // 12: streamdeck.v1.TextOptions
+	(*SetKeyTextRequest)(nil),         // 13: streamdeck.v1.SetKeyTextRequest
+	(*ClearKeyRequest)(nil),           // 14: streamdeck.v1.ClearKeyRequest
+	(*SetInfoBarImageRequest)(nil),    // 15: streamdeck.v1.SetInfoBarImageRequest
+	(*SetInfoBarTextRequest)(nil),     // 16: streamdeck.v1.SetInfoBarTextRequest
+	(*ClearInfoBarRequest)(nil),       // 17: streamdeck.v1.ClearInfoBarRequest
+	(*SetTouchPointColorRequest)(nil), // 18: streamdeck.v1.SetTouchPointColorRequest
+	(*ClearTouchPointRequest)(nil),    // 19: streamdeck.v1.ClearTouchPointRequest
+	(*SetTouchStripImageRequest)(nil), // 20: streamdeck.v1.SetTouchStripImageRequest
+	(*ClearTouchStripRequest)(nil),    // 21: streamdeck.v1.ClearTouchStripRequest
+	(*Command)(nil),                   // 22: streamdeck.v1.Command
+	(*Event)(nil),                     // 23: streamdeck.v1.Event
+	(*KeyEvent)(nil),                  // 24: streamdeck.v1.KeyEvent
+	(*TouchPointEvent)(nil),           // 25: streamdeck.v1.TouchPointEvent
+	(*DialEvent)(nil),                 // 26: streamdeck.v1.DialEvent
+	(*TouchStripTouchEvent)(nil),      // 27: streamdeck.v1.TouchStripTouchEvent
+	(*TouchStripSwipeEvent)(nil),      // 28: streamdeck.v1.TouchStripSwipeEvent
+	(*CommandResult)(nil),             // 29: streamdeck.v1.CommandResult
+}
+var file_streamdeck_proto_depIdxs = []int32{
+	2,  // 0: streamdeck.v1.Device.key_image_rectangle:type_name -> streamdeck.v1.Rectangle
+	2,  // 1: streamdeck.v1.Device.info_bar_image_rectangle:type_name -> streamdeck.v1.Rectangle
+	2,  // 2: streamdeck.v1.Device.touch_strip_image_rectangle:type_name -> streamdeck.v1.Rectangle
+	1,  // 3: streamdeck.v1.SetKeyColorRequest.color:type_name -> streamdeck.v1.Color
+	1,  // 4: streamdeck.v1.TextOptions.foreground:type_name -> streamdeck.v1.Color
+	1,  // 5: streamdeck.v1.TextOptions.background:type_name -> streamdeck.v1.Color
+	12, // 6: streamdeck.v1.SetKeyTextRequest.options:type_name -> streamdeck.v1.TextOptions
+	12, // 7: streamdeck.v1.SetInfoBarTextRequest.options:type_name -> streamdeck.v1.TextOptions
+	1,  // 8: streamdeck.v1.SetTouchPointColorRequest.color:type_name -> streamdeck.v1.Color
+	2,  // 9: streamdeck.v1.SetTouchStripImageRequest.rectangle:type_name -> streamdeck.v1.Rectangle
+	2,  // 10: streamdeck.v1.ClearTouchStripRequest.rectangle:type_name -> streamdeck.v1.Rectangle
+	6,  // 11: streamdeck.v1.Command.set_brightness:type_name -> streamdeck.v1.SetBrightnessRequest
+	10, // 12: streamdeck.v1.Command.set_key_image:type_name -> streamdeck.v1.SetKeyImageRequest
+	11, // 13: streamdeck.v1.Command.set_key_color:type_name -> streamdeck.v1.SetKeyColorRequest
+	13, // 14: streamdeck.v1.Command.set_key_text:type_name -> streamdeck.v1.SetKeyTextRequest
+	14, // 15: streamdeck.v1.Command.clear_key:type_name -> streamdeck.v1.ClearKeyRequest
+	15, // 16: streamdeck.v1.Command.set_info_bar_image:type_name -> streamdeck.v1.SetInfoBarImageRequest
+	18, // 17: streamdeck.v1.Command.set_touch_point_color:type_name -> streamdeck.v1.SetTouchPointColorRequest
+	20, // 18: streamdeck.v1.Command.set_touch_strip_image:type_name -> streamdeck.v1.SetTouchStripImageRequest
+	24, // 19: streamdeck.v1.Event.key_press:type_name -> streamdeck.v1.KeyEvent
+	24, // 20: streamdeck.v1.Event.key_release:type_name -> streamdeck.v1.KeyEvent
+	25, // 21: streamdeck.v1.Event.touch_point_press:type_name -> streamdeck.v1.TouchPointEvent
+	25, // 22: streamdeck.v1.Event.touch_point_release:type_name -> streamdeck.v1.TouchPointEvent
+	26, // 23: streamdeck.v1.Event.dial_press:type_name -> streamdeck.v1.DialEvent
+	26, // 24: streamdeck.v1.Event.dial_release:type_name -> streamdeck.v1.DialEvent
+	26, // 25: streamdeck.v1.Event.dial_rotate:type_name -> streamdeck.v1.DialEvent
+	27, // 26: streamdeck.v1.Event.touch_strip_touch:type_name -> streamdeck.v1.TouchStripTouchEvent
+	28, // 27: streamdeck.v1.Event.touch_strip_swipe:type_name -> streamdeck.v1.TouchStripSwipeEvent
+	29, // 28: streamdeck.v1.Event.result:type_name -> streamdeck.v1.CommandResult
+	3,  // 29: streamdeck.v1.TouchStripTouchEvent.point:type_name -> streamdeck.v1.Point
+	3,  // 30: streamdeck.v1.TouchStripSwipeEvent.origin:type_name -> streamdeck.v1.Point
+	3,  // 31: streamdeck.v1.TouchStripSwipeEvent.destination:type_name -> streamdeck.v1.Point
+	4,  // 32: streamdeck.v1.StreamDeck.GetDevice:input_type -> streamdeck.v1.GetDeviceRequest
+	6,  // 33: streamdeck.v1.StreamDeck.SetBrightness:input_type -> streamdeck.v1.SetBrightnessRequest
+	7,  // 34: streamdeck.v1.StreamDeck.GetBrightness:input_type -> streamdeck.v1.GetBrightnessRequest
+	9,  // 35: streamdeck.v1.StreamDeck.Reset:input_type -> streamdeck.v1.ResetRequest
+	10, // 36: streamdeck.v1.StreamDeck.SetKeyImage:input_type -> streamdeck.v1.SetKeyImageRequest
+	11, // 37: streamdeck.v1.StreamDeck.SetKeyColor:input_type -> streamdeck.v1.SetKeyColorRequest
+	13, // 38: streamdeck.v1.StreamDeck.SetKeyText:input_type -> streamdeck.v1.SetKeyTextRequest
+	14, // 39: streamdeck.v1.StreamDeck.ClearKey:input_type -> streamdeck.v1.ClearKeyRequest
+	15, // 40: streamdeck.v1.StreamDeck.SetInfoBarImage:input_type -> streamdeck.v1.SetInfoBarImageRequest
+	16, // 41: streamdeck.v1.StreamDeck.SetInfoBarText:input_type -> streamdeck.v1.SetInfoBarTextRequest
+	17, // 42: streamdeck.v1.StreamDeck.ClearInfoBar:input_type -> streamdeck.v1.ClearInfoBarRequest
+	18, // 43: streamdeck.v1.StreamDeck.SetTouchPointColor:input_type -> streamdeck.v1.SetTouchPointColorRequest
+	19, // 44: streamdeck.v1.StreamDeck.ClearTouchPoint:input_type -> streamdeck.v1.ClearTouchPointRequest
+	20, // 45: streamdeck.v1.StreamDeck.SetTouchStripImage:input_type -> streamdeck.v1.SetTouchStripImageRequest
+	21, // 46: streamdeck.v1.StreamDeck.ClearTouchStrip:input_type -> streamdeck.v1.ClearTouchStripRequest
+	22, // 47: streamdeck.v1.StreamDeck.Events:input_type -> streamdeck.v1.Command
+	5,  // 48: streamdeck.v1.StreamDeck.GetDevice:output_type -> streamdeck.v1.Device
+	0,  // 49: streamdeck.v1.StreamDeck.SetBrightness:output_type -> streamdeck.v1.Empty
+	8,  // 50: streamdeck.v1.StreamDeck.GetBrightness:output_type -> streamdeck.v1.Brightness
+	0,  // 51: streamdeck.v1.StreamDeck.Reset:output_type -> streamdeck.v1.Empty
+	0,  // 52: streamdeck.v1.StreamDeck.SetKeyImage:output_type -> streamdeck.v1.Empty
+	0,  // 53: streamdeck.v1.StreamDeck.SetKeyColor:output_type -> streamdeck.v1.Empty
+	0,  // 54: streamdeck.v1.StreamDeck.SetKeyText:output_type -> streamdeck.v1.Empty
+	0,  // 55: streamdeck.v1.StreamDeck.ClearKey:output_type -> streamdeck.v1.Empty
+	0,  // 56: streamdeck.v1.StreamDeck.SetInfoBarImage:output_type -> streamdeck.v1.Empty
+	0,  // 57: streamdeck.v1.StreamDeck.SetInfoBarText:output_type -> streamdeck.v1.Empty
+	0,  // 58: streamdeck.v1.StreamDeck.ClearInfoBar:output_type -> streamdeck.v1.Empty
+	0,  // 59: streamdeck.v1.StreamDeck.SetTouchPointColor:output_type -> streamdeck.v1.Empty
+	0,  // 60: streamdeck.v1.StreamDeck.ClearTouchPoint:output_type -> streamdeck.v1.Empty
+	0,  // 61: streamdeck.v1.StreamDeck.SetTouchStripImage:output_type -> streamdeck.v1.Empty
+	0,  // 62: streamdeck.v1.StreamDeck.ClearTouchStrip:output_type -> streamdeck.v1.Empty
+	23, // 63: streamdeck.v1.StreamDeck.Events:output_type -> streamdeck.v1.Event
+	48, // [48:64] is the sub-list for method output_type
+	32, // [32:48] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
+}
+
+func init() { file_streamdeck_proto_init() }
+func file_streamdeck_proto_init() {
+	if File_streamdeck_proto != nil {
+		return
+	}
+	file_streamdeck_proto_msgTypes[22].OneofWrappers = []any{
+		(*Command_SetBrightness)(nil),
+		(*Command_SetKeyImage)(nil),
+		(*Command_SetKeyColor)(nil),
+		(*Command_SetKeyText)(nil),
+		(*Command_ClearKey)(nil),
+		(*Command_SetInfoBarImage)(nil),
+		(*Command_SetTouchPointColor)(nil),
+		(*Command_SetTouchStripImage)(nil),
+	}
+	file_streamdeck_proto_msgTypes[23].OneofWrappers = []any{
+		(*Event_KeyPress)(nil),
+		(*Event_KeyRelease)(nil),
+		(*Event_TouchPointPress)(nil),
+		(*Event_TouchPointRelease)(nil),
+		(*Event_DialPress)(nil),
+		(*Event_DialRelease)(nil),
+		(*Event_DialRotate)(nil),
+		(*Event_TouchStripTouch)(nil),
+		(*Event_TouchStripSwipe)(nil),
+		(*Event_Result)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_streamdeck_proto_rawDesc), len(file_streamdeck_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   30,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_streamdeck_proto_goTypes,
+		DependencyIndexes: file_streamdeck_proto_depIdxs,
+		MessageInfos:      file_streamdeck_proto_msgTypes,
+	}.Build()
+	File_streamdeck_proto = out.File
+	file_streamdeck_proto_goTypes = nil
+	file_streamdeck_proto_depIdxs = nil
+}
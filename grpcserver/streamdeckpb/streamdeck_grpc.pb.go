@@ -0,0 +1,702 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Service definition for remote control of an Elgato Stream Deck device.
+//
+// Keys, touch points and dials are numbered starting from 1. Images are
+// encoded in any of the formats supported by the streamdeck package (BMP,
+// GIF, JPEG, PNG, WebP or SVG), and scaled as needed.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: streamdeck.proto
+
+package streamdeckpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StreamDeck_GetDevice_FullMethodName          = "/streamdeck.v1.StreamDeck/GetDevice"
+	StreamDeck_SetBrightness_FullMethodName      = "/streamdeck.v1.StreamDeck/SetBrightness"
+	StreamDeck_GetBrightness_FullMethodName      = "/streamdeck.v1.StreamDeck/GetBrightness"
+	StreamDeck_Reset_FullMethodName              = "/streamdeck.v1.StreamDeck/Reset"
+	StreamDeck_SetKeyImage_FullMethodName        = "/streamdeck.v1.StreamDeck/SetKeyImage"
+	StreamDeck_SetKeyColor_FullMethodName        = "/streamdeck.v1.StreamDeck/SetKeyColor"
+	StreamDeck_SetKeyText_FullMethodName         = "/streamdeck.v1.StreamDeck/SetKeyText"
+	StreamDeck_ClearKey_FullMethodName           = "/streamdeck.v1.StreamDeck/ClearKey"
+	StreamDeck_SetInfoBarImage_FullMethodName    = "/streamdeck.v1.StreamDeck/SetInfoBarImage"
+	StreamDeck_SetInfoBarText_FullMethodName     = "/streamdeck.v1.StreamDeck/SetInfoBarText"
+	StreamDeck_ClearInfoBar_FullMethodName       = "/streamdeck.v1.StreamDeck/ClearInfoBar"
+	StreamDeck_SetTouchPointColor_FullMethodName = "/streamdeck.v1.StreamDeck/SetTouchPointColor"
+	StreamDeck_ClearTouchPoint_FullMethodName    = "/streamdeck.v1.StreamDeck/ClearTouchPoint"
+	StreamDeck_SetTouchStripImage_FullMethodName = "/streamdeck.v1.StreamDeck/SetTouchStripImage"
+	StreamDeck_ClearTouchStrip_FullMethodName    = "/streamdeck.v1.StreamDeck/ClearTouchStrip"
+	StreamDeck_Events_FullMethodName             = "/streamdeck.v1.StreamDeck/Events"
+)
+
+// StreamDeckClient is the client API for StreamDeck service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreamDeckClient interface {
+	GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error)
+	SetBrightness(ctx context.Context, in *SetBrightnessRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetBrightness(ctx context.Context, in *GetBrightnessRequest, opts ...grpc.CallOption) (*Brightness, error)
+	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetKeyImage(ctx context.Context, in *SetKeyImageRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetKeyColor(ctx context.Context, in *SetKeyColorRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetKeyText(ctx context.Context, in *SetKeyTextRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearKey(ctx context.Context, in *ClearKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetInfoBarImage(ctx context.Context, in *SetInfoBarImageRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetInfoBarText(ctx context.Context, in *SetInfoBarTextRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearInfoBar(ctx context.Context, in *ClearInfoBarRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetTouchPointColor(ctx context.Context, in *SetTouchPointColorRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearTouchPoint(ctx context.Context, in *ClearTouchPointRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetTouchStripImage(ctx context.Context, in *SetTouchStripImageRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearTouchStrip(ctx context.Context, in *ClearTouchStripRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Events streams the input events of the device. Commands sent by the
+	// client through the same stream are applied in order, and answered with
+	// a result event carrying the command identifier.
+	Events(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Command, Event], error)
+}
+
+type streamDeckClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreamDeckClient(cc grpc.ClientConnInterface) StreamDeckClient {
+	return &streamDeckClient{cc}
+}
+
+func (c *streamDeckClient) GetDevice(ctx context.Context, in *GetDeviceRequest, opts ...grpc.CallOption) (*Device, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Device)
+	err := c.cc.Invoke(ctx, StreamDeck_GetDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetBrightness(ctx context.Context, in *SetBrightnessRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetBrightness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) GetBrightness(ctx context.Context, in *GetBrightnessRequest, opts ...grpc.CallOption) (*Brightness, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Brightness)
+	err := c.cc.Invoke(ctx, StreamDeck_GetBrightness_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_Reset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetKeyImage(ctx context.Context, in *SetKeyImageRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetKeyImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetKeyColor(ctx context.Context, in *SetKeyColorRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetKeyColor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetKeyText(ctx context.Context, in *SetKeyTextRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetKeyText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) ClearKey(ctx context.Context, in *ClearKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_ClearKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetInfoBarImage(ctx context.Context, in *SetInfoBarImageRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetInfoBarImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetInfoBarText(ctx context.Context, in *SetInfoBarTextRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetInfoBarText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) ClearInfoBar(ctx context.Context, in *ClearInfoBarRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_ClearInfoBar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetTouchPointColor(ctx context.Context, in *SetTouchPointColorRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetTouchPointColor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) ClearTouchPoint(ctx context.Context, in *ClearTouchPointRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_ClearTouchPoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) SetTouchStripImage(ctx context.Context, in *SetTouchStripImageRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_SetTouchStripImage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) ClearTouchStrip(ctx context.Context, in *ClearTouchStripRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, StreamDeck_ClearTouchStrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streamDeckClient) Events(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[Command, Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StreamDeck_ServiceDesc.Streams[0], StreamDeck_Events_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Command, Event]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StreamDeck_EventsClient = grpc.BidiStreamingClient[Command, Event]
+
+// StreamDeckServer is the server API for StreamDeck service.
+// All implementations must embed UnimplementedStreamDeckServer
+// for forward compatibility.
+type StreamDeckServer interface {
+	GetDevice(context.Context, *GetDeviceRequest) (*Device, error)
+	SetBrightness(context.Context, *SetBrightnessRequest) (*Empty, error)
+	GetBrightness(context.Context, *GetBrightnessRequest) (*Brightness, error)
+	Reset(context.Context, *ResetRequest) (*Empty, error)
+	SetKeyImage(context.Context, *SetKeyImageRequest) (*Empty, error)
+	SetKeyColor(context.Context, *SetKeyColorRequest) (*Empty, error)
+	SetKeyText(context.Context, *SetKeyTextRequest) (*Empty, error)
+	ClearKey(context.Context, *ClearKeyRequest) (*Empty, error)
+	SetInfoBarImage(context.Context, *SetInfoBarImageRequest) (*Empty, error)
+	SetInfoBarText(context.Context, *SetInfoBarTextRequest) (*Empty, error)
+	ClearInfoBar(context.Context, *ClearInfoBarRequest) (*Empty, error)
+	SetTouchPointColor(context.Context, *SetTouchPointColorRequest) (*Empty, error)
+	ClearTouchPoint(context.Context, *ClearTouchPointRequest) (*Empty, error)
+	SetTouchStripImage(context.Context, *SetTouchStripImageRequest) (*Empty, error)
+	ClearTouchStrip(context.Context, *ClearTouchStripRequest) (*Empty, error)
+	// Events streams the input events of the device. Commands sent by the
+	// client through the same stream are applied in order, and answered with
+	// a result event carrying the command identifier.
+	Events(grpc.BidiStreamingServer[Command, Event]) error
+	mustEmbedUnimplementedStreamDeckServer()
+}
+
+// UnimplementedStreamDeckServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStreamDeckServer struct{}
+
+func (UnimplementedStreamDeckServer) GetDevice(context.Context, *GetDeviceRequest) (*Device, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDevice not implemented")
+}
+func (UnimplementedStreamDeckServer) SetBrightness(context.Context, *SetBrightnessRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetBrightness not implemented")
+}
+func (UnimplementedStreamDeckServer) GetBrightness(context.Context, *GetBrightnessRequest) (*Brightness, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBrightness not implemented")
+}
+func (UnimplementedStreamDeckServer) Reset(context.Context, *ResetRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method Reset not implemented")
+}
+func (UnimplementedStreamDeckServer) SetKeyImage(context.Context, *SetKeyImageRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetKeyImage not implemented")
+}
+func (UnimplementedStreamDeckServer) SetKeyColor(context.Context, *SetKeyColorRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetKeyColor not implemented")
+}
+func (UnimplementedStreamDeckServer) SetKeyText(context.Context, *SetKeyTextRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetKeyText not implemented")
+}
+func (UnimplementedStreamDeckServer) ClearKey(context.Context, *ClearKeyRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearKey not implemented")
+}
+func (UnimplementedStreamDeckServer) SetInfoBarImage(context.Context, *SetInfoBarImageRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetInfoBarImage not implemented")
+}
+func (UnimplementedStreamDeckServer) SetInfoBarText(context.Context, *SetInfoBarTextRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetInfoBarText not implemented")
+}
+func (UnimplementedStreamDeckServer) ClearInfoBar(context.Context, *ClearInfoBarRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearInfoBar not implemented")
+}
+func (UnimplementedStreamDeckServer) SetTouchPointColor(context.Context, *SetTouchPointColorRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTouchPointColor not implemented")
+}
+func (UnimplementedStreamDeckServer) ClearTouchPoint(context.Context, *ClearTouchPointRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearTouchPoint not implemented")
+}
+func (UnimplementedStreamDeckServer) SetTouchStripImage(context.Context, *SetTouchStripImageRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTouchStripImage not implemented")
+}
+func (UnimplementedStreamDeckServer) ClearTouchStrip(context.Context, *ClearTouchStripRequest) (*Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearTouchStrip not implemented")
+}
+func (UnimplementedStreamDeckServer) Events(grpc.BidiStreamingServer[Command, Event]) error {
+	return status.Error(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedStreamDeckServer) mustEmbedUnimplementedStreamDeckServer() {}
+func (UnimplementedStreamDeckServer) testEmbeddedByValue()                    {}
+
+// UnsafeStreamDeckServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreamDeckServer will
+// result in compilation errors.
+type UnsafeStreamDeckServer interface {
+	mustEmbedUnimplementedStreamDeckServer()
+}
+
+func RegisterStreamDeckServer(s grpc.ServiceRegistrar, srv StreamDeckServer) {
+	// If the following call panics, it indicates UnimplementedStreamDeckServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StreamDeck_ServiceDesc, srv)
+}
+
+func _StreamDeck_GetDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).GetDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_GetDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).GetDevice(ctx, req.(*GetDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetBrightness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBrightnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetBrightness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetBrightness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetBrightness(ctx, req.(*SetBrightnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_GetBrightness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBrightnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).GetBrightness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_GetBrightness_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).GetBrightness(ctx, req.(*GetBrightnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetKeyImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetKeyImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetKeyImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetKeyImage(ctx, req.(*SetKeyImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetKeyColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetKeyColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetKeyColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetKeyColor(ctx, req.(*SetKeyColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetKeyText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetKeyTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetKeyText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetKeyText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetKeyText(ctx, req.(*SetKeyTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_ClearKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).ClearKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_ClearKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).ClearKey(ctx, req.(*ClearKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetInfoBarImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInfoBarImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetInfoBarImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetInfoBarImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetInfoBarImage(ctx, req.(*SetInfoBarImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetInfoBarText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetInfoBarTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetInfoBarText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetInfoBarText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetInfoBarText(ctx, req.(*SetInfoBarTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_ClearInfoBar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearInfoBarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).ClearInfoBar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_ClearInfoBar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).ClearInfoBar(ctx, req.(*ClearInfoBarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetTouchPointColor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTouchPointColorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetTouchPointColor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetTouchPointColor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetTouchPointColor(ctx, req.(*SetTouchPointColorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_ClearTouchPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearTouchPointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).ClearTouchPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_ClearTouchPoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).ClearTouchPoint(ctx, req.(*ClearTouchPointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_SetTouchStripImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTouchStripImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).SetTouchStripImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_SetTouchStripImage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).SetTouchStripImage(ctx, req.(*SetTouchStripImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_ClearTouchStrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearTouchStripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreamDeckServer).ClearTouchStrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StreamDeck_ClearTouchStrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreamDeckServer).ClearTouchStrip(ctx, req.(*ClearTouchStripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreamDeck_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StreamDeckServer).Events(&grpc.GenericServerStream[Command, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StreamDeck_EventsServer = grpc.BidiStreamingServer[Command, Event]
+
+// StreamDeck_ServiceDesc is the grpc.ServiceDesc for StreamDeck service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreamDeck_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streamdeck.v1.StreamDeck",
+	HandlerType: (*StreamDeckServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDevice",
+			Handler:    _StreamDeck_GetDevice_Handler,
+		},
+		{
+			MethodName: "SetBrightness",
+			Handler:    _StreamDeck_SetBrightness_Handler,
+		},
+		{
+			MethodName: "GetBrightness",
+			Handler:    _StreamDeck_GetBrightness_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _StreamDeck_Reset_Handler,
+		},
+		{
+			MethodName: "SetKeyImage",
+			Handler:    _StreamDeck_SetKeyImage_Handler,
+		},
+		{
+			MethodName: "SetKeyColor",
+			Handler:    _StreamDeck_SetKeyColor_Handler,
+		},
+		{
+			MethodName: "SetKeyText",
+			Handler:    _StreamDeck_SetKeyText_Handler,
+		},
+		{
+			MethodName: "ClearKey",
+			Handler:    _StreamDeck_ClearKey_Handler,
+		},
+		{
+			MethodName: "SetInfoBarImage",
+			Handler:    _StreamDeck_SetInfoBarImage_Handler,
+		},
+		{
+			MethodName: "SetInfoBarText",
+			Handler:    _StreamDeck_SetInfoBarText_Handler,
+		},
+		{
+			MethodName: "ClearInfoBar",
+			Handler:    _StreamDeck_ClearInfoBar_Handler,
+		},
+		{
+			MethodName: "SetTouchPointColor",
+			Handler:    _StreamDeck_SetTouchPointColor_Handler,
+		},
+		{
+			MethodName: "ClearTouchPoint",
+			Handler:    _StreamDeck_ClearTouchPoint_Handler,
+		},
+		{
+			MethodName: "SetTouchStripImage",
+			Handler:    _StreamDeck_SetTouchStripImage_Handler,
+		},
+		{
+			MethodName: "ClearTouchStrip",
+			Handler:    _StreamDeck_ClearTouchStrip_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Events",
+			Handler:       _StreamDeck_Events_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "streamdeck.proto",
+}
@@ -0,0 +1,566 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package homeassistant binds Elgato Stream Deck keys and dials to Home
+// Assistant entities, through the Home Assistant WebSocket API.
+//
+// Keys bound to entities display the entity name and state, with a
+// background color, and optionally an icon, following the live entity
+// state, and toggle the entity when pressed. Dials bound to lights adjust
+// their brightness, and dials bound to climate entities adjust their target
+// temperature, and toggle the entity when pressed. Dial values are
+// displayed in the touch strip segment of the dial, on supported models.
+//
+//	c, err := homeassistant.Dial("ws://homeassistant.local:8123/api/websocket", token)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer c.Close()
+//
+//	c.BindKeyToEntity(dev, streamdeck.KEY_1, "light.kitchen")
+//	c.BindDialToEntity(dev, streamdeck.DIAL_1, "climate.living_room")
+//
+// Inputs are only handled while the application calls Device.Listen.
+package homeassistant
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the homeassistant package.
+var (
+	ErrAuthenticationFailed = errors.New("homeassistant: authentication failed")
+	ErrClientClosed         = errors.New("homeassistant: client is closed")
+	ErrEntityInvalid        = errors.New("homeassistant: entity is not valid")
+	ErrEntityNotSupported   = errors.New("homeassistant: entity is not supported")
+	ErrProtocol             = errors.New("homeassistant: protocol error")
+	ErrRequestFailed        = errors.New("homeassistant: request failed")
+)
+
+const (
+	handshakeTimeout = 5 * time.Second
+
+	// brightnessStep is the light brightness change, in percent, for each
+	// dial rotation step.
+	brightnessStep = 5
+
+	// temperatureStep is the temperature change for each dial rotation step,
+	// used if the entity does not define target_temp_step.
+	temperatureStep = 0.5
+)
+
+var (
+	colorOn          = color.RGBA{R: 0xf0, G: 0xb0, B: 0x20, A: 0xff}
+	colorOff         = color.RGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xff}
+	colorUnavailable = color.RGBA{R: 0x60, G: 0x10, B: 0x10, A: 0xff}
+)
+
+// State represents the state of a Home Assistant entity.
+type State struct {
+	EntityID   string         `json:"entity_id"`
+	State      string         `json:"state"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// GetName returns the friendly name of the entity, falling back to the
+// entity identifier.
+func (s *State) GetName() string {
+	if name, ok := s.Attributes["friendly_name"].(string); ok && name != "" {
+		return name
+	}
+	return s.EntityID
+}
+
+func (s *State) number(attr string) (float64, bool) {
+	v, ok := s.Attributes[attr].(float64)
+	return v, ok
+}
+
+type message struct {
+	ID      int64           `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Success bool            `json:"success,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+	Event *struct {
+		EventType string `json:"event_type"`
+		Data      struct {
+			EntityID string `json:"entity_id"`
+			NewState *State `json:"new_state"`
+		} `json:"data"`
+	} `json:"event,omitempty"`
+}
+
+// Binding represents an Elgato Stream Deck key or dial bound to a Home
+// Assistant entity. Errors drawing state changes received from Home Assistant
+// are reported with Device.ReportError.
+type Binding struct {
+	client   *Client
+	dev      *streamdeck.Device
+	entityID string
+	key      streamdeck.KeyID
+	dial     streamdeck.DialID
+	regs     []*streamdeck.HandlerRegistration
+
+	icons map[string]image.Image
+}
+
+// SetStateIcon sets an icon displayed by a key binding while the entity is
+// in the given state, like "on" or "off". A nil icon removes the icon of the
+// state.
+func (b *Binding) SetStateIcon(state string, icon image.Image) {
+	b.client.mtx.Lock()
+	if icon == nil {
+		delete(b.icons, state)
+	} else {
+		b.icons[state] = icon
+	}
+	b.client.mtx.Unlock()
+
+	b.client.render(b)
+}
+
+// Remove unregisters the input handlers of the Binding, and stops updating
+// the display. The display is not cleared.
+func (b *Binding) Remove() {
+	b.client.mtx.Lock()
+	delete(b.client.bindings, b)
+	b.client.mtx.Unlock()
+
+	for _, reg := range b.regs {
+		reg.Remove()
+	}
+}
+
+func (b *Binding) render(s *State, icon image.Image) error {
+	opts := streamdeck.TextOptions{
+		Foreground: color.White,
+		Background: colorUnavailable,
+		Icon:       icon,
+	}
+
+	label := b.entityID
+	if s != nil {
+		label = s.GetName()
+		switch s.State {
+		case "on", "heat", "cool", "heat_cool", "auto", "dry", "fan_only", "open", "playing":
+			opts.Background = colorOn
+		case "off", "closed", "idle", "paused", "standby":
+			opts.Background = colorOff
+		case "unavailable", "unknown":
+		default:
+			opts.Background = colorOff
+			label += "\n" + s.State
+		}
+	}
+
+	if b.dial == 0 {
+		return b.dev.SetKeyTextWithOptions(b.key, label, opts)
+	}
+
+	if !b.dev.GetTouchStripSupported() {
+		return nil
+	}
+	if s != nil {
+		label += "\n" + dialValue(s)
+	}
+	opts.Icon = nil
+	return b.dev.SetTouchStripSegmentText(b.dial, label, opts)
+}
+
+func dialValue(s *State) string {
+	switch domain(s.EntityID) {
+	case "light":
+		if s.State != "on" {
+			return s.State
+		}
+		if v, ok := s.number("brightness"); ok {
+			return fmt.Sprintf("%d%%", int(v*100/255+0.5))
+		}
+		return s.State
+
+	case "climate":
+		if v, ok := s.number("temperature"); ok {
+			return fmt.Sprintf("%.1f°", v)
+		}
+	}
+	return s.State
+}
+
+func domain(entityID string) string {
+	d, _, _ := strings.Cut(entityID, ".")
+	return d
+}
+
+// Client is a connection to the Home Assistant WebSocket API.
+type Client struct {
+	ws   *websocket.Conn
+	done chan struct{}
+	once sync.Once
+
+	mtx      sync.Mutex
+	nextID   int64
+	pending  map[int64]chan *message
+	bindings map[*Binding]struct{}
+	states   map[string]*State
+}
+
+// Dial connects to the Home Assistant WebSocket API, like
+// "ws://homeassistant.local:8123/api/websocket", and authenticates with a
+// long-lived access token.
+func Dial(url string, token string) (*Client, error) {
+	ws, err := websocket.Dial(url, "", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authenticate(ws, token); err != nil {
+		ws.Close()
+		return nil, err
+	}
+
+	rv := &Client{
+		ws:       ws,
+		done:     make(chan struct{}),
+		pending:  map[int64]chan *message{},
+		bindings: map[*Binding]struct{}{},
+		states:   map[string]*State{},
+	}
+	go rv.receive()
+
+	if err := rv.request(map[string]any{"type": "subscribe_events", "event_type": "state_changed"}, nil); err != nil {
+		rv.Close()
+		return nil, err
+	}
+
+	states := []*State{}
+	if err := rv.request(map[string]any{"type": "get_states"}, &states); err != nil {
+		rv.Close()
+		return nil, err
+	}
+
+	rv.mtx.Lock()
+	for _, s := range states {
+		rv.states[s.EntityID] = s
+	}
+	rv.mtx.Unlock()
+	return rv, nil
+}
+
+func authenticate(ws *websocket.Conn, token string) error {
+	if err := ws.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+
+	msg := message{}
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		return err
+	}
+	if msg.Type != "auth_required" {
+		return fmt.Errorf("%w: unexpected message: %s", ErrProtocol, msg.Type)
+	}
+
+	if err := websocket.JSON.Send(ws, map[string]string{"type": "auth", "access_token": token}); err != nil {
+		return err
+	}
+
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		return err
+	}
+	switch msg.Type {
+	case "auth_ok":
+	case "auth_invalid":
+		return ErrAuthenticationFailed
+	default:
+		return fmt.Errorf("%w: unexpected message: %s", ErrProtocol, msg.Type)
+	}
+	return ws.SetDeadline(time.Time{})
+}
+
+// Close closes the connection to Home Assistant, and unregisters the input
+// handlers of all the bindings. The displays are not cleared.
+func (c *Client) Close() error {
+	err := c.ws.Close()
+	c.shutdown()
+	return err
+}
+
+func (c *Client) shutdown() {
+	c.once.Do(func() {
+		close(c.done)
+
+		c.mtx.Lock()
+		bindings := c.bindings
+		c.bindings = map[*Binding]struct{}{}
+		c.mtx.Unlock()
+
+		for b := range bindings {
+			for _, reg := range b.regs {
+				reg.Remove()
+			}
+		}
+	})
+}
+
+func (c *Client) receive() {
+	defer c.shutdown()
+
+	for {
+		msg := &message{}
+		if err := websocket.JSON.Receive(c.ws, msg); err != nil {
+			return
+		}
+
+		switch msg.Type {
+		case "result":
+			c.mtx.Lock()
+			ch, found := c.pending[msg.ID]
+			delete(c.pending, msg.ID)
+			c.mtx.Unlock()
+
+			if found {
+				ch <- msg
+			}
+
+		case "event":
+			if msg.Event == nil || msg.Event.EventType != "state_changed" {
+				continue
+			}
+
+			entityID := msg.Event.Data.EntityID
+			c.mtx.Lock()
+			if msg.Event.Data.NewState == nil {
+				delete(c.states, entityID)
+			} else {
+				c.states[entityID] = msg.Event.Data.NewState
+			}
+			bindings := []*Binding{}
+			for b := range c.bindings {
+				if b.entityID == entityID {
+					bindings = append(bindings, b)
+				}
+			}
+			c.mtx.Unlock()
+
+			for _, b := range bindings {
+				c.render(b)
+			}
+		}
+	}
+}
+
+func (c *Client) render(b *Binding) {
+	c.mtx.Lock()
+	s := c.states[b.entityID]
+	var icon image.Image
+	if s != nil {
+		icon = b.icons[s.State]
+	}
+	c.mtx.Unlock()
+
+	if err := b.render(s, icon); err != nil {
+		b.dev.ReportError("homeassistant: failed to render binding", fmt.Errorf("homeassistant: %s: %w", b.entityID, err))
+	}
+}
+
+func (c *Client) request(cmd map[string]any, out any) error {
+	c.mtx.Lock()
+	c.nextID++
+	id := c.nextID
+	ch := make(chan *message, 1)
+	c.pending[id] = ch
+	c.mtx.Unlock()
+
+	defer func() {
+		c.mtx.Lock()
+		delete(c.pending, id)
+		c.mtx.Unlock()
+	}()
+
+	select {
+	case <-c.done:
+		return ErrClientClosed
+	default:
+	}
+
+	cmd["id"] = id
+	if err := websocket.JSON.Send(c.ws, cmd); err != nil {
+		return err
+	}
+
+	select {
+	case msg := <-ch:
+		if !msg.Success {
+			if msg.Error != nil {
+				return fmt.Errorf("%w: %s: %s: %s", ErrRequestFailed, cmd["type"], msg.Error.Code, msg.Error.Message)
+			}
+			return fmt.Errorf("%w: %s", ErrRequestFailed, cmd["type"])
+		}
+		if out != nil && len(msg.Result) > 0 {
+			if err := json.Unmarshal(msg.Result, out); err != nil {
+				return fmt.Errorf("%w: %w", ErrProtocol, err)
+			}
+		}
+		return nil
+
+	case <-c.done:
+		return ErrClientClosed
+	}
+}
+
+// GetState returns the last known state of an entity.
+func (c *Client) GetState(entityID string) (*State, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	s, found := c.states[entityID]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrEntityInvalid, entityID)
+	}
+	return s, nil
+}
+
+// CallService calls a Home Assistant service targeting an entity, like
+// "light.turn_on", with optional service data.
+func (c *Client) CallService(service string, entityID string, data map[string]any) error {
+	d, s, found := strings.Cut(service, ".")
+	if !found {
+		return fmt.Errorf("%w: service is not valid: %s", ErrRequestFailed, service)
+	}
+
+	cmd := map[string]any{
+		"type":    "call_service",
+		"domain":  d,
+		"service": s,
+		"target":  map[string]string{"entity_id": entityID},
+	}
+	if len(data) > 0 {
+		cmd["service_data"] = data
+	}
+	return c.request(cmd, nil)
+}
+
+// Toggle toggles an entity.
+func (c *Client) Toggle(entityID string) error {
+	return c.CallService("homeassistant.toggle", entityID, nil)
+}
+
+func (c *Client) adjust(entityID string, delta int8) error {
+	switch domain(entityID) {
+	case "light":
+		return c.CallService("light.turn_on", entityID, map[string]any{"brightness_step_pct": int(delta) * brightnessStep})
+
+	case "climate":
+		s, err := c.GetState(entityID)
+		if err != nil {
+			return err
+		}
+		temp, ok := s.number("temperature")
+		if !ok {
+			return fmt.Errorf("%w: no target temperature: %s", ErrEntityNotSupported, entityID)
+		}
+		step, ok := s.number("target_temp_step")
+		if !ok || step <= 0 {
+			step = temperatureStep
+		}
+		temp += float64(delta) * step
+		if v, ok := s.number("min_temp"); ok && temp < v {
+			temp = v
+		}
+		if v, ok := s.number("max_temp"); ok && temp > v {
+			temp = v
+		}
+		return c.CallService("climate.set_temperature", entityID, map[string]any{"temperature": temp})
+	}
+	return fmt.Errorf("%w: %s", ErrEntityNotSupported, entityID)
+}
+
+func (c *Client) bind(b *Binding, add func() ([]*streamdeck.HandlerRegistration, error)) (*Binding, error) {
+	if _, err := c.GetState(b.entityID); err != nil {
+		return nil, err
+	}
+
+	regs, err := add()
+	if err != nil {
+		for _, reg := range regs {
+			reg.Remove()
+		}
+		return nil, err
+	}
+	b.regs = regs
+
+	c.mtx.Lock()
+	c.bindings[b] = struct{}{}
+	c.mtx.Unlock()
+
+	select {
+	case <-c.done:
+		b.Remove()
+		return nil, ErrClientClosed
+	default:
+	}
+
+	c.render(b)
+	return b, nil
+}
+
+// BindKeyToEntity binds an Elgato Stream Deck key to an entity, toggling it
+// when the key is pressed. The key displays the entity name and state.
+func (c *Client) BindKeyToEntity(dev *streamdeck.Device, key streamdeck.KeyID, entityID string) (*Binding, error) {
+	b := &Binding{client: c, dev: dev, entityID: entityID, key: key, icons: map[string]image.Image{}}
+	return c.bind(b, func() ([]*streamdeck.HandlerRegistration, error) {
+		reg, err := dev.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+			return c.Toggle(entityID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []*streamdeck.HandlerRegistration{reg}, nil
+	})
+}
+
+// BindDialToEntity binds an Elgato Stream Deck dial to a light or climate
+// entity. Rotating the dial adjusts the light brightness or the target
+// temperature, and pressing the dial toggles the entity.
+func (c *Client) BindDialToEntity(dev *streamdeck.Device, dial streamdeck.DialID, entityID string) (*Binding, error) {
+	switch domain(entityID) {
+	case "light", "climate":
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrEntityNotSupported, entityID)
+	}
+
+	b := &Binding{client: c, dev: dev, entityID: entityID, dial: dial, icons: map[string]image.Image{}}
+	return c.bind(b, func() ([]*streamdeck.HandlerRegistration, error) {
+		rv := []*streamdeck.HandlerRegistration{}
+
+		reg, err := dev.AddDialRotateHandler(dial, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+			return c.adjust(entityID, delta)
+		})
+		if err != nil {
+			return rv, err
+		}
+		rv = append(rv, reg)
+
+		reg, err = dev.AddDialPressHandler(dial, func(d *streamdeck.Device, di *streamdeck.Dial) error {
+			return c.Toggle(entityID)
+		})
+		if err != nil {
+			return rv, err
+		}
+		return append(rv, reg), nil
+	})
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package homeassistant
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func waitColor(t *testing.T, img func() image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	for range 100 {
+		r, g, b, _ := img().At(x, y).RGBA()
+		if byte(r>>8) == want.R && byte(g>>8) == want.G && byte(b>>8) == want.B {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertColor(t, img(), x, y, want)
+}
+
+type serviceCall struct {
+	Domain      string            `json:"domain"`
+	Service     string            `json:"service"`
+	Target      map[string]string `json:"target"`
+	ServiceData map[string]any    `json:"service_data"`
+}
+
+type fakeServer struct {
+	mtx    sync.Mutex
+	states map[string]*State
+	calls  []serviceCall
+}
+
+func (f *fakeServer) getCalls() []serviceCall {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	return append([]serviceCall{}, f.calls...)
+}
+
+func (f *fakeServer) serve(ws *websocket.Conn) {
+	defer ws.Close()
+
+	if err := websocket.JSON.Send(ws, map[string]string{"type": "auth_required"}); err != nil {
+		return
+	}
+	auth := map[string]string{}
+	if err := websocket.JSON.Receive(ws, &auth); err != nil {
+		return
+	}
+	if auth["access_token"] != "token" {
+		websocket.JSON.Send(ws, map[string]string{"type": "auth_invalid"})
+		return
+	}
+	if err := websocket.JSON.Send(ws, map[string]string{"type": "auth_ok"}); err != nil {
+		return
+	}
+
+	subscription := int64(0)
+	for {
+		req := struct {
+			ID   int64  `json:"id"`
+			Type string `json:"type"`
+			serviceCall
+		}{}
+		if err := websocket.JSON.Receive(ws, &req); err != nil {
+			return
+		}
+
+		result := map[string]any{"id": req.ID, "type": "result", "success": true}
+
+		f.mtx.Lock()
+		switch req.Type {
+		case "subscribe_events":
+			subscription = req.ID
+
+		case "get_states":
+			states := []*State{}
+			for _, s := range f.states {
+				states = append(states, s)
+			}
+			result["result"] = states
+
+		case "call_service":
+			f.calls = append(f.calls, req.serviceCall)
+
+			s := f.states[req.Target["entity_id"]]
+			if req.Domain == "homeassistant" && req.Service == "toggle" && s != nil {
+				ns := &State{EntityID: s.EntityID, State: "on", Attributes: s.Attributes}
+				if s.State == "on" {
+					ns.State = "off"
+				}
+				f.states[s.EntityID] = ns
+				websocket.JSON.Send(ws, map[string]any{
+					"id":    subscription,
+					"type":  "event",
+					"event": map[string]any{"event_type": "state_changed", "data": map[string]any{"entity_id": s.EntityID, "new_state": ns}},
+				})
+			}
+
+		default:
+			result = map[string]any{"id": req.ID, "type": "result", "success": false, "error": map[string]string{"code": "unknown_command", "message": "Unknown command."}}
+		}
+		f.mtx.Unlock()
+
+		if err := websocket.JSON.Send(ws, result); err != nil {
+			return
+		}
+	}
+}
+
+func serve(t *testing.T) (*fakeServer, string) {
+	t.Helper()
+
+	f := &fakeServer{
+		states: map[string]*State{
+			"light.kitchen": {EntityID: "light.kitchen", State: "on", Attributes: map[string]any{"friendly_name": "Kitchen", "brightness": 128.0}},
+			"climate.living_room": {EntityID: "climate.living_room", State: "heat", Attributes: map[string]any{
+				"temperature": 21.0,
+				"max_temp":    21.5,
+			}},
+			"sensor.power": {EntityID: "sensor.power", State: "230"},
+		},
+	}
+	ts := httptest.NewServer(websocket.Server{Handler: f.serve})
+	t.Cleanup(ts.Close)
+	return f, "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+func TestDial(t *testing.T) {
+	_, url := serve(t)
+
+	if _, err := Dial(url, "bola"); !errors.Is(err, ErrAuthenticationFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	c, err := Dial(url, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s, err := c.GetState("light.kitchen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.State != "on" || s.GetName() != "Kitchen" {
+		t.Errorf("bad state: %+v", s)
+	}
+	if _, err := c.GetState("light.bola"); !errors.Is(err, ErrEntityInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := c.request(map[string]any{"type": "bola"}, nil); !errors.Is(err, ErrRequestFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Toggle("light.kitchen"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBindKeyToEntity(t *testing.T) {
+	f, url := serve(t)
+
+	c, err := Dial(url, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	b, err := c.BindKeyToEntity(dev, streamdeck.KEY_1, "light.kitchen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindKeyToEntity(dev, streamdeck.KEY_2, "sensor.power"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindKeyToEntity(dev, streamdeck.KEY_3, "light.bola"); !errors.Is(err, ErrEntityInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, colorOn)
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, colorOff)
+
+	icon := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := range icon.Pix {
+		icon.Pix[i] = 0xff
+	}
+	b.SetStateIcon("off", icon)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, func() image.Image { return m.KeyImage(streamdeck.KEY_1) }, 2, 2, colorOff)
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 20, color.RGBA{R: 0xff, G: 0xff, B: 0xff})
+
+	if calls := f.getCalls(); len(calls) != 1 || calls[0].Service != "toggle" || calls[0].Target["entity_id"] != "light.kitchen" {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
+
+func TestBindDialToEntity(t *testing.T) {
+	f, url := serve(t)
+
+	c, err := Dial(url, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if _, err := c.BindDialToEntity(dev, streamdeck.DIAL_1, "light.kitchen"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindDialToEntity(dev, streamdeck.DIAL_2, "climate.living_room"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindDialToEntity(dev, streamdeck.DIAL_3, "sensor.power"); !errors.Is(err, ErrEntityNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	rect, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.TouchStripImage(), rect.Min.X+2, rect.Min.Y+2, colorOn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	waitCalls := func(n int) {
+		for range 100 {
+			if len(f.getCalls()) == n {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := m.RotateDial(streamdeck.DIAL_1, -2); err != nil {
+		t.Fatal(err)
+	}
+	waitCalls(1)
+	if err := m.RotateDial(streamdeck.DIAL_2, 3); err != nil {
+		t.Fatal(err)
+	}
+	waitCalls(2)
+
+	calls := f.getCalls()
+	if len(calls) != 2 {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+	if calls[0].Service != "turn_on" || calls[0].ServiceData["brightness_step_pct"] != -10.0 {
+		t.Errorf("unexpected call: %+v", calls[0])
+	}
+	if calls[1].Service != "set_temperature" || calls[1].ServiceData["temperature"] != 21.5 {
+		t.Errorf("unexpected call: %+v", calls[1])
+	}
+}
@@ -0,0 +1,598 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package httpserver exposes an Elgato Stream Deck device through a REST API,
+// to bridge it to systems that speak HTTP but not USB HID, like home
+// automation servers.
+//
+// The Server implements http.Handler, with the following endpoints:
+//
+//	GET    /device               device information, as JSON
+//	GET    /snapshot             current contents of all displays, as PNG
+//	GET    /brightness           current brightness, as JSON
+//	PUT    /brightness           set brightness, from {"brightness": 60}
+//	PUT    /keys/{key}/image     set key image, from the multipart "image" file
+//	PUT    /keys/{key}/text      set key text, from {"text": "Play"}
+//	DELETE /keys/{key}           clear key
+//	GET    /events               input events, as server-sent events
+//
+// Keys are numbered starting from 1 for the top left key. Errors are
+// reported as {"error": "..."} JSON documents. SVG key images require an SVG
+// rasterizer registered with streamdeck.RegisterSVGRasterizer, e.g. by
+// importing the rafaelmartins.com/p/streamdeck/svg package.
+//
+// The JSON endpoints require the application/json content type. Requests
+// changing the device, sent by browsers from pages served by other hosts,
+// are rejected, unless their origins are allowed with
+// Options.AllowedOrigins, to protect the device from cross-site request
+// forgery.
+//
+// The Server does not listen to the device input. Events are only reported
+// while the application calls Device.Listen.
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the httpserver package.
+var (
+	ErrColorInvalid       = errors.New("httpserver: color is not valid")
+	ErrContentTypeInvalid = errors.New("httpserver: content type is not valid")
+	ErrOriginInvalid      = errors.New("httpserver: origin is not allowed")
+	ErrRequestInvalid     = errors.New("httpserver: request is not valid")
+)
+
+// maxImageSize is the maximum size of the images uploaded to keys.
+const maxImageSize = 10 << 20
+
+// subscriberBuffer is the number of events buffered for each subscriber.
+// Events are dropped for subscribers that do not keep up.
+const subscriberBuffer = 16
+
+// Point represents a point of the touch strip display.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Event represents an input event of the Elgato Stream Deck device, as sent
+// to the event subscribers. Only the fields relevant to the event type are
+// set.
+type Event struct {
+	// Type is the event type, one of key_press, key_release,
+	// touch_point_press, touch_point_release, dial_press, dial_release,
+	// dial_rotate, touch_strip_touch and touch_strip_swipe.
+	Type string `json:"type"`
+
+	Key        streamdeck.KeyID        `json:"key,omitempty"`
+	TouchPoint streamdeck.TouchPointID `json:"touch_point,omitempty"`
+	Dial       streamdeck.DialID       `json:"dial,omitempty"`
+
+	// Duration is the time the input was held, in milliseconds, for release
+	// events.
+	Duration int64 `json:"duration,omitempty"`
+
+	// Delta is the rotation delta, for dial rotate events.
+	Delta int8 `json:"delta,omitempty"`
+
+	// Long is set for long touches, for touch strip touch events.
+	Long bool `json:"long,omitempty"`
+
+	// Point is the touched point, for touch strip touch events, or the
+	// origin point, for touch strip swipe events.
+	Point *Point `json:"point,omitempty"`
+
+	// Destination is the destination point, for touch strip swipe events.
+	Destination *Point `json:"destination,omitempty"`
+}
+
+// Device represents the device information returned by the /device endpoint.
+type Device struct {
+	ModelName       string `json:"model_name"`
+	ModelID         string `json:"model_id"`
+	SerialNumber    string `json:"serial_number"`
+	FirmwareVersion string `json:"firmware_version,omitempty"`
+	KeyCount        byte   `json:"key_count"`
+	KeyColumns      int    `json:"key_columns"`
+	KeyRows         int    `json:"key_rows"`
+	TouchPointCount byte   `json:"touch_point_count"`
+	DialCount       byte   `json:"dial_count"`
+	InfoBar         bool   `json:"info_bar"`
+	TouchStrip      bool   `json:"touch_strip"`
+}
+
+// Brightness represents the body of the /brightness endpoint.
+type Brightness struct {
+	Brightness byte `json:"brightness"`
+}
+
+// Text represents the body of the /keys/{key}/text endpoint.
+type Text struct {
+	Text string `json:"text"`
+
+	// Foreground and Background are the text and background colors, in the
+	// #rgb or #rrggbb formats. If empty, default to white and black.
+	Foreground string `json:"foreground,omitempty"`
+	Background string `json:"background,omitempty"`
+
+	// Size is the font size, in points. If zero, the text is fitted to the
+	// key.
+	Size float64 `json:"size,omitempty"`
+}
+
+// Options represents the settings of a Server created with NewWithOptions.
+type Options struct {
+	// AllowedOrigins is the list of origins, like
+	// "https://dashboard.example.com", allowed to send requests changing the
+	// device in addition to the same host as the Server, or "*" to allow any
+	// origin. Requests without Origin and Sec-Fetch-Site headers, that are
+	// not sent by browsers, are always accepted.
+	AllowedOrigins []string
+}
+
+// Server is an http.Handler exposing an Elgato Stream Deck device through a
+// REST API.
+type Server struct {
+	device  *streamdeck.Device
+	mux     *http.ServeMux
+	origins []string
+	regs    []*streamdeck.HandlerRegistration
+	done    chan struct{}
+	once    sync.Once
+
+	mtx         sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// New creates a Server bound to an open Elgato Stream Deck device, and
+// registers the input handlers used to report events. Browser requests
+// changing the device are only accepted from the same host as the Server.
+func New(dev *streamdeck.Device) (*Server, error) {
+	return NewWithOptions(dev, Options{})
+}
+
+// NewWithOptions creates a Server bound to an open Elgato Stream Deck device,
+// with the given Options, and registers the input handlers used to report
+// events.
+func NewWithOptions(dev *streamdeck.Device, opts Options) (*Server, error) {
+	if dev == nil || !dev.IsOpen() {
+		return nil, fmt.Errorf("%w: %w", ErrRequestInvalid, streamdeck.ErrDeviceIsClosed)
+	}
+
+	rv := &Server{
+		device:      dev,
+		mux:         http.NewServeMux(),
+		done:        make(chan struct{}),
+		subscribers: map[chan Event]struct{}{},
+	}
+	for _, o := range opts.AllowedOrigins {
+		rv.origins = append(rv.origins, strings.ToLower(strings.TrimSuffix(o, "/")))
+	}
+
+	if err := rv.register(); err != nil {
+		rv.Close()
+		return nil, err
+	}
+
+	rv.mux.HandleFunc("GET /device", rv.handleDevice)
+	rv.mux.HandleFunc("GET /snapshot", rv.handleSnapshot)
+	rv.mux.HandleFunc("GET /brightness", rv.handleGetBrightness)
+	rv.mux.HandleFunc("PUT /brightness", rv.handleSetBrightness)
+	rv.mux.HandleFunc("PUT /keys/{key}/image", rv.handleKeyImage)
+	rv.mux.HandleFunc("PUT /keys/{key}/text", rv.handleKeyText)
+	rv.mux.HandleFunc("DELETE /keys/{key}", rv.handleClearKey)
+	rv.mux.HandleFunc("GET /events", rv.handleEvents)
+	return rv, nil
+}
+
+func (s *Server) register() error {
+	dev := s.device
+	add := func(reg *streamdeck.HandlerRegistration, err error) error {
+		if err != nil {
+			return err
+		}
+		s.regs = append(s.regs, reg)
+		return nil
+	}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if err := add(dev.AddKeyPressHandler(k, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			s.publish(Event{Type: "key_press", Key: key.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, key *streamdeck.Key, duration time.Duration) error {
+			s.publish(Event{Type: "key_release", Key: key.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		if err := add(dev.AddTouchPointPressHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint) error {
+			s.publish(Event{Type: "touch_point_press", TouchPoint: t.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddTouchPointReleaseHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint, duration time.Duration) error {
+			s.publish(Event{Type: "touch_point_release", TouchPoint: t.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachDial(func(di streamdeck.DialID) error {
+		if err := add(dev.AddDialPressHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial) error {
+			s.publish(Event{Type: "dial_press", Dial: dial.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddDialReleaseHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, duration time.Duration) error {
+			s.publish(Event{Type: "dial_release", Dial: dial.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddDialRotateHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, delta int8) error {
+			s.publish(Event{Type: "dial_rotate", Dial: dial.GetID(), Delta: delta})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if dev.GetTouchStripSupported() {
+		if err := add(dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+			s.publish(Event{Type: "touch_strip_touch", Long: t == streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, Point: &Point{X: p.X, Y: p.Y}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
+			s.publish(Event{Type: "touch_strip_swipe", Point: &Point{X: origin.X, Y: origin.Y}, Destination: &Point{X: destination.X, Y: destination.Y}})
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Server) publish(e Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (s *Server) subscribe() chan Event {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	rv := make(chan Event, subscriberBuffer)
+	s.subscribers[rv] = struct{}{}
+	return rv
+}
+
+func (s *Server) unsubscribe(ch chan Event) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	delete(s.subscribers, ch)
+}
+
+// ServeHTTP handles the requests of the REST API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+		if err := s.checkOrigin(r); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) checkOrigin(r *http.Request) error {
+	if slices.Contains(s.origins, "*") {
+		return nil
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		switch r.Header.Get("Sec-Fetch-Site") {
+		case "", "same-origin", "none":
+			return nil
+		}
+		return fmt.Errorf("%w: cross-site request", ErrOriginInvalid)
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%w: %s", ErrOriginInvalid, origin)
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return nil
+	}
+	o := (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+	if slices.Contains(s.origins, strings.ToLower(o)) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrOriginInvalid, o)
+}
+
+// Close unregisters the input handlers of the Server and ends the event
+// streams of the connected subscribers. The device is not closed.
+func (s *Server) Close() error {
+	s.once.Do(func() {
+		for _, reg := range s.regs {
+			reg.Remove()
+		}
+		close(s.done)
+	})
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrRequestInvalid),
+		errors.Is(err, ErrColorInvalid),
+		errors.Is(err, streamdeck.ErrKeyInvalid),
+		errors.Is(err, streamdeck.ErrImageInvalid),
+		errors.Is(err, image.ErrFormat):
+		status = http.StatusBadRequest
+
+	case errors.Is(err, ErrOriginInvalid):
+		status = http.StatusForbidden
+
+	case errors.Is(err, ErrContentTypeInvalid):
+		status = http.StatusUnsupportedMediaType
+
+	case errors.Is(err, streamdeck.ErrDeviceKeyDisplayNotSupported),
+		errors.Is(err, streamdeck.ErrDeviceBrightnessNotSupported):
+		status = http.StatusNotImplemented
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func readJSON(r *http.Request, v any) error {
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/json" {
+		return fmt.Errorf("%w: %q", ErrContentTypeInvalid, r.Header.Get("Content-Type"))
+	}
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(v); err != nil {
+		return fmt.Errorf("%w: %w", ErrRequestInvalid, err)
+	}
+	return nil
+}
+
+func parseKey(r *http.Request) (streamdeck.KeyID, error) {
+	v, err := strconv.ParseUint(r.PathValue("key"), 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w: %s", ErrRequestInvalid, streamdeck.ErrKeyInvalid, r.PathValue("key"))
+	}
+	return streamdeck.KeyID(v), nil
+}
+
+func parseColor(s string, def color.Color) (color.Color, error) {
+	if s == "" {
+		return def, nil
+	}
+
+	h, ok := strings.CutPrefix(s, "#")
+	if ok && len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if !ok || len(h) != 6 {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}, nil
+}
+
+func (s *Server) handleDevice(w http.ResponseWriter, r *http.Request) {
+	dev := s.device
+	rows, cols := dev.GetKeyLayout()
+	rv := Device{
+		ModelName:       dev.GetModelName(),
+		ModelID:         dev.GetModelID(),
+		SerialNumber:    dev.GetSerialNumber(),
+		KeyCount:        dev.GetKeyCount(),
+		KeyColumns:      cols,
+		KeyRows:         rows,
+		TouchPointCount: dev.GetTouchPointCount(),
+		DialCount:       dev.GetDialCount(),
+		InfoBar:         dev.GetInfoBarSupported(),
+		TouchStrip:      dev.GetTouchStripSupported(),
+	}
+	if v, err := dev.GetFirmwareVersion(); err == nil {
+		rv.FirmwareVersion = v
+	}
+	writeJSON(w, http.StatusOK, rv)
+}
+
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	img, err := s.device.RenderSnapshot()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	png.Encode(w, img)
+}
+
+func (s *Server) handleGetBrightness(w http.ResponseWriter, r *http.Request) {
+	b, err := s.device.GetBrightness()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, Brightness{Brightness: b})
+}
+
+func (s *Server) handleSetBrightness(w http.ResponseWriter, r *http.Request) {
+	req := Brightness{}
+	if err := readJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.device.SetBrightness(req.Brightness); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleKeyImage(w http.ResponseWriter, r *http.Request) {
+	key, err := parseKey(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageSize)
+	fp, hdr, err := r.FormFile("image")
+	if err != nil {
+		writeError(w, fmt.Errorf("%w: %w", ErrRequestInvalid, err))
+		return
+	}
+	defer fp.Close()
+
+	ct, _, _ := mime.ParseMediaType(hdr.Header.Get("Content-Type"))
+	if ct == "image/svg+xml" || strings.HasSuffix(strings.ToLower(hdr.Filename), ".svg") {
+		err = s.device.SetKeyImageFromSVG(key, fp)
+	} else {
+		err = s.device.SetKeyImageFromReader(key, fp)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleKeyText(w http.ResponseWriter, r *http.Request) {
+	key, err := parseKey(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	req := Text{}
+	if err := readJSON(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	fg, err := parseColor(req.Foreground, color.White)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	bg, err := parseColor(req.Background, color.Black)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.device.SetKeyTextWithOptions(key, req.Text, streamdeck.TextOptions{
+		Foreground: fg,
+		Background: bg,
+		Size:       req.Size,
+	}); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleClearKey(w http.ResponseWriter, r *http.Request) {
+	key, err := parseKey(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.device.ClearKey(key); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errors.New("httpserver: streaming is not supported"))
+		return
+	}
+
+	ch := s.subscribe()
+	defer s.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-s.done:
+			return
+
+		case e := <-ch:
+			data, err := json.Marshal(e)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
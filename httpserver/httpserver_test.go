@@ -0,0 +1,336 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package httpserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func request(t *testing.T, ts *httptest.Server, method string, path string, contentType string, body []byte) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, ts.URL+path, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	buf := &bytes.Buffer{}
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, buf.Bytes()
+}
+
+func newServer(t *testing.T, modelID string) (*streamdeck.Device, *mock.Device, *httptest.Server) {
+	t.Helper()
+
+	dev, m, err := mock.Open(modelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dev.Close() })
+
+	srv, err := New(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return dev, m, ts
+}
+
+func TestDeviceAndBrightness(t *testing.T) {
+	_, m, ts := newServer(t, "plus")
+
+	status, body := request(t, ts, http.MethodGet, "/device", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	info := Device{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.ModelID != "plus" || info.SerialNumber != "MOCKplus" || info.KeyCount != 8 || info.KeyColumns != 4 || info.KeyRows != 2 || info.DialCount != 4 || !info.TouchStrip {
+		t.Errorf("unexpected device: %+v", info)
+	}
+
+	status, body = request(t, ts, http.MethodPut, "/brightness", "application/json", []byte(`{"brightness": 42}`))
+	if status != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	if m.Brightness() != 42 {
+		t.Errorf("unexpected brightness: %d", m.Brightness())
+	}
+
+	status, body = request(t, ts, http.MethodGet, "/brightness", "", nil)
+	if status != http.StatusOK || strings.TrimSpace(string(body)) != `{"brightness":42}` {
+		t.Errorf("unexpected response: %d: %s", status, body)
+	}
+
+	status, body = request(t, ts, http.MethodPut, "/brightness", "application/json", []byte(`bola`))
+	if status != http.StatusBadRequest || !strings.Contains(string(body), `"error"`) {
+		t.Errorf("unexpected response: %d: %s", status, body)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	dev, m, ts := newServer(t, "mk2")
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for y := range 20 {
+		for x := range 20 {
+			img.Set(x, y, color.RGBA{R: 0xff, A: 0xff})
+		}
+	}
+
+	form := &bytes.Buffer{}
+	mw := multipart.NewWriter(form)
+	fw, err := mw.CreateFormFile("image", "red.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(fw, img); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := request(t, ts, http.MethodPut, "/keys/3/image", mw.FormDataContentType(), form.Bytes())
+	if status != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 36, 36, color.RGBA{R: 0xff, A: 0xff})
+
+	status, body = request(t, ts, http.MethodPut, "/keys/4/text", "application/json", []byte(`{"text": "Hi", "background": "#00f"}`))
+	if status != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_4), 1, 1, color.RGBA{B: 0xff, A: 0xff})
+
+	status, body = request(t, ts, http.MethodGet, "/snapshot", "", nil)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	snapshot, err := png.Decode(bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck, err := dev.GetDeckImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := snapshot.Bounds(); b.Dx() <= deck.Dx() || b.Dy() <= deck.Dy() {
+		t.Errorf("unexpected snapshot bounds: %s", b)
+	}
+
+	status, body = request(t, ts, http.MethodDelete, "/keys/3", "", nil)
+	if status != http.StatusNoContent {
+		t.Fatalf("unexpected status: %d: %s", status, body)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 36, 36, color.RGBA{A: 0xff})
+
+	for _, tc := range []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodDelete, "/keys/16", ""},
+		{http.MethodDelete, "/keys/bola", ""},
+		{http.MethodPut, "/keys/1/text", `{"text": "Hi", "foreground": "red"}`},
+		{http.MethodPut, "/keys/1/image", ""},
+	} {
+		status, body := request(t, ts, tc.method, tc.path, "application/json", []byte(tc.body))
+		if status != http.StatusBadRequest {
+			t.Errorf("unexpected response for %s %s: %d: %s", tc.method, tc.path, status, body)
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	dev, m, ts := newServer(t, "plus")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if l := sc.Text(); l != "" {
+				lines <- l
+			}
+		}
+	}()
+
+	next := func() string {
+		t.Helper()
+
+		select {
+		case l := <-lines:
+			return l
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+		return ""
+	}
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	if l := next(); l != "event: key_press" {
+		t.Errorf("unexpected line: %s", l)
+	}
+	if l := next(); l != `data: {"type":"key_press","key":2}` {
+		t.Errorf("unexpected line: %s", l)
+	}
+
+	if err := m.RotateDial(streamdeck.DIAL_3, -2); err != nil {
+		t.Fatal(err)
+	}
+	if l := next(); l != "event: dial_rotate" {
+		t.Errorf("unexpected line: %s", l)
+	}
+	if l := next(); l != `data: {"type":"dial_rotate","dial":3,"delta":-2}` {
+		t.Errorf("unexpected line: %s", l)
+	}
+
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, image.Pt(12, 34)); err != nil {
+		t.Fatal(err)
+	}
+	if l := next(); l != "event: touch_strip_touch" {
+		t.Errorf("unexpected line: %s", l)
+	}
+	if l := next(); l != `data: {"type":"touch_strip_touch","long":true,"point":{"x":12,"y":34}}` {
+		t.Errorf("unexpected line: %s", l)
+	}
+}
+
+func TestCrossSite(t *testing.T) {
+	dev, m, ts := newServer(t, "mk2")
+
+	status, body := request(t, ts, http.MethodPut, "/brightness", "text/plain", []byte(`{"brightness": 42}`))
+	if status != http.StatusUnsupportedMediaType || !strings.Contains(string(body), `"error"`) {
+		t.Errorf("unexpected response: %d: %s", status, body)
+	}
+
+	srv, err := NewWithOptions(dev, Options{AllowedOrigins: []string{"https://Dashboard.example.com/"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	allowed := httptest.NewServer(srv)
+	defer allowed.Close()
+
+	for _, tc := range []struct {
+		ts      *httptest.Server
+		headers map[string]string
+		allowed bool
+	}{
+		{ts, nil, true},
+		{ts, map[string]string{"Origin": ts.URL, "Sec-Fetch-Site": "same-origin"}, true},
+		{ts, map[string]string{"Sec-Fetch-Site": "none"}, true},
+		{ts, map[string]string{"Origin": "http://evil.example.com", "Sec-Fetch-Site": "cross-site"}, false},
+		{ts, map[string]string{"Origin": "null"}, false},
+		{ts, map[string]string{"Sec-Fetch-Site": "cross-site"}, false},
+		{allowed, map[string]string{"Origin": "https://dashboard.example.com", "Sec-Fetch-Site": "cross-site"}, true},
+		{allowed, map[string]string{"Origin": "http://dashboard.example.com"}, false},
+	} {
+		req, err := http.NewRequest(http.MethodPut, tc.ts.URL+"/brightness", strings.NewReader(`{"brightness": 42}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := tc.ts.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		want := http.StatusForbidden
+		if tc.allowed {
+			want = http.StatusNoContent
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%v: unexpected status: got %d, want %d", tc.headers, resp.StatusCode, want)
+		}
+	}
+
+	// reads are not protected, as their responses are not shared with other
+	// origins by the browsers.
+	req, err := http.NewRequest(http.MethodGet, ts.URL+"/brightness", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Origin", "http://evil.example.com")
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	if m.Brightness() != 42 {
+		t.Errorf("unexpected brightness: %d", m.Brightness())
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// builtinIcons are the paths of the icons of the "builtin" icon set, drawn
+// in a 24x24 view box.
+var builtinIcons = map[string]string{
+	"arrow-left":    "M20 11H7.83l5.59-5.59L12 4l-8 8 8 8 1.41-1.41L7.83 13H20v-2z",
+	"arrow-right":   "M4 11h12.17l-5.59-5.59L12 4l8 8-8 8-1.41-1.41L16.17 13H4v-2z",
+	"check":         "M9 16.2L4.8 12l-1.4 1.4L9 19 21 7l-1.4-1.4L9 16.2z",
+	"close":         "M19 6.41L17.59 5 12 10.59 6.41 5 5 6.41 10.59 12 5 17.59 6.41 19 12 13.41 17.59 19 19 17.59 13.41 12z",
+	"home":          "M10 20v-6h4v6h5v-8h3L12 3 2 12h3v8z",
+	"minus":         "M19 13H5v-2h14v2z",
+	"pause":         "M6 19h4V5H6v14zm8-14v14h4V5h-4z",
+	"play":          "M8 5v14l11-7z",
+	"plus":          "M19 13h-6v6h-2v-6H5v-2h6V5h2v6h6v2z",
+	"skip-next":     "M6 18l8.5-6L6 6v12zM16 6v12h2V6h-2z",
+	"skip-previous": "M6 6h2v12H6zm3.5 6l8.5 6V6z",
+	"stop":          "M6 6h12v12H6z",
+	"volume-high":   "M3 9v6h4l5 5V4L7 9H3zm13.5 3A4.5 4.5 0 0 0 14 7.97v8.05A4.5 4.5 0 0 0 16.5 12zM14 3.23v2.06a7 7 0 0 1 0 13.42v2.06A9 9 0 0 0 14 3.23z",
+	"volume-off":    "M3 9v6h4l5 5V4L7 9H3zm13.59 3L14 9.41 15.41 8 18 10.59 20.59 8 22 9.41 19.41 12 22 14.59 20.59 16 18 13.41 15.41 16 14 14.59z",
+}
+
+var (
+	iconSetsMtx sync.RWMutex
+	iconSets    = map[string]fs.FS{}
+)
+
+// RegisterIconSet adds a set of icons, available to SetKeyIcon and
+// RasterizeIcon with names in the form "prefix:icon". Each icon is read from
+// the "icon.svg" file of the file system, e.g. the svg directory of the
+// Material Design Icons distribution, registered with the "mdi" prefix,
+// provides the "mdi:volume-high" icon. The "builtin" prefix is reserved for
+// the icons included in the package.
+func RegisterIconSet(prefix string, fsys fs.FS) error {
+	if prefix == "" || prefix == "builtin" || strings.Contains(prefix, ":") || fsys == nil {
+		return fmt.Errorf("streamdeck: %w: icon set not valid: %q", ErrIconInvalid, prefix)
+	}
+
+	iconSetsMtx.Lock()
+	defer iconSetsMtx.Unlock()
+
+	iconSets[prefix] = fsys
+	return nil
+}
+
+func readIcon(name string) ([]byte, error) {
+	prefix, icon, found := strings.Cut(name, ":")
+	if !found || icon == "" {
+		return nil, fmt.Errorf("%w: %s", ErrIconInvalid, name)
+	}
+
+	if prefix == "builtin" {
+		path, found := builtinIcons[icon]
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrIconInvalid, name)
+		}
+		return []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="` + path + `"/></svg>`), nil
+	}
+
+	iconSetsMtx.RLock()
+	fsys, found := iconSets[prefix]
+	iconSetsMtx.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrIconInvalid, name)
+	}
+
+	rv, err := fs.ReadFile(fsys, icon+".svg")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrIconInvalid, name, err)
+	}
+	return rv, nil
+}
+
+// renderIcon rasterizes an icon to an image with the given geometry, painted
+// with the given color, over a transparent background.
+func renderIcon(name string, rect image.Rectangle, c color.Color) (*image.RGBA, error) {
+	data, err := readIcon(name)
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := renderSVG(bytes.NewReader(data), rect)
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil {
+		c = color.White
+	}
+	rv := image.NewRGBA(mask.Rect)
+	draw.DrawMask(rv, rv.Rect, image.NewUniform(c), image.Point{}, mask, image.Point{}, draw.Src)
+	return rv, nil
+}
+
+// RasterizeIcon rasterizes an icon from the built-in or registered icon sets
+// to an image of the given size, painted with the given color (white if nil).
+// The icon is scaled to fit the image, keeping its aspect ratio, and the
+// remaining area is transparent. Icons are SVG documents, and are drawn with
+// the rasterizer set with RegisterSVGRasterizer.
+func RasterizeIcon(name string, size image.Point, c color.Color) (*image.RGBA, error) {
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, wrapErr(ErrImageInvalid)
+	}
+
+	rv, err := renderIcon(name, image.Rectangle{Max: size}, c)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return rv, nil
+}
+
+// SetKeyIcon draws an icon from the built-in or registered icon sets to an
+// Elgato Stream Deck key background display, e.g. "builtin:play". The icon is
+// rasterized at the native resolution of the display, painted with the given
+// color (white if nil) over a black background.
+func (d *Device) SetKeyIcon(key KeyID, name string, c color.Color) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	icon, err := renderIcon(name, d.model.keyImageRect, c)
+	if err != nil {
+		return wrapErr(err)
+	}
+
+	img := image.NewRGBA(d.model.keyImageRect)
+	draw.Draw(img, img.Rect, image.Black, image.Point{}, draw.Src)
+	draw.Draw(img, img.Rect, icon, icon.Rect.Min, draw.Over)
+	return d.setKeyImage(key, img)
+}
+
+// SetKeyIconWithLabel draws an icon from the built-in or registered icon sets
+// to an Elgato Stream Deck key background display, painted with the given
+// color (white if nil), above a text label rendered with the given
+// TextOptions. The Icon text option is replaced by the icon.
+func (d *Device) SetKeyIconWithLabel(key KeyID, name string, c color.Color, label string, opts TextOptions) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	icon, err := renderIcon(name, d.model.keyImageRect, c)
+	if err != nil {
+		return wrapErr(err)
+	}
+
+	opts.Icon = icon
+	img, err := renderText(d.model.keyImageRect, label, d.textOptions(opts), d.GetAccessibilityOptions())
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setKeyImage(key, img)
+}
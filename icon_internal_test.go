@@ -0,0 +1,89 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"testing/fstest"
+)
+
+func TestRasterizeIcon(t *testing.T) {
+	setSVGRasterizer(t, testSVGRasterizer)
+
+	for name := range builtinIcons {
+		t.Run(name, func(t *testing.T) {
+			img, err := RasterizeIcon("builtin:"+name, image.Pt(72, 72), color.RGBA{R: 0xff, A: 0xff})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			painted := 0
+			for i := 0; i < len(img.Pix); i += 4 {
+				if img.Pix[i+3] == 0 {
+					continue
+				}
+				if img.Pix[i+1] != 0 || img.Pix[i+2] != 0 {
+					t.Fatalf("unexpected color at %d: %v", i/4, img.Pix[i:i+4])
+				}
+				painted++
+			}
+			if painted == 0 || painted == 72*72 {
+				t.Errorf("unexpected painted area: %d pixels", painted)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "play", "builtin:", "builtin:foo", "foo:play"} {
+		if _, err := RasterizeIcon(name, image.Pt(72, 72), nil); !errors.Is(err, ErrIconInvalid) {
+			t.Errorf("%q: unexpected error: %v", name, err)
+		}
+	}
+	if _, err := RasterizeIcon("builtin:play", image.Point{}, nil); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	RegisterSVGRasterizer(nil)
+	if _, err := RasterizeIcon("builtin:play", image.Pt(72, 72), nil); !errors.Is(err, ErrSVGNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegisterIconSet(t *testing.T) {
+	setSVGRasterizer(t, testSVGRasterizer)
+
+	fsys := fstest.MapFS{
+		"square.svg": &fstest.MapFile{
+			Data: []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24"><path d="M0 0h24v24H0z"/></svg>`),
+		},
+	}
+	for _, prefix := range []string{"", "builtin", "foo:bar"} {
+		if err := RegisterIconSet(prefix, fsys); !errors.Is(err, ErrIconInvalid) {
+			t.Errorf("%q: unexpected error: %v", prefix, err)
+		}
+	}
+	if err := RegisterIconSet("test", fsys); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		iconSetsMtx.Lock()
+		delete(iconSets, "test")
+		iconSetsMtx.Unlock()
+	})
+
+	img, err := RasterizeIcon("test:square", image.Pt(10, 10), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := img.RGBAAt(5, 5); c != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("unexpected color: %v", c)
+	}
+
+	if _, err := RasterizeIcon("test:circle", image.Pt(10, 10), nil); !errors.Is(err, ErrIconInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"image/color"
+	"maps"
+	"time"
+)
+
+const (
+	identifyFlashes  = 3
+	identifyInterval = 150 * time.Millisecond
+)
+
+// identifyFrame is the encoded payload of a solid color for each display.
+type identifyFrame struct {
+	key        []byte
+	infoBar    []byte
+	touchStrip []byte
+	color      color.Color
+}
+
+func (d *Device) newIdentifyFrame(c color.Color) (*identifyFrame, error) {
+	var err error
+	rv := &identifyFrame{color: c}
+	opts := d.GetImageOptions()
+
+	if d.model.keyImageSend != nil {
+		rv.key, err = genImage(&imageColor{c: c, b: d.model.keyImageRect}, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.model.infoBarImageSend != nil {
+		rv.infoBar, err = genImage(&imageColor{c: c, b: d.model.infoBarImageRect}, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if d.model.touchStripImageSend != nil {
+		rv.touchStrip, err = genImage(&imageColor{c: c, b: d.model.touchStripImageRect}, d.model.touchStripImageRect, d.model.touchStripImageFormat, d.model.touchStripImageTransform, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rv, nil
+}
+
+func (d *Device) drawIdentifyFrame(f *identifyFrame) error {
+	if f.key != nil {
+		if err := d.ForEachKey(func(key KeyID) error {
+			return d.sendKeyImage(key, f.key)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := d.ForEachTouchPoint(func(tp TouchPointID) error {
+		return d.SetTouchPointColor(tp, f.color)
+	}); err != nil {
+		return err
+	}
+
+	if f.infoBar != nil {
+		if err := d.sendInfoBarImage(f.infoBar); err != nil {
+			return err
+		}
+	}
+
+	if f.touchStrip != nil {
+		if err := d.sendTouchStripImage(f.touchStrip, d.model.touchStripImageRect); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Identify flashes all the displays and the touch points of the Elgato Stream
+// Deck device a few times, and then restores their contents, so that users
+// with several identical devices can tell which physical unit is which, e.g.
+// by serial number. It returns early, restoring the contents, if the context
+// is cancelled. Displays that were not drawn to since the device was opened
+// are left black, and images drawn to the displays while identifying are
+// replaced when restoring.
+func (d *Device) Identify(ctx context.Context) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if d.model.keyImageSend == nil && d.model.infoBarImageSend == nil && d.model.touchStripImageSend == nil && d.model.touchPointCount == 0 {
+		return wrapErr(ErrDeviceKeyDisplayNotSupported)
+	}
+
+	on, err := d.newIdentifyFrame(color.White)
+	if err != nil {
+		return wrapErr(err)
+	}
+	off, err := d.newIdentifyFrame(color.Black)
+	if err != nil {
+		return wrapErr(err)
+	}
+
+	// the flashes are written over the overlays, that must survive them
+	st := d.saveState()
+	d.overlays.mtx.Lock()
+	overlays := maps.Clone(d.overlays.keys)
+	d.overlays.mtx.Unlock()
+
+	var rv error
+flashes:
+	for range identifyFlashes {
+		for _, f := range []*identifyFrame{on, off} {
+			if err := d.drawIdentifyFrame(f); err != nil {
+				rv = err
+				break flashes
+			}
+
+			select {
+			case <-ctx.Done():
+				break flashes
+			case <-time.After(identifyInterval):
+			}
+		}
+	}
+
+	if err := d.drawIdentifyFrame(off); err != nil && rv == nil {
+		rv = err
+	}
+
+	d.overlays.mtx.Lock()
+	d.overlays.keys = overlays
+	d.overlays.mtx.Unlock()
+
+	if err := d.restoreState(st); err != nil && rv == nil {
+		rv = err
+	}
+	return rv
+}
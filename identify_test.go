@@ -0,0 +1,79 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestIdentify(t *testing.T) {
+	dev, m, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, red); err != nil {
+		t.Fatal(err)
+	}
+	badge := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(badge, badge.Rect, image.NewUniform(color.RGBA{G: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, badge, streamdeck.CORNER_TOP_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetTouchPointColor(streamdeck.TOUCH_POINT_1, red); err != nil {
+		t.Fatal(err)
+	}
+	m.ClearWrites()
+
+	if err := dev.Identify(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	flashes := 0
+	for _, w := range m.Writes() {
+		if w.Surface == mock.SURFACE_INFO_BAR {
+			r, g, b, _ := w.Image.At(10, 10).RGBA()
+			if r>>8 > 0xf0 && g>>8 > 0xf0 && b>>8 > 0xf0 {
+				flashes++
+			}
+		}
+	}
+	if flashes != 3 {
+		t.Errorf("unexpected flashes: %d", flashes)
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 50, 50, red)
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 50, 50, color.RGBA{})
+	assertColor(t, m.InfoBarImage(), 10, 10, color.RGBA{})
+	if c := m.TouchPointColor(streamdeck.TOUCH_POINT_1); c != color.Color(red) {
+		t.Errorf("unexpected touch point color: %v", c)
+	}
+
+	// overlays survive the flashes
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, nil, streamdeck.CORNER_TOP_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, red)
+
+	pedal, _, err := mock.Open("pedal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pedal.Close()
+
+	if err := pedal.Identify(context.Background()); !errors.Is(err, streamdeck.ErrDeviceKeyDisplayNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
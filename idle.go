@@ -0,0 +1,329 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image/color"
+	"sync"
+	"time"
+)
+
+const (
+	idleDimBrightness = 10
+	idleFrameRate     = 10
+)
+
+// IdleAction represents the action performed to the Elgato Stream Deck
+// device displays after no input was received for the idle timeout.
+type IdleAction byte
+
+// String returns a string representation of the IdleAction.
+func (a IdleAction) String() string {
+	switch a {
+	case IDLE_ACTION_DIM:
+		return "IDLE_ACTION_DIM"
+	case IDLE_ACTION_SLEEP:
+		return "IDLE_ACTION_SLEEP"
+	case IDLE_ACTION_ANIMATION:
+		return "IDLE_ACTION_ANIMATION"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck idle actions. These constants represent what happens to
+// the device displays while the device is idle.
+const (
+	IDLE_ACTION_DIM IdleAction = iota + 1
+	IDLE_ACTION_SLEEP
+	IDLE_ACTION_ANIMATION
+)
+
+// IdleOptions represents the settings of the Elgato Stream Deck device idle
+// monitor.
+type IdleOptions struct {
+	// Action is the action performed when the device becomes idle.
+	Action IdleAction
+
+	// SwallowWakeUp prevents the input that wakes the device up from being
+	// dispatched to the handlers.
+	SwallowWakeUp bool
+
+	// Animation is the frame function of the screensaver rendered across
+	// all the keys, as done by Device.SetDeckImage, while the device is idle.
+	// Required by IDLE_ACTION_ANIMATION.
+	Animation FrameFunc
+
+	// FrameRate is the frame rate of the screensaver animation. If zero,
+	// defaults to 10 frames per second.
+	FrameRate int
+}
+
+type idleMonitor struct {
+	timeout  time.Duration
+	opts     IdleOptions
+	mtx      sync.Mutex
+	idle     bool
+	activity chan chan struct{}
+	stop     chan bool
+	stopped  chan struct{}
+}
+
+// touch registers input activity, returning true if the device was idle. If
+// the device was idle, it blocks until the previous state is restored, so that
+// the handlers of the input are not affected by the restoration.
+func (m *idleMonitor) touch() bool {
+	m.mtx.Lock()
+	rv := m.idle
+	m.idle = false
+	m.mtx.Unlock()
+
+	if !rv {
+		select {
+		case m.activity <- nil:
+		default:
+		}
+		return false
+	}
+
+	ack := make(chan struct{})
+	select {
+	case m.activity <- ack:
+	case <-m.stopped:
+		return true
+	}
+
+	select {
+	case <-ack:
+	case <-m.stopped:
+	}
+	return true
+}
+
+// SetIdleTimeout enables an idle monitor that dims or blanks the Elgato
+// Stream Deck device displays, or renders a screensaver animation, after no
+// input was received for the given timeout. The previous state is restored
+// on the next input. Setting a zero timeout disables the idle monitor.
+//
+// Input is tracked by Listen and ListenContext, that must be running for the
+// device to wake up.
+func (d *Device) SetIdleTimeout(timeout time.Duration, opts IdleOptions) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if timeout > 0 {
+		switch opts.Action {
+		case IDLE_ACTION_DIM, IDLE_ACTION_SLEEP:
+			if d.model.brightness == nil {
+				return wrapErr(ErrDeviceBrightnessNotSupported)
+			}
+
+		case IDLE_ACTION_ANIMATION:
+			if err := d.validateKeyDisplay(); err != nil {
+				return err
+			}
+			if opts.Animation == nil {
+				return wrapErr(ErrAnimationInvalid)
+			}
+
+		default:
+			return wrapErr(ErrIdleActionInvalid)
+		}
+	}
+
+	d.stopIdle(true)
+	if timeout <= 0 {
+		return nil
+	}
+
+	m := &idleMonitor{
+		timeout:  timeout,
+		opts:     opts,
+		activity: make(chan chan struct{}, 1),
+		stop:     make(chan bool),
+		stopped:  make(chan struct{}),
+	}
+
+	d.mtx.Lock()
+	d.idle = m
+	done := d.done
+	d.mtx.Unlock()
+
+	go d.runIdle(m, done)
+	return nil
+}
+
+// IsIdle returns true if the Elgato Stream Deck device idle monitor detected
+// that the device is idle.
+func (d *Device) IsIdle() bool {
+	d.mtx.Lock()
+	m := d.idle
+	d.mtx.Unlock()
+
+	if m == nil {
+		return false
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.idle
+}
+
+// idleActivity registers input activity to the idle monitor, returning true
+// if the input must be swallowed.
+func (d *Device) idleActivity() bool {
+	d.mtx.Lock()
+	m := d.idle
+	d.mtx.Unlock()
+
+	if m == nil {
+		return false
+	}
+	return m.touch() && m.opts.SwallowWakeUp
+}
+
+func (d *Device) stopIdle(restore bool) {
+	d.mtx.Lock()
+	m := d.idle
+	d.idle = nil
+	d.mtx.Unlock()
+
+	if m != nil {
+		m.stop <- restore
+		<-m.stopped
+	}
+}
+
+func (d *Device) runIdle(m *idleMonitor, done chan struct{}) {
+	defer close(m.stopped)
+
+	timer := time.NewTimer(m.timeout)
+	defer timer.Stop()
+
+	var (
+		state  *savedState
+		frames <-chan time.Time
+		ticker *time.Ticker
+		start  time.Time
+	)
+
+	sleep := func() error {
+		switch m.opts.Action {
+		case IDLE_ACTION_DIM:
+			return d.applyBrightness(min(d.lastBrightness(), idleDimBrightness))
+
+		case IDLE_ACTION_SLEEP:
+			return d.applyBrightness(0)
+
+		case IDLE_ACTION_ANIMATION:
+			state = d.saveState()
+			fps := m.opts.FrameRate
+			if fps <= 0 {
+				fps = idleFrameRate
+			}
+			ticker = time.NewTicker(time.Second / time.Duration(fps))
+			frames = ticker.C
+			start = time.Now()
+		}
+		return nil
+	}
+
+	wake := func() error {
+		if ticker != nil {
+			ticker.Stop()
+			ticker = nil
+			frames = nil
+		}
+
+		switch m.opts.Action {
+		case IDLE_ACTION_DIM, IDLE_ACTION_SLEEP:
+			return d.applyBrightness(d.lastBrightness())
+
+		case IDLE_ACTION_ANIMATION:
+			if state != nil {
+				st := state
+				state = nil
+				if err := d.restoreState(st); err != nil {
+					return err
+				}
+
+				// keys never drawn are not in the saved state
+				return d.ForEachKey(func(key KeyID) error {
+					if _, found := st.Keys[key]; found {
+						return nil
+					}
+					return d.setKeyImage(key, &imageColor{
+						c: color.Black,
+						b: d.model.keyImageRect,
+					})
+				})
+			}
+		}
+		return nil
+	}
+
+	sleeping := false
+	for {
+		select {
+		case <-done:
+			return
+
+		case restore := <-m.stop:
+			if sleeping && restore {
+				if err := wake(); err != nil {
+					d.logError("streamdeck: idle handling failed", err)
+				}
+			} else if ticker != nil {
+				ticker.Stop()
+			}
+			return
+
+		case ack := <-m.activity:
+			if sleeping {
+				m.mtx.Lock()
+				m.idle = false
+				m.mtx.Unlock()
+
+				sleeping = false
+				if err := wake(); err != nil {
+					d.logError("streamdeck: idle handling failed", err)
+				}
+			}
+			if ack != nil {
+				close(ack)
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(m.timeout)
+
+		case <-timer.C:
+			m.mtx.Lock()
+			m.idle = true
+			m.mtx.Unlock()
+
+			sleeping = true
+			if err := sleep(); err != nil {
+				d.logError("streamdeck: idle handling failed", err)
+			}
+
+		case <-frames:
+			img, err := m.opts.Animation(time.Since(start))
+			if err != nil {
+				d.logError("streamdeck: idle handling failed", err)
+				continue
+			}
+			if img != nil {
+				if err := d.SetDeckImage(img); err != nil {
+					d.logError("streamdeck: idle handling failed", err)
+				}
+			}
+		}
+	}
+}
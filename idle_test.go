@@ -0,0 +1,153 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestIdleTimeout(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetBrightness(80); err != nil {
+		t.Fatal(err)
+	}
+
+	pressed := make(chan struct{}, 10)
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		pressed <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	go dev.Listen(nil)
+
+	if err := dev.SetIdleTimeout(50*time.Millisecond, streamdeck.IdleOptions{
+		Action:        streamdeck.IDLE_ACTION_DIM,
+		SwallowWakeUp: true,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if !dev.IsIdle() {
+		t.Fatal("device should be idle")
+	}
+	if b := m.Brightness(); b != 10 {
+		t.Errorf("bad idle brightness: %d", b)
+	}
+
+	press := func() {
+		t.Helper()
+		if err := m.PressKey(streamdeck.KEY_1); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	press()
+	select {
+	case <-pressed:
+		t.Fatal("wake up press should be swallowed")
+	case <-time.After(30 * time.Millisecond):
+	}
+	if dev.IsIdle() {
+		t.Error("device should not be idle")
+	}
+	if b := m.Brightness(); b != 80 {
+		t.Errorf("brightness not restored: %d", b)
+	}
+
+	press()
+	select {
+	case <-pressed:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for key press")
+	}
+
+	if err := dev.SetIdleTimeout(time.Second, streamdeck.IdleOptions{Action: streamdeck.IDLE_ACTION_ANIMATION}); !errors.Is(err, streamdeck.ErrAnimationInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetIdleTimeout(0, streamdeck.IdleOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIdleAnimation(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the wake-up press is only dispatched after the displays are restored
+	woken := make(chan struct{}, 1)
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_2, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		woken <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	go dev.Listen(nil)
+
+	rect, err := dev.GetDeckImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.SetIdleTimeout(300*time.Millisecond, streamdeck.IdleOptions{
+		Action: streamdeck.IDLE_ACTION_ANIMATION,
+		Animation: func(elapsed time.Duration) (image.Image, error) {
+			img := image.NewRGBA(rect)
+			draw.Draw(img, img.Bounds(), image.NewUniform(color.RGBA{G: 0xff, A: 0xff}), image.Point{}, draw.Src)
+			return img, nil
+		},
+		FrameRate: 50,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(450 * time.Millisecond)
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{G: 0xff})
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-woken:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for wake-up")
+	}
+	if dev.IsIdle() {
+		t.Fatal("device still idle")
+	}
+
+	// no animation frames are written after waking up
+	m.ClearWrites()
+	time.Sleep(100 * time.Millisecond)
+	if w := m.Writes(); len(w) > 0 {
+		t.Errorf("unexpected writes after waking up: %d", len(w))
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{R: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{})
+}
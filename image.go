@@ -16,10 +16,11 @@ import (
 	"io"
 	"io/fs"
 	"os"
+	"sync"
 
 	"golang.org/x/image/bmp"
 	"golang.org/x/image/draw"
-	"rafaelmartins.com/p/usbhid"
+	_ "golang.org/x/image/webp"
 )
 
 // TouchStripImageRectangleError represents an error when a provided image
@@ -55,6 +56,14 @@ func (ic *imageColor) Convert(color.Color) color.Color {
 	return ic.c
 }
 
+type displaySurface byte
+
+const (
+	displaySurfaceKey displaySurface = iota + 1
+	displaySurfaceInfoBar
+	displaySurfaceTouchStrip
+)
+
 type imageFormat byte
 
 const (
@@ -85,114 +94,274 @@ func getScaledRect(src image.Rectangle, dst image.Rectangle) image.Rectangle {
 	return image.Rect(x0, dst.Min.Y, x0+newWidth, dst.Max.Y)
 }
 
-func genImage(img image.Image, rect image.Rectangle, ifmt imageFormat, transform imageTransform) ([]byte, error) {
-	if img == nil {
-		return nil, wrapErr(ErrImageInvalid)
-	}
+func scaleImage(img image.Image, rect image.Rectangle, opts ImageOptions) *image.RGBA {
+	rv := image.NewRGBA(rect)
+	scaleImageTo(rv, img, opts)
+	return rv
+}
 
-	scaled := image.NewRGBA(rect)
+// scaleImageTo draws img scaled to fit dst, that must be zeroed.
+func scaleImageTo(dst *image.RGBA, img image.Image, opts ImageOptions) {
+	rect := dst.Rect
 	imgBounds := img.Bounds()
 	if imgBounds.Dx() == rect.Dx() && imgBounds.Dy() == rect.Dy() {
-		draw.Copy(scaled, image.Point{}, img, imgBounds, draw.Src, nil)
+		draw.Copy(dst, rect.Min, img, imgBounds, draw.Src, nil)
 	} else {
-		draw.BiLinear.Scale(scaled, getScaledRect(imgBounds, rect), img, imgBounds, draw.Src, nil)
+		opts.Scaler.scaler().Scale(dst, getScaledRect(imgBounds, rect), img, imgBounds, draw.Src, nil)
 	}
+}
+
+// rgbaPool holds the intermediate frames used by genImage, that are
+// allocated for every image sent to the device otherwise.
+var rgbaPool sync.Pool
+
+// encodeBufferPool holds the buffers images are encoded to by genImage.
+var encodeBufferPool = sync.Pool{
+	New: func() any {
+		return &bytes.Buffer{}
+	},
+}
+
+// packetPool holds the output report buffers used by imageSend. Buffers are
+// reused only if large enough for the output reports of the device, so
+// devices with different report lengths may share the pool.
+var packetPool sync.Pool
+
+func getPacket(length int) *[]byte {
+	if v, ok := packetPool.Get().(*[]byte); ok && cap(*v) >= length {
+		return v
+	}
+	rv := make([]byte, 0, length)
+	return &rv
+}
+
+// getRGBA returns a zeroed image.RGBA from the pool, reusing its pixel
+// buffer if large enough.
+func getRGBA(rect image.Rectangle) *image.RGBA {
+	n := 4 * rect.Dx() * rect.Dy()
+	if v, ok := rgbaPool.Get().(*image.RGBA); ok && cap(v.Pix) >= n {
+		v.Pix = v.Pix[:n]
+		clear(v.Pix)
+		v.Stride = 4 * rect.Dx()
+		v.Rect = rect
+		return v
+	}
+	return image.NewRGBA(rect)
+}
+
+func putRGBA(img *image.RGBA) {
+	rgbaPool.Put(img)
+}
 
-	final := image.NewRGBA(rect)
-	for x := scaled.Bounds().Min.X; x < scaled.Bounds().Max.X; x++ {
-		for y := scaled.Bounds().Min.Y; y < scaled.Bounds().Max.Y; y++ {
+// transformPix copies the pixels of src to dst, of the same size, applying
+// the given transformations.
+func transformPix(dst *image.RGBA, src *image.RGBA, transform imageTransform) {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	for y := 0; y < h; y++ {
+		row := src.Pix[y*src.Stride : y*src.Stride+4*w]
+		for x := 0; x < w; x++ {
 			xd := x
 			yd := y
 
 			if transform&imageTransformFlipHorizontal == imageTransformFlipHorizontal {
-				xd = scaled.Bounds().Dx() - 1 - xd
+				xd = w - 1 - xd
 			}
 
 			if transform&imageTransformFlipVertical == imageTransformFlipVertical {
-				yd = scaled.Bounds().Dy() - 1 - yd
+				yd = h - 1 - yd
 			}
 
 			if transform&imageTransformRotate90 == imageTransformRotate90 {
-				if rect.Dx() != rect.Dy() {
-					return nil, fmt.Errorf("%w: cannot rotate non-square canvas", ErrImageInvalid)
-				}
-
 				xxd := xd
 				xd = yd
-				yd = scaled.Bounds().Dx() - 1 - xxd
+				yd = w - 1 - xxd
 			}
 
-			c := scaled.At(x, y)
-			if ifmt == imageFormatBMP {
-				r, g, b, _ := c.RGBA()
-				c = color.RGBA{
-					R: byte(r),
-					G: byte(g),
-					B: byte(b),
-					A: 0xff,
-				}
-			}
-			final.Set(xd, yd, c)
+			i := yd*dst.Stride + 4*xd
+			copy(dst.Pix[i:i+4], row[4*x:4*x+4])
 		}
 	}
+}
+
+func genImage(img image.Image, rect image.Rectangle, ifmt imageFormat, transform imageTransform, opts ImageOptions) ([]byte, error) {
+	if img == nil {
+		return nil, wrapErr(ErrImageInvalid)
+	}
+
+	if transform&imageTransformRotate90 == imageTransformRotate90 && rect.Dx() != rect.Dy() {
+		return nil, fmt.Errorf("%w: cannot rotate non-square canvas", ErrImageInvalid)
+	}
+
+	scaled := getRGBA(rect)
+	defer putRGBA(scaled)
+	scaleImageTo(scaled, img, opts)
+
+	final := scaled
+	if transform != 0 {
+		final = getRGBA(rect)
+		defer putRGBA(final)
+		transformPix(final, scaled, transform)
+	}
+
+	// bitmaps are opaque, and the color channels are kept premultiplied
+	if ifmt == imageFormatBMP {
+		for i := 3; i < len(final.Pix); i += 4 {
+			final.Pix[i] = 0xff
+		}
+	}
+
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer encodeBufferPool.Put(buf)
 
-	buf := bytes.Buffer{}
 	switch ifmt {
 	case imageFormatBMP:
-		if err := bmp.Encode(&buf, final); err != nil {
+		if err := bmp.Encode(buf, final); err != nil {
 			return nil, err
 		}
 
 	case imageFormatJPEG:
-		if err := jpeg.Encode(&buf, final, &jpeg.Options{Quality: 100}); err != nil {
+		if err := jpeg.Encode(buf, final, &jpeg.Options{Quality: opts.jpegQuality()}); err != nil {
 			return nil, err
 		}
 
 	default:
 		return nil, errors.New("invalid key image format")
 	}
-	return buf.Bytes(), nil
+
+	// payloads are kept by the write cache and the display state, so they
+	// can not share the pooled buffer
+	return bytes.Clone(buf.Bytes()), nil
+}
+
+// decodeImage decodes a payload generated by genImage, reverting the
+// transformations applied to it.
+func decodeImage(data []byte, rect image.Rectangle, ifmt imageFormat, transform imageTransform) (*image.RGBA, error) {
+	var (
+		img image.Image
+		err error
+	)
+	switch ifmt {
+	case imageFormatBMP:
+		img, err = bmp.Decode(bytes.NewReader(data))
+	case imageFormatJPEG:
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	default:
+		return nil, errors.New("invalid key image format")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b := img.Bounds()
+	if b.Dx() != rect.Dx() || b.Dy() != rect.Dy() {
+		return nil, fmt.Errorf("%w: unexpected image size: %dx%d", ErrImageInvalid, b.Dx(), b.Dy())
+	}
+
+	rv := image.NewRGBA(rect)
+	for x := 0; x < rect.Dx(); x++ {
+		for y := 0; y < rect.Dy(); y++ {
+			xd := x
+			yd := y
+
+			if transform&imageTransformFlipHorizontal == imageTransformFlipHorizontal {
+				xd = rect.Dx() - 1 - xd
+			}
+
+			if transform&imageTransformFlipVertical == imageTransformFlipVertical {
+				yd = rect.Dy() - 1 - yd
+			}
+
+			if transform&imageTransformRotate90 == imageTransformRotate90 {
+				xxd := xd
+				xd = yd
+				yd = rect.Dx() - 1 - xxd
+			}
+
+			rv.Set(rect.Min.X+x, rect.Min.Y+y, img.At(b.Min.X+xd, b.Min.Y+yd))
+		}
+	}
+	return rv, nil
+}
+
+// imagePagePayloadSize returns the number of bytes of image data carried by
+// each output report of an image transfer, after the header.
+func imagePagePayloadSize(dev HIDDevice, headerLength int) int {
+	return int(dev.GetOutputReportLength()) - headerLength
 }
 
-func imageSend(dev *usbhid.Device, id byte, hdr []byte, imgData []byte, updateCb func(hdr []byte, page byte, last byte, size uint16)) error {
+// imageSend sends imgData split into output reports, each one starting with
+// hdr. A single packet buffer is used for all the reports: the header is
+// copied to it once, and updated in place by updateCb before each report is
+// sent.
+func imageSend(dev HIDDevice, id byte, hdr []byte, imgData []byte, updateCb func(hdr []byte, page byte, last byte, size uint16)) error {
 	if updateCb == nil {
 		return errors.New("image update callback not set")
 	}
 
-	var (
-		start uint16
-		page  byte
-		last  byte
-	)
+	length := int(dev.GetOutputReportLength())
+	size := imagePagePayloadSize(dev, len(hdr))
+	if size <= 0 {
+		return errors.New("image header does not fit the output report")
+	}
+
+	packet := getPacket(length)
+	defer packetPool.Put(packet)
+
+	buf := (*packet)[:length]
+	h := buf[:copy(buf, hdr)]
+	payload := buf[len(hdr):]
+
+	for page, start := 0, 0; ; page++ {
+		end := min(start+size, len(imgData))
+		n := copy(payload, imgData[start:end])
 
-	for last == 0 {
-		end := start + dev.GetOutputReportLength() - uint16(len(hdr))
-		if l := uint16(len(imgData)); end >= l {
-			end = l
+		last := byte(0)
+		if end == len(imgData) {
 			last = 1
+			clear(payload[n:])
 		}
+		updateCb(h, byte(page), last, uint16(n))
 
-		to_send := imgData[start:end]
-		updateCb(hdr, page, last, uint16(len(to_send)))
-
-		payload := append(hdr, to_send...)
-		payload = append(payload, make([]byte, dev.GetOutputReportLength()-uint16(len(payload)))...)
-		if err := dev.SetOutputReport(id, payload); err != nil {
+		if err := dev.SetOutputReport(id, buf); err != nil {
 			return err
 		}
-
-		start += dev.GetOutputReportLength() - uint16(len(hdr))
-		page++
+		if last != 0 {
+			return nil
+		}
+		start = end
 	}
-	return nil
 }
 
 func (d *Device) setKeyImage(key KeyID, img image.Image) error {
-	data, err := genImage(img, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform)
+	data, err := genImage(img, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform, d.GetImageOptions())
 	if err != nil {
 		return wrapErr(err)
 	}
-	return wrapErr(d.model.keyImageSend(d.dev, key, data))
+
+	return d.sendKeyImage(key, data)
+}
+
+func (d *Device) sendKeyImage(key KeyID, data []byte) error {
+	return d.frames.write(d, animationTarget{surface: displaySurfaceKey, key: key}, func() error { return d.writeKeyImage(key, data) })
+}
+
+func (d *Device) writeKeyImage(key KeyID, data []byte) error {
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+
+	h, cached := d.writeCache.key(key, data)
+	if cached {
+		return nil
+	}
+
+	if err := d.model.keyImageSend(d.dev, key, data); err != nil {
+		return wrapErr(err)
+	}
+	d.writeCache.setKey(key, h)
+	d.state.setKey(key, data)
+	d.overlays.written(key, data)
+	return nil
 }
 
 func (d *Device) setKeyImageFromReader(key KeyID, r io.Reader) error {
@@ -215,11 +384,17 @@ func (d *Device) SetKeyImage(key KeyID, img image.Image) error {
 		return err
 	}
 
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
 	return d.setKeyImage(key, img)
 }
 
 // SetKeyImageFromReader draws an image from an io.Reader to an Elgato Stream
 // Deck key background display. The image is decoded and scaled as needed.
+// Supported formats are BMP, GIF, JPEG, PNG and WebP, and additional formats
+// may be registered with image.RegisterFormat.
 func (d *Device) SetKeyImageFromReader(key KeyID, r io.Reader) error {
 	if err := d.validateOpen(); err != nil {
 		return err
@@ -229,6 +404,10 @@ func (d *Device) SetKeyImageFromReader(key KeyID, r io.Reader) error {
 		return err
 	}
 
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
 	if r == nil {
 		return wrapErr(ErrImageInvalid)
 	}
@@ -248,6 +427,10 @@ func (d *Device) SetKeyImageFromReadCloser(key KeyID, r io.ReadCloser) error {
 		return err
 	}
 
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
 	if r == nil {
 		return wrapErr(ErrImageInvalid)
 	}
@@ -267,6 +450,10 @@ func (d *Device) SetKeyImageFromFile(key KeyID, name string) error {
 		return err
 	}
 
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
 	fp, err := os.Open(name)
 	if err != nil {
 		return wrapErr(err)
@@ -288,6 +475,10 @@ func (d *Device) SetKeyImageFromFS(key KeyID, ffs fs.FS, name string) error {
 		return err
 	}
 
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
 	fp, err := ffs.Open(name)
 	if err != nil {
 		return wrapErr(err)
@@ -313,16 +504,40 @@ func (d *Device) ClearKey(key KeyID) error {
 // GetKeyImageRectangle returns an image.Rectangle representing the geometry
 // of the Elgato Stream Deck key background displays.
 func (d *Device) GetKeyImageRectangle() (image.Rectangle, error) {
-	return d.model.keyImageRect, nil // at some point there could be a stream deck without key display?
+	if d.model.keyImageSend == nil {
+		return image.Rectangle{}, wrapErr(ErrDeviceKeyDisplayNotSupported)
+	}
+	return d.model.keyImageRect, nil
 }
 
 func (d *Device) setInfoBarImage(img image.Image) error {
-	data, err := genImage(img, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform)
+	data, err := genImage(img, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform, d.GetImageOptions())
 	if err != nil {
 		return wrapErr(err)
 	}
 
-	return wrapErr(d.model.infoBarImageSend(d.dev, data))
+	return d.sendInfoBarImage(data)
+}
+
+func (d *Device) sendInfoBarImage(data []byte) error {
+	return d.frames.write(d, animationTarget{surface: displaySurfaceInfoBar}, func() error { return d.writeInfoBarImage(data) })
+}
+
+func (d *Device) writeInfoBarImage(data []byte) error {
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+
+	h, cached := d.writeCache.infoBarImage(data)
+	if cached {
+		return nil
+	}
+
+	if err := d.model.infoBarImageSend(d.dev, data); err != nil {
+		return wrapErr(err)
+	}
+	d.writeCache.setInfoBar(h)
+	d.state.setInfoBar(data)
+	return nil
 }
 
 func (d *Device) setInfoBarImageFromReader(r io.Reader) error {
@@ -465,7 +680,14 @@ func (d *Device) SetTouchPointColor(tp TouchPointID, c color.Color) error {
 		return err
 	}
 
-	return d.model.touchPointColorSend(d.dev, tp, c)
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+
+	if err := d.model.touchPointColorSend(d.dev, tp, c); err != nil {
+		return err
+	}
+	d.state.setTouchPoint(tp, c)
+	return nil
 }
 
 // ClearTouchPoint clears the color set to a touch point strip available in
@@ -482,12 +704,33 @@ func (d *Device) setTouchStripImage(img image.Image, rect *image.Rectangle) erro
 		v = image.Rect(0, 0, r.Dx(), r.Dy())
 	}
 
-	data, err := genImage(img, v, d.model.touchStripImageFormat, d.model.touchStripImageTransform)
+	data, err := genImage(img, v, d.model.touchStripImageFormat, d.model.touchStripImageTransform, d.GetImageOptions())
 	if err != nil {
 		return wrapErr(err)
 	}
 
-	return wrapErr(d.model.touchStripImageSend(d.dev, data, r))
+	return d.sendTouchStripImage(data, r)
+}
+
+func (d *Device) sendTouchStripImage(data []byte, rect image.Rectangle) error {
+	return d.frames.write(d, animationTarget{surface: displaySurfaceTouchStrip, rect: rect}, func() error { return d.writeTouchStripImage(data, rect) })
+}
+
+func (d *Device) writeTouchStripImage(data []byte, rect image.Rectangle) error {
+	d.writeMtx.Lock()
+	defer d.writeMtx.Unlock()
+
+	h, cached := d.writeCache.touchStripImage(rect, data)
+	if cached {
+		return nil
+	}
+
+	if err := d.model.touchStripImageSend(d.dev, data, rect); err != nil {
+		return wrapErr(err)
+	}
+	d.writeCache.setTouchStrip(rect, h)
+	d.state.setTouchStrip(rect, data)
+	return nil
 }
 
 func (d *Device) setTouchStripImageFromReader(r io.Reader, rect *image.Rectangle) error {
@@ -758,8 +1001,10 @@ func (d *Device) GetTouchStripImageRectangle() (image.Rectangle, error) {
 }
 
 func (d *Device) closeDisplays() error {
-	if err := d.ForEachKey(d.ClearKey); err != nil {
-		return err
+	if d.GetKeyDisplaySupported() {
+		if err := d.ForEachKey(d.ClearKey); err != nil {
+			return err
+		}
 	}
 
 	if err := d.ForEachTouchPoint(d.ClearTouchPoint); err != nil {
@@ -6,6 +6,7 @@ package streamdeck
 
 import (
 	"bytes"
+	"encoding/base64"
 	"errors"
 	"image"
 	"image/color"
@@ -38,7 +39,7 @@ func TestGenImage_BMPFormat(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatBMP, 0)
+	data, err := genImage(img, rect, imageFormatBMP, 0, ImageOptions{})
 	if err != nil {
 		t.Fatalf("genImage failed: %v", err)
 	}
@@ -61,7 +62,7 @@ func TestGenImage_JPEGFormat(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatJPEG, 0)
+	data, err := genImage(img, rect, imageFormatJPEG, 0, ImageOptions{})
 	if err != nil {
 		t.Fatalf("genImage failed: %v", err)
 	}
@@ -75,7 +76,7 @@ func TestGenImage_FlipHorizontal(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatBMP, imageTransformFlipHorizontal)
+	data, err := genImage(img, rect, imageFormatBMP, imageTransformFlipHorizontal, ImageOptions{})
 	if err != nil {
 		t.Fatalf("genImage failed: %v", err)
 	}
@@ -98,7 +99,7 @@ func TestGenImage_FlipVertical(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatBMP, imageTransformFlipVertical)
+	data, err := genImage(img, rect, imageFormatBMP, imageTransformFlipVertical, ImageOptions{})
 	if err != nil {
 		t.Fatalf("genImage failed: %v", err)
 	}
@@ -121,7 +122,7 @@ func TestGenImage_Rotate90(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatBMP, imageTransformRotate90)
+	data, err := genImage(img, rect, imageFormatBMP, imageTransformRotate90, ImageOptions{})
 	if err != nil {
 		t.Fatalf("genImage failed: %v", err)
 	}
@@ -144,7 +145,7 @@ func TestGenImage_NonSquareRotation(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 4, 4))
 	rect := image.Rect(0, 0, 4, 6)
 
-	if _, err := genImage(img, rect, imageFormatJPEG, imageTransformRotate90); !errors.Is(err, ErrImageInvalid) {
+	if _, err := genImage(img, rect, imageFormatJPEG, imageTransformRotate90, ImageOptions{}); !errors.Is(err, ErrImageInvalid) {
 		t.Error("expected error for rotating non-square canvas")
 	}
 }
@@ -154,7 +155,7 @@ func TestGenImage_Scaling(t *testing.T) {
 	img := createTestImage(image.Rect(0, 0, 2, 2))
 	rect := image.Rect(0, 0, 4, 4)
 
-	data, err := genImage(img, rect, imageFormatBMP, 0)
+	data, err := genImage(img, rect, imageFormatBMP, 0, ImageOptions{})
 	if err != nil {
 		t.Fatalf("upscaling failed: %v", err)
 	}
@@ -187,3 +188,161 @@ func TestGetScaledRect_AspectRatio(t *testing.T) {
 		t.Errorf("expected %v, got %v", expected, result)
 	}
 }
+
+func TestDecodeImage(t *testing.T) {
+	rect := image.Rect(0, 0, 8, 8)
+	img := createTestImage(rect)
+
+	for _, tc := range []struct {
+		format    imageFormat
+		transform imageTransform
+	}{
+		{imageFormatBMP, 0},
+		{imageFormatBMP, imageTransformFlipHorizontal | imageTransformFlipVertical},
+		{imageFormatBMP, imageTransformRotate90 | imageTransformFlipHorizontal},
+		{imageFormatJPEG, imageTransformFlipHorizontal | imageTransformFlipVertical},
+	} {
+		data, err := genImage(img, rect, tc.format, tc.transform, ImageOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := decodeImage(data, rect, tc.format, tc.transform)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range []image.Point{{1, 1}, {6, 1}, {1, 6}, {6, 6}} {
+			r1, g1, b1, _ := img.At(p.X, p.Y).RGBA()
+			r2, g2, b2, _ := decoded.At(p.X, p.Y).RGBA()
+			if absDiff(r1, r2) > 0x2000 || absDiff(g1, g2) > 0x2000 || absDiff(b1, b2) > 0x2000 {
+				t.Errorf("format %d transform %d: bad color at %s", tc.format, tc.transform, p)
+			}
+		}
+	}
+
+	if _, err := decodeImage([]byte("bola"), rect, imageFormatBMP, 0); err == nil {
+		t.Error("invalid data should fail to decode")
+	}
+}
+
+func absDiff(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestValidateRawImage(t *testing.T) {
+	rect := image.Rect(0, 0, 72, 72)
+	img := createTestImage(rect)
+
+	jbuf := bytes.Buffer{}
+	if err := jpeg.Encode(&jbuf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	bbuf := bytes.Buffer{}
+	if err := bmp.Encode(&bbuf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateRawImage(jbuf.Bytes(), rect, imageFormatJPEG); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateRawImage(bbuf.Bytes(), rect, imageFormatBMP); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateRawImage(jbuf.Bytes(), rect, imageFormatBMP); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateRawImage(jbuf.Bytes(), image.Rect(0, 0, 96, 96), imageFormatJPEG); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateRawImage(nil, rect, imageFormatJPEG); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenImage_Options(t *testing.T) {
+	rect := image.Rect(0, 0, 72, 72)
+	img := createTestImage(image.Rect(0, 0, 50, 50))
+
+	high, err := genImage(img, rect, imageFormatJPEG, 0, ImageOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	low, err := genImage(img, rect, imageFormatJPEG, 0, ImageOptions{JPEGQuality: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(low) >= len(high) {
+		t.Errorf("lower quality should produce smaller payload: %d >= %d", len(low), len(high))
+	}
+
+	for _, s := range []ImageScaler{IMAGE_SCALER_CATMULL_ROM, IMAGE_SCALER_APPROX_BILINEAR, IMAGE_SCALER_NEAREST_NEIGHBOR} {
+		data, err := genImage(img, rect, imageFormatBMP, 0, ImageOptions{Scaler: s})
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		decoded, err := bmp.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if decoded.Bounds() != rect {
+			t.Errorf("%s: bad bounds: %s", s, decoded.Bounds())
+		}
+	}
+}
+
+func TestDecodeWebP(t *testing.T) {
+	// 1x1 lossless webp image
+	data, err := base64.StdEncoding.DecodeString("UklGRhoAAABXRUJQVlA4TA0AAAAvAAAAEAcQERGIiP4HAA==")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != "webp" {
+		t.Errorf("bad format: %s", format)
+	}
+	if img.Bounds() != image.Rect(0, 0, 1, 1) {
+		t.Errorf("bad bounds: %s", img.Bounds())
+	}
+}
+
+func BenchmarkGenImage(b *testing.B) {
+	for _, pid := range []uint16{0x0060, 0x0080, 0x0084} {
+		md := models[pid]
+		for _, tt := range []struct {
+			name string
+			src  image.Rectangle
+		}{
+			{"native", md.keyImageRect},
+			{"scaled", image.Rect(0, 0, 256, 256)},
+		} {
+			b.Run(md.id+"/"+tt.name, func(b *testing.B) {
+				img := createTestImage(tt.src)
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := genImage(img, md.keyImageRect, md.keyImageFormat, md.keyImageTransform, ImageOptions{}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+
+	b.Run("plus/touch-strip", func(b *testing.B) {
+		md := models[0x0084]
+		img := createTestImage(md.touchStripImageRect)
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := genImage(img, md.touchStripImageRect, md.touchStripImageFormat, md.touchStripImageTransform, ImageOptions{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
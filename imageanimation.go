@@ -0,0 +1,188 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"time"
+)
+
+// gifMinimumDelay is the delay used for GIF frames with delays shorter than
+// 20ms, like web browsers do.
+const gifMinimumDelay = 100 * time.Millisecond
+
+// ImageAnimation represents a sequence of fully composed frames, each one
+// displayed for its delay, as decoded from animated image formats. Frames
+// decoded from formats not supported by the package, like APNG, may be used
+// by creating the ImageAnimation directly.
+type ImageAnimation struct {
+	Frames []image.Image
+	Delays []time.Duration
+
+	// Loops is the number of times the animation is played. The last frame is
+	// kept on the display after the animation ends. If set to zero, the
+	// animation loops forever.
+	Loops int
+}
+
+// NewImageAnimationFromGIF creates an ImageAnimation from a GIF image decoded
+// with gif.DecodeAll, composing its frames according to their disposal
+// methods.
+func NewImageAnimationFromGIF(g *gif.GIF) (*ImageAnimation, error) {
+	if g == nil || len(g.Image) == 0 {
+		return nil, wrapErr(ErrAnimationInvalid)
+	}
+
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() {
+		bounds = g.Image[0].Bounds()
+		for _, frame := range g.Image[1:] {
+			bounds = bounds.Union(frame.Bounds())
+		}
+	}
+
+	rv := &ImageAnimation{
+		Frames: make([]image.Image, 0, len(g.Image)),
+		Delays: make([]time.Duration, 0, len(g.Image)),
+	}
+	switch {
+	case g.LoopCount < 0:
+		rv.Loops = 1
+	case g.LoopCount > 0:
+		rv.Loops = g.LoopCount + 1
+	}
+
+	canvas := image.NewRGBA(bounds)
+	for i, frame := range g.Image {
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+
+		var previous *image.RGBA
+		if disposal == gif.DisposalPrevious {
+			previous = image.NewRGBA(bounds)
+			copy(previous.Pix, canvas.Pix)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+
+		composed := image.NewRGBA(bounds)
+		copy(composed.Pix, canvas.Pix)
+		rv.Frames = append(rv.Frames, composed)
+
+		delay := gifMinimumDelay
+		if i < len(g.Delay) && g.Delay[i] > 1 {
+			delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+		rv.Delays = append(rv.Delays, delay)
+
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return rv, nil
+}
+
+func (a *ImageAnimation) validate() error {
+	if a == nil || len(a.Frames) == 0 || len(a.Frames) != len(a.Delays) {
+		return wrapErr(ErrAnimationInvalid)
+	}
+	for i, frame := range a.Frames {
+		if frame == nil || a.Delays[i] <= 0 {
+			return fmt.Errorf("streamdeck: %w: frame %d", ErrAnimationInvalid, i)
+		}
+	}
+	return nil
+}
+
+// frameRate returns the frame rate required to display the shortest frame of
+// the animation.
+func (a *ImageAnimation) frameRate() int {
+	shortest := a.Delays[0]
+	for _, delay := range a.Delays[1:] {
+		shortest = min(shortest, delay)
+	}
+	return max(int(time.Second/shortest), 1)
+}
+
+// frameFunc returns a FrameFunc selecting the frame to display for the
+// elapsed time, that returns nil images while the frame is unchanged.
+func (a *ImageAnimation) frameFunc() FrameFunc {
+	frames := append([]image.Image(nil), a.Frames...)
+	ends := make([]time.Duration, len(a.Delays))
+	total := time.Duration(0)
+	for i, delay := range a.Delays {
+		total += delay
+		ends[i] = total
+	}
+	loops := a.Loops
+	current := -1
+
+	return func(elapsed time.Duration) (image.Image, error) {
+		idx := len(frames) - 1
+		if loops <= 0 || elapsed < total*time.Duration(loops) {
+			pos := elapsed % total
+			for i, end := range ends {
+				if pos < end {
+					idx = i
+					break
+				}
+			}
+		}
+
+		if idx == current {
+			return nil, nil
+		}
+		current = idx
+		return frames[idx], nil
+	}
+}
+
+// SetKeyAnimation registers an ImageAnimation for an Elgato Stream Deck key
+// background display, replacing any animation previously registered for the
+// key. Frames are scaled as needed.
+func (a *Animator) SetKeyAnimation(key KeyID, anim *ImageAnimation) (*Animation, error) {
+	if err := anim.validate(); err != nil {
+		return nil, err
+	}
+	return a.AnimateKey(key, anim.frameRate(), anim.frameFunc())
+}
+
+// SetInfoBarAnimation registers an ImageAnimation for the info bar display
+// available on some Elgato Stream Deck models, replacing any animation
+// previously registered for it. Frames are scaled as needed.
+func (a *Animator) SetInfoBarAnimation(anim *ImageAnimation) (*Animation, error) {
+	if err := anim.validate(); err != nil {
+		return nil, err
+	}
+	return a.AnimateInfoBar(anim.frameRate(), anim.frameFunc())
+}
+
+// SetTouchStripAnimation registers an ImageAnimation for the touch strip
+// display available on some Elgato Stream Deck models, replacing any
+// animation previously registered for the whole touch strip. Frames are
+// scaled as needed.
+func (a *Animator) SetTouchStripAnimation(anim *ImageAnimation) (*Animation, error) {
+	return a.SetTouchStripAnimationWithRectangle(a.device.model.touchStripImageRect, anim)
+}
+
+// SetTouchStripAnimationWithRectangle registers an ImageAnimation for a
+// rectangle of the touch strip display available on some Elgato Stream Deck
+// models, replacing any animation previously registered for the same
+// rectangle. Frames are scaled as needed to fit the rectangle, and the rest
+// of the touch strip is not changed.
+func (a *Animator) SetTouchStripAnimationWithRectangle(rect image.Rectangle, anim *ImageAnimation) (*Animation, error) {
+	if err := anim.validate(); err != nil {
+		return nil, err
+	}
+	return a.AnimateTouchStripWithRectangle(rect, anim.frameRate(), anim.frameFunc())
+}
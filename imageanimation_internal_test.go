@@ -0,0 +1,116 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"testing"
+	"time"
+)
+
+func gifFrame(rect image.Rectangle, c color.Color) *image.Paletted {
+	rv := image.NewPaletted(rect, palette.Plan9)
+	idx := uint8(rv.Palette.Index(c))
+	for i := range rv.Pix {
+		rv.Pix[i] = idx
+	}
+	return rv
+}
+
+func TestNewImageAnimationFromGIF(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	g := &gif.GIF{
+		Image: []*image.Paletted{
+			gifFrame(image.Rect(0, 0, 10, 10), red),
+			gifFrame(image.Rect(5, 5, 10, 10), blue),
+			gifFrame(image.Rect(0, 0, 5, 5), blue),
+		},
+		Delay:    []int{0, 5, 20},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalNone},
+		Config:   image.Config{Width: 10, Height: 10},
+	}
+
+	anim, err := NewImageAnimationFromGIF(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anim.Loops != 0 {
+		t.Errorf("unexpected loops: %d", anim.Loops)
+	}
+	if want := []time.Duration{100 * time.Millisecond, 50 * time.Millisecond, 200 * time.Millisecond}; len(anim.Delays) != len(want) || anim.Delays[0] != want[0] || anim.Delays[1] != want[1] || anim.Delays[2] != want[2] {
+		t.Errorf("unexpected delays: %v", anim.Delays)
+	}
+
+	for _, tt := range []struct {
+		frame int
+		x, y  int
+		want  color.RGBA
+	}{
+		{0, 7, 7, red},
+		{1, 7, 7, blue},
+		{1, 2, 2, red},
+		{2, 7, 7, color.RGBA{}},
+		{2, 2, 2, blue},
+	} {
+		c := color.RGBAModel.Convert(anim.Frames[tt.frame].At(tt.x, tt.y)).(color.RGBA)
+		if c != tt.want {
+			t.Errorf("frame %d: unexpected color at (%d, %d): got %v, want %v", tt.frame, tt.x, tt.y, c, tt.want)
+		}
+	}
+
+	if _, err := NewImageAnimationFromGIF(&gif.GIF{}); !errors.Is(err, ErrAnimationInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestImageAnimation_frameFunc(t *testing.T) {
+	frames := []image.Image{
+		image.NewRGBA(image.Rect(0, 0, 1, 1)),
+		image.NewRGBA(image.Rect(0, 0, 2, 2)),
+	}
+	anim := &ImageAnimation{
+		Frames: frames,
+		Delays: []time.Duration{100 * time.Millisecond, 50 * time.Millisecond},
+		Loops:  2,
+	}
+	if err := anim.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if fps := anim.frameRate(); fps != 20 {
+		t.Errorf("unexpected frame rate: %d", fps)
+	}
+
+	fn := anim.frameFunc()
+	for _, tt := range []struct {
+		elapsed time.Duration
+		want    image.Image
+	}{
+		{0, frames[0]},
+		{50 * time.Millisecond, nil},
+		{100 * time.Millisecond, frames[1]},
+		{160 * time.Millisecond, frames[0]},
+		{260 * time.Millisecond, frames[1]},
+		{time.Second, nil},
+	} {
+		img, err := fn(tt.elapsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img != tt.want {
+			t.Errorf("%s: unexpected frame", tt.elapsed)
+		}
+	}
+
+	anim.Delays = anim.Delays[:1]
+	if err := anim.validate(); !errors.Is(err, ErrAnimationInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestTouchStripAnimation(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	red := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	draw.Draw(red, red.Rect, image.NewUniform(color.RGBA{R: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	blue := image.NewRGBA(image.Rect(0, 0, 20, 10))
+	draw.Draw(blue, blue.Rect, image.NewUniform(color.RGBA{B: 0xff, A: 0xff}), image.Point{}, draw.Src)
+
+	anim := &streamdeck.ImageAnimation{
+		Frames: []image.Image{red, blue},
+		Delays: []time.Duration{20 * time.Millisecond, 20 * time.Millisecond},
+		Loops:  1,
+	}
+
+	a := dev.NewAnimator()
+	if _, err := a.SetTouchStripAnimation(&streamdeck.ImageAnimation{}); !errors.Is(err, streamdeck.ErrAnimationInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	rect := image.Rect(200, 0, 400, 100)
+	if _, err := a.SetTouchStripAnimationWithRectangle(rect, anim); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := a.Run(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the animation plays once, keeping the last frame on the display
+	w := m.Writes()
+	if len(w) != 2 {
+		t.Fatalf("bad writes: %+v", w)
+	}
+	for _, wr := range w {
+		if wr.Surface != mock.SURFACE_TOUCH_STRIP || wr.Rect != rect {
+			t.Errorf("bad write: %+v", wr)
+		}
+	}
+	img := m.TouchStripImage()
+	assertColor(t, img, 300, 50, color.RGBA{B: 0xff, A: 0xff})
+	assertColor(t, img, 100, 50, color.RGBA{A: 0xff})
+}
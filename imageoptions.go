@@ -0,0 +1,88 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"golang.org/x/image/draw"
+)
+
+// ImageScaler represents the interpolation algorithm used to scale images to
+// the Elgato Stream Deck displays.
+type ImageScaler byte
+
+// Image scalers, from the highest quality to the fastest one.
+const (
+	IMAGE_SCALER_BILINEAR ImageScaler = iota
+	IMAGE_SCALER_CATMULL_ROM
+	IMAGE_SCALER_APPROX_BILINEAR
+	IMAGE_SCALER_NEAREST_NEIGHBOR
+)
+
+// String returns a string representation of the ImageScaler.
+func (s ImageScaler) String() string {
+	switch s {
+	case IMAGE_SCALER_BILINEAR:
+		return "IMAGE_SCALER_BILINEAR"
+	case IMAGE_SCALER_CATMULL_ROM:
+		return "IMAGE_SCALER_CATMULL_ROM"
+	case IMAGE_SCALER_APPROX_BILINEAR:
+		return "IMAGE_SCALER_APPROX_BILINEAR"
+	case IMAGE_SCALER_NEAREST_NEIGHBOR:
+		return "IMAGE_SCALER_NEAREST_NEIGHBOR"
+	default:
+		return ""
+	}
+}
+
+func (s ImageScaler) scaler() draw.Scaler {
+	switch s {
+	case IMAGE_SCALER_CATMULL_ROM:
+		return draw.CatmullRom
+	case IMAGE_SCALER_APPROX_BILINEAR:
+		return draw.ApproxBiLinear
+	case IMAGE_SCALER_NEAREST_NEIGHBOR:
+		return draw.NearestNeighbor
+	default:
+		return draw.BiLinear
+	}
+}
+
+// ImageOptions represents the settings used to scale and encode images before
+// sending them to the Elgato Stream Deck displays. The zero value uses
+// bilinear scaling and maximum JPEG quality.
+type ImageOptions struct {
+	// JPEGQuality is the quality used to encode images for the displays that
+	// use JPEG, ranging from 1 to 100. Lower values produce smaller payloads
+	// and faster updates. If zero, defaults to 100.
+	JPEGQuality int
+
+	// Scaler is the interpolation algorithm used when images do not match
+	// the native display size.
+	Scaler ImageScaler
+}
+
+func (o ImageOptions) jpegQuality() int {
+	if o.JPEGQuality <= 0 {
+		return 100
+	}
+	return min(o.JPEGQuality, 100)
+}
+
+// SetImageOptions sets the settings used to scale and encode images sent to
+// the Elgato Stream Deck device displays. It can be called at runtime, and
+// affects all the images drawn or prepared after it returns.
+func (d *Device) SetImageOptions(opts ImageOptions) {
+	d.mtx.Lock()
+	d.imageOptions = opts
+	d.mtx.Unlock()
+}
+
+// GetImageOptions returns the settings used to scale and encode images sent
+// to the Elgato Stream Deck device displays.
+func (d *Device) GetImageOptions() ImageOptions {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.imageOptions
+}
@@ -1,13 +1,57 @@
 package streamdeck
 
 import (
+	"context"
 	"fmt"
 	"image"
-	"log"
+	"runtime/debug"
 	"sync"
 	"time"
 )
 
+// HandlerRegistration represents a handler callback registered to an input of
+// an Elgato Stream Deck device. It is returned by the Add*Handler methods and
+// may be used to unregister the handler callback.
+type HandlerRegistration struct {
+	once   sync.Once
+	remove func()
+}
+
+// Remove unregisters the handler callback. Handler callbacks already running
+// are not interrupted. Calling Remove more than once is a no-op.
+func (r *HandlerRegistration) Remove() {
+	if r == nil || r.remove == nil {
+		return
+	}
+	r.once.Do(r.remove)
+}
+
+type handler[T any] struct {
+	reg *HandlerRegistration
+	fn  T
+}
+
+func addHandler[T any](mtx *sync.Mutex, handlers *[]handler[T], fn T) *HandlerRegistration {
+	rv := &HandlerRegistration{}
+	rv.remove = func() {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		hnds := []handler[T]{}
+		for _, h := range *handlers {
+			if h.reg != rv {
+				hnds = append(hnds, h)
+			}
+		}
+		*handlers = hnds
+	}
+
+	mtx.Lock()
+	*handlers = append(*handlers, handler[T]{reg: rv, fn: fn})
+	mtx.Unlock()
+	return rv
+}
+
 // KeyHandlerError represents an error returned by a key handler including the
 // key identifier.
 type KeyHandlerError struct {
@@ -25,25 +69,93 @@ func (b KeyHandlerError) Unwrap() error {
 	return b.Err
 }
 
+// HandlerPanicError represents a panic recovered from an input handler. It
+// is wrapped by the error type of the input that called the handler, like
+// KeyHandlerError, and can be tested with errors.As.
+type HandlerPanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error returns a string representation of a handler panic error.
+func (b HandlerPanicError) Error() string {
+	return fmt.Sprintf("handler panic: %v", b.Value)
+}
+
+// Unwrap returns the panic value, if it is an error.
+func (b HandlerPanicError) Unwrap() error {
+	if err, ok := b.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// callHandler calls an input handler, converting panics to
+// HandlerPanicError, so that they don't crash the application.
+func callHandler(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = HandlerPanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+	return fn()
+}
+
 // KeyHandler represents a callback function that is called when a key is
 // pressed. It receives the Device and Key instances as parameters.
 type KeyHandler func(d *Device, k *Key) error
 
+// KeyContextHandler represents a callback function that is called when a key
+// is pressed. It receives a context.Context, that is cancelled when the key is
+// released or the device is closed, and the Device and Key instances as
+// parameters.
+type KeyContextHandler func(ctx context.Context, d *Device, k *Key) error
+
+// KeyReleaseHandler represents a callback function that is called when a key
+// is released. It receives the Device and Key instances, and the duration the
+// key was held down as parameters.
+type KeyReleaseHandler func(d *Device, k *Key, duration time.Duration) error
+
 // Key represents a physical key on the Elgato Stream Deck device.
 type Key struct {
-	id       KeyID
-	handlers []KeyHandler
-	input    *input
+	id              KeyID
+	handlers        []handler[KeyHandler]
+	contextHandlers []handler[KeyContextHandler]
+	pressHandlers   []handler[KeyHandler]
+	releaseHandlers []handler[KeyReleaseHandler]
+	repeat          keyRepeat
+	input           *input
 }
 
-func (k *Key) addHandler(h KeyHandler) {
+func (k *Key) addHandler(h KeyHandler) *HandlerRegistration {
 	if h == nil || k.input == nil {
-		return
+		return nil
+	}
+	return addHandler(&k.input.mtx, &k.handlers, h)
+}
+
+func (k *Key) addContextHandler(h KeyContextHandler) *HandlerRegistration {
+	if h == nil || k.input == nil {
+		return nil
 	}
+	return addHandler(&k.input.mtx, &k.contextHandlers, h)
+}
 
-	k.input.mtx.Lock()
-	k.handlers = append(k.handlers, h)
-	k.input.mtx.Unlock()
+func (k *Key) addPressHandler(h KeyHandler) *HandlerRegistration {
+	if h == nil || k.input == nil {
+		return nil
+	}
+	return addHandler(&k.input.mtx, &k.pressHandlers, h)
+}
+
+func (k *Key) addReleaseHandler(h KeyReleaseHandler) *HandlerRegistration {
+	if h == nil || k.input == nil {
+		return nil
+	}
+	return addHandler(&k.input.mtx, &k.releaseHandlers, h)
 }
 
 // WaitForRelease blocks until the key is released and returns the duration
@@ -90,6 +202,23 @@ const (
 	KEY_13
 	KEY_14
 	KEY_15
+	KEY_16
+	KEY_17
+	KEY_18
+	KEY_19
+	KEY_20
+	KEY_21
+	KEY_22
+	KEY_23
+	KEY_24
+	KEY_25
+	KEY_26
+	KEY_27
+	KEY_28
+	KEY_29
+	KEY_30
+	KEY_31
+	KEY_32
 )
 
 // TouchPointHandlerError represents an error returned by a touch point
@@ -114,22 +243,54 @@ func (b TouchPointHandlerError) Unwrap() error {
 // as parameters.
 type TouchPointHandler func(d *Device, tp *TouchPoint) error
 
+// TouchPointContextHandler represents a callback function that is called when
+// a touch point is activated. It receives a context.Context, that is cancelled
+// when the touch point is released or the device is closed, and the Device
+// and TouchPoint instances as parameters.
+type TouchPointContextHandler func(ctx context.Context, d *Device, tp *TouchPoint) error
+
+// TouchPointReleaseHandler represents a callback function that is called when
+// a touch point is released. It receives the Device and TouchPoint instances,
+// and the duration the touch point was held down as parameters.
+type TouchPointReleaseHandler func(d *Device, tp *TouchPoint, duration time.Duration) error
+
 // TouchPoint represents a touch-sensitive area on supported Elgato Stream
 // Deck devices.
 type TouchPoint struct {
-	id       TouchPointID
-	handlers []TouchPointHandler
-	input    *input
+	id              TouchPointID
+	handlers        []handler[TouchPointHandler]
+	contextHandlers []handler[TouchPointContextHandler]
+	pressHandlers   []handler[TouchPointHandler]
+	releaseHandlers []handler[TouchPointReleaseHandler]
+	input           *input
 }
 
-func (tp *TouchPoint) addHandler(h TouchPointHandler) {
+func (tp *TouchPoint) addHandler(h TouchPointHandler) *HandlerRegistration {
 	if h == nil || tp.input == nil {
-		return
+		return nil
+	}
+	return addHandler(&tp.input.mtx, &tp.handlers, h)
+}
+
+func (tp *TouchPoint) addContextHandler(h TouchPointContextHandler) *HandlerRegistration {
+	if h == nil || tp.input == nil {
+		return nil
+	}
+	return addHandler(&tp.input.mtx, &tp.contextHandlers, h)
+}
+
+func (tp *TouchPoint) addPressHandler(h TouchPointHandler) *HandlerRegistration {
+	if h == nil || tp.input == nil {
+		return nil
 	}
+	return addHandler(&tp.input.mtx, &tp.pressHandlers, h)
+}
 
-	tp.input.mtx.Lock()
-	tp.handlers = append(tp.handlers, h)
-	tp.input.mtx.Unlock()
+func (tp *TouchPoint) addReleaseHandler(h TouchPointReleaseHandler) *HandlerRegistration {
+	if h == nil || tp.input == nil {
+		return nil
+	}
+	return addHandler(&tp.input.mtx, &tp.releaseHandlers, h)
 }
 
 // WaitForRelease blocks until the touch point is released and returns the
@@ -187,38 +348,76 @@ func (b DialHandlerError) Unwrap() error {
 // parameters.
 type DialSwitchHandler func(d *Device, di *Dial) error
 
+// DialSwitchContextHandler represents a callback function that is called when
+// a dial switch is activated. It receives a context.Context, that is
+// cancelled when the dial switch is released or the device is closed, and the
+// Device and Dial instances as parameters.
+type DialSwitchContextHandler func(ctx context.Context, d *Device, di *Dial) error
+
 // DialRotateHandler represents a callback function that is called when a
 // dial is rotated. It receives the Device, the Dial instance and the rotation
 // delta as parameters.
 type DialRotateHandler func(d *Device, di *Dial, delta int8) error
 
+// DialReleaseHandler represents a callback function that is called when a
+// dial switch is released. It receives the Device and Dial instances, and the
+// duration the dial switch was held closed as parameters.
+type DialReleaseHandler func(d *Device, di *Dial, duration time.Duration) error
+
 // Dial represents a rotative encoder with switch available on some Elgato
 // Stream Deck devices.
 type Dial struct {
-	id             DialID
-	switchHandlers []DialSwitchHandler
-	rotateHandlers []DialRotateHandler
-	input          *input
+	id               DialID
+	switchHandlers   []handler[DialSwitchHandler]
+	contextHandlers  []handler[DialSwitchContextHandler]
+	pressHandlers    []handler[DialSwitchHandler]
+	releaseHandlers  []handler[DialReleaseHandler]
+	rotateHandlers   []handler[DialRotateHandler]
+	rotationHandlers []handler[DialRotationHandler]
+	rotation         dialRotation
+	input            *input
+}
+
+func (d *Dial) addSwitchHandler(h DialSwitchHandler) *HandlerRegistration {
+	if h == nil || d.input == nil {
+		return nil
+	}
+	return addHandler(&d.input.mtx, &d.switchHandlers, h)
 }
 
-func (d *Dial) addSwitchHandler(h DialSwitchHandler) {
+func (d *Dial) addContextHandler(h DialSwitchContextHandler) *HandlerRegistration {
 	if h == nil || d.input == nil {
-		return
+		return nil
 	}
+	return addHandler(&d.input.mtx, &d.contextHandlers, h)
+}
 
-	d.input.mtx.Lock()
-	d.switchHandlers = append(d.switchHandlers, h)
-	d.input.mtx.Unlock()
+func (d *Dial) addRotateHandler(h DialRotateHandler) *HandlerRegistration {
+	if h == nil || d.input == nil {
+		return nil
+	}
+	return addHandler(&d.input.mtx, &d.rotateHandlers, h)
 }
 
-func (d *Dial) addRotateHandler(h DialRotateHandler) {
+func (d *Dial) addRotationHandler(h DialRotationHandler) *HandlerRegistration {
 	if h == nil || d.input == nil {
-		return
+		return nil
 	}
+	return addHandler(&d.input.mtx, &d.rotationHandlers, h)
+}
 
-	d.input.mtx.Lock()
-	d.rotateHandlers = append(d.rotateHandlers, h)
-	d.input.mtx.Unlock()
+func (d *Dial) addPressHandler(h DialSwitchHandler) *HandlerRegistration {
+	if h == nil || d.input == nil {
+		return nil
+	}
+	return addHandler(&d.input.mtx, &d.pressHandlers, h)
+}
+
+func (d *Dial) addReleaseHandler(h DialReleaseHandler) *HandlerRegistration {
+	if h == nil || d.input == nil {
+		return nil
+	}
+	return addHandler(&d.input.mtx, &d.releaseHandlers, h)
 }
 
 // WaitForRelease blocks until the dial switch is released and returns the
@@ -325,38 +524,37 @@ type TouchStripTouchHandler func(d *Device, t TouchStripTouchType, p image.Point
 type TouchStripSwipeHandler func(d *Device, origin image.Point, destination image.Point) error
 
 type touchStrip struct {
-	touchHandlers []TouchStripTouchHandler
-	swipeHandlers []TouchStripSwipeHandler
+	touchHandlers []handler[TouchStripTouchHandler]
+	swipeHandlers []handler[TouchStripSwipeHandler]
+	dragHandlers  []handler[TouchStripDragHandler]
 	input         *input
 }
 
-func (t *touchStrip) addTouchHandler(h TouchStripTouchHandler) {
+func (t *touchStrip) addTouchHandler(h TouchStripTouchHandler) *HandlerRegistration {
 	if h == nil || t.input == nil {
-		return
+		return nil
 	}
-
-	t.input.mtx.Lock()
-	t.touchHandlers = append(t.touchHandlers, h)
-	t.input.mtx.Unlock()
+	return addHandler(&t.input.mtx, &t.touchHandlers, h)
 }
 
-func (t *touchStrip) addSwipeHandler(h TouchStripSwipeHandler) {
+func (t *touchStrip) addSwipeHandler(h TouchStripSwipeHandler) *HandlerRegistration {
 	if h == nil || t.input == nil {
-		return
+		return nil
 	}
-
-	t.input.mtx.Lock()
-	t.swipeHandlers = append(t.swipeHandlers, h)
-	t.input.mtx.Unlock()
+	return addHandler(&t.input.mtx, &t.swipeHandlers, h)
 }
 
 type input struct {
 	mtx        sync.Mutex
 	device     *Device
 	channel    chan bool
+	cancel     context.CancelCauseFunc
+	dispatched chan struct{}
 	pressed    time.Time
 	released   time.Time
 	duration   time.Duration
+	changed    time.Time
+	debounce   debounce
 	key        *Key
 	tp         *TouchPoint
 	dial       *Dial
@@ -412,98 +610,266 @@ func newTouchStripInput(d *Device) *input {
 	return rv
 }
 
+// dispatch calls fn from a new goroutine, after the function previously
+// dispatched for the same input returns, to guarantee that press and release
+// handlers are called in order. It must be called with the input mutex held.
+func (in *input) dispatch(fn func()) {
+	// the serial dispatcher already calls everything in order
+	if in.device.GetDispatchMode() == DISPATCH_MODE_SERIAL {
+		in.device.goHandler(fn)
+		return
+	}
+
+	prev := in.dispatched
+	done := make(chan struct{})
+	in.dispatched = done
+
+	go func() {
+		defer close(done)
+		if prev != nil {
+			<-prev
+		}
+		fn()
+	}()
+}
+
+func (in *input) dispatchPress(errCh chan error) {
+	if in.key != nil && len(in.key.pressHandlers) > 0 {
+		hnds := append([]handler[KeyHandler]{}, in.key.pressHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.key) }); err != nil {
+					in.device.sendHandlerError(errCh, KeyHandlerError{KeyID: in.key.id, Err: err})
+				}
+			}
+		})
+	}
+
+	if in.tp != nil && len(in.tp.pressHandlers) > 0 {
+		hnds := append([]handler[TouchPointHandler]{}, in.tp.pressHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.tp) }); err != nil {
+					in.device.sendHandlerError(errCh, TouchPointHandlerError{TouchPointID: in.tp.id, Err: err})
+				}
+			}
+		})
+	}
+
+	if in.dial != nil && len(in.dial.pressHandlers) > 0 {
+		hnds := append([]handler[DialSwitchHandler]{}, in.dial.pressHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.dial) }); err != nil {
+					in.device.sendHandlerError(errCh, DialHandlerError{DialID: in.dial.id, Err: err})
+				}
+			}
+		})
+	}
+}
+
+func (in *input) dispatchRelease(duration time.Duration, errCh chan error) {
+	if in.key != nil && len(in.key.releaseHandlers) > 0 {
+		hnds := append([]handler[KeyReleaseHandler]{}, in.key.releaseHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.key, duration) }); err != nil {
+					in.device.sendHandlerError(errCh, KeyHandlerError{KeyID: in.key.id, Err: err})
+				}
+			}
+		})
+	}
+
+	if in.tp != nil && len(in.tp.releaseHandlers) > 0 {
+		hnds := append([]handler[TouchPointReleaseHandler]{}, in.tp.releaseHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.tp, duration) }); err != nil {
+					in.device.sendHandlerError(errCh, TouchPointHandlerError{TouchPointID: in.tp.id, Err: err})
+				}
+			}
+		})
+	}
+
+	if in.dial != nil && len(in.dial.releaseHandlers) > 0 {
+		hnds := append([]handler[DialReleaseHandler]{}, in.dial.releaseHandlers...)
+		in.dispatch(func() {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, in.dial, duration) }); err != nil {
+					in.device.sendHandlerError(errCh, DialHandlerError{DialID: in.dial.id, Err: err})
+				}
+			}
+		})
+	}
+}
+
+// dispatchContext calls the context handlers of a pressed input, with a
+// context that is cancelled when the input is released or the device is
+// closed. It must be called with the input mutex held.
+func (in *input) dispatchContext(errCh chan error) {
+	if in.cancel != nil {
+		in.cancel(ErrInputReleased)
+		in.cancel = nil
+	}
+
+	n := 0
+	switch {
+	case in.key != nil:
+		n = len(in.key.contextHandlers)
+	case in.tp != nil:
+		n = len(in.tp.contextHandlers)
+	case in.dial != nil:
+		n = len(in.dial.contextHandlers)
+	}
+	if n == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	in.cancel = cancel
+
+	in.device.mtx.Lock()
+	done := in.device.done
+	in.device.mtx.Unlock()
+	go func() {
+		select {
+		case <-done:
+			cancel(ErrDeviceIsClosed)
+		case <-ctx.Done():
+		}
+	}()
+
+	if in.key != nil {
+		for _, h := range in.key.contextHandlers {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(ctx, in.device, in.key) }); err != nil {
+					in.device.sendHandlerError(errCh, KeyHandlerError{KeyID: in.key.id, Err: err})
+				}
+			})
+		}
+	}
+
+	if in.tp != nil {
+		for _, h := range in.tp.contextHandlers {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(ctx, in.device, in.tp) }); err != nil {
+					in.device.sendHandlerError(errCh, TouchPointHandlerError{TouchPointID: in.tp.id, Err: err})
+				}
+			})
+		}
+	}
+
+	if in.dial != nil {
+		for _, h := range in.dial.contextHandlers {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(ctx, in.device, in.dial) }); err != nil {
+					in.device.sendHandlerError(errCh, DialHandlerError{DialID: in.dial.id, Err: err})
+				}
+			})
+		}
+	}
+}
+
 func (in *input) press(t time.Time, errCh chan error) {
 	in.mtx.Lock()
 	defer in.mtx.Unlock()
 
+	in.transition(t, true, errCh)
+}
+
+func (in *input) release(t time.Time, errCh chan error) {
+	in.mtx.Lock()
+	defer in.mtx.Unlock()
+
+	in.transition(t, false, errCh)
+}
+
+// applyPress dispatches a press of the input. It must be called with the
+// input mutex held.
+func (in *input) applyPress(t time.Time, errCh chan error) {
+	in.changed = t
 	in.channel = make(chan bool)
 	in.pressed = t
 	in.released = time.Time{}
 	in.duration = 0
+	in.dispatchPress(errCh)
+	in.dispatchContext(errCh)
+	in.startRepeat(errCh)
 
 	if in.key != nil {
 		for _, h := range in.key.handlers {
-			go func(in *input, hnd KeyHandler) {
-				if err := hnd(in.device, in.key); err != nil {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(in.device, in.key) }); err != nil {
 					e := KeyHandlerError{
 						KeyID: in.key.id,
 						Err:   err,
 					}
 
-					if errCh != nil {
-						select {
-						case errCh <- e:
-						default:
-						}
-					} else {
-						log.Printf("error: %s", e)
-					}
+					in.device.sendHandlerError(errCh, e)
 				}
-			}(in, h)
+			})
 		}
 	}
 
 	if in.tp != nil {
 		for _, h := range in.tp.handlers {
-			go func(in *input, hnd TouchPointHandler) {
-				if err := hnd(in.device, in.tp); err != nil {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(in.device, in.tp) }); err != nil {
 					e := TouchPointHandlerError{
 						TouchPointID: in.tp.id,
 						Err:          err,
 					}
 
-					if errCh != nil {
-						select {
-						case errCh <- e:
-						default:
-						}
-					} else {
-						log.Printf("error: %s", e)
-					}
+					in.device.sendHandlerError(errCh, e)
 				}
-			}(in, h)
+			})
 		}
 	}
 
 	if in.dial != nil {
 		for _, h := range in.dial.switchHandlers {
-			go func(in *input, hnd DialSwitchHandler) {
-				if err := hnd(in.device, in.dial); err != nil {
+			hnd := h.fn
+			in.device.goHandler(func() {
+				if err := callHandler(func() error { return hnd(in.device, in.dial) }); err != nil {
 					e := DialHandlerError{
 						DialID: in.dial.id,
 						Err:    err,
 					}
 
-					if errCh != nil {
-						select {
-						case errCh <- e:
-						default:
-						}
-					} else {
-						log.Printf("error: %s", e)
-					}
+					in.device.sendHandlerError(errCh, e)
 				}
-			}(in, h)
+			})
 		}
 	}
 }
 
-func (in *input) release(t time.Time) {
-	in.mtx.Lock()
-	defer in.mtx.Unlock()
-
-	// currently released
-	if !in.released.IsZero() {
+// applyRelease dispatches a release of the input. It must be called with the
+// input mutex held.
+func (in *input) applyRelease(t time.Time, errCh chan error) {
+	// currently released, or never pressed
+	if in.pressed.IsZero() {
 		return
 	}
 
+	in.changed = t
+	in.stopRepeat()
 	in.released = t
 	in.duration = in.released.Sub(in.pressed)
 	in.pressed = time.Time{}
 	close(in.channel)
+	if in.cancel != nil {
+		in.cancel(ErrInputReleased)
+		in.cancel = nil
+	}
+	in.dispatchRelease(in.duration, errCh)
 }
 
-func (in *input) rotate(delta int8, errCh chan error) {
+func (in *input) rotate(t time.Time, delta int8, errCh chan error) {
 	in.mtx.Lock()
 	defer in.mtx.Unlock()
 
@@ -511,24 +877,20 @@ func (in *input) rotate(delta int8, errCh chan error) {
 		return
 	}
 
+	in.accumulateRotation(t, delta, errCh)
+
 	for _, h := range in.dial.rotateHandlers {
-		go func(in *input, hnd DialRotateHandler) {
-			if err := hnd(in.device, in.dial, delta); err != nil {
+		hnd := h.fn
+		in.device.goHandler(func() {
+			if err := callHandler(func() error { return hnd(in.device, in.dial, delta) }); err != nil {
 				e := DialHandlerError{
 					DialID: in.dial.id,
 					Err:    err,
 				}
 
-				if errCh != nil {
-					select {
-					case errCh <- e:
-					default:
-					}
-				} else {
-					log.Printf("error: %s", e)
-				}
+				in.device.sendHandlerError(errCh, e)
 			}
-		}(in, h)
+		})
 	}
 }
 
@@ -540,25 +902,26 @@ func (in *input) touch(t TouchStripTouchType, p image.Point, errCh chan error) {
 		return
 	}
 
+	if t == TOUCH_STRIP_TOUCH_TYPE_LONG {
+		in.dispatchDrag([]touchStripDragEvent{
+			{TOUCH_STRIP_DRAG_PHASE_BEGIN, p},
+			{TOUCH_STRIP_DRAG_PHASE_END, p},
+		}, errCh)
+	}
+
 	for _, h := range in.touchStrip.touchHandlers {
-		go func(in *input, hnd TouchStripTouchHandler) {
-			if err := hnd(in.device, t, p); err != nil {
+		hnd := h.fn
+		in.device.goHandler(func() {
+			if err := callHandler(func() error { return hnd(in.device, t, p) }); err != nil {
 				e := TouchStripTouchHandlerError{
 					Type:  t,
 					Point: p,
 					Err:   err,
 				}
 
-				if errCh != nil {
-					select {
-					case errCh <- e:
-					default:
-					}
-				} else {
-					log.Printf("error: %s", e)
-				}
+				in.device.sendHandlerError(errCh, e)
 			}
-		}(in, h)
+		})
 	}
 }
 
@@ -570,24 +933,24 @@ func (in *input) swipe(origin image.Point, destination image.Point, errCh chan e
 		return
 	}
 
+	in.dispatchDrag([]touchStripDragEvent{
+		{TOUCH_STRIP_DRAG_PHASE_BEGIN, origin},
+		{TOUCH_STRIP_DRAG_PHASE_MOVE, destination},
+		{TOUCH_STRIP_DRAG_PHASE_END, destination},
+	}, errCh)
+
 	for _, h := range in.touchStrip.swipeHandlers {
-		go func(in *input, hnd TouchStripSwipeHandler) {
-			if err := hnd(in.device, origin, destination); err != nil {
+		hnd := h.fn
+		in.device.goHandler(func() {
+			if err := callHandler(func() error { return hnd(in.device, origin, destination) }); err != nil {
 				e := TouchStripSwipeHandlerError{
 					Origin:      origin,
 					Destination: destination,
 					Err:         err,
 				}
 
-				if errCh != nil {
-					select {
-					case errCh <- e:
-					default:
-					}
-				} else {
-					log.Printf("error: %s", e)
-				}
+				in.device.sendHandlerError(errCh, e)
 			}
-		}(in, h)
+		})
 	}
 }
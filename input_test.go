@@ -0,0 +1,81 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestHandlerPanic(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	released := make(chan struct{})
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		panic("bola")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyReleaseHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+		close(released)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialRotateHandler(streamdeck.DIAL_1, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+		panic(errors.New("guda"))
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 10)
+	go dev.Listen(errCh)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errCh:
+		khe := streamdeck.KeyHandlerError{}
+		phe := streamdeck.HandlerPanicError{}
+		if !errors.As(err, &khe) || khe.KeyID != streamdeck.KEY_1 || !errors.As(err, &phe) || phe.Value != "bola" || len(phe.Stack) == 0 {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+
+	// handlers dispatched after the panic are still called
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for release handler")
+	}
+
+	if err := m.RotateDial(streamdeck.DIAL_1, 1); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errCh:
+		dhe := streamdeck.DialHandlerError{}
+		if !errors.As(err, &dhe) || err.Error() != "handler panic: guda [DIAL_1]" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for error")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+// InputState represents a snapshot of the inputs of an Elgato Stream Deck
+// device currently held down.
+type InputState struct {
+	Keys        []KeyID
+	TouchPoints []TouchPointID
+	Dials       []DialID
+}
+
+// GetInputState returns a snapshot of the inputs of the Elgato Stream Deck
+// device currently held down. The states are tracked by Listen and
+// ListenContext, and all inputs are reported as released if the device is
+// not being listened to.
+func (d *Device) GetInputState() InputState {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	rv := InputState{}
+	for i, st := range d.keyStates {
+		if st == 0 {
+			continue
+		}
+		if i < int(d.model.keyCount) {
+			rv.Keys = append(rv.Keys, KEY_1+KeyID(i))
+		} else {
+			rv.TouchPoints = append(rv.TouchPoints, TOUCH_POINT_1+TouchPointID(i-int(d.model.keyCount)))
+		}
+	}
+	for i, st := range d.dialStates {
+		if st != 0 {
+			rv.Dials = append(rv.Dials, DIAL_1+DialID(i))
+		}
+	}
+	return rv
+}
+
+// IsKeyPressed returns true if the given Elgato Stream Deck key is currently
+// held down.
+func (d *Device) IsKeyPressed(key KeyID) (bool, error) {
+	if err := d.validateKey(key); err != nil {
+		return false, err
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	i := int(key - KEY_1)
+	return i < len(d.keyStates) && d.keyStates[i] != 0, nil
+}
+
+// IsTouchPointPressed returns true if the given Elgato Stream Deck touch
+// point is currently held down.
+func (d *Device) IsTouchPointPressed(tp TouchPointID) (bool, error) {
+	if err := d.validateTouchPoint(tp); err != nil {
+		return false, err
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	i := int(d.model.keyCount) + int(tp-TOUCH_POINT_1)
+	return i < len(d.keyStates) && d.keyStates[i] != 0, nil
+}
+
+// IsDialPressed returns true if the given Elgato Stream Deck dial is
+// currently held down.
+func (d *Device) IsDialPressed(di DialID) (bool, error) {
+	if err := d.validateDial(di); err != nil {
+		return false, err
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	i := int(di - DIAL_1)
+	return i < len(d.dialStates) && d.dialStates[i] != 0, nil
+}
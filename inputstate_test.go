@@ -0,0 +1,72 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestInputState(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	go dev.Listen(nil)
+
+	waitState := func(want streamdeck.InputState) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			st := dev.GetInputState()
+			if slices.Equal(st.Keys, want.Keys) && slices.Equal(st.TouchPoints, want.TouchPoints) && slices.Equal(st.Dials, want.Dials) {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("unexpected input state: got %+v, want %+v", st, want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PressDial(streamdeck.DIAL_2); err != nil {
+		t.Fatal(err)
+	}
+	waitState(streamdeck.InputState{
+		Keys:  []streamdeck.KeyID{streamdeck.KEY_3},
+		Dials: []streamdeck.DialID{streamdeck.DIAL_2},
+	})
+
+	if pressed, err := dev.IsKeyPressed(streamdeck.KEY_3); err != nil || !pressed {
+		t.Errorf("key should be pressed: %v", err)
+	}
+	if pressed, err := dev.IsKeyPressed(streamdeck.KEY_1); err != nil || pressed {
+		t.Errorf("key should be released: %v", err)
+	}
+	if pressed, err := dev.IsDialPressed(streamdeck.DIAL_2); err != nil || !pressed {
+		t.Errorf("dial should be pressed: %v", err)
+	}
+	if _, err := dev.IsDialPressed(streamdeck.DIAL_4 + 1); !errors.Is(err, streamdeck.ErrDialInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := m.ReleaseKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseDial(streamdeck.DIAL_2); err != nil {
+		t.Fatal(err)
+	}
+	waitState(streamdeck.InputState{})
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"time"
+)
+
+// KeyRepeatOptions represents the settings used to repeat the press events of
+// a key while it is held, like the keys of a keyboard. The zero value does not
+// repeat the press events.
+type KeyRepeatOptions struct {
+	// Delay is the time the key must be held for before the first repeated
+	// press event. If zero, Interval is used.
+	Delay time.Duration
+
+	// Interval is the time between repeated press events. If zero, press
+	// events are not repeated.
+	Interval time.Duration
+}
+
+type keyRepeat struct {
+	opts  KeyRepeatOptions
+	timer *time.Timer
+}
+
+// SetKeyRepeatOptions sets the settings used to repeat the press events of
+// the given key while it is held. Repeated press events call the callbacks
+// registered with AddKeyPressHandler, in order with the other press and
+// release callbacks of the key. The callbacks registered with AddKeyHandler
+// and AddKeyContextHandler are only called once for each press, and the
+// release callbacks receive the duration since the original press. It
+// affects the presses received after it returns.
+func (d *Device) SetKeyRepeatOptions(key KeyID, opts KeyRepeatOptions) error {
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			in.mtx.Lock()
+			in.key.repeat.opts = opts
+			in.mtx.Unlock()
+		}
+	}
+	return nil
+}
+
+// GetKeyRepeatOptions returns the settings used to repeat the press events of
+// the given key while it is held.
+func (d *Device) GetKeyRepeatOptions(key KeyID) (KeyRepeatOptions, error) {
+	if err := d.validateKey(key); err != nil {
+		return KeyRepeatOptions{}, err
+	}
+
+	d.initInputs()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == key {
+			in.mtx.Lock()
+			defer in.mtx.Unlock()
+			return in.key.repeat.opts, nil
+		}
+	}
+	return KeyRepeatOptions{}, nil
+}
+
+// startRepeat schedules the repeated press events of a pressed key. It must
+// be called with the input mutex held.
+func (in *input) startRepeat(errCh chan error) {
+	in.stopRepeat()
+	if in.key == nil || in.key.repeat.opts.Interval <= 0 {
+		return
+	}
+
+	in.device.mtx.Lock()
+	done := in.device.done
+	in.device.mtx.Unlock()
+
+	r := &in.key.repeat
+	interval := r.opts.Interval
+	delay := r.opts.Delay
+	if delay <= 0 {
+		delay = interval
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		in.mtx.Lock()
+		defer in.mtx.Unlock()
+
+		// released, or pressed again, since scheduled
+		if r.timer != timer {
+			return
+		}
+
+		select {
+		case <-done:
+			r.timer = nil
+			return
+		default:
+		}
+
+		in.dispatchPress(errCh)
+		timer.Reset(interval)
+	})
+	r.timer = timer
+}
+
+// stopRepeat cancels the repeated press events of a key. It must be called
+// with the input mutex held.
+func (in *input) stopRepeat() {
+	if in.key == nil || in.key.repeat.timer == nil {
+		return
+	}
+	in.key.repeat.timer.Stop()
+	in.key.repeat.timer = nil
+}
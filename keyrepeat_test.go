@@ -0,0 +1,77 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestKeyRepeat(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if _, err := dev.GetKeyRepeatOptions(0); !errors.Is(err, streamdeck.ErrKeyInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	opts := streamdeck.KeyRepeatOptions{
+		Delay:    50 * time.Millisecond,
+		Interval: 20 * time.Millisecond,
+	}
+	if err := dev.SetKeyRepeatOptions(streamdeck.KEY_1, opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := dev.GetKeyRepeatOptions(streamdeck.KEY_1); err != nil || got != opts {
+		t.Errorf("unexpected options: %+v: %v", got, err)
+	}
+
+	var presses atomic.Int32
+	handlers := make(chan struct{}, 10)
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		presses.Add(1)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		handlers <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// one press, plus repeats after 50ms every 20ms while held
+	n := presses.Load()
+	if n < 3 || n > 7 {
+		t.Errorf("unexpected number of presses: %d", n)
+	}
+	if l := len(handlers); l != 1 {
+		t.Errorf("unexpected number of key handler calls: %d", l)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if presses.Load() != n {
+		t.Errorf("presses repeated after release")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+)
+
+func (m *model) keyRows() int {
+	if m.keyColumns == 0 {
+		return 0
+	}
+	return (int(m.keyCount) + int(m.keyColumns) - 1) / int(m.keyColumns)
+}
+
+func (m *model) keyPosition(key KeyID) (int, int) {
+	i := int(key - KEY_1)
+	return i / int(m.keyColumns), i % int(m.keyColumns)
+}
+
+// GetKeyLayout returns the number of rows and columns of the Elgato Stream
+// Deck key grid. The last row may be incomplete on some models.
+func (d *Device) GetKeyLayout() (int, int) {
+	return d.model.keyRows(), int(d.model.keyColumns)
+}
+
+// KeyAt returns the KeyID of the Elgato Stream Deck key at the given
+// zero-based row and column of the key grid, counting from the top left key.
+func (d *Device) KeyAt(row int, col int) (KeyID, error) {
+	if row < 0 || col < 0 || col >= int(d.model.keyColumns) {
+		return 0, fmt.Errorf("streamdeck: %w: row %d, column %d", ErrKeyPositionInvalid, row, col)
+	}
+
+	key := KEY_1 + KeyID(row*int(d.model.keyColumns)+col)
+	if err := d.validateKey(key); err != nil {
+		return 0, fmt.Errorf("streamdeck: %w: row %d, column %d", ErrKeyPositionInvalid, row, col)
+	}
+	return key, nil
+}
+
+// KeyPosition returns the zero-based row and column of an Elgato Stream Deck
+// key in the key grid, counting from the top left key.
+func (d *Device) KeyPosition(key KeyID) (int, int, error) {
+	if err := d.validateKey(key); err != nil {
+		return 0, 0, err
+	}
+
+	row, col := d.model.keyPosition(key)
+	return row, col, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	leaseHeartbeatInterval = 250 * time.Millisecond
+	leaseStaleTimeout      = 5 * time.Second
+)
+
+var (
+	leaseDir = os.TempDir()
+
+	// leaseStaleHook is called by leaseDiscardStale after finding a stale
+	// lock file, to simulate races in tests.
+	leaseStaleHook func()
+)
+
+// Lease represents a cooperative lease of an Elgato Stream Deck device,
+// shared between processes in the same machine. It allows cooperating
+// processes to hand the device back and forth cleanly, instead of fighting
+// over exclusive opens.
+//
+// The lease holder must watch the Revoked channel, and when it is closed
+// (because another process requested a takeover), close the device and call
+// Release as soon as possible.
+type Lease struct {
+	lockFile    string
+	requestFile string
+	owner       string
+	mtx         sync.Mutex
+	revoked     chan struct{}
+	stop        chan struct{}
+	stopped     chan struct{}
+}
+
+// instanceID returns a string that identifies the Elgato Stream Deck device
+// across processes, safe to be used as part of file names.
+func (d *Device) instanceID() string {
+	id := d.GetSerialNumber()
+	if id == "" {
+		h := sha256.Sum256([]byte(d.dev.Path()))
+		return hex.EncodeToString(h[:8])
+	}
+
+	b := strings.Builder{}
+	for _, r := range id {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+func leaseFiles(id string) (string, string) {
+	base := filepath.Join(leaseDir, "streamdeck-"+id)
+	return base + ".lock", base + ".request"
+}
+
+func leaseStale(name string) bool {
+	st, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return time.Since(st.ModTime()) > leaseStaleTimeout
+}
+
+func leaseOwner(name string) string {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// leaseTake moves the lock file aside to a name unique to the owner, so that
+// only one process can claim it, even if several processes concurrently
+// found it stale or are releasing it. It returns the new name of the lock
+// file, or an empty string if the lock file was moved by another process.
+func leaseTake(lock string, owner string) string {
+	rv := lock + "." + owner
+	if err := os.Rename(lock, rv); err != nil {
+		return ""
+	}
+	return rv
+}
+
+// leaseRestore moves a lock file taken by mistake back in place, unless a
+// new lock file was created meanwhile. The holder of the lost lock notices
+// that on its next heartbeat.
+func leaseRestore(taken string, lock string) {
+	os.Link(taken, lock)
+	os.Remove(taken)
+}
+
+// leaseDiscardStale removes the lock file if it is stale. The lock file is
+// taken before being checked again, so that a fresh lock file created by a
+// process that discarded the same stale lock file concurrently is never
+// removed.
+func leaseDiscardStale(lock string, owner string) {
+	if !leaseStale(lock) {
+		return
+	}
+	if leaseStaleHook != nil {
+		leaseStaleHook()
+	}
+
+	taken := leaseTake(lock, owner)
+	if taken == "" {
+		return
+	}
+	if leaseStale(taken) {
+		os.Remove(taken)
+		return
+	}
+	leaseRestore(taken, lock)
+}
+
+// AcquireLease acquires a Lease for the Elgato Stream Deck device. If the
+// lease is held by another process, it blocks until it is released, or the
+// context is cancelled. If takeover is true, the current holder is asked to
+// release the lease.
+//
+// Leases whose holder died without releasing them are detected and
+// discarded automatically.
+func (d *Device) AcquireLease(ctx context.Context, takeover bool) (*Lease, error) {
+	return acquireLease(ctx, d.instanceID(), takeover)
+}
+
+func acquireLease(ctx context.Context, id string, takeover bool) (*Lease, error) {
+	lock, request := leaseFiles(id)
+	owner := strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	requested := false
+	defer func() {
+		if requested {
+			os.Remove(request)
+		}
+	}()
+
+	for {
+		fp, err := os.OpenFile(lock, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, err = fp.WriteString(owner + "\n")
+			if cerr := fp.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(lock)
+				return nil, fmt.Errorf("streamdeck: failed to create lease: %w", err)
+			}
+
+			rv := &Lease{
+				lockFile:    lock,
+				requestFile: request,
+				owner:       owner,
+				revoked:     make(chan struct{}),
+				stop:        make(chan struct{}),
+				stopped:     make(chan struct{}),
+			}
+			go rv.heartbeat()
+			return rv, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("streamdeck: failed to create lease: %w", err)
+		}
+
+		if leaseStale(lock) {
+			leaseDiscardStale(lock, owner)
+			continue
+		}
+
+		if takeover && !requested {
+			if err := os.WriteFile(request, []byte(owner+"\n"), 0o644); err != nil {
+				return nil, fmt.Errorf("streamdeck: failed to request lease takeover: %w", err)
+			}
+			requested = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(leaseHeartbeatInterval):
+		}
+	}
+}
+
+func (l *Lease) heartbeat() {
+	defer close(l.stopped)
+
+	ticker := time.NewTicker(leaseHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		// the lock file is only touched while owned, as it may have been
+		// discarded as stale and acquired by another process, e.g. after
+		// the machine was suspended
+		if leaseOwner(l.lockFile) != l.owner {
+			l.revoke()
+			continue
+		}
+		now := time.Now()
+		os.Chtimes(l.lockFile, now, now)
+
+		if data, err := os.ReadFile(l.requestFile); err == nil && strings.TrimSpace(string(data)) != l.owner {
+			l.revoke()
+		}
+	}
+}
+
+func (l *Lease) revoke() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	select {
+	case <-l.revoked:
+	default:
+		close(l.revoked)
+	}
+}
+
+// Revoked returns a channel that is closed when another process requests a
+// takeover of the Lease, or when the Lease was lost, because it was
+// discarded as stale by another process.
+func (l *Lease) Revoked() <-chan struct{} {
+	return l.revoked
+}
+
+// Release releases the Lease, allowing other processes to acquire it. The
+// device must be closed before releasing the lease.
+func (l *Lease) Release() error {
+	l.mtx.Lock()
+	select {
+	case <-l.stop:
+		l.mtx.Unlock()
+		return nil
+	default:
+		close(l.stop)
+	}
+	l.mtx.Unlock()
+
+	<-l.stopped
+
+	// the lock file of another process is never removed, if the lease was
+	// lost
+	taken := leaseTake(l.lockFile, l.owner)
+	if taken == "" {
+		return nil
+	}
+	if leaseOwner(taken) != l.owner {
+		leaseRestore(taken, l.lockFile)
+		return nil
+	}
+	if err := os.Remove(taken); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("streamdeck: failed to release lease: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLease_Takeover(t *testing.T) {
+	leaseDir = t.TempDir()
+
+	l1, err := acquireLease(context.Background(), "ABC123", false)
+	if err != nil {
+		t.Fatalf("failed to acquire lease: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := acquireLease(ctx, "ABC123", false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	go func() {
+		<-l1.Revoked()
+		l1.Release()
+	}()
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel2()
+	l2, err := acquireLease(ctx2, "ABC123", true)
+	if err != nil {
+		t.Fatalf("failed to take over lease: %v", err)
+	}
+
+	_, request := leaseFiles("ABC123")
+	if _, err := os.Stat(request); !errors.Is(err, os.ErrNotExist) {
+		t.Error("takeover request file was not removed")
+	}
+
+	select {
+	case <-l2.Revoked():
+		t.Error("new lease should not be revoked")
+	default:
+	}
+
+	if err := l2.Release(); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+}
+
+func TestLease_Stale(t *testing.T) {
+	leaseDir = t.TempDir()
+
+	lock, _ := leaseFiles("stale_device")
+	if err := os.WriteFile(lock, []byte("1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	old := time.Now().Add(-2 * leaseStaleTimeout)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatalf("failed to change lock file times: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	l, err := acquireLease(ctx, "stale_device", false)
+	if err != nil {
+		t.Fatalf("failed to acquire stale lease: %v", err)
+	}
+	if err := l.Release(); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+}
+
+func TestLease_StaleConcurrent(t *testing.T) {
+	leaseDir = t.TempDir()
+
+	lock, _ := leaseFiles("stale_device")
+	if err := os.WriteFile(lock, []byte("1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	old := time.Now().Add(-2 * leaseStaleTimeout)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatalf("failed to change lock file times: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// all the waiters find the same stale lock file, and must hold the
+	// lease one at a time
+	holders := atomic.Int32{}
+	errs := make(chan error, 5)
+	for range cap(errs) {
+		go func() {
+			l, err := acquireLease(ctx, "stale_device", false)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if n := holders.Add(1); n != 1 {
+				errs <- fmt.Errorf("lease held by %d processes", n)
+				return
+			}
+			if owner := leaseOwner(lock); owner != l.owner {
+				errs <- fmt.Errorf("unexpected lock owner: %q", owner)
+				return
+			}
+			time.Sleep(50 * time.Millisecond)
+			holders.Add(-1)
+			errs <- l.Release()
+		}()
+	}
+	for range cap(errs) {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLease_Lost(t *testing.T) {
+	leaseDir = t.TempDir()
+
+	l, err := acquireLease(context.Background(), "lost_device", false)
+	if err != nil {
+		t.Fatalf("failed to acquire lease: %v", err)
+	}
+
+	// another process discarded the lease as stale, and acquired it
+	lock, _ := leaseFiles("lost_device")
+	if err := os.WriteFile(lock, []byte("other\n"), 0o644); err != nil {
+		t.Fatalf("failed to replace lock file: %v", err)
+	}
+
+	select {
+	case <-l.Revoked():
+	case <-time.After(time.Second):
+		t.Fatal("lost lease not revoked")
+	}
+	if err := l.Release(); err != nil {
+		t.Fatalf("failed to release lease: %v", err)
+	}
+	if owner := leaseOwner(lock); owner != "other" {
+		t.Errorf("lock file of another process removed: %q", owner)
+	}
+}
+
+func TestLease_StaleRace(t *testing.T) {
+	leaseDir = t.TempDir()
+	t.Cleanup(func() {
+		leaseStaleHook = nil
+	})
+
+	lock, _ := leaseFiles("stale_device")
+	if err := os.WriteFile(lock, []byte("1\n"), 0o644); err != nil {
+		t.Fatalf("failed to create lock file: %v", err)
+	}
+	old := time.Now().Add(-2 * leaseStaleTimeout)
+	if err := os.Chtimes(lock, old, old); err != nil {
+		t.Fatalf("failed to change lock file times: %v", err)
+	}
+
+	// another waiter discards the same stale lock file and acquires the
+	// lease, between this waiter finding it stale and taking it over
+	leaseStaleHook = func() {
+		leaseStaleHook = nil
+		if err := os.Remove(lock); err != nil {
+			t.Errorf("failed to remove lock file: %v", err)
+		}
+		if err := os.WriteFile(lock, []byte("other\n"), 0o644); err != nil {
+			t.Errorf("failed to create lock file: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := acquireLease(ctx, "stale_device", false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if owner := leaseOwner(lock); owner != "other" {
+		t.Errorf("fresh lock file removed: %q", owner)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/image/draw"
+)
+
+// LevelFeed represents a source of signal levels, like audio volume levels,
+// to be consumed by level meter widgets. Levels are normalized to the
+// [0, 1] range.
+type LevelFeed interface {
+	Level() float64
+}
+
+// LevelFeedFunc is an adapter to allow the use of ordinary functions as
+// LevelFeed.
+type LevelFeedFunc func() float64
+
+// Level returns the current level, by calling f.
+func (f LevelFeedFunc) Level() float64 {
+	return f()
+}
+
+// PCMLevelFeed is a LevelFeed that computes peak levels from a stream of
+// signed 16-bit little-endian interleaved PCM samples. Levels decay smoothly
+// after peaks, like a VU meter.
+type PCMLevelFeed struct {
+	r        io.Reader
+	channels int
+	level    atomic.Uint64
+}
+
+// NewPCMLevelFeed creates a PCMLevelFeed reading signed 16-bit little-endian
+// PCM samples with the given number of interleaved channels from an
+// io.Reader. The PCMLevelFeed.Run method must be called to start processing
+// samples.
+func NewPCMLevelFeed(r io.Reader, channels int) *PCMLevelFeed {
+	if channels <= 0 {
+		channels = 1
+	}
+	return &PCMLevelFeed{
+		r:        r,
+		channels: channels,
+	}
+}
+
+const (
+	pcmLevelFramesPerBlock = 512
+	pcmLevelDecay          = 0.85
+)
+
+// Run reads samples from the io.Reader until it returns an error, updating
+// the level after each block of samples. It returns nil when the reader
+// reaches end of file.
+func (f *PCMLevelFeed) Run() error {
+	buf := make([]byte, pcmLevelFramesPerBlock*f.channels*2)
+	for {
+		n, err := io.ReadFull(f.r, buf)
+		if n >= 2 {
+			peak := 0.
+			for i := 0; i+1 < n; i += 2 {
+				s := math.Abs(float64(int16(binary.LittleEndian.Uint16(buf[i:]))) / math.MaxInt16)
+				peak = max(peak, s)
+			}
+
+			prev := math.Float64frombits(f.level.Load())
+			f.level.Store(math.Float64bits(min(max(peak, prev*pcmLevelDecay), 1)))
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// Level returns the current peak level.
+func (f *PCMLevelFeed) Level() float64 {
+	return math.Float64frombits(f.level.Load())
+}
+
+// LevelMeter is a widget that renders a LevelFeed as a bar meter.
+type LevelMeter struct {
+	// Feed is the source of levels rendered by the meter.
+	Feed LevelFeed
+
+	// Foreground is the color of the bar. If nil, a green color is used.
+	Foreground color.Color
+
+	// Background is the color of the meter background. If nil, black is used.
+	Background color.Color
+
+	// Vertical renders the bar bottom to top, instead of left to right.
+	Vertical bool
+}
+
+// Render renders the current level of the meter to an image with the
+// dimensions of the provided rectangle.
+func (m *LevelMeter) Render(rect image.Rectangle) image.Image {
+	return m.render(rect, AccessibilityOptions{})
+}
+
+func (m *LevelMeter) render(rect image.Rectangle, opts AccessibilityOptions) image.Image {
+	fg := m.Foreground
+	if fg == nil {
+		fg = color.RGBA{0x00, 0xc8, 0x53, 0xff}
+	}
+	bg := m.Background
+	if bg == nil {
+		bg = color.Black
+	}
+	fg, bg = opts.colors(fg, bg)
+
+	level := 0.
+	if m.Feed != nil {
+		level = min(max(m.Feed.Level(), 0), 1)
+	}
+
+	rv := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(rv, rv.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	bar := rv.Bounds()
+	if m.Vertical {
+		bar.Min.Y = bar.Max.Y - int(math.Round(float64(bar.Dy())*level))
+	} else {
+		bar.Max.X = bar.Min.X + int(math.Round(float64(bar.Dx())*level))
+	}
+	draw.Draw(rv, bar, image.NewUniform(fg), image.Point{}, draw.Src)
+	return rv
+}
+
+// Stream renders the meter to a FrameSink at the frame sink rate, until the
+// context is cancelled or the frame sink fails. The accessibility settings of
+// the frame sink device are enforced.
+func (m *LevelMeter) Stream(ctx context.Context, sink *FrameSink) error {
+	if sink == nil {
+		return wrapErr(ErrFrameSinkInvalid)
+	}
+
+	rect := sink.device.model.touchStripImageRect
+	if sink.rect != nil {
+		rect = *sink.rect
+	}
+
+	ticker := time.NewTicker(sink.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := sink.WriteFrame(m.render(rect, sink.device.GetAccessibilityOptions())); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// StreamLevelMeterToDial renders a LevelMeter to the touch strip area above
+// the given dial, at the provided frame rate, until the context is
+// cancelled. This is supported by Elgato Stream Deck models with dials and a
+// touch strip display.
+func (d *Device) StreamLevelMeterToDial(ctx context.Context, di DialID, m *LevelMeter, fps int) error {
+	if err := d.validateDial(di); err != nil {
+		return err
+	}
+
+	sink, err := d.NewTouchStripFrameSinkWithRectangle(fps, d.touchStripDialRect(di))
+	if err != nil {
+		return err
+	}
+
+	if err := m.Stream(ctx, sink); err != nil {
+		sink.Close()
+		return err
+	}
+	return sink.Close()
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestPCMLevelFeed(t *testing.T) {
+	samples := make([]int16, pcmLevelFramesPerBlock*2)
+	samples[10] = math.MaxInt16 / 2
+	samples[11] = -math.MaxInt16
+
+	buf := bytes.Buffer{}
+	if err := binary.Write(&buf, binary.LittleEndian, samples); err != nil {
+		t.Fatalf("failed to write samples: %v", err)
+	}
+
+	f := NewPCMLevelFeed(&buf, 2)
+	if err := f.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if l := f.Level(); l != 1 {
+		t.Errorf("expected level 1, got %f", l)
+	}
+}
+
+func TestPCMLevelFeed_Decay(t *testing.T) {
+	samples := make([]int16, pcmLevelFramesPerBlock*2)
+	samples[0] = math.MaxInt16
+
+	buf := bytes.Buffer{}
+	if err := binary.Write(&buf, binary.LittleEndian, samples); err != nil {
+		t.Fatalf("failed to write samples: %v", err)
+	}
+
+	f := NewPCMLevelFeed(&buf, 1)
+	if err := f.Run(); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if l := f.Level(); math.Abs(l-pcmLevelDecay) > 1e-9 {
+		t.Errorf("expected level %f, got %f", pcmLevelDecay, l)
+	}
+}
+
+func TestLevelMeter_Render(t *testing.T) {
+	m := &LevelMeter{
+		Feed:       LevelFeedFunc(func() float64 { return 0.5 }),
+		Foreground: color.White,
+	}
+
+	img := m.Render(image.Rect(100, 0, 200, 10))
+	if b := img.Bounds(); b != image.Rect(0, 0, 100, 10) {
+		t.Fatalf("unexpected bounds: %s", b)
+	}
+
+	if r, _, _, _ := img.At(49, 5).RGBA(); r != 0xffff {
+		t.Error("pixel inside the bar doesn't match")
+	}
+	if r, _, _, _ := img.At(50, 5).RGBA(); r != 0 {
+		t.Error("pixel outside the bar doesn't match")
+	}
+}
+
+func TestLevelMeter_RenderVertical(t *testing.T) {
+	m := &LevelMeter{
+		Feed:       LevelFeedFunc(func() float64 { return 0.25 }),
+		Foreground: color.White,
+		Vertical:   true,
+	}
+
+	img := m.Render(image.Rect(0, 0, 10, 100))
+	if r, _, _, _ := img.At(5, 75).RGBA(); r != 0xffff {
+		t.Error("pixel inside the bar doesn't match")
+	}
+	if r, _, _, _ := img.At(5, 74).RGBA(); r != 0 {
+		t.Error("pixel outside the bar doesn't match")
+	}
+}
+
+func TestLevelMeter_RenderHighContrast(t *testing.T) {
+	m := &LevelMeter{
+		Feed:       LevelFeedFunc(func() float64 { return 0.5 }),
+		Foreground: color.RGBA{0x10, 0x20, 0x30, 0xff},
+		Background: color.RGBA{0x20, 0x20, 0x20, 0xff},
+	}
+
+	img := m.render(image.Rect(0, 0, 100, 10), AccessibilityOptions{HighContrast: true})
+	if r, g, b, _ := img.At(0, 5).RGBA(); r != 0xffff || g != 0xffff || b != 0xffff {
+		t.Error("bar color is not high-contrast")
+	}
+	if r, g, b, _ := img.At(99, 5).RGBA(); r != 0 || g != 0 || b != 0 {
+		t.Error("background color is not high-contrast")
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"errors"
+	"image"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// reportDevice is a fake HIDDevice that returns the given input reports, and
+// then fails.
+type reportDevice struct {
+	HIDDevice
+	productID uint16
+	reports   [][]byte
+}
+
+func (r *reportDevice) Open(lock bool) error {
+	return nil
+}
+
+func (r *reportDevice) IsOpen() bool {
+	return true
+}
+
+func (r *reportDevice) Close() error {
+	return nil
+}
+
+func (r *reportDevice) VendorId() uint16 {
+	return elgatoVendorID
+}
+
+func (r *reportDevice) ProductId() uint16 {
+	return r.productID
+}
+
+func (r *reportDevice) SerialNumber() string {
+	return "FUZZ"
+}
+
+func (r *reportDevice) GetInputReport() (byte, []byte, error) {
+	if len(r.reports) == 0 {
+		return 0, nil, ErrGetInputReportFailed
+	}
+	rv := r.reports[0]
+	r.reports = r.reports[1:]
+	return 1, rv, nil
+}
+
+func listenReports(t testing.TB, pid uint16, reports ...[]byte) {
+	d, err := NewDevice(&reportDevice{productID: pid, reports: reports})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.CloseWithoutClear()
+
+	// handlers are registered for every input, so that all the parsing paths
+	// are reached
+	if err := d.ForEachKey(func(k KeyID) error {
+		_, err := d.AddKeyPressHandler(k, func(d *Device, k *Key) error { return nil })
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ForEachTouchPoint(func(tp TouchPointID) error {
+		_, err := d.AddTouchPointPressHandler(tp, func(d *Device, tp *TouchPoint) error { return nil })
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ForEachDial(func(di DialID) error {
+		if _, err := d.AddDialPressHandler(di, func(d *Device, di *Dial) error { return nil }); err != nil {
+			return err
+		}
+		_, err := d.AddDialRotationHandler(di, func(d *Device, di *Dial, r DialRotation) error { return nil })
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if d.GetTouchStripSupported() {
+		if _, err := d.AddTouchStripSwipeHandler(func(d *Device, origin image.Point, destination image.Point) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := d.AddTouchStripDragHandler(func(d *Device, phase TouchStripDragPhase, p image.Point) error { return nil }); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := d.ListenContext(ctx, nil); !errors.Is(err, ErrGetInputReportFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func FuzzListen(f *testing.F) {
+	pids := []uint16{}
+	for pid := range models {
+		pids = append(pids, pid)
+	}
+	slices.Sort(pids)
+
+	for i := range pids {
+		for _, seed := range [][]byte{
+			{},
+			{1},
+			{2, 0, 0},
+			{2, 0, 0, 1, 0, 0x10, 0},
+			{2, 0, 0, 3, 0, 0x10, 0, 0x20, 0, 0x30},
+			{3, 0, 0, 0, 1},
+			{3, 0, 0, 1, 0, 0xff, 3},
+			{1, 0, 0, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1},
+		} {
+			f.Add(byte(i), seed)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, model byte, report []byte) {
+		pid := pids[int(model)%len(pids)]
+
+		// the report is also released by a zeroed copy, and truncated
+		listenReports(t, pid, report, make([]byte, len(report)), report[:len(report)/2])
+	})
+}
+
+func TestAddHandlerConcurrent(t *testing.T) {
+	d, err := NewDevice(&reportDevice{productID: 0x0084})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.CloseWithoutClear()
+
+	// the first handlers are registered concurrently with the listener, that
+	// must not race with the creation of the inputs
+	wg := sync.WaitGroup{}
+	for range 4 {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if _, err := d.AddKeyPressHandler(KEY_1, func(d *Device, k *Key) error { return nil }); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := d.AddDialRotateHandler(DIAL_1, func(d *Device, di *Dial, delta int8) error { return nil }); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := d.AddTouchStripTouchHandler(func(d *Device, typ TouchStripTouchType, p image.Point) error { return nil }); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		d.Listen(nil)
+	}()
+	wg.Wait()
+
+	for _, in := range d.inputs {
+		if in.key != nil && in.key.id == KEY_1 && len(in.key.pressHandlers) != 4 {
+			t.Errorf("unexpected number of handlers: %d", len(in.key.pressHandlers))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var reUSBPort = regexp.MustCompile(`^[0-9]+-[0-9]+(\.[0-9]+)*$`)
+
+// usbPort returns the USB port of a sysfs device path, like "1-2.3" for a
+// device connected to port 3 of a hub connected to port 2 of bus 1.
+func usbPort(sysPath string) string {
+	rv := ""
+	for _, p := range strings.Split(sysPath, "/") {
+		if reUSBPort.MatchString(p) {
+			rv = p
+		}
+	}
+	return rv
+}
+
+func deviceLocation(path string) (string, error) {
+	if !strings.HasPrefix(path, "/dev/hidraw") {
+		return "", fmt.Errorf("%w: %s", ErrDeviceLocationNotSupported, path)
+	}
+
+	sysPath, err := filepath.EvalSymlinks(filepath.Join("/sys/class/hidraw", filepath.Base(path), "device"))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrDeviceLocationNotSupported, err)
+	}
+
+	rv := usbPort(sysPath)
+	if rv == "" {
+		return "", fmt.Errorf("%w: %s", ErrDeviceLocationNotSupported, sysPath)
+	}
+	return rv, nil
+}
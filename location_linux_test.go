@@ -0,0 +1,26 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"testing"
+)
+
+func TestUSBPort(t *testing.T) {
+	for _, tc := range []struct {
+		path string
+		port string
+	}{
+		{"/sys/devices/pci0000:00/0000:00:14.0/usb1/1-2/1-2:1.0/0003:0FD9:0080.0001/hidraw/hidraw0", "1-2"},
+		{"/sys/devices/pci0000:00/0000:00:14.0/usb3/3-1/3-1.4/3-1.4.2/3-1.4.2:1.0/0003:0FD9:0084.0007/hidraw/hidraw3", "3-1.4.2"},
+		{"/sys/devices/virtual/misc/uhid/0003:0FD9:0080.0002/hidraw/hidraw1", ""},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			if port := usbPort(tc.path); port != tc.port {
+				t.Errorf("unexpected port: got %q, want %q", port, tc.port)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// lockOwner returns the pid of the process holding the lock of a file in
+// the contents of /proc/locks, or zero if not found. The file is identified
+// by the major and minor numbers of its device and its inode, as listed by
+// the kernel, e.g. "1: FLOCK ADVISORY WRITE 4242 00:05:1234 0 EOF".
+func lockOwner(r io.Reader, major uint64, minor uint64, inode uint64) int {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) < 6 || fields[1] == "->" {
+			continue
+		}
+
+		id := strings.Split(fields[5], ":")
+		if len(id) != 3 {
+			continue
+		}
+		maj, err1 := strconv.ParseUint(id[0], 16, 32)
+		mnr, err2 := strconv.ParseUint(id[1], 16, 32)
+		ino, err3 := strconv.ParseUint(id[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		if maj != major || mnr != minor || ino != inode {
+			continue
+		}
+
+		// open file description locks are not owned by a process, and are
+		// listed with pid -1
+		if pid, err := strconv.Atoi(fields[4]); err == nil && pid > 0 {
+			return pid
+		}
+	}
+	return 0
+}
+
+func deviceLockOwner(path string) (int, string, error) {
+	st := syscall.Stat_t{}
+	if err := syscall.Stat(path, &st); err != nil {
+		return 0, "", err
+	}
+	dev := uint64(st.Dev)
+	major := (dev>>8)&0xfff | (dev>>32)&^0xfff
+	minor := dev&0xff | (dev>>12)&^0xff
+
+	fp, err := os.Open("/proc/locks")
+	if err != nil {
+		return 0, "", err
+	}
+	defer fp.Close()
+
+	pid := lockOwner(fp, major, minor, uint64(st.Ino))
+	if pid == 0 {
+		return 0, "", errors.New("lock owner not found")
+	}
+
+	comm, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/comm")
+	if err != nil {
+		return pid, "", nil
+	}
+	return pid, strings.TrimSpace(string(comm)), nil
+}
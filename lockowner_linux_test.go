@@ -0,0 +1,65 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestLockOwner(t *testing.T) {
+	locks := `1: POSIX  ADVISORY  WRITE 911 00:1a:501 0 EOF
+2: FLOCK  ADVISORY  WRITE 4242 00:05:1234 0 EOF
+2: -> FLOCK  ADVISORY  WRITE 4343 00:05:1234 0 EOF
+3: OFDLCK ADVISORY  READ  -1 00:05:99 0 EOF
+4: FLOCK  ADVISORY  WRITE 5151 fd:01:1234 0 EOF
+bola
+`
+	for _, tc := range []struct {
+		major uint64
+		minor uint64
+		inode uint64
+		pid   int
+	}{
+		{0x00, 0x05, 1234, 4242},
+		{0x00, 0x1a, 501, 911},
+		{0xfd, 0x01, 1234, 5151},
+		{0x00, 0x05, 99, 0},
+		{0x00, 0x05, 4321, 0},
+	} {
+		if pid := lockOwner(strings.NewReader(locks), tc.major, tc.minor, tc.inode); pid != tc.pid {
+			t.Errorf("unexpected pid for %02x:%02x:%d: got %d, want %d", tc.major, tc.minor, tc.inode, pid, tc.pid)
+		}
+	}
+}
+
+func TestDeviceLockOwner(t *testing.T) {
+	fp, err := os.Create(filepath.Join(t.TempDir(), "hidraw0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fp.Close()
+
+	if _, _, err := deviceLockOwner(fp.Name()); err == nil {
+		t.Error("unexpected lock owner")
+	}
+
+	if err := syscall.Flock(int(fp.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		t.Skip(err)
+	}
+	pid, process, err := deviceLockOwner(fp.Name())
+	if err != nil {
+		t.Skip(err)
+	}
+	if pid != os.Getpid() {
+		t.Errorf("unexpected pid: %d", pid)
+	}
+	if process == "" {
+		t.Error("empty process name")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"log"
+	"log/slog"
+)
+
+// SetLogger sets the structured logger used by the Elgato Stream Deck device
+// to report errors that can not be returned to the caller, like input handler
+// errors when listening without an error channel and failures of background
+// tasks, and protocol warnings, like malformed input reports. Records include
+// the device serial number.
+//
+// If set to nil, the default, errors are reported to the standard logger and
+// protocol warnings are discarded. Errors and warnings are not logged if an
+// ErrorSink is set with SetErrorSink.
+func (d *Device) SetLogger(logger *slog.Logger) {
+	d.logger.Store(logger)
+}
+
+// GetLogger returns the structured logger used by the Elgato Stream Deck
+// device, or nil if not set.
+func (d *Device) GetLogger() *slog.Logger {
+	return d.logger.Load()
+}
+
+// ReportError reports an error that can not be returned to the caller, like
+// the failures of background tasks of packages built on top of the Elgato
+// Stream Deck device, the same way the device reports its own errors: to the
+// ErrorSink set with SetErrorSink, if any, or to the logger set with
+// SetLogger, with the given message, or to the standard logger.
+func (d *Device) ReportError(msg string, err error) {
+	d.logError(msg, err)
+}
+
+func (d *Device) logError(msg string, err error) {
+	if d.reportError(ERROR_SEVERITY_ERROR, err) {
+		return
+	}
+	if l := d.logger.Load(); l != nil {
+		l.Error(msg, "serial", d.GetSerialNumber(), "error", err)
+		return
+	}
+	log.Printf("error: %s", err)
+}
+
+func (d *Device) logWarn(msg string, args ...any) {
+	if d.reportError(ERROR_SEVERITY_WARNING, ProtocolWarning{Message: msg, Attrs: args}) {
+		return
+	}
+	if l := d.logger.Load(); l != nil {
+		l.Warn(msg, append([]any{"serial", d.GetSerialNumber()}, args...)...)
+	}
+}
+
+func (d *Device) sendHandlerError(errCh chan error, e error) {
+	if errCh != nil {
+		select {
+		case errCh <- e:
+		default:
+		}
+		d.reportError(ERROR_SEVERITY_ERROR, e)
+	} else {
+		d.logError("streamdeck: handler failed", e)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+type logBuffer struct {
+	mtx sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.buf.String()
+}
+
+func TestLogger(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if dev.GetLogger() != nil {
+		t.Fatal("unexpected logger")
+	}
+
+	buf := &logBuffer{}
+	logger := slog.New(slog.NewTextHandler(buf, nil))
+	dev.SetLogger(logger)
+	if dev.GetLogger() != logger {
+		t.Fatal("logger not set")
+	}
+
+	handled := make(chan struct{})
+	if _, err := dev.AddKeyPressHandler(streamdeck.KEY_2, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		defer close(handled)
+		return errors.New("boom")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	if err := m.InjectInputReport([]byte{2, 0, 0, 7}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("handler not called")
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for !strings.Contains(buf.String(), "handler failed") && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	out := buf.String()
+	for _, s := range []string{
+		`level=WARN msg="streamdeck: unknown touch strip event" serial=MOCKplus type=7`,
+		`level=ERROR msg="streamdeck: handler failed" serial=MOCKplus error="boom [KEY_2]"`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("log record not found: %s\n%s", s, out)
+		}
+	}
+}
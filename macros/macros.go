@@ -0,0 +1,255 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package macros binds scripted sequences of steps to Elgato Stream Deck
+// keys, like delays, key image changes, external commands and application
+// events, turning a device into a soundboard or macro pad.
+//
+// A macro starts when its key is pressed, and runs in the background, so
+// that other inputs are still handled. Pressing the key again while the
+// macro is running cancels it:
+//
+//	m, err := macros.New(
+//		macros.SetColor(color.RGBA{R: 0xff, A: 0xff}),
+//		macros.Run("paplay", "airhorn.wav"),
+//		macros.Delay(time.Second),
+//		macros.SetText("Airhorn", streamdeck.TextOptions{}),
+//	)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	m.Bind(dev, streamdeck.KEY_1)
+//
+// Key presses are only handled while the application calls Device.Listen.
+package macros
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os/exec"
+	"sync"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the macros package.
+var (
+	ErrCommandFailed = errors.New("macros: command failed")
+	ErrMacroInvalid  = errors.New("macros: macro is not valid")
+)
+
+// Step is a step of a Macro, called with the device and the key the macro
+// is bound to. Steps must return promptly when the context is cancelled.
+type Step func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error
+
+// Delay creates a Step that waits for the given duration.
+func Delay(d time.Duration) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+			return nil
+		}
+	}
+}
+
+// SetImage creates a Step that draws an image.Image to the key.
+func SetImage(img image.Image) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		return dev.SetKeyImage(key, img)
+	}
+}
+
+// SetColor creates a Step that fills the key with a color.
+func SetColor(c color.Color) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		return dev.SetKeyColor(key, c)
+	}
+}
+
+// SetText creates a Step that draws a text to the key, using the given
+// TextOptions.
+func SetText(text string, opts streamdeck.TextOptions) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		return dev.SetKeyTextWithOptions(key, text, opts)
+	}
+}
+
+// Run creates a Step that executes an external command, followed by its
+// arguments, and waits for it to finish. The command is not run by a shell,
+// and is killed if the macro is cancelled.
+func Run(command ...string) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		if len(command) == 0 || command[0] == "" {
+			return fmt.Errorf("%w: command is empty", ErrCommandFailed)
+		}
+
+		if err := exec.CommandContext(ctx, command[0], command[1:]...).Run(); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%w: %s: %w", ErrCommandFailed, command[0], err)
+		}
+		return nil
+	}
+}
+
+// Emit creates a Step that sends an event identifier to a channel, so that
+// the application can handle it. The step blocks until the event is
+// received, or the macro is cancelled.
+func Emit(events chan<- string, event string) Step {
+	return func(ctx context.Context, dev *streamdeck.Device, key streamdeck.KeyID) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case events <- event:
+			return nil
+		}
+	}
+}
+
+// Macro is a sequence of steps, bound to Elgato Stream Deck keys.
+type Macro struct {
+	steps []Step
+}
+
+// New creates a Macro running the given steps, in order.
+func New(steps ...Step) (*Macro, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("%w: no steps", ErrMacroInvalid)
+	}
+	for i, s := range steps {
+		if s == nil {
+			return nil, fmt.Errorf("%w: step %d is nil", ErrMacroInvalid, i)
+		}
+	}
+	return &Macro{steps: steps}, nil
+}
+
+// Binding represents a Macro bound to an Elgato Stream Deck key.
+type Binding struct {
+	macro *Macro
+	dev   *streamdeck.Device
+	key   streamdeck.KeyID
+	reg   *streamdeck.HandlerRegistration
+
+	mtx    sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Bind registers a handler for an Elgato Stream Deck key, that starts the
+// macro when the key is pressed, or cancels it if it is already running.
+// Errors returned by the steps stop the macro, and are reported with
+// Device.ReportError.
+func (m *Macro) Bind(dev *streamdeck.Device, key streamdeck.KeyID) (*Binding, error) {
+	if dev == nil {
+		return nil, fmt.Errorf("%w: device is nil", ErrMacroInvalid)
+	}
+
+	rv := &Binding{
+		macro: m,
+		dev:   dev,
+		key:   key,
+	}
+
+	reg, err := dev.AddKeyHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		rv.toggle()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	rv.reg = reg
+	return rv, nil
+}
+
+func (b *Binding) toggle() {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.cancel != nil {
+		b.cancel()
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	b.cancel = cancel
+	b.done = done
+
+	go func() {
+		defer close(done)
+		defer func() {
+			b.mtx.Lock()
+			if b.done == done {
+				b.cancel = nil
+				b.done = nil
+			}
+			b.mtx.Unlock()
+			cancel()
+		}()
+
+		for _, s := range b.macro.steps {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := s(ctx, b.dev, b.key); err != nil {
+				if ctx.Err() == nil {
+					b.logError(err)
+				}
+				return
+			}
+		}
+	}()
+}
+
+func (b *Binding) logError(err error) {
+	b.dev.ReportError("macros: macro failed", fmt.Errorf("macros: %s: %w", b.key, err))
+}
+
+// Running returns true if the macro is running.
+func (b *Binding) Running() bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.cancel != nil
+}
+
+// Cancel stops the macro, if running, and waits for the running step to
+// return.
+func (b *Binding) Cancel() {
+	b.mtx.Lock()
+	cancel, done := b.cancel, b.done
+	b.mtx.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Wait blocks until the macro is not running.
+func (b *Binding) Wait() {
+	b.mtx.Lock()
+	done := b.done
+	b.mtx.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Remove unregisters the key handler of the Binding, and cancels the macro,
+// if running. The key image is not cleared.
+func (b *Binding) Remove() {
+	b.reg.Remove()
+	b.Cancel()
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package macros
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func waitEvent(t *testing.T, events chan string, want string) {
+	t.Helper()
+
+	select {
+	case ev := <-events:
+		if ev != want {
+			t.Errorf("unexpected event: %q", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timeout waiting for event: %q", want)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(); !errors.Is(err, ErrMacroInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := New(Delay(time.Second), nil); !errors.Is(err, ErrMacroInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := Delay(time.Hour)(ctx, nil, streamdeck.KEY_1); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Emit(make(chan string), "foo")(ctx, nil, streamdeck.KEY_1); !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Run("true")(context.Background(), nil, streamdeck.KEY_1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, cmd := range [][]string{{}, {"false"}} {
+		if err := Run(cmd...)(context.Background(), nil, streamdeck.KEY_1); !errors.Is(err, ErrCommandFailed) {
+			t.Errorf("%v: unexpected error: %v", cmd, err)
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	macro, err := New(
+		SetColor(color.RGBA{R: 0xff, A: 0xff}),
+		Emit(events, "started"),
+		Delay(time.Hour),
+		SetColor(color.RGBA{B: 0xff, A: 0xff}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := macro.Bind(dev, streamdeck.KEY_1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quick, err := New(
+		SetColor(color.RGBA{G: 0xff, A: 0xff}),
+		Delay(10*time.Millisecond),
+		Emit(events, "done"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qb, err := quick.Bind(dev, streamdeck.KEY_2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, "started")
+	if !b.Running() {
+		t.Error("macro not running")
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{R: 0xff})
+
+	// other keys are handled while the macro runs
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	waitEvent(t, events, "done")
+	qb.Wait()
+	if qb.Running() {
+		t.Error("macro still running")
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{G: 0xff})
+
+	// pressing the key again cancels the macro
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		b.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for macro to be cancelled")
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 36, color.RGBA{R: 0xff})
+
+	b.Remove()
+	qb.Remove()
+	if _, err := macro.Bind(nil, streamdeck.KEY_1); !errors.Is(err, ErrMacroInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
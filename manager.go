@@ -0,0 +1,419 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ManagerEventType represents the type of a ManagerEvent.
+type ManagerEventType byte
+
+// String returns a string representation of the ManagerEventType.
+func (t ManagerEventType) String() string {
+	switch t {
+	case MANAGER_EVENT_TYPE_KEY_PRESS:
+		return "MANAGER_EVENT_TYPE_KEY_PRESS"
+	case MANAGER_EVENT_TYPE_KEY_RELEASE:
+		return "MANAGER_EVENT_TYPE_KEY_RELEASE"
+	case MANAGER_EVENT_TYPE_TOUCH_POINT_PRESS:
+		return "MANAGER_EVENT_TYPE_TOUCH_POINT_PRESS"
+	case MANAGER_EVENT_TYPE_TOUCH_POINT_RELEASE:
+		return "MANAGER_EVENT_TYPE_TOUCH_POINT_RELEASE"
+	case MANAGER_EVENT_TYPE_DIAL_PRESS:
+		return "MANAGER_EVENT_TYPE_DIAL_PRESS"
+	case MANAGER_EVENT_TYPE_DIAL_RELEASE:
+		return "MANAGER_EVENT_TYPE_DIAL_RELEASE"
+	case MANAGER_EVENT_TYPE_DIAL_ROTATE:
+		return "MANAGER_EVENT_TYPE_DIAL_ROTATE"
+	case MANAGER_EVENT_TYPE_TOUCH_STRIP_TOUCH:
+		return "MANAGER_EVENT_TYPE_TOUCH_STRIP_TOUCH"
+	case MANAGER_EVENT_TYPE_TOUCH_STRIP_SWIPE:
+		return "MANAGER_EVENT_TYPE_TOUCH_STRIP_SWIPE"
+	default:
+		return ""
+	}
+}
+
+// Manager event types. These constants represent the input events of the
+// managed devices reported by Manager.
+const (
+	MANAGER_EVENT_TYPE_KEY_PRESS ManagerEventType = iota + 1
+	MANAGER_EVENT_TYPE_KEY_RELEASE
+	MANAGER_EVENT_TYPE_TOUCH_POINT_PRESS
+	MANAGER_EVENT_TYPE_TOUCH_POINT_RELEASE
+	MANAGER_EVENT_TYPE_DIAL_PRESS
+	MANAGER_EVENT_TYPE_DIAL_RELEASE
+	MANAGER_EVENT_TYPE_DIAL_ROTATE
+	MANAGER_EVENT_TYPE_TOUCH_STRIP_TOUCH
+	MANAGER_EVENT_TYPE_TOUCH_STRIP_SWIPE
+)
+
+// ManagerEvent represents an input event of one of the Elgato Stream Deck
+// devices of a Manager. Only the fields relevant to the event type are set.
+type ManagerEvent struct {
+	Type   ManagerEventType
+	Serial string
+	Device *Device
+
+	Key        KeyID
+	TouchPoint TouchPointID
+	Dial       DialID
+
+	// Duration is the time the input was held, for release events.
+	Duration time.Duration
+
+	// Delta is the rotation delta, for dial rotate events.
+	Delta int8
+
+	// TouchType and Point are the touch type and touched point, for touch
+	// strip touch events. Point is also the origin of touch strip swipe
+	// events.
+	TouchType   TouchStripTouchType
+	Point       image.Point
+	Destination image.Point
+}
+
+// ManagerEventHandler represents a callback function that is called for the
+// input events of all the devices of a Manager. It receives the Manager and
+// the ManagerEvent as parameters.
+type ManagerEventHandler func(m *Manager, e ManagerEvent) error
+
+// ManagerDeviceError represents an error of one of the Elgato Stream Deck
+// devices of a Manager, including the device serial number.
+type ManagerDeviceError struct {
+	Serial string
+	Err    error
+}
+
+// Error returns a string representation of a manager device error.
+func (b ManagerDeviceError) Error() string {
+	return fmt.Sprintf("%s [%s]", b.Err, b.Serial)
+}
+
+// Unwrap returns the underlying manager device error.
+func (b ManagerDeviceError) Unwrap() error {
+	return b.Err
+}
+
+// Manager manages several Elgato Stream Deck devices together, aggregating
+// their input events and broadcasting operations to all of them in
+// parallel.
+//
+// Failures of a single device do not affect the others: broadcast operations
+// are attempted on every device and return the errors of the failed ones as
+// ManagerDeviceError values, and devices whose listener fails, usually
+// because they were disconnected, are closed and removed from the Manager.
+type Manager struct {
+	mtx      sync.Mutex
+	devices  []*Device
+	regs     map[*Device][]*HandlerRegistration
+	handlers []handler[ManagerEventHandler]
+}
+
+// OpenManager opens all the supported Elgato Stream Deck devices connected
+// to the computer, and returns a Manager for them. See NewManager for
+// details.
+func OpenManager() (*Manager, error) {
+	devices, err := Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	return NewManager(devices)
+}
+
+// NewManager creates a Manager for the given Elgato Stream Deck devices,
+// opening the ones that are not open yet. Devices that fail to open are not
+// managed, and their errors are returned as ManagerDeviceError values along
+// with the Manager. An error is returned without a Manager if no device could
+// be opened.
+func NewManager(devices []*Device) (*Manager, error) {
+	rv := &Manager{
+		regs: map[*Device][]*HandlerRegistration{},
+	}
+
+	errs := []error{}
+	for _, dev := range devices {
+		if !dev.IsOpen() {
+			if err := dev.Open(); err != nil {
+				errs = append(errs, ManagerDeviceError{Serial: dev.GetSerialNumber(), Err: err})
+				continue
+			}
+		}
+
+		regs, err := rv.register(dev)
+		if err != nil {
+			for _, reg := range regs {
+				reg.Remove()
+			}
+			errs = append(errs, ManagerDeviceError{Serial: dev.GetSerialNumber(), Err: err})
+			continue
+		}
+		rv.devices = append(rv.devices, dev)
+		rv.regs[dev] = regs
+	}
+
+	if len(rv.devices) == 0 {
+		return nil, errors.Join(append([]error{wrapErr(ErrNoDeviceFound)}, errs...)...)
+	}
+	return rv, errors.Join(errs...)
+}
+
+func (m *Manager) register(dev *Device) ([]*HandlerRegistration, error) {
+	regs := []*HandlerRegistration{}
+	add := func(reg *HandlerRegistration, err error) error {
+		if err != nil {
+			return err
+		}
+		regs = append(regs, reg)
+		return nil
+	}
+
+	if err := dev.ForEachKey(func(k KeyID) error {
+		if err := add(dev.AddKeyPressHandler(k, func(d *Device, key *Key) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_KEY_PRESS, Device: d, Key: key.GetID()})
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddKeyReleaseHandler(k, func(d *Device, key *Key, duration time.Duration) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_KEY_RELEASE, Device: d, Key: key.GetID(), Duration: duration})
+		}))
+	}); err != nil {
+		return regs, err
+	}
+
+	if err := dev.ForEachTouchPoint(func(tp TouchPointID) error {
+		if err := add(dev.AddTouchPointPressHandler(tp, func(d *Device, t *TouchPoint) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_TOUCH_POINT_PRESS, Device: d, TouchPoint: t.GetID()})
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddTouchPointReleaseHandler(tp, func(d *Device, t *TouchPoint, duration time.Duration) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_TOUCH_POINT_RELEASE, Device: d, TouchPoint: t.GetID(), Duration: duration})
+		}))
+	}); err != nil {
+		return regs, err
+	}
+
+	if err := dev.ForEachDial(func(di DialID) error {
+		if err := add(dev.AddDialPressHandler(di, func(d *Device, dial *Dial) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_DIAL_PRESS, Device: d, Dial: dial.GetID()})
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddDialReleaseHandler(di, func(d *Device, dial *Dial, duration time.Duration) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_DIAL_RELEASE, Device: d, Dial: dial.GetID(), Duration: duration})
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddDialRotateHandler(di, func(d *Device, dial *Dial, delta int8) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_DIAL_ROTATE, Device: d, Dial: dial.GetID(), Delta: delta})
+		}))
+	}); err != nil {
+		return regs, err
+	}
+
+	if dev.GetTouchStripSupported() {
+		if err := add(dev.AddTouchStripTouchHandler(func(d *Device, t TouchStripTouchType, p image.Point) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_TOUCH_STRIP_TOUCH, Device: d, TouchType: t, Point: p})
+		})); err != nil {
+			return regs, err
+		}
+		if err := add(dev.AddTouchStripSwipeHandler(func(d *Device, origin image.Point, destination image.Point) error {
+			return m.emit(ManagerEvent{Type: MANAGER_EVENT_TYPE_TOUCH_STRIP_SWIPE, Device: d, Point: origin, Destination: destination})
+		})); err != nil {
+			return regs, err
+		}
+	}
+	return regs, nil
+}
+
+func (m *Manager) emit(e ManagerEvent) error {
+	e.Serial = e.Device.GetSerialNumber()
+
+	m.mtx.Lock()
+	hnds := append([]handler[ManagerEventHandler]{}, m.handlers...)
+	m.mtx.Unlock()
+
+	errs := []error{}
+	for _, h := range hnds {
+		if err := h.fn(m, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AddEventHandler registers a ManagerEventHandler callback to be called for
+// the input events of all the managed devices. The returned
+// HandlerRegistration can be used to unregister the callback.
+//
+// Events of each input are reported in order, but events of different
+// inputs and devices may be reported concurrently.
+func (m *Manager) AddEventHandler(fn ManagerEventHandler) (*HandlerRegistration, error) {
+	if fn == nil {
+		return nil, wrapErr(ErrDeviceEventHandlerInvalid)
+	}
+	return addHandler(&m.mtx, &m.handlers, fn), nil
+}
+
+// GetDevices returns the managed Elgato Stream Deck devices.
+func (m *Manager) GetDevices() []*Device {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return slices.Clone(m.devices)
+}
+
+// GetDevice returns the managed Elgato Stream Deck device that matches the
+// provided serial number.
+func (m *Manager) GetDevice(serialNumber string) (*Device, error) {
+	for _, dev := range m.GetDevices() {
+		if dev.GetSerialNumber() == serialNumber {
+			return dev, nil
+		}
+	}
+	return nil, fmt.Errorf("streamdeck: %w [%q]", ErrNoDeviceFound, serialNumber)
+}
+
+func (m *Manager) remove(dev *Device) {
+	m.mtx.Lock()
+	m.devices = slices.DeleteFunc(m.devices, func(d *Device) bool {
+		return d == dev
+	})
+	regs := m.regs[dev]
+	delete(m.regs, dev)
+	m.mtx.Unlock()
+
+	for _, reg := range regs {
+		reg.Remove()
+	}
+}
+
+// ForEach calls the provided callback function for each managed device, in
+// parallel, and waits for all of them to return. The errors returned by the
+// callback function are returned as ManagerDeviceError values.
+func (m *Manager) ForEach(cb func(d *Device) error) error {
+	devices := m.GetDevices()
+	errs := make([]error, len(devices))
+
+	wg := sync.WaitGroup{}
+	for i, dev := range devices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := cb(dev); err != nil {
+				errs[i] = ManagerDeviceError{Serial: dev.GetSerialNumber(), Err: err}
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// SetBrightnessAll sets the brightness of all the managed devices, in
+// percent.
+func (m *Manager) SetBrightnessAll(perc byte) error {
+	return m.ForEach(func(d *Device) error {
+		return d.SetBrightness(perc)
+	})
+}
+
+// ClearAll clears all the displays of all the managed devices.
+func (m *Manager) ClearAll() error {
+	return m.ForEach(func(d *Device) error {
+		if err := d.Batch(func(tx *Tx) error {
+			if d.GetKeyDisplaySupported() {
+				if err := d.ForEachKey(tx.ClearKey); err != nil {
+					return err
+				}
+			}
+			if d.GetInfoBarSupported() {
+				if err := tx.ClearInfoBar(); err != nil {
+					return err
+				}
+			}
+			if d.GetTouchStripSupported() {
+				return tx.ClearTouchStrip()
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return d.ForEachTouchPoint(d.ClearTouchPoint)
+	})
+}
+
+// Listen listens to input events from all the managed devices and calls
+// the handler callbacks as required, until the context is cancelled or all
+// the device listeners stop.
+//
+// Devices whose listener fails are closed and removed from the Manager, and
+// the failure is sent to the error channel as a ManagerDeviceError. Errors
+// from the input handlers are also sent to the error channel as
+// ManagerDeviceError values.
+//
+// errCh is an error channel to receive errors. If set to a nil channel,
+// errors are sent to the device logger. Errors are sent non-blocking.
+func (m *Manager) Listen(ctx context.Context, errCh chan error) error {
+	devices := m.GetDevices()
+	if len(devices) == 0 {
+		return wrapErr(ErrNoDeviceFound)
+	}
+
+	wg := sync.WaitGroup{}
+	for _, dev := range devices {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			serial := dev.GetSerialNumber()
+			done := make(chan struct{})
+			dErrCh := make(chan error, 10)
+			go func() {
+				for {
+					select {
+					case err := <-dErrCh:
+						dev.sendHandlerError(errCh, ManagerDeviceError{Serial: serial, Err: err})
+					case <-done:
+						return
+					}
+				}
+			}()
+
+			err := dev.ListenContext(ctx, dErrCh)
+			close(done)
+
+			if err != nil && ctx.Err() == nil {
+				m.remove(dev)
+				if dev.IsOpen() {
+					dev.Close()
+				}
+				dev.sendHandlerError(errCh, ManagerDeviceError{Serial: serial, Err: err})
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Close closes all the managed devices. The errors returned by the devices
+// are returned as ManagerDeviceError values.
+func (m *Manager) Close() error {
+	errs := []error{}
+	for _, dev := range m.GetDevices() {
+		m.remove(dev)
+		if err := dev.Close(); err != nil {
+			errs = append(errs, ManagerDeviceError{Serial: dev.GetSerialNumber(), Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
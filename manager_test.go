@@ -0,0 +1,164 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestManager(t *testing.T) {
+	m1, err := mock.New("mk2", "MOCK1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m2, err := mock.New("plus", "MOCK2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices := []*streamdeck.Device{}
+	for _, m := range []*mock.Device{m1, m2} {
+		dev, err := streamdeck.NewDevice(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		devices = append(devices, dev)
+	}
+
+	mgr, err := streamdeck.NewManager(devices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mgr.Close()
+
+	if len(mgr.GetDevices()) != 2 {
+		t.Fatalf("unexpected devices: %d", len(mgr.GetDevices()))
+	}
+	if dev, err := mgr.GetDevice("MOCK2"); err != nil || dev != devices[1] {
+		t.Fatalf("unexpected device: %v, %v", dev, err)
+	}
+	if _, err := mgr.GetDevice("MOCK3"); !errors.Is(err, streamdeck.ErrNoDeviceFound) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := make(chan streamdeck.ManagerEvent, 10)
+	if _, err := mgr.AddEventHandler(func(m *streamdeck.Manager, e streamdeck.ManagerEvent) error {
+		events <- e
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 10)
+	done := make(chan error)
+	go func() {
+		done <- mgr.Listen(ctx, errCh)
+	}()
+
+	next := func() streamdeck.ManagerEvent {
+		t.Helper()
+
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(time.Second):
+			t.Fatal("event not received")
+		}
+		return streamdeck.ManagerEvent{}
+	}
+
+	if err := m1.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	if e := next(); e.Type != streamdeck.MANAGER_EVENT_TYPE_KEY_PRESS || e.Serial != "MOCK1" || e.Device != devices[0] || e.Key != streamdeck.KEY_3 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+	if err := m1.ReleaseKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	if e := next(); e.Type != streamdeck.MANAGER_EVENT_TYPE_KEY_RELEASE || e.Serial != "MOCK1" || e.Key != streamdeck.KEY_3 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if err := m2.RotateDial(streamdeck.DIAL_2, -3); err != nil {
+		t.Fatal(err)
+	}
+	if e := next(); e.Type != streamdeck.MANAGER_EVENT_TYPE_DIAL_ROTATE || e.Serial != "MOCK2" || e.Dial != streamdeck.DIAL_2 || e.Delta != -3 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if err := m2.SwipeTouchStrip(image.Pt(10, 20), image.Pt(300, 40)); err != nil {
+		t.Fatal(err)
+	}
+	if e := next(); e.Type != streamdeck.MANAGER_EVENT_TYPE_TOUCH_STRIP_SWIPE || e.Serial != "MOCK2" || e.Point != image.Pt(10, 20) || e.Destination != image.Pt(300, 40) {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if err := mgr.SetBrightnessAll(40); err != nil {
+		t.Fatal(err)
+	}
+	if m1.Brightness() != 40 || m2.Brightness() != 40 {
+		t.Errorf("unexpected brightness: %d, %d", m1.Brightness(), m2.Brightness())
+	}
+
+	if err := mgr.ClearAll(); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*mock.Device{m1, m2} {
+		img := m.KeyImage(streamdeck.KEY_1)
+		if img == nil {
+			t.Fatal("key not cleared")
+		}
+		assertColor(t, img, 10, 10, color.RGBA{A: 0xff})
+	}
+	if img := m2.TouchStripImage(); img == nil {
+		t.Error("touch strip not cleared")
+	}
+
+	// a disconnected device is removed, while the other keeps working
+	if err := m2.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errCh:
+		var e streamdeck.ManagerDeviceError
+		if !errors.As(err, &e) || e.Serial != "MOCK2" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("error not received")
+	}
+	if devs := mgr.GetDevices(); len(devs) != 1 || devs[0] != devices[0] {
+		t.Errorf("unexpected devices: %v", devs)
+	}
+
+	if err := mgr.SetBrightnessAll(60); err != nil {
+		t.Fatal(err)
+	}
+	if m1.Brightness() != 60 {
+		t.Errorf("unexpected brightness: %d", m1.Brightness())
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("listener not stopped")
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	marqueeFrameRate    = 30
+	marqueeDefaultSpeed = 40
+)
+
+// ScrollingText represents text rendered as a horizontally scrolling ticker
+// to an Elgato Stream Deck display, as registered with the Animator methods.
+// Text wider than the display is rendered as a single line scrolling from
+// right to left, and shorter text is drawn once, like the Set*TextWithOptions
+// methods. The Icon text option is ignored.
+//
+// The speed is affected by the AnimationScale accessibility option.
+type ScrollingText struct {
+	Text    string
+	Options TextOptions
+
+	// Speed is the scrolling speed, in pixels per second. Defaults to 40
+	// pixels per second if zero.
+	Speed float64
+
+	// Gap is the distance, in pixels, between the end of the text and its
+	// next repetition. Defaults to half the display width if zero.
+	Gap int
+
+	// Loops is the number of times the text scrolls through the display,
+	// stopping at the starting position. If zero, the text scrolls forever.
+	Loops int
+}
+
+// renderTextLine renders text as a single line into an image with the height
+// of the given rectangle and the width of the text, followed by a gap (half
+// the rectangle width if not positive). It returns false if the text fits the
+// rectangle width, without rendering it.
+func renderTextLine(rect image.Rectangle, text string, opts TextOptions, acc AccessibilityOptions, gap int) (*image.RGBA, bool, error) {
+	fg := opts.Foreground
+	if fg == nil {
+		fg = color.White
+	}
+	bg := opts.Background
+	if bg == nil {
+		bg = color.Black
+	}
+	fg, bg = acc.colors(fg, bg)
+
+	area := rect.Inset(opts.Padding)
+	if area.Empty() {
+		return nil, false, nil
+	}
+
+	minSize := opts.MinSize
+	if minSize <= 0 {
+		minSize = 8
+	}
+	size := opts.Size
+	if size <= 0 {
+		size = opts.MaxSize
+		if size <= 0 {
+			size = float64(area.Dy()) / 2
+		}
+	}
+	size = acc.textSize(max(size, minSize))
+
+	face, err := opts.newFace(size)
+	if err != nil {
+		return nil, false, err
+	}
+	defer face.Close()
+
+	width := font.MeasureString(face, text)
+	if width <= fixed.I(area.Dx()) {
+		return nil, false, nil
+	}
+
+	if gap <= 0 {
+		gap = rect.Dx() / 2
+	}
+	rv := image.NewRGBA(image.Rect(0, 0, width.Ceil()+gap, rect.Dy()))
+	draw.Draw(rv, rv.Bounds(), image.NewUniform(bg), image.Point{}, draw.Src)
+
+	lh := lineHeight(face, opts.LineSpacing)
+	y := fixed.I(area.Min.Y-rect.Min.Y) + (fixed.I(area.Dy())-lh)/2
+
+	m := face.Metrics()
+	y += (lh-m.Ascent-m.Descent)/2 + m.Ascent
+
+	dr := &font.Drawer{
+		Dst:  rv,
+		Src:  image.NewUniform(fg),
+		Face: face,
+		Dot:  fixed.Point26_6{Y: y},
+	}
+	dr.DrawString(text)
+	return rv, true, nil
+}
+
+// marquee returns a FrameFunc scrolling text horizontally through the given
+// rectangle, if it does not fit in a single line, or showing it statically
+// otherwise.
+func (d *Device) marquee(rect image.Rectangle, st ScrollingText) (FrameFunc, error) {
+	opts := d.textOptions(st.Options)
+	opts.Icon = nil
+	acc := d.GetAccessibilityOptions()
+
+	text := strings.Join(strings.Fields(st.Text), " ")
+	line, scroll, err := renderTextLine(rect, text, opts, acc, st.Gap)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	if !scroll {
+		img, err := renderText(rect, text, opts, acc)
+		if err != nil {
+			return nil, wrapErr(err)
+		}
+
+		drawn := false
+		return func(elapsed time.Duration) (image.Image, error) {
+			if drawn {
+				return nil, nil
+			}
+			drawn = true
+			return img, nil
+		}, nil
+	}
+
+	speed := st.Speed
+	if speed <= 0 {
+		speed = marqueeDefaultSpeed
+	}
+	if acc.AnimationScale > 0 {
+		speed /= acc.AnimationScale
+	}
+
+	stopped := false
+	return func(elapsed time.Duration) (image.Image, error) {
+		if stopped {
+			return nil, nil
+		}
+
+		w := line.Bounds().Dx()
+		pos := int(elapsed.Seconds() * speed)
+		if st.Loops > 0 && pos >= w*st.Loops {
+			stopped = true
+			pos = 0
+		}
+		off := pos % w
+
+		rv := image.NewRGBA(rect)
+		for x := -off; x < rect.Dx(); x += w {
+			draw.Draw(rv, line.Bounds().Add(rect.Min).Add(image.Pt(x, 0)), line, image.Point{}, draw.Src)
+		}
+		return rv, nil
+	}, nil
+}
+
+// InfoBarMarquee returns a FrameFunc rendering text to the info bar display
+// available on some Elgato Stream Deck models, to be registered with
+// Animator.AnimateInfoBar. Text wider than the info bar is rendered as a
+// single line scrolling from right to left at the given speed, in pixels per
+// second (defaults to 40 pixels per second if zero), and shorter text is
+// drawn once, like SetInfoBarTextWithOptions. The Icon option is ignored.
+//
+// The speed is affected by the AnimationScale accessibility option.
+func (d *Device) InfoBarMarquee(text string, opts TextOptions, speed float64) (FrameFunc, error) {
+	if err := d.validateInfoBar(); err != nil {
+		return nil, err
+	}
+
+	return d.marquee(d.model.infoBarImageRect, ScrollingText{
+		Text:    text,
+		Options: opts,
+		Speed:   speed,
+	})
+}
+
+// ScrollInfoBarText draws text to the info bar display available on some
+// Elgato Stream Deck models, scrolling it as a marquee if it is wider than
+// the display, until the context is cancelled or the device is closed. See
+// InfoBarMarquee for details.
+func (d *Device) ScrollInfoBarText(ctx context.Context, text string, opts TextOptions, speed float64) error {
+	fn, err := d.InfoBarMarquee(text, opts, speed)
+	if err != nil {
+		return err
+	}
+
+	a := d.NewAnimator()
+	if _, err := a.AnimateInfoBar(marqueeFrameRate, fn); err != nil {
+		return err
+	}
+	return a.Run(ctx, nil)
+}
+
+// AnimateKeyScrollingText registers a ScrollingText for an Elgato Stream Deck
+// key background display, replacing any animation previously registered for
+// the key.
+func (a *Animator) AnimateKeyScrollingText(key KeyID, st ScrollingText) (*Animation, error) {
+	if err := a.device.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	if err := a.device.validateKeyDisplay(); err != nil {
+		return nil, err
+	}
+
+	fn, err := a.device.marquee(a.device.model.keyImageRect, st)
+	if err != nil {
+		return nil, err
+	}
+	return a.AnimateKey(key, marqueeFrameRate, fn)
+}
+
+// AnimateInfoBarScrollingText registers a ScrollingText for the info bar
+// display available on some Elgato Stream Deck models, replacing any
+// animation previously registered for it.
+func (a *Animator) AnimateInfoBarScrollingText(st ScrollingText) (*Animation, error) {
+	if err := a.device.validateInfoBar(); err != nil {
+		return nil, err
+	}
+
+	fn, err := a.device.marquee(a.device.model.infoBarImageRect, st)
+	if err != nil {
+		return nil, err
+	}
+	return a.AnimateInfoBar(marqueeFrameRate, fn)
+}
+
+// AnimateTouchStripSegmentScrollingText registers a ScrollingText for the
+// segment of the touch strip display above the given dial, available on some
+// Elgato Stream Deck models, replacing any animation previously registered
+// for the segment.
+func (a *Animator) AnimateTouchStripSegmentScrollingText(di DialID, st ScrollingText) (*Animation, error) {
+	rect, err := a.device.GetTouchStripSegmentRectangle(di)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, err := a.device.marquee(image.Rect(0, 0, rect.Dx(), rect.Dy()), st)
+	if err != nil {
+		return nil, err
+	}
+	return a.AnimateTouchStripWithRectangle(rect, marqueeFrameRate, fn)
+}
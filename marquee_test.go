@@ -0,0 +1,144 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/draw"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestInfoBarMarquee(t *testing.T) {
+	dev, _, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	fn, err := dev.InfoBarMarquee("Now playing: a very long track title by some artist", streamdeck.TextOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := fn(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == nil || first.Bounds() != image.Rect(0, 0, 248, 58) {
+		t.Fatalf("bad frame: %v", first)
+	}
+	second, err := fn(500 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(first.(*image.RGBA).Pix, second.(*image.RGBA).Pix) {
+		t.Error("text not scrolled")
+	}
+
+	fn, err = dev.InfoBarMarquee("12:00", streamdeck.TextOptions{}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img, err := fn(0); err != nil || img == nil {
+		t.Fatalf("short text not drawn: %v", err)
+	}
+	if img, err := fn(time.Second); err != nil || img != nil {
+		t.Errorf("short text should not scroll: %v", err)
+	}
+
+	if _, err := dev.InfoBarMarquee("foo", streamdeck.TextOptions{}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	mini, _, err := mock.Open("mini")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mini.Close()
+
+	if _, err := mini.InfoBarMarquee("foo", streamdeck.TextOptions{}, 0); !errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestScrollingText(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	a := dev.NewAnimator()
+	if _, err := a.AnimateInfoBarScrollingText(streamdeck.ScrollingText{Text: "foo"}); !errors.Is(err, streamdeck.ErrDeviceInfoBarNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := a.AnimateTouchStripSegmentScrollingText(streamdeck.DIAL_4+1, streamdeck.ScrollingText{Text: "foo"}); !errors.Is(err, streamdeck.ErrDialInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// the text scrolls through the segment once, quickly, and stops at the
+	// starting position, as rendered by a text that never leaves it
+	rect, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := streamdeck.ScrollingText{
+		Text:  "Now playing: a very long track title by some artist",
+		Speed: 5000,
+		Gap:   20,
+		Loops: 1,
+	}
+	if _, err := a.AnimateTouchStripSegmentScrollingText(streamdeck.DIAL_2, st); err != nil {
+		t.Fatal(err)
+	}
+	st.Speed = 0.001
+	if _, err := a.AnimateTouchStripSegmentScrollingText(streamdeck.DIAL_3, st); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if err := a.Run(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	toRGBA := func(img image.Image) []byte {
+		rv := image.NewRGBA(img.Bounds())
+		draw.Draw(rv, rv.Rect, img, img.Bounds().Min, draw.Src)
+		return rv.Pix
+	}
+
+	var scrolled, static []mock.Write
+	for _, wr := range m.Writes() {
+		switch {
+		case wr.Surface == mock.SURFACE_TOUCH_STRIP && wr.Rect == rect:
+			scrolled = append(scrolled, wr)
+		case wr.Surface == mock.SURFACE_TOUCH_STRIP && wr.Rect == ref:
+			static = append(static, wr)
+		default:
+			t.Fatalf("bad write: %+v", wr)
+		}
+	}
+	if len(scrolled) < 3 || len(static) != 1 {
+		t.Fatalf("bad frames written: %d, %d", len(scrolled), len(static))
+	}
+	if !bytes.Equal(toRGBA(static[0].Image), toRGBA(scrolled[len(scrolled)-1].Image)) {
+		t.Error("text not stopped at the starting position")
+	}
+	if bytes.Equal(toRGBA(scrolled[0].Image), toRGBA(scrolled[1].Image)) {
+		t.Error("text not scrolled")
+	}
+}
@@ -0,0 +1,856 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mock provides a fake Elgato Stream Deck USB HID device, that can be
+// used to test applications built on top of the streamdeck package without
+// real hardware.
+//
+// The fake device speaks the same protocol as the emulated model: output
+// reports sent by the streamdeck package are decoded back into images, and
+// input reports can be injected to simulate key presses, dial rotations and
+// touch strip events.
+package mock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"sort"
+	"sync"
+
+	"golang.org/x/image/bmp"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the mock package.
+var (
+	ErrDialInvalid       = errors.New("mock: dial is not valid")
+	ErrKeyInvalid        = errors.New("mock: key is not valid")
+	ErrModelInvalid      = errors.New("mock: model is not valid")
+	ErrReportInvalid     = errors.New("mock: report is not valid")
+	ErrTouchPointInvalid = errors.New("mock: touch point is not valid")
+	ErrTouchStripInvalid = errors.New("mock: touch strip not supported")
+)
+
+// Surface represents an Elgato Stream Deck display surface.
+type Surface byte
+
+// Elgato Stream Deck display surfaces.
+const (
+	SURFACE_KEY Surface = iota + 1
+	SURFACE_INFO_BAR
+	SURFACE_TOUCH_STRIP
+)
+
+// String returns a string representation of the display surface.
+func (s Surface) String() string {
+	switch s {
+	case SURFACE_KEY:
+		return "key"
+	case SURFACE_INFO_BAR:
+		return "info bar"
+	case SURFACE_TOUCH_STRIP:
+		return "touch strip"
+	}
+	return "unknown"
+}
+
+// Write represents an image written by the streamdeck package to one of the
+// device displays.
+type Write struct {
+	Surface Surface
+	Key     streamdeck.KeyID
+	Rect    image.Rectangle
+	Image   image.Image
+}
+
+type pending struct {
+	surface Surface
+	key     streamdeck.KeyID
+	rect    image.Rectangle
+	data    []byte
+}
+
+// Device is a fake Elgato Stream Deck USB HID device. It implements the
+// streamdeck.HIDDevice interface.
+type Device struct {
+	spec   *modelSpec
+	serial string
+	input  chan []byte
+	closed chan struct{}
+
+	mtx         sync.Mutex
+	open        bool
+	locked      bool
+	openFails   int
+	location    string
+	firmware    string
+	bootloader  string
+	secondary   string
+	hwSerial    string
+	brightness  byte
+	resets      int
+	keyStates   []byte
+	dialStates  []byte
+	pending     map[string]*pending
+	keys        map[streamdeck.KeyID]image.Image
+	infoBar     image.Image
+	touchStrip  *image.RGBA
+	touchPoints map[streamdeck.TouchPointID]color.Color
+	writes      []Write
+}
+
+// Models returns the identifiers of the Elgato Stream Deck models that can be
+// emulated, as returned by streamdeck.Device.GetModelID.
+func Models() []string {
+	rv := make([]string, 0, len(models))
+	for k := range models {
+		rv = append(rv, k)
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// New creates a fake Elgato Stream Deck USB HID device emulating the given
+// model identifier, as returned by streamdeck.Device.GetModelID.
+func New(modelID string, serialNumber string) (*Device, error) {
+	spec, found := models[modelID]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrModelInvalid, modelID)
+	}
+
+	rv := &Device{
+		spec:     spec,
+		serial:   serialNumber,
+		firmware: "1.00.000",
+		hwSerial: serialNumber,
+	}
+	rv.reset()
+	return rv, nil
+}
+
+// Open creates a fake Elgato Stream Deck USB HID device emulating the given
+// model identifier, and returns it alongside an opened streamdeck.Device
+// backed by it.
+func Open(modelID string) (*streamdeck.Device, *Device, error) {
+	m, err := New(modelID, "MOCK"+modelID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dev, err := streamdeck.NewDevice(m)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := dev.Open(); err != nil {
+		return nil, nil, err
+	}
+	return dev, m, nil
+}
+
+func (d *Device) reset() {
+	d.keyStates = make([]byte, d.spec.keyCount+d.spec.touchPointCount)
+	d.dialStates = make([]byte, d.spec.dialCount)
+	d.pending = map[string]*pending{}
+	d.keys = map[streamdeck.KeyID]image.Image{}
+	d.infoBar = nil
+	d.touchStrip = nil
+	d.touchPoints = map[streamdeck.TouchPointID]color.Color{}
+	d.writes = nil
+}
+
+// SetFirmwareVersion sets the firmware version reported by the fake device.
+func (d *Device) SetFirmwareVersion(v string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.firmware = v
+}
+
+// SetHardwareSerialNumber sets the serial number reported by the fake device
+// hardware with a feature report, that defaults to the serial number of the
+// USB string descriptor.
+func (d *Device) SetHardwareSerialNumber(v string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.hwSerial = v
+}
+
+// SetFirmwareComponentVersions sets the bootloader and secondary firmware
+// versions reported by the fake device, for models using the second
+// generation protocol. Components with empty versions are not reported.
+func (d *Device) SetFirmwareComponentVersions(bootloader string, secondary string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.bootloader = bootloader
+	d.secondary = secondary
+}
+
+// Open opens the fake USB HID device for usage.
+func (d *Device) Open(lock bool) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.open {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsOpen, d.Path())
+	}
+
+	if d.openFails > 0 {
+		d.openFails--
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceLocked, d.Path())
+	}
+
+	d.open = true
+	d.locked = lock
+	d.input = make(chan []byte, 128)
+	d.closed = make(chan struct{})
+	return nil
+}
+
+// FailOpen makes the next n calls to Open fail, as if the device was locked
+// by another process.
+func (d *Device) FailOpen(n int) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.openFails = n
+}
+
+// IsLocked checks if the fake USB HID device was opened with an exclusive
+// lock.
+func (d *Device) IsLocked() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.open && d.locked
+}
+
+// IsOpen checks if the fake USB HID device is open and available for usage.
+func (d *Device) IsOpen() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.open
+}
+
+// Close closes the fake USB HID device. Pending GetInputReport calls return
+// an error.
+func (d *Device) Close() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.open {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+
+	d.open = false
+	close(d.closed)
+	return nil
+}
+
+func (d *Device) channels() (chan []byte, chan struct{}, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.open {
+		return nil, nil, fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+	return d.input, d.closed, nil
+}
+
+// GetInputReport blocks until an input report is injected into the fake USB
+// HID device, or until it is closed.
+func (d *Device) GetInputReport() (byte, []byte, error) {
+	input, closed, err := d.channels()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	select {
+	case buf := <-input:
+		return 1, buf, nil
+	case <-closed:
+		return 0, nil, fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+}
+
+// SetOutputReport receives an output report sent to the fake USB HID device.
+// Image pages are reassembled and decoded once the last page is received.
+func (d *Device) SetOutputReport(reportId byte, data []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.open {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+	if len(data) > int(d.spec.outputLength) {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrReportBufferOverflow, d.Path())
+	}
+	if reportId != 2 {
+		return fmt.Errorf("%w: unexpected output report id: %d", ErrReportInvalid, reportId)
+	}
+
+	switch d.spec.protocol {
+	case protocolGen1:
+		return d.outputGen1(data)
+	case protocolGen2:
+		return d.outputGen2(data)
+	}
+	return fmt.Errorf("%w: unsupported protocol", ErrReportInvalid)
+}
+
+func (d *Device) outputGen1(data []byte) error {
+	if len(data) < 15 || data[0] != 1 || data[4] == 0 || data[4] > d.spec.keyCount {
+		return fmt.Errorf("%w: malformed key image report", ErrReportInvalid)
+	}
+
+	key := streamdeck.KEY_1 + streamdeck.KeyID(d.spec.keyIndex(data[4]-1))
+	return d.appendPage(fmt.Sprintf("key%d", key), &pending{
+		surface: SURFACE_KEY,
+		key:     key,
+		rect:    d.spec.keyRect,
+	}, int(data[1])-int(d.spec.pageOffset), data[3] != 0, data[15:])
+}
+
+func (d *Device) outputGen2(data []byte) error {
+	if len(data) < 7 {
+		return fmt.Errorf("%w: report too short", ErrReportInvalid)
+	}
+
+	switch data[0] {
+	case 7:
+		if data[1] >= d.spec.keyCount || d.spec.keyRect.Empty() {
+			return fmt.Errorf("%w: malformed key image report", ErrReportInvalid)
+		}
+		key := streamdeck.KEY_1 + streamdeck.KeyID(data[1])
+		size := int(data[3]) | int(data[4])<<8
+		return d.appendPage(fmt.Sprintf("key%d", key), &pending{
+			surface: SURFACE_KEY,
+			key:     key,
+			rect:    d.spec.keyRect,
+		}, int(data[5])|int(data[6])<<8, data[2] != 0, payload(data, 7, size))
+
+	case 11:
+		if d.spec.infoBarRect.Empty() {
+			return fmt.Errorf("%w: info bar not supported", ErrReportInvalid)
+		}
+		size := int(data[3]) | int(data[4])<<8
+		return d.appendPage("infobar", &pending{
+			surface: SURFACE_INFO_BAR,
+			rect:    d.spec.infoBarRect,
+		}, int(data[5])|int(data[6])<<8, data[2] != 0, payload(data, 7, size))
+
+	case 12:
+		if len(data) < 15 || d.spec.touchStripRect.Empty() {
+			return fmt.Errorf("%w: malformed touch strip image report", ErrReportInvalid)
+		}
+		x := int(data[1]) | int(data[2])<<8
+		y := int(data[3]) | int(data[4])<<8
+		w := int(data[5]) | int(data[6])<<8
+		h := int(data[7]) | int(data[8])<<8
+		size := int(data[12]) | int(data[13])<<8
+		rect := image.Rect(x, y, x+w, y+h)
+		return d.appendPage("touchstrip"+rect.String(), &pending{
+			surface: SURFACE_TOUCH_STRIP,
+			rect:    rect,
+		}, int(data[10])|int(data[11])<<8, data[9] != 0, payload(data, 15, size))
+	}
+	return fmt.Errorf("%w: unexpected output report command: %d", ErrReportInvalid, data[0])
+}
+
+func payload(data []byte, start int, size int) []byte {
+	if end := start + size; end < len(data) {
+		return data[start:end]
+	}
+	return data[start:]
+}
+
+func (d *Device) appendPage(id string, p *pending, page int, last bool, data []byte) error {
+	// the device assembles one image at a time, so a new transfer aborts
+	// any transfer interleaved with it
+	if page == 0 {
+		clear(d.pending)
+		d.pending[id] = p
+	}
+
+	pp, found := d.pending[id]
+	if !found {
+		return fmt.Errorf("%w: unexpected image page: %d", ErrReportInvalid, page)
+	}
+	pp.data = append(pp.data, data...)
+
+	if !last {
+		return nil
+	}
+	delete(d.pending, id)
+
+	var (
+		img image.Image
+		err error
+		tr  transform
+	)
+	switch d.spec.protocol {
+	case protocolGen1:
+		img, err = bmp.Decode(bytes.NewReader(pp.data))
+	case protocolGen2:
+		img, err = jpeg.Decode(bytes.NewReader(pp.data))
+	}
+	if err != nil {
+		return fmt.Errorf("%w: failed to decode image: %w", ErrReportInvalid, err)
+	}
+
+	switch pp.surface {
+	case SURFACE_KEY:
+		tr = d.spec.keyTransform
+	case SURFACE_INFO_BAR:
+		tr = d.spec.infoBarTransform
+	}
+	img = untransform(img, tr)
+
+	switch pp.surface {
+	case SURFACE_KEY:
+		d.keys[pp.key] = img
+
+	case SURFACE_INFO_BAR:
+		d.infoBar = img
+
+	case SURFACE_TOUCH_STRIP:
+		if d.touchStrip == nil {
+			d.touchStrip = image.NewRGBA(d.spec.touchStripRect)
+		}
+		draw.Draw(d.touchStrip, pp.rect, img, img.Bounds().Min, draw.Src)
+	}
+
+	d.writes = append(d.writes, Write{
+		Surface: pp.surface,
+		Key:     pp.key,
+		Rect:    pp.rect,
+		Image:   img,
+	})
+	return nil
+}
+
+// untransform reverts the transformations applied by the streamdeck package
+// before sending an image to the device.
+func untransform(img image.Image, tr transform) image.Image {
+	b := img.Bounds()
+	rv := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+
+	for x := 0; x < b.Dx(); x++ {
+		for y := 0; y < b.Dy(); y++ {
+			xd := x
+			yd := y
+
+			if tr&transformFlipHorizontal == transformFlipHorizontal {
+				xd = b.Dx() - 1 - xd
+			}
+
+			if tr&transformFlipVertical == transformFlipVertical {
+				yd = b.Dy() - 1 - yd
+			}
+
+			if tr&transformRotate90 == transformRotate90 {
+				xxd := xd
+				xd = yd
+				yd = b.Dx() - 1 - xxd
+			}
+
+			rv.Set(x, y, img.At(b.Min.X+xd, b.Min.Y+yd))
+		}
+	}
+	return rv
+}
+
+// GetFeatureReport returns a feature report from the fake USB HID device.
+func (d *Device) GetFeatureReport(reportId byte) ([]byte, error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.open {
+		return nil, fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+
+	buf := make([]byte, d.spec.featureLength)
+	switch {
+	case d.spec.protocol == protocolGen1 && reportId == 3:
+		copy(buf[4:], d.hwSerial)
+	case d.spec.protocol == protocolGen1 && reportId == 4:
+		copy(buf[4:], d.firmware)
+	case d.spec.protocol == protocolGen2 && reportId == 6:
+		copy(buf[1:], d.hwSerial)
+	case d.spec.protocol == protocolGen2 && reportId == 5:
+		copy(buf[5:], d.firmware)
+	case d.spec.protocol == protocolGen2 && reportId == 4 && d.bootloader != "":
+		copy(buf[5:], d.bootloader)
+	case d.spec.protocol == protocolGen2 && reportId == 7 && d.secondary != "":
+		copy(buf[5:], d.secondary)
+	default:
+		return nil, fmt.Errorf("%w: unexpected feature report id: %d", ErrReportInvalid, reportId)
+	}
+	return buf, nil
+}
+
+// SetFeatureReport receives a feature report sent to the fake USB HID device.
+func (d *Device) SetFeatureReport(reportId byte, data []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if !d.open {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+	if len(data) > int(d.spec.featureLength) {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrReportBufferOverflow, d.Path())
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("%w: empty feature report", ErrReportInvalid)
+	}
+
+	switch d.spec.protocol {
+	case protocolGen1:
+		switch {
+		case reportId == 5 && len(data) >= 5 && bytes.Equal(data[:4], []byte{0x55, 0xaa, 0xd1, 0x01}):
+			d.brightness = data[4]
+			return nil
+
+		case reportId == 11 && data[0] == 0x63:
+			d.resets++
+			d.reset()
+			return nil
+		}
+
+	case protocolGen2:
+		if reportId != 3 {
+			break
+		}
+
+		switch data[0] {
+		case 0x02:
+			d.resets++
+			d.reset()
+			return nil
+
+		case 0x06:
+			if len(data) < 5 || data[1] < d.spec.keyCount || data[1] >= d.spec.keyCount+d.spec.touchPointCount {
+				break
+			}
+			tp := streamdeck.TOUCH_POINT_1 + streamdeck.TouchPointID(data[1]-d.spec.keyCount)
+			d.touchPoints[tp] = color.RGBA{R: data[2], G: data[3], B: data[4], A: 0xff}
+			return nil
+
+		case 0x08:
+			if len(data) < 2 || d.spec.keyRect.Empty() {
+				break
+			}
+			d.brightness = data[1]
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unexpected feature report: %d", ErrReportInvalid, reportId)
+}
+
+// GetInputReportLength returns the input report buffer length of the fake USB
+// HID device.
+func (d *Device) GetInputReportLength() uint16 {
+	return d.spec.inputLength
+}
+
+// GetOutputReportLength returns the output report buffer length of the fake
+// USB HID device.
+func (d *Device) GetOutputReportLength() uint16 {
+	return d.spec.outputLength
+}
+
+// GetFeatureReportLength returns the feature report buffer length of the fake
+// USB HID device.
+func (d *Device) GetFeatureReportLength() uint16 {
+	return d.spec.featureLength
+}
+
+// Path returns a fake path identifying the USB HID device.
+func (d *Device) Path() string {
+	return "mock:" + d.spec.product + ":" + d.serial
+}
+
+// VendorId returns the vendor identifier of the fake USB HID device.
+func (d *Device) VendorId() uint16 {
+	return 0x0fd9
+}
+
+// ProductId returns the product identifier of the fake USB HID device.
+func (d *Device) ProductId() uint16 {
+	return d.spec.productID
+}
+
+// Product returns the product name of the fake USB HID device.
+func (d *Device) Product() string {
+	return d.spec.product
+}
+
+// SerialNumber returns the serial number of the fake USB HID device.
+func (d *Device) SerialNumber() string {
+	return d.serial
+}
+
+// SetLocation sets the physical location of the fake USB HID device, as
+// returned by streamdeck.Device.GetLocation.
+func (d *Device) SetLocation(location string) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.location = location
+}
+
+// Location returns the physical location of the fake USB HID device.
+func (d *Device) Location() string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.location
+}
+
+func (d *Device) inject(buf []byte) error {
+	if d.input == nil {
+		return fmt.Errorf("%w [%s]", streamdeck.ErrDeviceIsClosed, d.Path())
+	}
+
+	select {
+	case d.input <- buf:
+		return nil
+	default:
+		return fmt.Errorf("%w [%s]", streamdeck.ErrReportBufferOverflow, d.Path())
+	}
+}
+
+func (d *Device) keyReport() []byte {
+	buf := make([]byte, d.spec.inputLength)
+	for i := byte(0); i < d.spec.keyCount; i++ {
+		buf[d.spec.keyStart+d.spec.keyIndex(i)] = d.keyStates[i]
+	}
+	for i := byte(0); i < d.spec.touchPointCount; i++ {
+		buf[d.spec.touchPointStart+i] = d.keyStates[d.spec.keyCount+i]
+	}
+	return buf
+}
+
+func (d *Device) setKey(key streamdeck.KeyID, st byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if key < streamdeck.KEY_1 || key >= streamdeck.KEY_1+streamdeck.KeyID(d.spec.keyCount) {
+		return fmt.Errorf("%w: %s", ErrKeyInvalid, key)
+	}
+
+	d.keyStates[key-streamdeck.KEY_1] = st
+	return d.inject(d.keyReport())
+}
+
+// PressKey injects an input report simulating a key press.
+func (d *Device) PressKey(key streamdeck.KeyID) error {
+	return d.setKey(key, 1)
+}
+
+// ReleaseKey injects an input report simulating a key release.
+func (d *Device) ReleaseKey(key streamdeck.KeyID) error {
+	return d.setKey(key, 0)
+}
+
+func (d *Device) setTouchPoint(tp streamdeck.TouchPointID, st byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if tp < streamdeck.TOUCH_POINT_1 || tp >= streamdeck.TOUCH_POINT_1+streamdeck.TouchPointID(d.spec.touchPointCount) {
+		return fmt.Errorf("%w: %s", ErrTouchPointInvalid, tp)
+	}
+
+	d.keyStates[d.spec.keyCount+byte(tp-streamdeck.TOUCH_POINT_1)] = st
+	return d.inject(d.keyReport())
+}
+
+// PressTouchPoint injects an input report simulating a touch point press.
+func (d *Device) PressTouchPoint(tp streamdeck.TouchPointID) error {
+	return d.setTouchPoint(tp, 1)
+}
+
+// ReleaseTouchPoint injects an input report simulating a touch point release.
+func (d *Device) ReleaseTouchPoint(tp streamdeck.TouchPointID) error {
+	return d.setTouchPoint(tp, 0)
+}
+
+func (d *Device) validateDial(di streamdeck.DialID) error {
+	if di < streamdeck.DIAL_1 || di >= streamdeck.DIAL_1+streamdeck.DialID(d.spec.dialCount) {
+		return fmt.Errorf("%w: %s", ErrDialInvalid, di)
+	}
+	return nil
+}
+
+func (d *Device) setDial(di streamdeck.DialID, st byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if err := d.validateDial(di); err != nil {
+		return err
+	}
+
+	d.dialStates[di-streamdeck.DIAL_1] = st
+
+	buf := make([]byte, d.spec.inputLength)
+	buf[0] = 3
+	buf[3] = 0
+	copy(buf[d.spec.dialStart:], d.dialStates)
+	return d.inject(buf)
+}
+
+// PressDial injects an input report simulating a dial press.
+func (d *Device) PressDial(di streamdeck.DialID) error {
+	return d.setDial(di, 1)
+}
+
+// ReleaseDial injects an input report simulating a dial release.
+func (d *Device) ReleaseDial(di streamdeck.DialID) error {
+	return d.setDial(di, 0)
+}
+
+// RotateDial injects an input report simulating a dial rotation. Negative
+// deltas rotate the dial counterclockwise.
+func (d *Device) RotateDial(di streamdeck.DialID, delta int8) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if err := d.validateDial(di); err != nil {
+		return err
+	}
+
+	buf := make([]byte, d.spec.inputLength)
+	buf[0] = 3
+	buf[3] = 1
+	buf[d.spec.dialStart+byte(di-streamdeck.DIAL_1)] = byte(delta)
+	return d.inject(buf)
+}
+
+func (d *Device) touchStripReport(t byte, p ...image.Point) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.spec.touchStripRect.Empty() {
+		return ErrTouchStripInvalid
+	}
+
+	buf := make([]byte, d.spec.inputLength)
+	buf[0] = 2
+	buf[3] = t
+	for i, pt := range p {
+		buf[5+4*i] = byte(pt.X)
+		buf[6+4*i] = byte(pt.X >> 8)
+		buf[7+4*i] = byte(pt.Y)
+		buf[8+4*i] = byte(pt.Y >> 8)
+	}
+	return d.inject(buf)
+}
+
+// InjectInputReport injects a raw input report, padded with zeros to the
+// input report length, for example to simulate malformed reports.
+func (d *Device) InjectInputReport(buf []byte) error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	rv := make([]byte, max(len(buf), int(d.spec.inputLength)))
+	copy(rv, buf)
+	return d.inject(rv)
+}
+
+// TouchStrip injects an input report simulating a touch strip touch.
+func (d *Device) TouchStrip(t streamdeck.TouchStripTouchType, p image.Point) error {
+	switch t {
+	case streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT:
+		return d.touchStripReport(1, p)
+	case streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG:
+		return d.touchStripReport(2, p)
+	}
+	return fmt.Errorf("%w: invalid touch type: %s", ErrReportInvalid, t)
+}
+
+// SwipeTouchStrip injects an input report simulating a touch strip swipe.
+func (d *Device) SwipeTouchStrip(origin image.Point, destination image.Point) error {
+	return d.touchStripReport(3, origin, destination)
+}
+
+// KeyImage returns the last image drawn to a key display, or nil.
+func (d *Device) KeyImage(key streamdeck.KeyID) image.Image {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.keys[key]
+}
+
+// InfoBarImage returns the last image drawn to the info bar display, or nil.
+func (d *Device) InfoBarImage() image.Image {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.infoBar
+}
+
+// TouchStripImage returns the current contents of the touch strip display,
+// composed from all the images drawn to it, or nil.
+func (d *Device) TouchStripImage() image.Image {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.touchStrip == nil {
+		return nil
+	}
+
+	rv := image.NewRGBA(d.touchStrip.Bounds())
+	copy(rv.Pix, d.touchStrip.Pix)
+	return rv
+}
+
+// TouchPointColor returns the last color set to a touch point, or nil.
+func (d *Device) TouchPointColor(tp streamdeck.TouchPointID) color.Color {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.touchPoints[tp]
+}
+
+// Brightness returns the last brightness percentage set to the device.
+func (d *Device) Brightness() byte {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.brightness
+}
+
+// Resets returns the number of times the device was reset.
+func (d *Device) Resets() int {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	return d.resets
+}
+
+// Writes returns all the images written to the device displays, in order.
+func (d *Device) Writes() []Write {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	rv := make([]Write, len(d.writes))
+	copy(rv, d.writes)
+	return rv
+}
+
+// ClearWrites discards the list of images written to the device displays.
+func (d *Device) ClearWrites() {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	d.writes = nil
+}
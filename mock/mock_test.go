@@ -0,0 +1,309 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mock
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+func testImage(rect image.Rectangle) *image.RGBA {
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c := color.RGBA{A: 0xff}
+			if x < rect.Min.X+rect.Dx()/2 {
+				c.R = 0xff
+			}
+			if y < rect.Min.Y+rect.Dy()/2 {
+				c.B = 0xff
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func TestKeyImage(t *testing.T) {
+	for _, id := range Models() {
+		if id == "pedal" {
+			continue
+		}
+
+		t.Run(id, func(t *testing.T) {
+			dev, m, err := Open(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			if dev.GetModelID() != id {
+				t.Fatalf("bad model id: %s", dev.GetModelID())
+			}
+
+			rect, err := dev.GetKeyImageRectangle()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key := streamdeck.KEY_1 + streamdeck.KeyID(dev.GetKeyCount()) - 1
+			if err := dev.SetKeyImage(key, testImage(rect)); err != nil {
+				t.Fatal(err)
+			}
+
+			img := m.KeyImage(key)
+			if img == nil {
+				t.Fatal("key image not recorded")
+			}
+			if img.Bounds().Dx() != rect.Dx() || img.Bounds().Dy() != rect.Dy() {
+				t.Fatalf("bad key image size: %s", img.Bounds())
+			}
+
+			// top left is red + blue, bottom right is black
+			assertColor(t, img, 2, 2, color.RGBA{R: 0xff, B: 0xff})
+			assertColor(t, img, rect.Dx()-3, 2, color.RGBA{B: 0xff})
+			assertColor(t, img, 2, rect.Dy()-3, color.RGBA{R: 0xff})
+			assertColor(t, img, rect.Dx()-3, rect.Dy()-3, color.RGBA{})
+
+			if w := m.Writes(); len(w) != 1 || w[0].Surface != SURFACE_KEY || w[0].Key != key {
+				t.Errorf("bad writes: %+v", w)
+			}
+		})
+	}
+}
+
+func TestInfoBarAndTouchPoints(t *testing.T) {
+	dev, m, err := Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetInfoBarImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetInfoBarImage(testImage(rect)); err != nil {
+		t.Fatal(err)
+	}
+
+	img := m.InfoBarImage()
+	if img == nil {
+		t.Fatal("info bar image not recorded")
+	}
+	assertColor(t, img, 2, 2, color.RGBA{R: 0xff, B: 0xff})
+	assertColor(t, img, rect.Dx()-3, rect.Dy()-3, color.RGBA{})
+
+	if err := dev.SetTouchPointColor(streamdeck.TOUCH_POINT_2, color.RGBA{R: 1, G: 2, B: 3, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if c := m.TouchPointColor(streamdeck.TOUCH_POINT_2); c != (color.RGBA{R: 1, G: 2, B: 3, A: 0xff}) {
+		t.Errorf("bad touch point color: %v", c)
+	}
+}
+
+func TestTouchStrip(t *testing.T) {
+	dev, m, err := Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect := image.Rect(200, 0, 400, 100)
+	if err := dev.SetTouchStripImageWithRectangle(testImage(image.Rect(0, 0, 200, 100)), rect); err != nil {
+		t.Fatal(err)
+	}
+
+	img := m.TouchStripImage()
+	if img == nil {
+		t.Fatal("touch strip image not recorded")
+	}
+	if img.Bounds() != image.Rect(0, 0, 800, 100) {
+		t.Fatalf("bad touch strip size: %s", img.Bounds())
+	}
+	assertColor(t, img, 10, 10, color.RGBA{})
+	assertColor(t, img, 202, 2, color.RGBA{R: 0xff, B: 0xff})
+	assertColor(t, img, 397, 97, color.RGBA{})
+
+	if w := m.Writes(); len(w) != 1 || w[0].Surface != SURFACE_TOUCH_STRIP || w[0].Rect != rect {
+		t.Errorf("bad writes: %+v", w)
+	}
+}
+
+func TestBrightnessAndFirmware(t *testing.T) {
+	for _, id := range []string{"mini", "mk2"} {
+		t.Run(id, func(t *testing.T) {
+			dev, m, err := Open(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dev.Close()
+
+			if err := dev.SetBrightness(42); err != nil {
+				t.Fatal(err)
+			}
+			if b := m.Brightness(); b != 42 {
+				t.Errorf("bad brightness: %d", b)
+			}
+
+			m.SetFirmwareVersion("3.14")
+			if v, err := dev.GetFirmwareVersion(); err != nil || v != "3.14" {
+				t.Errorf("bad firmware version: %q, %v", v, err)
+			}
+
+			// only the second generation protocol reports other components
+			m.SetFirmwareComponentVersions("0.01", "2.71")
+			want := streamdeck.FirmwareVersions{Application: "3.14"}
+			if id == "mk2" {
+				want.Bootloader = "0.01"
+				want.Secondary = "2.71"
+			}
+			if v, err := dev.GetFirmwareVersions(); err != nil || v != want {
+				t.Errorf("bad firmware versions: %+v, %v", v, err)
+			}
+
+			m.SetFirmwareComponentVersions("", "")
+			if v, err := dev.GetFirmwareVersions(); err != nil || v != (streamdeck.FirmwareVersions{Application: "3.14"}) {
+				t.Errorf("bad firmware versions: %+v, %v", v, err)
+			}
+		})
+	}
+}
+
+func TestInput(t *testing.T) {
+	dev, m, err := Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_7, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "key"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddDialRotateHandler(streamdeck.DIAL_2, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+		if delta == -3 {
+			events <- "rotate"
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+		if t == streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG && p == image.Pt(300, 50) {
+			events <- "touch"
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- dev.Listen(nil)
+	}()
+
+	if err := m.PressKey(streamdeck.KEY_7); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_7); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.RotateDial(streamdeck.DIAL_2, -3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, image.Pt(300, 50)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]bool{}
+	for len(got) < 3 {
+		select {
+		case e := <-events:
+			got[e] = true
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for events, got: %v", got)
+		}
+	}
+
+	if err := dev.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-errCh:
+	case <-time.After(time.Second):
+		t.Fatal("listen did not return after close")
+	}
+}
+
+func TestMirroredKeys(t *testing.T) {
+	dev, m, err := Open("original")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	pressed := make(chan struct{}, 1)
+	if _, err := dev.AddKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		pressed <- struct{}{}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-pressed:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for key press")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if _, err := New("bola", ""); !errors.Is(err, ErrModelInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	m, err := New("mk2", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := m.GetInputReport(); !errors.Is(err, streamdeck.ErrDeviceIsClosed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := m.PressKey(streamdeck.KEY_16); !errors.Is(err, ErrKeyInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT, image.Point{}); !errors.Is(err, ErrTouchStripInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
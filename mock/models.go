@@ -0,0 +1,174 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mock
+
+import (
+	"image"
+)
+
+type protocol byte
+
+const (
+	// 15 bytes output report header, BMP images, no explicit payload size
+	protocolGen1 protocol = iota + 1
+
+	// 7 bytes output report header (15 bytes for touch strip), JPEG images
+	protocolGen2
+)
+
+type transform byte
+
+const (
+	transformFlipVertical transform = (1 << iota)
+	transformFlipHorizontal
+	transformRotate90
+)
+
+type modelSpec struct {
+	productID        uint16
+	product          string
+	protocol         protocol
+	pageOffset       byte
+	inputLength      uint16
+	outputLength     uint16
+	featureLength    uint16
+	keyStart         byte
+	keyCount         byte
+	keyColumns       byte
+	keyMirrored      bool
+	keyRect          image.Rectangle
+	keyTransform     transform
+	infoBarRect      image.Rectangle
+	infoBarTransform transform
+	touchPointStart  byte
+	touchPointCount  byte
+	dialStart        byte
+	dialCount        byte
+	touchStripRect   image.Rectangle
+}
+
+var models = map[string]*modelSpec{
+	"original": {
+		productID:     0x0060,
+		product:       "Stream Deck",
+		protocol:      protocolGen1,
+		pageOffset:    1,
+		inputLength:   16,
+		outputLength:  8190,
+		featureLength: 16,
+		keyStart:      0,
+		keyCount:      15,
+		keyColumns:    5,
+		keyMirrored:   true,
+		keyRect:       image.Rect(0, 0, 72, 72),
+		keyTransform:  transformFlipHorizontal | transformFlipVertical,
+	},
+	"mini": {
+		productID:     0x0063,
+		product:       "Stream Deck Mini",
+		protocol:      protocolGen1,
+		inputLength:   16,
+		outputLength:  1023,
+		featureLength: 16,
+		keyStart:      0,
+		keyCount:      6,
+		keyColumns:    3,
+		keyRect:       image.Rect(0, 0, 80, 80),
+		keyTransform:  transformRotate90 | transformFlipHorizontal,
+	},
+	"xl": {
+		productID:     0x006c,
+		product:       "Stream Deck XL",
+		protocol:      protocolGen2,
+		inputLength:   511,
+		outputLength:  1023,
+		featureLength: 31,
+		keyStart:      3,
+		keyCount:      32,
+		keyColumns:    8,
+		keyRect:       image.Rect(0, 0, 96, 96),
+		keyTransform:  transformFlipHorizontal | transformFlipVertical,
+	},
+	"mk2": {
+		productID:     0x0080,
+		product:       "Stream Deck MK.2",
+		protocol:      protocolGen2,
+		inputLength:   511,
+		outputLength:  1023,
+		featureLength: 31,
+		keyStart:      3,
+		keyCount:      15,
+		keyColumns:    5,
+		keyRect:       image.Rect(0, 0, 72, 72),
+		keyTransform:  transformFlipHorizontal | transformFlipVertical,
+	},
+	"plus": {
+		productID:      0x0084,
+		product:        "Stream Deck +",
+		protocol:       protocolGen2,
+		inputLength:    511,
+		outputLength:   1023,
+		featureLength:  31,
+		keyStart:       3,
+		keyCount:       8,
+		keyColumns:     4,
+		keyRect:        image.Rect(0, 0, 120, 120),
+		dialStart:      4,
+		dialCount:      4,
+		touchStripRect: image.Rect(0, 0, 800, 100),
+	},
+	"pedal": {
+		productID:     0x0086,
+		product:       "Stream Deck Pedal",
+		protocol:      protocolGen2,
+		inputLength:   511,
+		outputLength:  1023,
+		featureLength: 31,
+		keyStart:      3,
+		keyCount:      3,
+		keyColumns:    3,
+	},
+	"neo": {
+		productID:        0x009a,
+		product:          "Stream Deck Neo",
+		protocol:         protocolGen2,
+		inputLength:      511,
+		outputLength:     1023,
+		featureLength:    31,
+		keyStart:         3,
+		keyCount:         8,
+		keyColumns:       4,
+		keyRect:          image.Rect(0, 0, 96, 96),
+		keyTransform:     transformFlipHorizontal | transformFlipVertical,
+		infoBarRect:      image.Rect(0, 0, 248, 58),
+		infoBarTransform: transformFlipHorizontal | transformFlipVertical,
+		touchPointStart:  11,
+		touchPointCount:  2,
+	},
+	"studio": {
+		productID:     0x00aa,
+		product:       "Stream Deck Studio",
+		protocol:      protocolGen2,
+		inputLength:   511,
+		outputLength:  1023,
+		featureLength: 31,
+		keyStart:      3,
+		keyCount:      32,
+		keyColumns:    16,
+		keyRect:       image.Rect(0, 0, 144, 112),
+		keyTransform:  transformFlipHorizontal | transformFlipVertical,
+		dialStart:     4,
+		dialCount:     2,
+	},
+}
+
+func (m *modelSpec) keyIndex(i byte) byte {
+	if !m.keyMirrored || m.keyColumns == 0 {
+		return i
+	}
+
+	col := i % m.keyColumns
+	return i - col + (m.keyColumns - 1 - col)
+}
@@ -0,0 +1,248 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+)
+
+// modelsMtx protects the models map, that may be extended by RegisterModel.
+var modelsMtx sync.RWMutex
+
+// ImageTransform represents the transformations applied to the images sent to
+// an Elgato Stream Deck display, to match the orientation of the hardware.
+// Transformations may be combined with a bitwise OR.
+type ImageTransform byte
+
+// Elgato Stream Deck image transformations.
+const (
+	IMAGE_TRANSFORM_FLIP_VERTICAL ImageTransform = 1 << iota
+	IMAGE_TRANSFORM_FLIP_HORIZONTAL
+	IMAGE_TRANSFORM_ROTATE_90
+)
+
+func importImageTransform(t ImageTransform) imageTransform {
+	rv := imageTransform(0)
+	if t&IMAGE_TRANSFORM_FLIP_VERTICAL != 0 {
+		rv |= imageTransformFlipVertical
+	}
+	if t&IMAGE_TRANSFORM_FLIP_HORIZONTAL != 0 {
+		rv |= imageTransformFlipHorizontal
+	}
+	if t&IMAGE_TRANSFORM_ROTATE_90 != 0 {
+		rv |= imageTransformRotate90
+	}
+	return rv
+}
+
+func importImageFormat(f ImageFormat) (imageFormat, error) {
+	switch f {
+	case IMAGE_FORMAT_BMP:
+		return imageFormatBMP, nil
+	case IMAGE_FORMAT_JPEG:
+		return imageFormatJPEG, nil
+	default:
+		return 0, fmt.Errorf("image format not supported: %d", f)
+	}
+}
+
+// ModelDefinition represents an Elgato Stream Deck model to be registered
+// with RegisterModel. The input report offsets are relative to the start of
+// the reports, as returned by HIDDevice.GetInputReport, and the callback
+// functions receive the HIDDevice of the device being controlled.
+type ModelDefinition struct {
+	// ProductID is the USB product identifier of the model. The USB vendor
+	// identifier is always the one used by Elgato.
+	ProductID uint16
+
+	// ID is the model identifier returned by Device.GetModelID.
+	ID string
+
+	// KeyStart is the offset of the key states in the input reports.
+	// KeyColumns is the number of columns of the key grid. KeyMirrored is set
+	// if the hardware numbers the keys from right to left in each row.
+	KeyStart    byte
+	KeyCount    byte
+	KeyColumns  byte
+	KeyMirrored bool
+
+	// KeyImageSend sends an encoded image to a key display. If nil, the keys
+	// do not include displays.
+	KeyImageRect      image.Rectangle
+	KeyImageGap       image.Point
+	KeyImageFormat    ImageFormat
+	KeyImageTransform ImageTransform
+	KeyImageSend      func(dev HIDDevice, key KeyID, imgData []byte) error
+
+	// InfoBarImageSend sends an encoded image to the info bar display. If
+	// nil, the model does not include an info bar.
+	InfoBarImageRect      image.Rectangle
+	InfoBarImageFormat    ImageFormat
+	InfoBarImageTransform ImageTransform
+	InfoBarImageSend      func(dev HIDDevice, imgData []byte) error
+
+	// TouchPointStart is the offset of the touch point states in the input
+	// reports.
+	TouchPointStart     byte
+	TouchPointCount     byte
+	TouchPointColorSend func(dev HIDDevice, tp TouchPointID, c color.Color) error
+
+	// DialStart is the offset of the dial states and rotations in the dial
+	// input reports.
+	DialStart byte
+	DialCount byte
+
+	// TouchStripImageSend sends an encoded image to the given area of the
+	// touch strip display. If nil, the model does not include a touch strip.
+	TouchStripImageRect      image.Rectangle
+	TouchStripImageFormat    ImageFormat
+	TouchStripImageTransform ImageTransform
+	TouchStripImageSend      func(dev HIDDevice, imgData []byte, rect image.Rectangle) error
+
+	// Reset and FirmwareVersion are required. Brightness is nil if the model
+	// does not support brightness control.
+	Reset           func(dev HIDDevice) error
+	Brightness      func(dev HIDDevice, perc byte) error
+	FirmwareVersion func(dev HIDDevice) (string, error)
+}
+
+func (def ModelDefinition) model() (*model, error) {
+	if def.ID == "" {
+		return nil, fmt.Errorf("model identifier not set")
+	}
+	if def.ProductID == 0 {
+		return nil, fmt.Errorf("product identifier not set")
+	}
+	if def.KeyCount > 0 && def.KeyColumns == 0 {
+		return nil, fmt.Errorf("key columns not set")
+	}
+	if def.Reset == nil || def.FirmwareVersion == nil {
+		return nil, fmt.Errorf("reset and firmware version callbacks are required")
+	}
+
+	rv := &model{
+		id:                  def.ID,
+		keyStart:            def.KeyStart,
+		keyCount:            def.KeyCount,
+		keyColumns:          def.KeyColumns,
+		keyMirrored:         def.KeyMirrored,
+		touchPointStart:     def.TouchPointStart,
+		touchPointCount:     def.TouchPointCount,
+		touchPointColorSend: def.TouchPointColorSend,
+		dialStart:           def.DialStart,
+		dialCount:           def.DialCount,
+		reset:               def.Reset,
+		brightness:          def.Brightness,
+		firmwareVersion:     def.FirmwareVersion,
+	}
+
+	if def.KeyImageSend != nil {
+		if def.KeyCount == 0 || def.KeyImageRect.Empty() {
+			return nil, fmt.Errorf("key image rectangle not set")
+		}
+		f, err := importImageFormat(def.KeyImageFormat)
+		if err != nil {
+			return nil, err
+		}
+		rv.keyImageRect = def.KeyImageRect
+		rv.keyImageGap = def.KeyImageGap
+		rv.keyImageFormat = f
+		rv.keyImageTransform = importImageTransform(def.KeyImageTransform)
+		rv.keyImageSend = def.KeyImageSend
+	}
+
+	if def.InfoBarImageSend != nil {
+		if def.InfoBarImageRect.Empty() {
+			return nil, fmt.Errorf("info bar image rectangle not set")
+		}
+		f, err := importImageFormat(def.InfoBarImageFormat)
+		if err != nil {
+			return nil, err
+		}
+		rv.infoBarImageRect = def.InfoBarImageRect
+		rv.infoBarImageFormat = f
+		rv.infoBarImageTransform = importImageTransform(def.InfoBarImageTransform)
+		rv.infoBarImageSend = def.InfoBarImageSend
+	}
+
+	if def.TouchStripImageSend != nil {
+		if def.TouchStripImageRect.Empty() {
+			return nil, fmt.Errorf("touch strip image rectangle not set")
+		}
+		f, err := importImageFormat(def.TouchStripImageFormat)
+		if err != nil {
+			return nil, err
+		}
+		rv.touchStripImageRect = def.TouchStripImageRect
+		rv.touchStripImageFormat = f
+		rv.touchStripImageTransform = importImageTransform(def.TouchStripImageTransform)
+		rv.touchStripImageSend = def.TouchStripImageSend
+	}
+	return rv, nil
+}
+
+// RegisterModel adds an Elgato Stream Deck model definition, so that devices
+// of models not supported by the package can be found by Enumerate and
+// GetDevice, and used like any other device. Models can not be replaced, so
+// the product identifier and the model identifier must not be used by any
+// model already supported or registered.
+func RegisterModel(def ModelDefinition) error {
+	md, err := def.model()
+	if err != nil {
+		return fmt.Errorf("streamdeck: %w: %s", ErrModelInvalid, err)
+	}
+
+	modelsMtx.Lock()
+	defer modelsMtx.Unlock()
+
+	if _, found := models[def.ProductID]; found {
+		return fmt.Errorf("streamdeck: %w: product identifier already registered: %04x", ErrModelInvalid, def.ProductID)
+	}
+	if _, found := modelAliases[def.ProductID]; found {
+		return fmt.Errorf("streamdeck: %w: product identifier already registered: %04x", ErrModelInvalid, def.ProductID)
+	}
+	for _, m := range models {
+		if m.id == def.ID {
+			return fmt.Errorf("streamdeck: %w: model identifier already registered: %s", ErrModelInvalid, def.ID)
+		}
+	}
+
+	models[def.ProductID] = md
+	return nil
+}
+
+// SendImageReports sends an encoded image to the device as a sequence of
+// output reports with the given report identifier, each one starting with the
+// header, as used by the image transfers of all the supported models. The
+// callback is called before each report is sent, to update the header in
+// place with the page number, the last page flag and the size of the
+// payload, so fields not updated keep the values set for the previous
+// report. It is useful to implement the image callbacks of a
+// ModelDefinition.
+func SendImageReports(dev HIDDevice, reportID byte, hdr []byte, imgData []byte, cb func(hdr []byte, page byte, last byte, size uint16)) error {
+	return imageSend(dev, reportID, hdr, imgData, cb)
+}
+
+// ImagePagePayloadSize returns the maximum number of bytes of image data
+// carried by each output report sent by SendImageReports with a header of
+// the given length, so that callers can split the data into pages in
+// advance.
+func ImagePagePayloadSize(dev HIDDevice, headerLength int) int {
+	return max(imagePagePayloadSize(dev, headerLength), 0)
+}
+
+func lookupModel(id uint16) (*model, bool) {
+	modelsMtx.RLock()
+	defer modelsMtx.RUnlock()
+
+	if ma, found := modelAliases[id]; found {
+		id = ma
+	}
+	md, found := models[id]
+	return md, found
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"testing"
+)
+
+type registryDevice struct {
+	HIDDevice
+	productID uint16
+	reports   [][]byte
+}
+
+func (r *registryDevice) VendorId() uint16 {
+	return elgatoVendorID
+}
+
+func (r *registryDevice) ProductId() uint16 {
+	return r.productID
+}
+
+func (r *registryDevice) GetOutputReportLength() uint16 {
+	return 16
+}
+
+func (r *registryDevice) SetOutputReport(id byte, data []byte) error {
+	r.reports = append(r.reports, append([]byte{id}, data...))
+	return nil
+}
+
+func TestRegisterModel(t *testing.T) {
+	def := ModelDefinition{
+		ProductID:         0xfff0,
+		ID:                "test",
+		KeyStart:          3,
+		KeyCount:          6,
+		KeyColumns:        3,
+		KeyImageRect:      image.Rect(0, 0, 64, 64),
+		KeyImageGap:       image.Pt(16, 16),
+		KeyImageFormat:    IMAGE_FORMAT_JPEG,
+		KeyImageTransform: IMAGE_TRANSFORM_FLIP_HORIZONTAL | IMAGE_TRANSFORM_ROTATE_90,
+		KeyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
+			return SendImageReports(dev, 2, []byte{7, byte(key), 0, 0}, imgData, func(hdr []byte, page, last byte, size uint16) {
+				hdr[2] = page
+				hdr[3] = last
+			})
+		},
+		Reset:           func(dev HIDDevice) error { return nil },
+		FirmwareVersion: func(dev HIDDevice) (string, error) { return "1.0", nil },
+	}
+	t.Cleanup(func() {
+		modelsMtx.Lock()
+		delete(models, def.ProductID)
+		modelsMtx.Unlock()
+	})
+
+	dev := &registryDevice{productID: def.ProductID}
+	if _, err := NewDevice(dev); !errors.Is(err, ErrDeviceEnumerationFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, tt := range []func(d *ModelDefinition){
+		func(d *ModelDefinition) { d.ID = "" },
+		func(d *ModelDefinition) { d.ID = "plus" },
+		func(d *ModelDefinition) { d.ProductID = 0x0084 },
+		func(d *ModelDefinition) { d.ProductID = 0x006d },
+		func(d *ModelDefinition) { d.KeyColumns = 0 },
+		func(d *ModelDefinition) { d.KeyImageFormat = 0 },
+		func(d *ModelDefinition) { d.KeyImageRect = image.Rectangle{} },
+		func(d *ModelDefinition) { d.Reset = nil },
+	} {
+		bad := def
+		tt(&bad)
+		if err := RegisterModel(bad); !errors.Is(err, ErrModelInvalid) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if err := RegisterModel(def); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterModel(def); !errors.Is(err, ErrModelInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	d, err := NewDevice(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := d.GetCapabilities()
+	if c.ModelID != "test" || c.KeyRows != 2 || c.KeyColumns != 3 || c.KeyImageFormat != IMAGE_FORMAT_JPEG || c.DeckImageRect != image.Rect(0, 0, 224, 144) {
+		t.Errorf("unexpected capabilities: %+v", c)
+	}
+	if tr := d.model.keyImageTransform; tr != imageTransformFlipHorizontal|imageTransformRotate90 {
+		t.Errorf("unexpected transform: %d", tr)
+	}
+
+	if n := ImagePagePayloadSize(dev, 4); n != 12 {
+		t.Errorf("unexpected page payload size: %d", n)
+	}
+	if err := d.model.keyImageSend(dev, KEY_2, make([]byte, 20)); err != nil {
+		t.Fatal(err)
+	}
+	if len(dev.reports) != 2 {
+		t.Fatalf("unexpected number of reports: %d", len(dev.reports))
+	}
+	for i, want := range [][]byte{{2, 7, 2, 0, 0}, {2, 7, 2, 1, 1}} {
+		if got := dev.reports[i][:5]; string(got) != string(want) {
+			t.Errorf("unexpected report header %d: %v", i, got)
+		}
+	}
+}
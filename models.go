@@ -19,37 +19,88 @@ type model struct {
 	id                       string
 	keyStart                 byte
 	keyCount                 byte
+	keyColumns               byte
+	keyMirrored              bool
 	keyImageRect             image.Rectangle
+	keyImageGap              image.Point
 	keyImageFormat           imageFormat
 	keyImageTransform        imageTransform
-	keyImageSend             func(dev *usbhid.Device, key KeyID, imgData []byte) error
+	keyImageSend             func(dev HIDDevice, key KeyID, imgData []byte) error
 	infoBarImageRect         image.Rectangle
 	infoBarImageFormat       imageFormat
 	infoBarImageTransform    imageTransform
-	infoBarImageSend         func(dev *usbhid.Device, imgData []byte) error
+	infoBarImageSend         func(dev HIDDevice, imgData []byte) error
 	touchPointStart          byte
 	touchPointCount          byte
-	touchPointColorSend      func(dev *usbhid.Device, tp TouchPointID, c color.Color) error
+	touchPointColorSend      func(dev HIDDevice, tp TouchPointID, c color.Color) error
 	dialStart                byte
 	dialCount                byte
 	touchStripImageRect      image.Rectangle
 	touchStripImageFormat    imageFormat
 	touchStripImageTransform imageTransform
-	touchStripImageSend      func(dev *usbhid.Device, imgData []byte, rect image.Rectangle) error
-	reset                    func(dev *usbhid.Device) error
-	brightness               func(dev *usbhid.Device, perc byte) error
-	firmwareVersion          func(dev *usbhid.Device) (string, error)
+	touchStripImageSend      func(dev HIDDevice, imgData []byte, rect image.Rectangle) error
+	reset                    func(dev HIDDevice) error
+	brightness               func(dev HIDDevice, perc byte) error
+	firmwareVersion          func(dev HIDDevice) (string, error)
+	firmwareVersions         func(dev HIDDevice) (FirmwareVersions, error)
+	serialNumber             func(dev HIDDevice) (string, error)
 }
 
 var models = map[uint16]*model{
+	0x0060: {
+		id:                "original",
+		keyStart:          0,
+		keyCount:          15,
+		keyColumns:        5,
+		keyMirrored:       true,
+		keyImageRect:      image.Rect(0, 0, 72, 72),
+		keyImageGap:       image.Pt(25, 25),
+		keyImageFormat:    imageFormatBMP,
+		keyImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
+			k := byte(key - KEY_1)
+			hdr := make([]byte, 15)
+			hdr[0] = 1
+			hdr[4] = 1 + k - k%5 + (5 - 1 - k%5)
+			return imageSend(dev, 2, hdr, imgData, func(hdr []byte, page, last byte, size uint16) {
+				hdr[1] = page + 1
+				hdr[3] = last
+			})
+		},
+		reset: func(dev HIDDevice) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x63
+			return dev.SetFeatureReport(11, pl)
+		},
+		brightness: func(dev HIDDevice, perc byte) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x55
+			pl[1] = 0xaa
+			pl[2] = 0xd1
+			pl[3] = 0x01
+			pl[4] = perc
+			return dev.SetFeatureReport(5, pl)
+		},
+		firmwareVersion: func(dev HIDDevice) (string, error) {
+			buf, err := dev.GetFeatureReport(4)
+			if err != nil {
+				return "", err
+			}
+			b, _, _ := bytes.Cut(buf[4:], []byte{0})
+			return string(b), nil
+		},
+		serialNumber: gen1SerialNumber,
+	},
 	0x0063: {
 		id:                "mini",
 		keyStart:          0,
 		keyCount:          6,
+		keyColumns:        3,
 		keyImageRect:      image.Rect(0, 0, 80, 80),
+		keyImageGap:       image.Pt(28, 28),
 		keyImageFormat:    imageFormatBMP,
 		keyImageTransform: imageTransformRotate90 | imageTransformFlipHorizontal,
-		keyImageSend: func(dev *usbhid.Device, key KeyID, imgData []byte) error {
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
 			hdr := make([]byte, 15)
 			hdr[0] = 1
 			hdr[4] = 1 + byte(key-KEY_1)
@@ -58,12 +109,12 @@ var models = map[uint16]*model{
 				hdr[3] = last
 			})
 		},
-		reset: func(dev *usbhid.Device) error {
+		reset: func(dev HIDDevice) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x63
 			return dev.SetFeatureReport(11, pl)
 		},
-		brightness: func(dev *usbhid.Device, perc byte) error {
+		brightness: func(dev HIDDevice, perc byte) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x55
 			pl[1] = 0xaa
@@ -72,7 +123,7 @@ var models = map[uint16]*model{
 			pl[4] = perc
 			return dev.SetFeatureReport(5, pl)
 		},
-		firmwareVersion: func(dev *usbhid.Device) (string, error) {
+		firmwareVersion: func(dev HIDDevice) (string, error) {
 			buf, err := dev.GetFeatureReport(4)
 			if err != nil {
 				return "", err
@@ -80,15 +131,60 @@ var models = map[uint16]*model{
 			b, _, _ := bytes.Cut(buf[4:], []byte{0})
 			return string(b), nil
 		},
+		serialNumber: gen1SerialNumber,
+	},
+	0x006c: {
+		id:                "xl",
+		keyStart:          3,
+		keyCount:          32,
+		keyColumns:        8,
+		keyImageRect:      image.Rect(0, 0, 96, 96),
+		keyImageGap:       image.Pt(32, 32),
+		keyImageFormat:    imageFormatJPEG,
+		keyImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
+			hdr := make([]byte, 7)
+			hdr[0] = 7
+			hdr[1] = byte(key - KEY_1)
+			return imageSend(dev, 2, hdr, imgData, func(hdr []byte, page, last byte, size uint16) {
+				hdr[2] = last
+				hdr[3] = byte(size)
+				hdr[4] = byte(size >> 8)
+				hdr[5] = byte(page)
+			})
+		},
+		reset: func(dev HIDDevice) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x02
+			return dev.SetFeatureReport(3, pl)
+		},
+		brightness: func(dev HIDDevice, perc byte) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x08
+			pl[1] = perc
+			return dev.SetFeatureReport(3, pl)
+		},
+		firmwareVersion: func(dev HIDDevice) (string, error) {
+			buf, err := dev.GetFeatureReport(5)
+			if err != nil {
+				return "", err
+			}
+			b, _, _ := bytes.Cut(buf[5:], []byte{0})
+			return string(b), nil
+		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
 	},
 	0x0080: {
 		id:                "mk2",
 		keyStart:          3,
 		keyCount:          15,
+		keyColumns:        5,
 		keyImageRect:      image.Rect(0, 0, 72, 72),
+		keyImageGap:       image.Pt(25, 25),
 		keyImageFormat:    imageFormatJPEG,
 		keyImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
-		keyImageSend: func(dev *usbhid.Device, key KeyID, imgData []byte) error {
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
 			hdr := make([]byte, 7)
 			hdr[0] = 7
 			hdr[1] = byte(key - KEY_1)
@@ -99,18 +195,18 @@ var models = map[uint16]*model{
 				hdr[5] = byte(page)
 			})
 		},
-		reset: func(dev *usbhid.Device) error {
+		reset: func(dev HIDDevice) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x02
 			return dev.SetFeatureReport(3, pl)
 		},
-		brightness: func(dev *usbhid.Device, perc byte) error {
+		brightness: func(dev HIDDevice, perc byte) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x08
 			pl[1] = perc
 			return dev.SetFeatureReport(3, pl)
 		},
-		firmwareVersion: func(dev *usbhid.Device) (string, error) {
+		firmwareVersion: func(dev HIDDevice) (string, error) {
 			buf, err := dev.GetFeatureReport(5)
 			if err != nil {
 				return "", err
@@ -118,15 +214,19 @@ var models = map[uint16]*model{
 			b, _, _ := bytes.Cut(buf[5:], []byte{0})
 			return string(b), nil
 		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
 	},
 	0x0084: {
 		id:                "plus",
 		keyStart:          3,
 		keyCount:          8,
+		keyColumns:        4,
 		keyImageRect:      image.Rect(0, 0, 120, 120),
+		keyImageGap:       image.Pt(40, 40),
 		keyImageFormat:    imageFormatJPEG,
 		keyImageTransform: 0,
-		keyImageSend: func(dev *usbhid.Device, key KeyID, imgData []byte) error {
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
 			hdr := make([]byte, 7)
 			hdr[0] = 7
 			hdr[1] = byte(key - KEY_1)
@@ -142,7 +242,7 @@ var models = map[uint16]*model{
 		touchStripImageRect:      image.Rect(0, 0, 800, 100),
 		touchStripImageFormat:    imageFormatJPEG,
 		touchStripImageTransform: 0,
-		touchStripImageSend: func(dev *usbhid.Device, imgData []byte, rect image.Rectangle) error {
+		touchStripImageSend: func(dev HIDDevice, imgData []byte, rect image.Rectangle) error {
 			hdr := make([]byte, 15)
 			hdr[0] = 12
 			hdr[1] = byte(rect.Min.X)
@@ -161,18 +261,18 @@ var models = map[uint16]*model{
 				hdr[13] = byte(size >> 8)
 			})
 		},
-		reset: func(dev *usbhid.Device) error {
+		reset: func(dev HIDDevice) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x02
 			return dev.SetFeatureReport(3, pl)
 		},
-		brightness: func(dev *usbhid.Device, perc byte) error {
+		brightness: func(dev HIDDevice, perc byte) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x08
 			pl[1] = perc
 			return dev.SetFeatureReport(3, pl)
 		},
-		firmwareVersion: func(dev *usbhid.Device) (string, error) {
+		firmwareVersion: func(dev HIDDevice) (string, error) {
 			buf, err := dev.GetFeatureReport(5)
 			if err != nil {
 				return "", err
@@ -180,15 +280,40 @@ var models = map[uint16]*model{
 			b, _, _ := bytes.Cut(buf[5:], []byte{0})
 			return string(b), nil
 		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
+	},
+	0x0086: {
+		id:         "pedal",
+		keyStart:   3,
+		keyCount:   3,
+		keyColumns: 3,
+		reset: func(dev HIDDevice) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x02
+			return dev.SetFeatureReport(3, pl)
+		},
+		firmwareVersion: func(dev HIDDevice) (string, error) {
+			buf, err := dev.GetFeatureReport(5)
+			if err != nil {
+				return "", err
+			}
+			b, _, _ := bytes.Cut(buf[5:], []byte{0})
+			return string(b), nil
+		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
 	},
 	0x009a: {
 		id:                "neo",
 		keyStart:          3,
 		keyCount:          8,
+		keyColumns:        4,
 		keyImageRect:      image.Rect(0, 0, 96, 96),
+		keyImageGap:       image.Pt(32, 32),
 		keyImageFormat:    imageFormatJPEG,
 		keyImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
-		keyImageSend: func(dev *usbhid.Device, key KeyID, imgData []byte) error {
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
 			hdr := make([]byte, 7)
 			hdr[0] = 7
 			hdr[1] = byte(key - KEY_1)
@@ -202,7 +327,7 @@ var models = map[uint16]*model{
 		infoBarImageRect:      image.Rect(0, 0, 248, 58),
 		infoBarImageFormat:    imageFormatJPEG,
 		infoBarImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
-		infoBarImageSend: func(dev *usbhid.Device, imgData []byte) error {
+		infoBarImageSend: func(dev HIDDevice, imgData []byte) error {
 			hdr := make([]byte, 7)
 			hdr[0] = 11
 			return imageSend(dev, 2, hdr, imgData, func(hdr []byte, page, last byte, size uint16) {
@@ -214,7 +339,7 @@ var models = map[uint16]*model{
 		},
 		touchPointStart: 11,
 		touchPointCount: 2,
-		touchPointColorSend: func(dev *usbhid.Device, tp TouchPointID, c color.Color) error {
+		touchPointColorSend: func(dev HIDDevice, tp TouchPointID, c color.Color) error {
 			r, g, b, _ := c.RGBA()
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x06
@@ -224,18 +349,18 @@ var models = map[uint16]*model{
 			pl[4] = byte(b)
 			return dev.SetFeatureReport(3, pl)
 		},
-		reset: func(dev *usbhid.Device) error {
+		reset: func(dev HIDDevice) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x02
 			return dev.SetFeatureReport(3, pl)
 		},
-		brightness: func(dev *usbhid.Device, perc byte) error {
+		brightness: func(dev HIDDevice, perc byte) error {
 			pl := make([]byte, dev.GetFeatureReportLength())
 			pl[0] = 0x08
 			pl[1] = perc
 			return dev.SetFeatureReport(3, pl)
 		},
-		firmwareVersion: func(dev *usbhid.Device) (string, error) {
+		firmwareVersion: func(dev HIDDevice) (string, error) {
 			buf, err := dev.GetFeatureReport(5)
 			if err != nil {
 				return "", err
@@ -243,24 +368,113 @@ var models = map[uint16]*model{
 			b, _, _ := bytes.Cut(buf[5:], []byte{0})
 			return string(b), nil
 		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
 	},
+	0x00aa: {
+		id:                "studio",
+		keyStart:          3,
+		keyCount:          32,
+		keyColumns:        16,
+		keyImageRect:      image.Rect(0, 0, 144, 112),
+		keyImageGap:       image.Pt(40, 40),
+		keyImageFormat:    imageFormatJPEG,
+		keyImageTransform: imageTransformFlipHorizontal | imageTransformFlipVertical,
+		keyImageSend: func(dev HIDDevice, key KeyID, imgData []byte) error {
+			hdr := make([]byte, 7)
+			hdr[0] = 7
+			hdr[1] = byte(key - KEY_1)
+			return imageSend(dev, 2, hdr, imgData, func(hdr []byte, page, last byte, size uint16) {
+				hdr[2] = last
+				hdr[3] = byte(size)
+				hdr[4] = byte(size >> 8)
+				hdr[5] = byte(page)
+			})
+		},
+		dialStart: 4,
+		dialCount: 2,
+		reset: func(dev HIDDevice) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x02
+			return dev.SetFeatureReport(3, pl)
+		},
+		brightness: func(dev HIDDevice, perc byte) error {
+			pl := make([]byte, dev.GetFeatureReportLength())
+			pl[0] = 0x08
+			pl[1] = perc
+			return dev.SetFeatureReport(3, pl)
+		},
+		firmwareVersion: func(dev HIDDevice) (string, error) {
+			buf, err := dev.GetFeatureReport(5)
+			if err != nil {
+				return "", err
+			}
+			b, _, _ := bytes.Cut(buf[5:], []byte{0})
+			return string(b), nil
+		},
+		firmwareVersions: gen2FirmwareVersions,
+		serialNumber:     gen2SerialNumber,
+	},
+}
+
+// keyIndex converts between a zero-based key index, as used by KeyID, and
+// the index used by the device hardware to report and address the key.
+func (m *model) keyIndex(i byte) byte {
+	if !m.keyMirrored || m.keyColumns == 0 {
+		return i
+	}
+
+	// keys are numbered right to left in each row
+	col := i % m.keyColumns
+	return i - col + (m.keyColumns - 1 - col)
+}
+
+// readFeatureString reads a NUL-terminated string from a feature report,
+// starting at the given offset.
+func readFeatureString(dev HIDDevice, id byte, offset int) (string, error) {
+	buf, err := dev.GetFeatureReport(id)
+	if err != nil {
+		return "", err
+	}
+	if len(buf) < offset {
+		return "", fmt.Errorf("feature report too short: %d", len(buf))
+	}
+	b, _, _ := bytes.Cut(buf[offset:], []byte{0})
+	return string(b), nil
+}
+
+func gen2FirmwareVersions(dev HIDDevice) (FirmwareVersions, error) {
+	app, err := readFeatureString(dev, 5, 5)
+	if err != nil {
+		return FirmwareVersions{}, err
+	}
+
+	// the other components are not reported by all the firmwares
+	rv := FirmwareVersions{Application: app}
+	rv.Bootloader, _ = readFeatureString(dev, 4, 5)
+	rv.Secondary, _ = readFeatureString(dev, 7, 5)
+	return rv, nil
+}
+
+func gen1SerialNumber(dev HIDDevice) (string, error) {
+	return readFeatureString(dev, 3, 4)
+}
+
+func gen2SerialNumber(dev HIDDevice) (string, error) {
+	return readFeatureString(dev, 6, 1)
 }
 
 var modelAliases = map[uint16]uint16{
 	0x006d: 0x0080,
+	0x008f: 0x006c,
 }
 
-func getModel(dev *usbhid.Device) (*model, error) {
+func getModel(dev HIDDevice) (*model, error) {
 	if dev.VendorId() != elgatoVendorID {
 		return nil, fmt.Errorf("%w: not an Elgato device: %04x", ErrDeviceEnumerationFailed, dev.VendorId())
 	}
 
-	id := dev.ProductId()
-	if ma, found := modelAliases[id]; found {
-		id = ma
-	}
-
-	md, found := models[id]
+	md, found := lookupModel(dev.ProductId())
 	if !found {
 		return nil, fmt.Errorf("%w: device not supported: %04x:%04x", ErrDeviceEnumerationFailed, dev.VendorId(), dev.ProductId())
 	}
@@ -271,10 +485,6 @@ func enumerateFunc(dev *usbhid.Device) bool {
 	if dev.VendorId() != elgatoVendorID {
 		return false
 	}
-	id := dev.ProductId()
-	if ma, found := modelAliases[id]; found {
-		id = ma
-	}
-	_, found := models[id]
+	_, found := lookupModel(dev.ProductId())
 	return found
 }
@@ -0,0 +1,108 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestModel_KeyIndex(t *testing.T) {
+	md := models[0x0060]
+
+	want := []byte{4, 3, 2, 1, 0, 9, 8, 7, 6, 5, 14, 13, 12, 11, 10}
+	for i, w := range want {
+		if got := md.keyIndex(byte(i)); got != w {
+			t.Errorf("keyIndex(%d) = %d, want %d", i, got, w)
+		}
+	}
+
+	md = models[0x0080]
+	for i := range md.keyCount {
+		if got := md.keyIndex(i); got != i {
+			t.Errorf("keyIndex(%d) = %d, want %d", i, got, i)
+		}
+	}
+}
+
+func TestKeyLayout(t *testing.T) {
+	d := &Device{model: models[0x009a]}
+
+	if rows, cols := d.GetKeyLayout(); rows != 2 || cols != 4 {
+		t.Fatalf("bad layout: %dx%d", rows, cols)
+	}
+
+	key, err := d.KeyAt(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != KEY_7 {
+		t.Errorf("bad key: %s", key)
+	}
+
+	row, col, err := d.KeyPosition(KEY_7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row != 1 || col != 2 {
+		t.Errorf("bad position: %d, %d", row, col)
+	}
+
+	for _, pos := range [][2]int{{2, 0}, {0, 4}, {-1, 0}} {
+		if _, err := d.KeyAt(pos[0], pos[1]); !errors.Is(err, ErrKeyPositionInvalid) {
+			t.Errorf("unexpected error for %v: %v", pos, err)
+		}
+	}
+	if _, _, err := d.KeyPosition(KEY_9); !errors.Is(err, ErrKeyInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestStudioInput(t *testing.T) {
+	keys := make([]byte, 35)
+	keys[34] = 1
+
+	d, err := NewDevice(&reportDevice{productID: 0x00aa, reports: [][]byte{
+		keys,
+		{3, 0, 0, 1, 0, 0xfe},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer d.CloseWithoutClear()
+
+	events := make(chan string, 2)
+	if _, err := d.AddKeyPressHandler(KEY_32, func(d *Device, k *Key) error {
+		events <- "press"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.AddDialRotateHandler(DIAL_2, func(d *Device, di *Dial, delta int8) error {
+		if delta == -2 {
+			events <- "rotate"
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.Listen(nil); !errors.Is(err, ErrGetInputReportFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	got := map[string]bool{}
+	for len(got) < 2 {
+		select {
+		case e := <-events:
+			got[e] = true
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for events, got: %v", got)
+		}
+	}
+}
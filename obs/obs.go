@@ -0,0 +1,514 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package obs binds Elgato Stream Deck keys to OBS Studio, through the
+// obs-websocket v5 protocol.
+//
+// Keys may be bound to scenes, switching the program scene when pressed, or
+// to inputs, toggling their mute state when pressed. Bound keys display the
+// scene or input name, and follow the live OBS state: scene keys are
+// highlighted in red while their scene is on program, and mute keys are
+// green while the input is live and red while it is muted.
+//
+//	c, err := obs.Dial("ws://localhost:4455", "password")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer c.Close()
+//
+//	c.BindKeyToScene(dev, streamdeck.KEY_1, "Gameplay")
+//	c.BindKeyToMute(dev, streamdeck.KEY_2, "Mic/Aux")
+//
+// Key presses are only handled while the application calls Device.Listen.
+package obs
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the obs package.
+var (
+	ErrAuthenticationRequired = errors.New("obs: authentication is required")
+	ErrClientClosed           = errors.New("obs: client is closed")
+	ErrProtocol               = errors.New("obs: protocol error")
+	ErrRequestFailed          = errors.New("obs: request failed")
+)
+
+const (
+	opHello           = 0
+	opIdentify        = 1
+	opIdentified      = 2
+	opEvent           = 5
+	opRequest         = 6
+	opRequestResponse = 7
+
+	rpcVersion = 1
+
+	// EventSubscription::Scenes | EventSubscription::Inputs
+	eventSubscriptions = (1 << 2) | (1 << 3)
+
+	handshakeTimeout = 5 * time.Second
+)
+
+var (
+	colorSceneActive   = color.RGBA{R: 0xc0, G: 0x20, B: 0x20, A: 0xff}
+	colorSceneInactive = color.RGBA{R: 0x30, G: 0x30, B: 0x30, A: 0xff}
+	colorInputLive     = color.RGBA{R: 0x20, G: 0x90, B: 0x20, A: 0xff}
+	colorInputMuted    = color.RGBA{R: 0xc0, G: 0x20, B: 0x20, A: 0xff}
+)
+
+type message struct {
+	Op int             `json:"op"`
+	D  json.RawMessage `json:"d"`
+}
+
+type hello struct {
+	Authentication *struct {
+		Challenge string `json:"challenge"`
+		Salt      string `json:"salt"`
+	} `json:"authentication"`
+}
+
+type identify struct {
+	RPCVersion         int    `json:"rpcVersion"`
+	Authentication     string `json:"authentication,omitempty"`
+	EventSubscriptions int    `json:"eventSubscriptions"`
+}
+
+type request struct {
+	RequestType string `json:"requestType"`
+	RequestID   string `json:"requestId"`
+	RequestData any    `json:"requestData,omitempty"`
+}
+
+type response struct {
+	RequestType   string `json:"requestType"`
+	RequestID     string `json:"requestId"`
+	RequestStatus struct {
+		Result  bool   `json:"result"`
+		Code    int    `json:"code"`
+		Comment string `json:"comment"`
+	} `json:"requestStatus"`
+	ResponseData json.RawMessage `json:"responseData"`
+}
+
+type event struct {
+	EventType string          `json:"eventType"`
+	EventData json.RawMessage `json:"eventData"`
+}
+
+// Binding represents an Elgato Stream Deck key bound to OBS Studio. Keys are
+// redrawn when OBS Studio reports changes, and rendering errors are reported
+// with Device.ReportError.
+type Binding struct {
+	client *Client
+	dev    *streamdeck.Device
+	key    streamdeck.KeyID
+	scene  string
+	input  string
+	reg    *streamdeck.HandlerRegistration
+}
+
+// Remove unregisters the key handler of the Binding, and stops updating the
+// key image. The key image is not cleared.
+func (b *Binding) Remove() {
+	b.client.mtx.Lock()
+	delete(b.client.bindings, b)
+	b.client.mtx.Unlock()
+
+	b.reg.Remove()
+}
+
+func (b *Binding) render(active bool) error {
+	opts := streamdeck.TextOptions{
+		Foreground: color.White,
+	}
+
+	label := b.scene
+	if b.input != "" {
+		label = b.input
+		opts.Background = colorInputLive
+		if active {
+			opts.Background = colorInputMuted
+		}
+	} else {
+		opts.Background = colorSceneInactive
+		if active {
+			opts.Background = colorSceneActive
+		}
+	}
+	return b.dev.SetKeyTextWithOptions(b.key, label, opts)
+}
+
+// Client is a connection to an obs-websocket v5 server.
+type Client struct {
+	ws   *websocket.Conn
+	done chan struct{}
+	once sync.Once
+
+	mtx      sync.Mutex
+	nextID   uint64
+	pending  map[string]chan *response
+	bindings map[*Binding]struct{}
+	scene    string
+	muted    map[string]bool
+}
+
+// Dial connects to an obs-websocket v5 server, like "ws://localhost:4455",
+// and authenticates with the given password, if required by the server.
+func Dial(url string, password string) (*Client, error) {
+	ws, err := websocket.Dial(url, "obswebsocket.json", "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := identifyConn(ws, password); err != nil {
+		ws.Close()
+		return nil, err
+	}
+
+	rv := &Client{
+		ws:       ws,
+		done:     make(chan struct{}),
+		pending:  map[string]chan *response{},
+		bindings: map[*Binding]struct{}{},
+		muted:    map[string]bool{},
+	}
+	go rv.receive()
+	return rv, nil
+}
+
+func identifyConn(ws *websocket.Conn, password string) error {
+	if err := ws.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+		return err
+	}
+
+	msg := message{}
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		return err
+	}
+	if msg.Op != opHello {
+		return fmt.Errorf("%w: unexpected opcode: %d", ErrProtocol, msg.Op)
+	}
+
+	h := hello{}
+	if err := json.Unmarshal(msg.D, &h); err != nil {
+		return fmt.Errorf("%w: %w", ErrProtocol, err)
+	}
+
+	id := identify{
+		RPCVersion:         rpcVersion,
+		EventSubscriptions: eventSubscriptions,
+	}
+	if h.Authentication != nil {
+		if password == "" {
+			return ErrAuthenticationRequired
+		}
+		id.Authentication = authenticate(password, h.Authentication.Salt, h.Authentication.Challenge)
+	}
+	if err := send(ws, opIdentify, id); err != nil {
+		return err
+	}
+
+	// servers close the connection when authentication fails
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		return err
+	}
+	if msg.Op != opIdentified {
+		return fmt.Errorf("%w: unexpected opcode: %d", ErrProtocol, msg.Op)
+	}
+	return ws.SetDeadline(time.Time{})
+}
+
+func authenticate(password string, salt string, challenge string) string {
+	secret := sha256.Sum256([]byte(password + salt))
+	auth := sha256.Sum256([]byte(base64.StdEncoding.EncodeToString(secret[:]) + challenge))
+	return base64.StdEncoding.EncodeToString(auth[:])
+}
+
+func send(ws *websocket.Conn, op int, d any) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return websocket.JSON.Send(ws, message{Op: op, D: data})
+}
+
+// Close closes the connection to the server, and unregisters the key
+// handlers of all the bindings. The key images are not cleared.
+func (c *Client) Close() error {
+	err := c.ws.Close()
+	c.shutdown()
+	return err
+}
+
+func (c *Client) shutdown() {
+	c.once.Do(func() {
+		close(c.done)
+
+		c.mtx.Lock()
+		bindings := c.bindings
+		c.bindings = map[*Binding]struct{}{}
+		c.mtx.Unlock()
+
+		for b := range bindings {
+			b.reg.Remove()
+		}
+	})
+}
+
+func (c *Client) receive() {
+	defer c.shutdown()
+
+	for {
+		msg := message{}
+		if err := websocket.JSON.Receive(c.ws, &msg); err != nil {
+			return
+		}
+
+		switch msg.Op {
+		case opRequestResponse:
+			resp := &response{}
+			if err := json.Unmarshal(msg.D, resp); err != nil {
+				continue
+			}
+
+			c.mtx.Lock()
+			ch, found := c.pending[resp.RequestID]
+			delete(c.pending, resp.RequestID)
+			c.mtx.Unlock()
+
+			if found {
+				ch <- resp
+			}
+
+		case opEvent:
+			ev := event{}
+			if err := json.Unmarshal(msg.D, &ev); err != nil {
+				continue
+			}
+			c.handleEvent(ev)
+		}
+	}
+}
+
+func (c *Client) handleEvent(ev event) {
+	switch ev.EventType {
+	case "CurrentProgramSceneChanged":
+		data := struct {
+			SceneName string `json:"sceneName"`
+		}{}
+		if err := json.Unmarshal(ev.EventData, &data); err != nil {
+			return
+		}
+
+		c.mtx.Lock()
+		c.scene = data.SceneName
+		c.mtx.Unlock()
+		c.renderAll(func(b *Binding) bool { return b.scene != "" })
+
+	case "InputMuteStateChanged":
+		data := struct {
+			InputName  string `json:"inputName"`
+			InputMuted bool   `json:"inputMuted"`
+		}{}
+		if err := json.Unmarshal(ev.EventData, &data); err != nil {
+			return
+		}
+
+		c.mtx.Lock()
+		c.muted[data.InputName] = data.InputMuted
+		c.mtx.Unlock()
+		c.renderAll(func(b *Binding) bool { return b.input == data.InputName })
+	}
+}
+
+func (c *Client) renderAll(filter func(b *Binding) bool) {
+	c.mtx.Lock()
+	bindings := []*Binding{}
+	for b := range c.bindings {
+		if filter(b) {
+			bindings = append(bindings, b)
+		}
+	}
+	c.mtx.Unlock()
+
+	for _, b := range bindings {
+		c.render(b)
+	}
+}
+
+func (c *Client) render(b *Binding) {
+	c.mtx.Lock()
+	active := c.muted[b.input]
+	if b.input == "" {
+		active = c.scene == b.scene
+	}
+	c.mtx.Unlock()
+
+	if err := b.render(active); err != nil {
+		b.dev.ReportError("obs: failed to render key", fmt.Errorf("obs: %s: %w", b.key, err))
+	}
+}
+
+func (c *Client) request(typ string, data any, out any) error {
+	c.mtx.Lock()
+	c.nextID++
+	id := strconv.FormatUint(c.nextID, 10)
+	ch := make(chan *response, 1)
+	c.pending[id] = ch
+	c.mtx.Unlock()
+
+	defer func() {
+		c.mtx.Lock()
+		delete(c.pending, id)
+		c.mtx.Unlock()
+	}()
+
+	select {
+	case <-c.done:
+		return ErrClientClosed
+	default:
+	}
+
+	if err := send(c.ws, opRequest, request{RequestType: typ, RequestID: id, RequestData: data}); err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-ch:
+		if !resp.RequestStatus.Result {
+			return fmt.Errorf("%w: %s: %d: %s", ErrRequestFailed, typ, resp.RequestStatus.Code, resp.RequestStatus.Comment)
+		}
+		if out != nil && len(resp.ResponseData) > 0 {
+			if err := json.Unmarshal(resp.ResponseData, out); err != nil {
+				return fmt.Errorf("%w: %w", ErrProtocol, err)
+			}
+		}
+		return nil
+
+	case <-c.done:
+		return ErrClientClosed
+	}
+}
+
+func (c *Client) bind(b *Binding, fn streamdeck.KeyHandler) (*Binding, error) {
+	reg, err := b.dev.AddKeyHandler(b.key, fn)
+	if err != nil {
+		return nil, err
+	}
+	b.reg = reg
+
+	c.mtx.Lock()
+	c.bindings[b] = struct{}{}
+	c.mtx.Unlock()
+
+	select {
+	case <-c.done:
+		b.Remove()
+		return nil, ErrClientClosed
+	default:
+	}
+
+	c.render(b)
+	return b, nil
+}
+
+// GetCurrentProgramScene returns the name of the scene currently on program.
+func (c *Client) GetCurrentProgramScene() (string, error) {
+	data := struct {
+		CurrentProgramSceneName string `json:"currentProgramSceneName"`
+	}{}
+	if err := c.request("GetCurrentProgramScene", nil, &data); err != nil {
+		return "", err
+	}
+	return data.CurrentProgramSceneName, nil
+}
+
+// SetCurrentProgramScene switches the scene on program.
+func (c *Client) SetCurrentProgramScene(sceneName string) error {
+	return c.request("SetCurrentProgramScene", map[string]string{"sceneName": sceneName}, nil)
+}
+
+// GetInputMute returns the mute state of an input.
+func (c *Client) GetInputMute(inputName string) (bool, error) {
+	data := struct {
+		InputMuted bool `json:"inputMuted"`
+	}{}
+	if err := c.request("GetInputMute", map[string]string{"inputName": inputName}, &data); err != nil {
+		return false, err
+	}
+	return data.InputMuted, nil
+}
+
+// ToggleInputMute toggles the mute state of an input, and returns the new
+// state.
+func (c *Client) ToggleInputMute(inputName string) (bool, error) {
+	data := struct {
+		InputMuted bool `json:"inputMuted"`
+	}{}
+	if err := c.request("ToggleInputMute", map[string]string{"inputName": inputName}, &data); err != nil {
+		return false, err
+	}
+	return data.InputMuted, nil
+}
+
+// BindKeyToScene binds an Elgato Stream Deck key to a scene, switching the
+// program scene when the key is pressed. The key displays the scene name,
+// highlighted while the scene is on program.
+func (c *Client) BindKeyToScene(dev *streamdeck.Device, key streamdeck.KeyID, sceneName string) (*Binding, error) {
+	scene, err := c.GetCurrentProgramScene()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.scene = scene
+	c.mtx.Unlock()
+
+	return c.bind(&Binding{client: c, dev: dev, key: key, scene: sceneName}, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		return c.SetCurrentProgramScene(sceneName)
+	})
+}
+
+// BindKeyToMute binds an Elgato Stream Deck key to an input, toggling its
+// mute state when the key is pressed. The key displays the input name, in
+// green while the input is live and in red while it is muted.
+func (c *Client) BindKeyToMute(dev *streamdeck.Device, key streamdeck.KeyID, inputName string) (*Binding, error) {
+	muted, err := c.GetInputMute(inputName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.muted[inputName] = muted
+	c.mtx.Unlock()
+
+	b := &Binding{client: c, dev: dev, key: key, input: inputName}
+	return c.bind(b, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		muted, err := c.ToggleInputMute(inputName)
+		if err != nil {
+			return err
+		}
+
+		// the event with the new state may be delivered before the response,
+		// or not at all if the client is not subscribed to input events
+		c.mtx.Lock()
+		c.muted[inputName] = muted
+		c.mtx.Unlock()
+		c.render(b)
+		return nil
+	})
+}
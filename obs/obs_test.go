@@ -0,0 +1,244 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package obs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func waitColor(t *testing.T, m *mock.Device, key streamdeck.KeyID, want color.RGBA) {
+	t.Helper()
+
+	for range 100 {
+		r, g, b, _ := m.KeyImage(key).At(2, 2).RGBA()
+		if byte(r>>8) == want.R && byte(g>>8) == want.G && byte(b>>8) == want.B {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assertColor(t, m.KeyImage(key), 2, 2, want)
+}
+
+type fakeServer struct {
+	password string
+
+	mtx   sync.Mutex
+	scene string
+	muted bool
+}
+
+func (f *fakeServer) serve(ws *websocket.Conn) {
+	defer ws.Close()
+
+	if err := send(ws, opHello, map[string]any{
+		"obsWebSocketVersion": "5.0.0",
+		"rpcVersion":          1,
+		"authentication":      map[string]string{"challenge": "challenge", "salt": "salt"},
+	}); err != nil {
+		return
+	}
+
+	msg := message{}
+	if err := websocket.JSON.Receive(ws, &msg); err != nil {
+		return
+	}
+	id := identify{}
+	if err := json.Unmarshal(msg.D, &id); err != nil || id.Authentication != authenticate(f.password, "salt", "challenge") {
+		return
+	}
+	if err := send(ws, opIdentified, map[string]int{"negotiatedRpcVersion": 1}); err != nil {
+		return
+	}
+
+	for {
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			return
+		}
+		req := struct {
+			RequestType string            `json:"requestType"`
+			RequestID   string            `json:"requestId"`
+			RequestData map[string]string `json:"requestData"`
+		}{}
+		if err := json.Unmarshal(msg.D, &req); err != nil {
+			return
+		}
+
+		status := map[string]any{"result": true, "code": 100}
+		var data any
+
+		f.mtx.Lock()
+		switch req.RequestType {
+		case "GetCurrentProgramScene":
+			data = map[string]string{"currentProgramSceneName": f.scene}
+
+		case "SetCurrentProgramScene":
+			f.scene = req.RequestData["sceneName"]
+			send(ws, opEvent, map[string]any{
+				"eventType": "CurrentProgramSceneChanged",
+				"eventData": map[string]string{"sceneName": f.scene},
+			})
+
+		case "GetInputMute", "ToggleInputMute":
+			if req.RequestData["inputName"] != "Mic" {
+				status = map[string]any{"result": false, "code": 600, "comment": "no such input"}
+				break
+			}
+			if req.RequestType == "ToggleInputMute" {
+				f.muted = !f.muted
+			}
+			data = map[string]bool{"inputMuted": f.muted}
+		}
+		f.mtx.Unlock()
+
+		if err := send(ws, opRequestResponse, map[string]any{
+			"requestType":   req.RequestType,
+			"requestId":     req.RequestID,
+			"requestStatus": status,
+			"responseData":  data,
+		}); err != nil {
+			return
+		}
+	}
+}
+
+func serve(t *testing.T) (*fakeServer, string) {
+	t.Helper()
+
+	f := &fakeServer{password: "secret", scene: "Intro"}
+	ts := httptest.NewServer(websocket.Server{Handler: f.serve})
+	t.Cleanup(ts.Close)
+	return f, "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+func TestDial(t *testing.T) {
+	_, url := serve(t)
+
+	if _, err := Dial(url, ""); !errors.Is(err, ErrAuthenticationRequired) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := Dial(url, "bola"); err == nil {
+		t.Error("unexpected success with bad password")
+	}
+
+	c, err := Dial(url, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	scene, err := c.GetCurrentProgramScene()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scene != "Intro" {
+		t.Errorf("bad scene: %s", scene)
+	}
+
+	if _, err := c.GetInputMute("bola"); !errors.Is(err, ErrRequestFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCurrentProgramScene(); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBindings(t *testing.T) {
+	f, url := serve(t)
+
+	c, err := Dial(url, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if _, err := c.BindKeyToScene(dev, streamdeck.KEY_1, "Intro"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindKeyToScene(dev, streamdeck.KEY_2, "Gameplay"); err != nil {
+		t.Fatal(err)
+	}
+	mute, err := c.BindKeyToMute(dev, streamdeck.KEY_3, "Mic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BindKeyToMute(dev, streamdeck.KEY_4, "bola"); !errors.Is(err, ErrRequestFailed) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, colorSceneActive)
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, colorSceneInactive)
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 2, 2, colorInputLive)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	if err := m.PressKey(streamdeck.KEY_2); err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, m, streamdeck.KEY_2, colorSceneActive)
+	waitColor(t, m, streamdeck.KEY_1, colorSceneInactive)
+
+	f.mtx.Lock()
+	scene := f.scene
+	f.mtx.Unlock()
+	if scene != "Gameplay" {
+		t.Errorf("bad scene: %s", scene)
+	}
+
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, m, streamdeck.KEY_3, colorInputMuted)
+
+	mute.Remove()
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	f.mtx.Lock()
+	muted := f.muted
+	f.mtx.Unlock()
+	if !muted {
+		t.Error("removed binding toggled the input")
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+
+	"golang.org/x/image/draw"
+)
+
+// Corner represents a corner of an Elgato Stream Deck display.
+type Corner byte
+
+// String returns a string representation of the Corner.
+func (c Corner) String() string {
+	switch c {
+	case CORNER_TOP_LEFT:
+		return "CORNER_TOP_LEFT"
+	case CORNER_TOP_RIGHT:
+		return "CORNER_TOP_RIGHT"
+	case CORNER_BOTTOM_LEFT:
+		return "CORNER_BOTTOM_LEFT"
+	case CORNER_BOTTOM_RIGHT:
+		return "CORNER_BOTTOM_RIGHT"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck display corners.
+const (
+	CORNER_TOP_LEFT Corner = iota + 1
+	CORNER_TOP_RIGHT
+	CORNER_BOTTOM_LEFT
+	CORNER_BOTTOM_RIGHT
+)
+
+// keyOverlay is the base image of a key, as displayed before the first
+// overlay was added, with the overlays composited over it.
+type keyOverlay struct {
+	base     *image.RGBA
+	badges   map[Corner]image.Image
+	text     string
+	textOpts TextOptions
+	composed []byte
+}
+
+// keyOverlays tracks the overlays of the keys. Overlays are discarded when
+// anything else is written to their keys.
+type keyOverlays struct {
+	mtx  sync.Mutex
+	keys map[KeyID]*keyOverlay
+}
+
+func (o *keyOverlays) written(key KeyID, data []byte) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if ov, found := o.keys[key]; found && !bytes.Equal(ov.composed, data) {
+		delete(o.keys, key)
+	}
+}
+
+// overlay returns the overlay of a key, capturing the currently displayed
+// image as its base if needed. It returns true if the overlay was created.
+func (d *Device) overlay(key KeyID) (*keyOverlay, bool) {
+	if d.overlays.keys == nil {
+		d.overlays.keys = map[KeyID]*keyOverlay{}
+	}
+	if ov, found := d.overlays.keys[key]; found {
+		return ov, false
+	}
+
+	d.state.mtx.Lock()
+	data := d.state.keys[key]
+	d.state.mtx.Unlock()
+
+	rv := &keyOverlay{
+		base:   decodeSnapshotImage(data, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform),
+		badges: map[Corner]image.Image{},
+	}
+	d.overlays.keys[key] = rv
+	return rv, true
+}
+
+func (d *Device) composeOverlay(ov *keyOverlay) ([]byte, error) {
+	rect := d.model.keyImageRect
+	img := image.NewRGBA(rect)
+	copy(img.Pix, ov.base.Pix)
+
+	for corner, badge := range ov.badges {
+		b := badge.Bounds()
+		area := image.Rectangle{Max: b.Size()}
+		if area.Dx() > rect.Dx()/2 || area.Dy() > rect.Dy()/2 {
+			area = getScaledRect(b, image.Rect(0, 0, rect.Dx()/2, rect.Dy()/2))
+			area = area.Sub(area.Min)
+		}
+
+		switch corner {
+		case CORNER_TOP_LEFT:
+			area = area.Add(rect.Min)
+		case CORNER_TOP_RIGHT:
+			area = area.Add(image.Pt(rect.Max.X-area.Dx(), rect.Min.Y))
+		case CORNER_BOTTOM_LEFT:
+			area = area.Add(image.Pt(rect.Min.X, rect.Max.Y-area.Dy()))
+		case CORNER_BOTTOM_RIGHT:
+			area = area.Add(rect.Max.Sub(area.Size()))
+		}
+		draw.BiLinear.Scale(img, area, badge, b, draw.Over, nil)
+	}
+
+	if ov.text != "" {
+		opts := d.textOptions(ov.textOpts)
+		if opts.Background == nil {
+			opts.Background = color.Transparent
+		}
+		txt, err := renderText(rect, ov.text, opts, d.GetAccessibilityOptions())
+		if err != nil {
+			return nil, err
+		}
+		draw.Draw(img, rect, txt, txt.Rect.Min, draw.Over)
+	}
+
+	return genImage(img, rect, d.model.keyImageFormat, d.model.keyImageTransform, d.GetImageOptions())
+}
+
+func (d *Device) updateOverlay(key KeyID, fn func(ov *keyOverlay)) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	d.overlays.mtx.Lock()
+	ov, created := d.overlay(key)
+	fn(ov)
+
+	empty := len(ov.badges) == 0 && ov.text == ""
+	if empty {
+		delete(d.overlays.keys, key)
+		if created {
+			d.overlays.mtx.Unlock()
+			return nil
+		}
+	}
+
+	data, err := d.composeOverlay(ov)
+	if err != nil {
+		d.overlays.mtx.Unlock()
+		return wrapErr(err)
+	}
+	ov.composed = data
+	d.overlays.mtx.Unlock()
+
+	return d.sendKeyImage(key, data)
+}
+
+// SetKeyBadge composites a small image over the image currently displayed by
+// an Elgato Stream Deck key background display, aligned to the given corner,
+// replacing any badge previously set for the same corner. Badges larger than
+// a quarter of the display are scaled down to fit it. If the badge is nil,
+// the badge of the corner is removed.
+//
+// The image displayed when the first overlay is added is kept by the device
+// as the base of the overlays, so that overlays can be changed without the
+// caller retaining it. Overlays are discarded when any other image is drawn
+// to the key.
+func (d *Device) SetKeyBadge(key KeyID, badge image.Image, corner Corner) error {
+	if corner < CORNER_TOP_LEFT || corner > CORNER_BOTTOM_RIGHT {
+		return fmt.Errorf("streamdeck: %w: corner: %d", ErrImageInvalid, corner)
+	}
+
+	return d.updateOverlay(key, func(ov *keyOverlay) {
+		if badge == nil {
+			delete(ov.badges, corner)
+			return
+		}
+		ov.badges[corner] = badge
+	})
+}
+
+// SetKeyOverlayText composites text over the image currently displayed by an
+// Elgato Stream Deck key background display, using the given TextOptions,
+// replacing any overlay text previously set. The background is transparent
+// unless set in the options. If the text is empty, the overlay text is
+// removed. See SetKeyBadge for details about the base image.
+func (d *Device) SetKeyOverlayText(key KeyID, text string, opts TextOptions) error {
+	return d.updateOverlay(key, func(ov *keyOverlay) {
+		ov.text = text
+		ov.textOpts = opts
+	})
+}
+
+// ClearKeyOverlays removes all the badges and the overlay text of an Elgato
+// Stream Deck key background display, restoring its base image.
+func (d *Device) ClearKeyOverlays(key KeyID) error {
+	return d.updateOverlay(key, func(ov *keyOverlay) {
+		clear(ov.badges)
+		ov.text = ""
+	})
+}
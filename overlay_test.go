@@ -0,0 +1,89 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestKeyOverlays(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	if err := dev.SetKeyColor(streamdeck.KEY_1, blue); err != nil {
+		t.Fatal(err)
+	}
+
+	badge := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	draw.Draw(badge, badge.Rect, image.NewUniform(red), image.Point{}, draw.Src)
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, badge, streamdeck.CORNER_TOP_RIGHT); err != nil {
+		t.Fatal(err)
+	}
+	img := m.KeyImage(streamdeck.KEY_1)
+	assertColor(t, img, 66, 5, red)
+	assertColor(t, img, 5, 5, blue)
+
+	// badges larger than a quarter of the display are scaled down
+	large := image.NewRGBA(image.Rect(0, 0, 200, 200))
+	draw.Draw(large, large.Rect, image.NewUniform(green), image.Point{}, draw.Src)
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, large, streamdeck.CORNER_BOTTOM_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	img = m.KeyImage(streamdeck.KEY_1)
+	assertColor(t, img, 66, 5, red)
+	assertColor(t, img, 10, 60, green)
+	assertColor(t, img, 50, 60, blue)
+
+	if err := dev.SetKeyOverlayText(streamdeck.KEY_1, "3", streamdeck.TextOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, nil, streamdeck.CORNER_TOP_RIGHT); err != nil {
+		t.Fatal(err)
+	}
+	img = m.KeyImage(streamdeck.KEY_1)
+	assertColor(t, img, 66, 5, blue)
+	assertColor(t, img, 10, 60, green)
+
+	// the base image is restored without the caller retaining it
+	if err := dev.ClearKeyOverlays(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	img = m.KeyImage(streamdeck.KEY_1)
+	for _, p := range []image.Point{{66, 5}, {10, 60}, {36, 36}} {
+		assertColor(t, img, p.X, p.Y, blue)
+	}
+
+	// drawing another image discards the overlays
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, badge, streamdeck.CORNER_TOP_LEFT); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyColor(streamdeck.KEY_1, green); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, badge, streamdeck.CORNER_BOTTOM_RIGHT); err != nil {
+		t.Fatal(err)
+	}
+	img = m.KeyImage(streamdeck.KEY_1)
+	assertColor(t, img, 5, 5, green)
+	assertColor(t, img, 66, 66, red)
+
+	if err := dev.SetKeyBadge(streamdeck.KEY_1, badge, 0); !errors.Is(err, streamdeck.ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"slices"
+	"sync"
+)
+
+type pageKey struct {
+	img     image.Image
+	handler KeyHandler
+}
+
+// Page represents a named set of key images and handlers, managed by Pages.
+type Page struct {
+	pages *Pages
+	name  string
+	keys  map[KeyID]*pageKey
+}
+
+// Name returns the name of the page.
+func (pg *Page) Name() string {
+	return pg.name
+}
+
+func (pg *Page) key(key KeyID) *pageKey {
+	pk, ok := pg.keys[key]
+	if !ok {
+		pk = &pageKey{}
+		pg.keys[key] = pk
+	}
+	return pk
+}
+
+// SetKeyImage sets the image.Image displayed by an Elgato Stream Deck key
+// background display while the page is active. If the page is active, the
+// key is redrawn immediately.
+func (pg *Page) SetKeyImage(key KeyID, img image.Image) error {
+	d := pg.pages.device
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	pg.pages.mtx.Lock()
+	defer pg.pages.mtx.Unlock()
+
+	pg.key(key).img = img
+	if pg.pages.current != pg {
+		return nil
+	}
+	return pg.pages.renderKey(key)
+}
+
+// SetKeyColor sets the color displayed by an Elgato Stream Deck key
+// background display while the page is active. If the page is active, the
+// key is redrawn immediately.
+func (pg *Page) SetKeyColor(key KeyID, c color.Color) error {
+	return pg.SetKeyImage(key, &imageColor{
+		c: c,
+		b: pg.pages.device.model.keyImageRect,
+	})
+}
+
+// SetKeyHandler sets the KeyHandler callback called whenever the given key
+// is pressed while the page is active. Setting a nil handler unsets it.
+func (pg *Page) SetKeyHandler(key KeyID, fn KeyHandler) error {
+	if err := pg.pages.device.validateKey(key); err != nil {
+		return err
+	}
+
+	pg.pages.mtx.Lock()
+	pg.key(key).handler = fn
+	pg.pages.mtx.Unlock()
+	return nil
+}
+
+// ClearKey unsets the image and the handler of an Elgato Stream Deck key
+// while the page is active. If the page is active, the key is cleared
+// immediately.
+func (pg *Page) ClearKey(key KeyID) error {
+	if err := pg.pages.device.validateKey(key); err != nil {
+		return err
+	}
+
+	pg.pages.mtx.Lock()
+	defer pg.pages.mtx.Unlock()
+
+	delete(pg.keys, key)
+	if pg.pages.current != pg || !pg.pages.device.GetKeyDisplaySupported() {
+		return nil
+	}
+	return pg.pages.renderKey(key)
+}
+
+// Pages manages a set of named pages of an Elgato Stream Deck device, like
+// the folders and profiles of the official software. Switching pages redraws
+// all the keys and swaps the key handlers of the pages atomically.
+//
+// Pages handle key presses with their own handlers, registered to the device
+// on the first switch. Handlers registered directly to the device keep being
+// called for all pages.
+type Pages struct {
+	device     *Device
+	mtx        sync.Mutex
+	pages      map[string]*Page
+	order      []string
+	current    *Page
+	registered bool
+}
+
+// Pages returns the page manager of the Elgato Stream Deck device.
+func (d *Device) Pages() *Pages {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.pages == nil {
+		d.pages = &Pages{
+			device: d,
+			pages:  map[string]*Page{},
+		}
+	}
+	return d.pages
+}
+
+// Add creates a new empty page with the given name. Names must be unique and
+// non-empty.
+func (p *Pages) Add(name string) (*Page, error) {
+	if name == "" {
+		return nil, wrapErr(ErrPageInvalid)
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, found := p.pages[name]; found {
+		return nil, fmt.Errorf("streamdeck: %w: duplicated name: %s", ErrPageInvalid, name)
+	}
+
+	rv := &Page{
+		pages: p,
+		name:  name,
+		keys:  map[KeyID]*pageKey{},
+	}
+	p.pages[name] = rv
+	p.order = append(p.order, name)
+	return rv, nil
+}
+
+// Get returns the page with the given name.
+func (p *Pages) Get(name string) (*Page, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pg, found := p.pages[name]
+	if !found {
+		return nil, fmt.Errorf("streamdeck: %w: not found: %s", ErrPageInvalid, name)
+	}
+	return pg, nil
+}
+
+// Remove removes the page with the given name. The active page can not be
+// removed.
+func (p *Pages) Remove(name string) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pg, found := p.pages[name]
+	if !found {
+		return fmt.Errorf("streamdeck: %w: not found: %s", ErrPageInvalid, name)
+	}
+	if pg == p.current {
+		return fmt.Errorf("streamdeck: %w: page is active: %s", ErrPageInvalid, name)
+	}
+	delete(p.pages, name)
+	p.order = slices.DeleteFunc(p.order, func(n string) bool {
+		return n == name
+	})
+	return nil
+}
+
+// Current returns the active page, or nil if no page was activated yet.
+func (p *Pages) Current() *Page {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.current
+}
+
+func (p *Pages) renderKey(key KeyID) error {
+	d := p.device
+	if pk, ok := p.current.keys[key]; ok && pk.img != nil {
+		return d.setKeyImage(key, pk.img)
+	}
+	return d.setKeyImage(key, &imageColor{
+		c: color.Black,
+		b: d.model.keyImageRect,
+	})
+}
+
+func (p *Pages) keyHandler(key KeyID) KeyHandler {
+	return func(d *Device, k *Key) error {
+		p.mtx.Lock()
+		var fn KeyHandler
+		if p.current != nil {
+			if pk, ok := p.current.keys[key]; ok {
+				fn = pk.handler
+			}
+		}
+		p.mtx.Unlock()
+
+		if fn == nil {
+			return nil
+		}
+		return fn(d, k)
+	}
+}
+
+// Switch activates the page with the given name, redrawing all the keys of
+// the Elgato Stream Deck device. Keys without an image set in the page are
+// cleared. It is safe to call Switch from key handlers.
+func (p *Pages) Switch(name string) error {
+	d := p.device
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pg, found := p.pages[name]
+	if !found {
+		return fmt.Errorf("streamdeck: %w: not found: %s", ErrPageInvalid, name)
+	}
+
+	if !p.registered {
+		if err := d.ForEachKey(func(key KeyID) error {
+			_, err := d.AddKeyHandler(key, p.keyHandler(key))
+			return err
+		}); err != nil {
+			return err
+		}
+		p.registered = true
+	}
+
+	p.current = pg
+	if !d.GetKeyDisplaySupported() {
+		return nil
+	}
+	return d.ForEachKey(p.renderKey)
+}
+
+// turn activates the page at the given offset from the active page, in the
+// order the pages were added, wrapping around.
+func (p *Pages) turn(offset int) error {
+	p.mtx.Lock()
+	if len(p.order) == 0 {
+		p.mtx.Unlock()
+		return fmt.Errorf("streamdeck: %w: no pages", ErrPageInvalid)
+	}
+
+	idx := 0
+	if p.current != nil {
+		idx = slices.Index(p.order, p.current.name) + offset
+		idx = (idx%len(p.order) + len(p.order)) % len(p.order)
+	}
+	name := p.order[idx]
+	p.mtx.Unlock()
+
+	return p.Switch(name)
+}
+
+// Next activates the page added after the active page, wrapping around to
+// the first page. If no page was activated yet, the first page is activated.
+func (p *Pages) Next() error {
+	return p.turn(1)
+}
+
+// Previous activates the page added before the active page, wrapping around
+// to the last page. If no page was activated yet, the first page is
+// activated.
+func (p *Pages) Previous() error {
+	return p.turn(-1)
+}
+
+// BindTouchPoints registers press handlers for the given touch points that
+// turn to the previous and the next pages, like the page-turn touch points
+// of the Elgato Stream Deck Neo. The returned HandlerRegistration can be used
+// to unregister both handlers.
+func (p *Pages) BindTouchPoints(previous TouchPointID, next TouchPointID) (*HandlerRegistration, error) {
+	d := p.device
+	prev, err := d.AddTouchPointPressHandler(previous, func(d *Device, tp *TouchPoint) error {
+		return p.Previous()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nxt, err := d.AddTouchPointPressHandler(next, func(d *Device, tp *TouchPoint) error {
+		return p.Next()
+	})
+	if err != nil {
+		prev.Remove()
+		return nil, err
+	}
+
+	return &HandlerRegistration{
+		remove: func() {
+			prev.Remove()
+			nxt.Remove()
+		},
+	}, nil
+}
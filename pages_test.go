@@ -0,0 +1,177 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestPages(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	pages := dev.Pages()
+	home, err := pages.Add("home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	media, err := pages.Add("media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pages.Add("home"); !errors.Is(err, streamdeck.ErrPageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	events := make(chan string, 10)
+	if err := home.SetKeyColor(streamdeck.KEY_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := home.SetKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "home"
+		return pages.Switch("media")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := media.SetKeyColor(streamdeck.KEY_2, color.RGBA{B: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := media.SetKeyHandler(streamdeck.KEY_1, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		events <- "media"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pages.Switch("home"); err != nil {
+		t.Fatal(err)
+	}
+	if pages.Current() != home {
+		t.Fatal("bad current page")
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{R: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{})
+
+	go dev.Listen(nil)
+
+	for _, want := range []string{"home", "media"} {
+		if err := m.PressKey(streamdeck.KEY_1); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case e := <-events:
+			if e != want {
+				t.Fatalf("unexpected handler called: %s", e)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for key press")
+		}
+	}
+
+	if pages.Current() != media {
+		t.Fatal("page not switched")
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{})
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{B: 0xff})
+
+	if err := pages.Remove("media"); !errors.Is(err, streamdeck.ErrPageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := pages.Switch("bola"); !errors.Is(err, streamdeck.ErrPageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPagesTouchPoints(t *testing.T) {
+	dev, m, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	pages := dev.Pages()
+	if err := pages.Next(); !errors.Is(err, streamdeck.ErrPageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"one", "two", "three"} {
+		if _, err := pages.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reg, err := pages.BindTouchPoints(streamdeck.TOUCH_POINT_1, streamdeck.TOUCH_POINT_2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	waitPage := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			if pg := pages.Current(); pg != nil && pg.Name() == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("timeout waiting for page %q", want)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	for _, step := range []struct {
+		tp   streamdeck.TouchPointID
+		want string
+	}{
+		{streamdeck.TOUCH_POINT_2, "one"},
+		{streamdeck.TOUCH_POINT_2, "two"},
+		{streamdeck.TOUCH_POINT_1, "one"},
+		{streamdeck.TOUCH_POINT_1, "three"},
+		{streamdeck.TOUCH_POINT_2, "one"},
+	} {
+		if err := m.PressTouchPoint(step.tp); err != nil {
+			t.Fatal(err)
+		}
+		if err := m.ReleaseTouchPoint(step.tp); err != nil {
+			t.Fatal(err)
+		}
+		waitPage(step.want)
+	}
+
+	if err := pages.Remove("two"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pages.Next(); err != nil {
+		t.Fatal(err)
+	}
+	waitPage("three")
+
+	reg.Remove()
+	if err := m.PressTouchPoint(streamdeck.TOUCH_POINT_2); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseTouchPoint(streamdeck.TOUCH_POINT_2); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if pages.Current().Name() != "three" {
+		t.Error("touch points not unbound")
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cgo && (linux || darwin || freebsd)
+
+package plugins
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// Open loads a Go plugin, built with "go build -buildmode=plugin", and
+// returns its Factory, that must be exported as a function named NewTile:
+//
+//	func NewTile(settings map[string]any) (plugins.Tile, error)
+//
+// Go plugins must be built with the same Go version and package versions as
+// the host application, and are only supported on some platforms, returning
+// ErrPluginNotSupported otherwise. Command is preferred for plugins
+// distributed separately from the host.
+func Open(path string) (Factory, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPluginInvalid, err)
+	}
+
+	sym, err := p.Lookup("NewTile")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPluginInvalid, err)
+	}
+
+	fn, ok := sym.(func(map[string]any) (Tile, error))
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: NewTile has type %T", ErrPluginInvalid, path, sym)
+	}
+	return fn, nil
+}
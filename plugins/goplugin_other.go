@@ -0,0 +1,12 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !cgo || !(linux || darwin || freebsd)
+
+package plugins
+
+// Open loads a Go plugin, that is not supported on this platform.
+func Open(path string) (Factory, error) {
+	return nil, ErrPluginNotSupported
+}
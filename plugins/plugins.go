@@ -0,0 +1,191 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plugins hosts third-party Elgato Stream Deck "tiles", like weather,
+// CI status or stock tickers, that supply the images of keys and receive
+// their events, without changes to the host application.
+//
+// Tiles are created by a Factory, either loaded from a Go plugin with Open,
+// or running as a subprocess with Command, that speaks a simple protocol of
+// JSON lines over its standard input and output. See Command for the
+// protocol.
+//
+//	f, err := plugins.Command("weather-tile", "--city", "Berlin")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	tile, err := f(map[string]any{"units": "metric"})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	b, err := plugins.Bind(dev, streamdeck.KEY_1, tile)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer b.Remove()
+//
+// Key presses are only handled while the application calls Device.Listen.
+package plugins
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"sync"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the plugins package.
+var (
+	ErrPluginInvalid      = errors.New("plugins: plugin is not valid")
+	ErrPluginNotSupported = errors.New("plugins: go plugins are not supported on this platform")
+	ErrProtocol           = errors.New("plugins: protocol error")
+)
+
+// Host is the interface used by a Tile to draw its key. It is safe for
+// concurrent use, so tiles may update their keys from background goroutines,
+// e.g. when polling a service.
+type Host interface {
+	// ImageSize returns the native size of the key display.
+	ImageSize() image.Point
+
+	// SetImage draws an image.Image to the key, scaled as needed.
+	SetImage(img image.Image) error
+
+	// SetColor fills the key with a color.
+	SetColor(c color.Color) error
+
+	// SetText draws a text to the key, using the given TextOptions.
+	SetText(text string, opts streamdeck.TextOptions) error
+}
+
+// Tile is the interface implemented by the plugins, providing the contents
+// of an Elgato Stream Deck key and receiving its events.
+type Tile interface {
+	// Start is called when the tile is bound to a key, with the Host used to
+	// draw it, that is valid until Stop is called.
+	Start(host Host) error
+
+	// KeyDown and KeyUp are called when the key is pressed and released.
+	KeyDown() error
+	KeyUp() error
+
+	// Stop is called when the tile is unbound from the key.
+	Stop() error
+}
+
+// Factory creates tiles, configured with the given settings, that are
+// plugin specific.
+type Factory func(settings map[string]any) (Tile, error)
+
+type keyHost struct {
+	dev *streamdeck.Device
+	key streamdeck.KeyID
+}
+
+func (h *keyHost) ImageSize() image.Point {
+	rect, err := h.dev.GetKeyImageRectangle()
+	if err != nil {
+		return image.Point{}
+	}
+	return rect.Size()
+}
+
+func (h *keyHost) SetImage(img image.Image) error {
+	return h.dev.SetKeyImage(h.key, img)
+}
+
+func (h *keyHost) SetColor(c color.Color) error {
+	return h.dev.SetKeyColor(h.key, c)
+}
+
+func (h *keyHost) SetText(text string, opts streamdeck.TextOptions) error {
+	return h.dev.SetKeyTextWithOptions(h.key, text, opts)
+}
+
+// ErrorReporter is an optional interface implemented by the Host values
+// that receive the errors of the tiles that can not be returned to the
+// callers. The hosts of the tiles bound to keys report them with
+// Device.ReportError.
+type ErrorReporter interface {
+	ReportError(msg string, err error)
+}
+
+func (h *keyHost) ReportError(msg string, err error) {
+	h.dev.ReportError(msg, fmt.Errorf("%s: %w", h.key, err))
+}
+
+// logError reports the errors of the tiles that can not be returned to the
+// callers, if the host implements ErrorReporter.
+func logError(host Host, msg string, err error) {
+	if r, ok := host.(ErrorReporter); ok {
+		r.ReportError(msg, err)
+	}
+}
+
+// Binding represents a Tile bound to an Elgato Stream Deck key.
+type Binding struct {
+	tile Tile
+	regs []*streamdeck.HandlerRegistration
+	once sync.Once
+	err  error
+}
+
+// Bind starts a Tile, drawing to an Elgato Stream Deck key, and registers
+// handlers for the key, that deliver its events to the tile. Errors returned
+// by the tile while handling events are reported as handler errors.
+func Bind(dev *streamdeck.Device, key streamdeck.KeyID, tile Tile) (*Binding, error) {
+	if dev == nil {
+		return nil, fmt.Errorf("%w: device is nil", ErrPluginInvalid)
+	}
+	if tile == nil {
+		return nil, fmt.Errorf("%w: tile is nil", ErrPluginInvalid)
+	}
+
+	if !dev.GetKeyDisplaySupported() {
+		return nil, streamdeck.ErrDeviceKeyDisplayNotSupported
+	}
+
+	if err := tile.Start(&keyHost{dev: dev, key: key}); err != nil {
+		return nil, err
+	}
+
+	rv := &Binding{
+		tile: tile,
+	}
+
+	down, err := dev.AddKeyPressHandler(key, func(d *streamdeck.Device, k *streamdeck.Key) error {
+		return tile.KeyDown()
+	})
+	if err != nil {
+		return nil, errors.Join(err, tile.Stop())
+	}
+	rv.regs = append(rv.regs, down)
+
+	up, err := dev.AddKeyReleaseHandler(key, func(d *streamdeck.Device, k *streamdeck.Key, duration time.Duration) error {
+		return tile.KeyUp()
+	})
+	if err != nil {
+		down.Remove()
+		return nil, errors.Join(err, tile.Stop())
+	}
+	rv.regs = append(rv.regs, up)
+	return rv, nil
+}
+
+// Remove unregisters the key handlers of the Binding, and stops the tile,
+// returning the error returned by its Stop method. The key image is not
+// cleared. Calling Remove more than once returns the same error.
+func (b *Binding) Remove() error {
+	b.once.Do(func() {
+		for _, reg := range b.regs {
+			reg.Remove()
+		}
+		b.err = b.tile.Stop()
+	})
+	return b.err
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func near(v uint32, want byte) bool {
+	d := int(v) - int(want)
+	return d >= -16 && d <= 16
+}
+
+func waitColor(t *testing.T, m *mock.Device, key streamdeck.KeyID, want color.RGBA) {
+	t.Helper()
+
+	var r, g, b uint32
+	for range 100 {
+		if img := m.KeyImage(key); img != nil {
+			r, g, b, _ = img.At(36, 36).RGBA()
+			if near(r>>8, want.R) && near(g>>8, want.G) && near(b>>8, want.B) {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("bad color: got (%d, %d, %d), want %v", r>>8, g>>8, b>>8, want)
+}
+
+type testTile struct {
+	host   Host
+	events chan string
+}
+
+func (t *testTile) Start(host Host) error {
+	t.host = host
+	return host.SetColor(color.RGBA{R: 0xff, A: 0xff})
+}
+
+func (t *testTile) KeyDown() error {
+	t.events <- "down"
+	return t.host.SetColor(color.RGBA{B: 0xff, A: 0xff})
+}
+
+func (t *testTile) KeyUp() error {
+	t.events <- "up"
+	return nil
+}
+
+func (t *testTile) Stop() error {
+	t.events <- "stop"
+	return nil
+}
+
+func TestBind(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	tile := &testTile{events: make(chan string, 10)}
+	b, err := Bind(dev, streamdeck.KEY_4, tile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := tile.host.ImageSize(); s != image.Pt(72, 72) {
+		t.Errorf("bad image size: %s", s)
+	}
+	waitColor(t, m, streamdeck.KEY_4, color.RGBA{R: 0xff})
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_4); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_4); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"down", "up"} {
+		select {
+		case ev := <-tile.events:
+			if ev != want {
+				t.Errorf("unexpected event: %q", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for event: %q", want)
+		}
+	}
+	waitColor(t, m, streamdeck.KEY_4, color.RGBA{B: 0xff})
+
+	if err := b.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-tile.events; ev != "stop" {
+		t.Errorf("unexpected event: %q", ev)
+	}
+	select {
+	case ev := <-tile.events:
+		t.Errorf("unexpected event: %q", ev)
+	default:
+	}
+
+	if _, err := Bind(dev, streamdeck.KEY_4, nil); !errors.Is(err, ErrPluginInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := Bind(dev, 0, &testTile{events: make(chan string, 10)}); !errors.Is(err, streamdeck.ErrKeyInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	f, err := Command("sh", "-c", `
+while read -r line; do
+	case "$line" in
+	*'"start"'*'"city":"Berlin"'*) echo '{"command": "setColor", "color": "#f00"}' ;;
+	*keyDown*) echo; echo 'bola'; echo '{"command": "bola"}'; echo '{"command": "setText", "text": "x", "background": "#00f", "foreground": "#00f"}' ;;
+	*keyUp*) echo '{"command": "setColor", "color": "#0f0"}' ;;
+	*stop*) exit 0 ;;
+	esac
+done
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tile, err := f(map[string]any{"city": "Berlin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	// the invalid commands are reported to the error sink of the device
+	reported := make(chan error, 10)
+	dev.SetErrorSink(streamdeck.ErrorSinkFunc(func(e streamdeck.ErrorEvent) {
+		reported <- e.Err
+	}))
+
+	b, err := Bind(dev, streamdeck.KEY_1, tile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, m, streamdeck.KEY_1, color.RGBA{R: 0xff})
+
+	go dev.Listen(nil)
+
+	if err := m.PressKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, m, streamdeck.KEY_1, color.RGBA{B: 0xff})
+	select {
+	case err := <-reported:
+		if !errors.Is(err, ErrProtocol) || !strings.Contains(err.Error(), "KEY_1") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("invalid command not reported")
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_1); err != nil {
+		t.Fatal(err)
+	}
+	waitColor(t, m, streamdeck.KEY_1, color.RGBA{G: 0xff})
+
+	if err := b.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tile.KeyDown(); !errors.Is(err, ErrPluginInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := Command(""); !errors.Is(err, ErrPluginInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	f, err = Command(filepath.Join(t.TempDir(), "bola"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tile, err = f(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Bind(dev, streamdeck.KEY_2, tile); !errors.Is(err, ErrPluginInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "bola.so")); !errors.Is(err, ErrPluginInvalid) && !errors.Is(err, ErrPluginNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugins
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// processStopTimeout is how long a subprocess is given to exit after the
+// stop event, before being killed.
+const processStopTimeout = time.Second
+
+// processEvent is a message sent to the subprocesses.
+type processEvent struct {
+	Event    string         `json:"event"`
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	Settings map[string]any `json:"settings,omitempty"`
+}
+
+// processCommand is a message received from the subprocesses.
+type processCommand struct {
+	Command    string  `json:"command"`
+	Image      []byte  `json:"image"`
+	Color      string  `json:"color"`
+	Text       string  `json:"text"`
+	Foreground string  `json:"foreground"`
+	Background string  `json:"background"`
+	Size       float64 `json:"size"`
+}
+
+func parseColor(s string) (color.Color, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	h, ok := strings.CutPrefix(s, "#")
+	if ok && len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if !ok || len(h) != 6 {
+		return nil, fmt.Errorf("%w: color is not valid: %s", ErrProtocol, s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: color is not valid: %s", ErrProtocol, s)
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}, nil
+}
+
+type processTile struct {
+	command  []string
+	settings map[string]any
+
+	mtx   sync.Mutex
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	enc   *json.Encoder
+	done  chan struct{}
+}
+
+// Command creates a Factory of tiles running as subprocesses of the given
+// command, followed by its arguments, one per tile. The command is not run
+// by a shell, and its standard error is inherited from the host.
+//
+// The host and the subprocess exchange JSON objects, one per line, over the
+// standard input and output of the subprocess. The host sends events, with
+// an "event" field:
+//
+//	{"event": "start", "width": 72, "height": 72, "settings": {...}}
+//	{"event": "keyDown"}
+//	{"event": "keyUp"}
+//	{"event": "stop"}
+//
+// The subprocess must exit after the stop event, or when its standard input
+// is closed. It sends commands to draw its key at any time, with a "command"
+// field:
+//
+//	{"command": "setImage", "image": "<base64 encoded PNG, JPEG or GIF>"}
+//	{"command": "setColor", "color": "#rrggbb"}
+//	{"command": "setText", "text": "...", "foreground": "#rrggbb", "background": "#rrggbb", "size": 16}
+//
+// Colors may be empty, to use the defaults. Invalid commands are reported
+// to the host, if it implements ErrorReporter.
+func Command(name string, args ...string) (Factory, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: command is empty", ErrPluginInvalid)
+	}
+
+	command := append([]string{name}, args...)
+	return func(settings map[string]any) (Tile, error) {
+		return &processTile{
+			command:  command,
+			settings: settings,
+		}, nil
+	}, nil
+}
+
+func (t *processTile) Start(host Host) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.cmd != nil {
+		return fmt.Errorf("%w: tile already started", ErrPluginInvalid)
+	}
+
+	cmd := exec.Command(t.command[0], t.command[1:]...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrPluginInvalid, t.command[0], err)
+	}
+
+	t.cmd = cmd
+	t.stdin = stdin
+	t.enc = json.NewEncoder(stdin)
+	t.done = make(chan struct{})
+
+	go t.receive(host, stdout)
+
+	size := host.ImageSize()
+	return t.send(processEvent{
+		Event:    "start",
+		Width:    size.X,
+		Height:   size.Y,
+		Settings: t.settings,
+	})
+}
+
+func (t *processTile) receive(host Host, r io.Reader) {
+	defer close(t.done)
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16*1024*1024)
+	for sc.Scan() {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+
+		cmd := processCommand{}
+		if err := json.Unmarshal(sc.Bytes(), &cmd); err != nil {
+			logError(host, "plugins: invalid command", fmt.Errorf("%w: %s: %w", ErrProtocol, t.command[0], err))
+			continue
+		}
+		if err := t.handle(host, &cmd); err != nil {
+			logError(host, "plugins: command failed", fmt.Errorf("%s: %s: %w", t.command[0], cmd.Command, err))
+		}
+	}
+}
+
+func (t *processTile) handle(host Host, cmd *processCommand) error {
+	switch cmd.Command {
+	case "setImage":
+		img, _, err := image.Decode(bytes.NewReader(cmd.Image))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrProtocol, err)
+		}
+		return host.SetImage(img)
+
+	case "setColor":
+		c, err := parseColor(cmd.Color)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			c = color.Black
+		}
+		return host.SetColor(c)
+
+	case "setText":
+		fg, err := parseColor(cmd.Foreground)
+		if err != nil {
+			return err
+		}
+		bg, err := parseColor(cmd.Background)
+		if err != nil {
+			return err
+		}
+		return host.SetText(cmd.Text, streamdeck.TextOptions{
+			Foreground: fg,
+			Background: bg,
+			Size:       cmd.Size,
+		})
+
+	default:
+		return fmt.Errorf("%w: unknown command: %q", ErrProtocol, cmd.Command)
+	}
+}
+
+func (t *processTile) send(ev processEvent) error {
+	if t.enc == nil {
+		return fmt.Errorf("%w: tile not started", ErrPluginInvalid)
+	}
+	if err := t.enc.Encode(ev); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrProtocol, t.command[0], err)
+	}
+	return nil
+}
+
+func (t *processTile) KeyDown() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.send(processEvent{Event: "keyDown"})
+}
+
+func (t *processTile) KeyUp() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.send(processEvent{Event: "keyUp"})
+}
+
+func (t *processTile) Stop() error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.cmd == nil {
+		return nil
+	}
+
+	// errors are ignored, as the subprocess may exit before reading the stop
+	// event
+	t.send(processEvent{Event: "stop"})
+	t.stdin.Close()
+	t.enc = nil
+
+	select {
+	case <-t.done:
+	case <-time.After(processStopTimeout):
+		t.cmd.Process.Kill()
+	}
+
+	err := t.cmd.Wait()
+	t.cmd = nil
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrPluginInvalid, t.command[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+)
+
+// PreparedImage represents an image already scaled and encoded for one of
+// the displays of an Elgato Stream Deck device model. Displaying it only
+// requires writing the cached payload to the device, which makes it suitable
+// for images that are displayed repeatedly, like animation frames.
+//
+// A PreparedImage may be displayed on any device of the same model it was
+// prepared for, and is safe for concurrent use.
+type PreparedImage struct {
+	model   *model
+	surface displaySurface
+	size    image.Point
+	data    []byte
+}
+
+// Size returns the size, in pixels, of the display area the PreparedImage
+// was prepared for.
+func (p *PreparedImage) Size() image.Point {
+	if p == nil {
+		return image.Point{}
+	}
+	return p.size
+}
+
+func (d *Device) validatePreparedImage(p *PreparedImage, surface displaySurface, size image.Point) error {
+	if p == nil || p.model != d.model || p.surface != surface {
+		return wrapErr(ErrPreparedImageInvalid)
+	}
+	if p.size != size {
+		return fmt.Errorf("streamdeck: %w: prepared for %s, got %s", ErrPreparedImageInvalid, p.size, size)
+	}
+	return nil
+}
+
+func (d *Device) prepareImage(img image.Image, surface displaySurface, rect image.Rectangle, ifmt imageFormat, transform imageTransform) (*PreparedImage, error) {
+	data, err := genImage(img, rect, ifmt, transform, d.GetImageOptions())
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+
+	return &PreparedImage{
+		model:   d.model,
+		surface: surface,
+		size:    rect.Size(),
+		data:    data,
+	}, nil
+}
+
+// PrepareKeyImage scales and encodes a given image.Image for the Elgato
+// Stream Deck key background displays, returning a PreparedImage that can be
+// displayed with SetKeyPreparedImage. The device does not need to be open.
+func (d *Device) PrepareKeyImage(img image.Image) (*PreparedImage, error) {
+	if err := d.validateKeyDisplay(); err != nil {
+		return nil, err
+	}
+
+	return d.prepareImage(img, displaySurfaceKey, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform)
+}
+
+// SetKeyPreparedImage draws a PreparedImage, created with PrepareKeyImage, to
+// an Elgato Stream Deck key background display.
+func (d *Device) SetKeyPreparedImage(key KeyID, p *PreparedImage) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if err := d.validatePreparedImage(p, displaySurfaceKey, d.model.keyImageRect.Size()); err != nil {
+		return err
+	}
+
+	return d.sendKeyImage(key, p.data)
+}
+
+// PrepareInfoBarImage scales and encodes a given image.Image for the info bar
+// display available on some Elgato Stream Deck models, returning a
+// PreparedImage that can be displayed with SetInfoBarPreparedImage. The
+// device does not need to be open.
+func (d *Device) PrepareInfoBarImage(img image.Image) (*PreparedImage, error) {
+	if err := d.validateInfoBar(); err != nil {
+		return nil, err
+	}
+
+	return d.prepareImage(img, displaySurfaceInfoBar, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform)
+}
+
+// SetInfoBarPreparedImage draws a PreparedImage, created with
+// PrepareInfoBarImage, to the info bar display available on some Elgato
+// Stream Deck models.
+func (d *Device) SetInfoBarPreparedImage(p *PreparedImage) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateInfoBar(); err != nil {
+		return err
+	}
+
+	if err := d.validatePreparedImage(p, displaySurfaceInfoBar, d.model.infoBarImageRect.Size()); err != nil {
+		return err
+	}
+
+	return d.sendInfoBarImage(p.data)
+}
+
+// PrepareTouchStripImage scales and encodes a given image.Image for the whole
+// touch strip display available on some Elgato Stream Deck models, returning
+// a PreparedImage that can be displayed with SetTouchStripPreparedImage. The
+// device does not need to be open.
+func (d *Device) PrepareTouchStripImage(img image.Image) (*PreparedImage, error) {
+	return d.PrepareTouchStripImageWithSize(img, d.model.touchStripImageRect.Size())
+}
+
+// PrepareTouchStripImageWithSize scales and encodes a given image.Image for a
+// rectangle of the given size of the touch strip display available on some
+// Elgato Stream Deck models, returning a PreparedImage that can be displayed
+// with SetTouchStripPreparedImageWithRectangle to any rectangle of the same
+// size. The device does not need to be open.
+func (d *Device) PrepareTouchStripImageWithSize(img image.Image, size image.Point) (*PreparedImage, error) {
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	rect := image.Rectangle{Max: size}
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return nil, err
+	}
+
+	return d.prepareImage(img, displaySurfaceTouchStrip, rect, d.model.touchStripImageFormat, d.model.touchStripImageTransform)
+}
+
+// SetTouchStripPreparedImage draws a PreparedImage, created with
+// PrepareTouchStripImage, to the whole touch strip display available on some
+// Elgato Stream Deck models.
+func (d *Device) SetTouchStripPreparedImage(p *PreparedImage) error {
+	return d.SetTouchStripPreparedImageWithRectangle(p, d.model.touchStripImageRect)
+}
+
+// SetTouchStripPreparedImageWithRectangle draws a PreparedImage, created with
+// PrepareTouchStripImageWithSize, to a rectangle of the touch strip display
+// available on some Elgato Stream Deck models. The size of the rectangle must
+// match the size the image was prepared for.
+func (d *Device) SetTouchStripPreparedImageWithRectangle(p *PreparedImage, rect image.Rectangle) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	if err := d.validatePreparedImage(p, displaySurfaceTouchStrip, rect.Size()); err != nil {
+		return err
+	}
+
+	return d.sendTouchStripImage(p.data, rect)
+}
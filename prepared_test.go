@@ -0,0 +1,69 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestPreparedImage(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := dev.PrepareKeyImage(testImage(rect))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []streamdeck.KeyID{streamdeck.KEY_1, streamdeck.KEY_8} {
+		if err := dev.SetKeyPreparedImage(key, p); err != nil {
+			t.Fatal(err)
+		}
+		assertColor(t, m.KeyImage(key), 2, 2, color.RGBA{R: 0xff, B: 0xff})
+		assertColor(t, m.KeyImage(key), rect.Dx()-3, rect.Dy()-3, color.RGBA{})
+	}
+
+	ts, err := dev.PrepareTouchStripImageWithSize(testImage(image.Rect(0, 0, 200, 100)), image.Pt(200, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetTouchStripPreparedImageWithRectangle(ts, image.Rect(600, 0, 800, 100)); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.TouchStripImage(), 602, 2, color.RGBA{R: 0xff, B: 0xff})
+
+	if err := dev.SetTouchStripPreparedImage(ts); !errors.Is(err, streamdeck.ErrPreparedImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetTouchStripPreparedImageWithRectangle(p, image.Rect(0, 0, rect.Dx(), 100)); !errors.Is(err, streamdeck.ErrPreparedImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := dev.SetKeyPreparedImage(streamdeck.KEY_1, nil); !errors.Is(err, streamdeck.ErrPreparedImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	other, _, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	if err := other.SetKeyPreparedImage(streamdeck.KEY_1, p); !errors.Is(err, streamdeck.ErrPreparedImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,452 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package profile reads the profiles exported by the official Elgato Stream
+// Deck software (.streamDeckProfile files), and applies their static key
+// images and titles to devices.
+//
+// Both the legacy profile format, with a single page of actions, and the
+// multi-page format introduced by Stream Deck 6 are supported. Actions are
+// not executed, but their plugin identifiers are available, so that
+// applications can map them to their own handlers:
+//
+//	p, err := profile.ReadFile("Default.streamDeckProfile")
+//	if err != nil {
+//		return err
+//	}
+//	return p.Apply(dev)
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the profile package.
+var (
+	ErrProfileInvalid = errors.New("profile: profile is not valid")
+)
+
+// titleFontScale is the width of the key displays the title font sizes of
+// the profiles are relative to.
+const titleFontScale = 72
+
+// Key represents a key of an Elgato Stream Deck profile page.
+type Key struct {
+	// Row and Column are the zero-based position of the key in the key
+	// grid, counting from the top left key.
+	Row    int
+	Column int
+
+	// Action is the identifier of the action assigned to the key, e.g.
+	// "com.elgato.streamdeck.system.hotkey", and Name is its display name.
+	Action string
+	Name   string
+
+	// Title is the text rendered over the key image. It is empty if the
+	// title is hidden.
+	Title string
+
+	// TitleColor is the color of the title. If nil, defaults to white.
+	TitleColor color.Color
+
+	// TitleAlignment is the vertical position of the title.
+	TitleAlignment streamdeck.TextVerticalAlignment
+
+	// TitleSize is the font size of the title, in pixels of a 72x72 key
+	// display. If zero, the title is fitted to the display.
+	TitleSize float64
+
+	// Image is the encoded custom image of the current state of the key, in
+	// any of the formats supported by the image package or SVG, if an SVG
+	// rasterizer is registered with streamdeck.RegisterSVGRasterizer. If nil,
+	// the key has no custom image.
+	Image []byte
+}
+
+// Page represents a page of an Elgato Stream Deck profile.
+type Page struct {
+	// Name is the name of the page. It may be empty.
+	Name string
+
+	// Keys are the keys with actions assigned.
+	Keys []Key
+}
+
+// Profile represents an Elgato Stream Deck profile.
+type Profile struct {
+	// Name is the name of the profile.
+	Name string
+
+	// DeviceModel is the model identifier of the device the profile was
+	// created for, as reported by the official software.
+	DeviceModel string
+
+	// Pages are the pages of the profile, in order. Legacy profiles have a
+	// single page.
+	Pages []*Page
+
+	// Current is the index of the page displayed when the profile was
+	// exported.
+	Current int
+}
+
+type manifestState struct {
+	Image          string
+	Title          string
+	TitleAlignment string
+	TitleColor     string
+	FSize          json.RawMessage
+	FontSize       json.RawMessage
+	ShowTitle      *bool
+}
+
+type manifestAction struct {
+	Name   string
+	UUID   string
+	State  int
+	States []manifestState
+}
+
+type manifest struct {
+	Name        string
+	DeviceModel string
+	Device      struct {
+		Model string
+	}
+	Pages struct {
+		Current string
+		Pages   []string
+	}
+	Actions     map[string]manifestAction
+	Controllers []struct {
+		Type    string
+		Actions map[string]manifestAction
+	}
+}
+
+func readManifest(fsys fs.FS, name string) (*manifest, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProfileInvalid, err)
+	}
+
+	rv := &manifest{}
+	if err := json.Unmarshal(data, rv); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrProfileInvalid, name, err)
+	}
+	return rv, nil
+}
+
+// parseNumber parses the font sizes of the manifests, that are strings in
+// the legacy format and numbers in the current one.
+func parseNumber(v json.RawMessage) float64 {
+	s := strings.Trim(string(v), `"`)
+	if s == "" {
+		return 0
+	}
+	rv, err := strconv.ParseFloat(s, 64)
+	if err != nil || rv < 0 {
+		return 0
+	}
+	return rv
+}
+
+func parseColor(s string) color.Color {
+	h, ok := strings.CutPrefix(s, "#")
+	if !ok || len(h) != 6 {
+		return nil
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}
+}
+
+func parseAlignment(s string) streamdeck.TextVerticalAlignment {
+	switch s {
+	case "top":
+		return streamdeck.TEXT_VERTICAL_ALIGNMENT_TOP
+	case "middle":
+		return streamdeck.TEXT_VERTICAL_ALIGNMENT_MIDDLE
+	default:
+		return streamdeck.TEXT_VERTICAL_ALIGNMENT_BOTTOM
+	}
+}
+
+// findFile returns the name of a file of the archive, ignoring case, because
+// the page identifiers of the manifests and the directory names of the
+// archives are not always consistent.
+func findFile(fsys fs.FS, name string) (string, bool) {
+	if _, err := fs.Stat(fsys, name); err == nil {
+		return name, true
+	}
+
+	rv := ""
+	fs.WalkDir(fsys, ".", func(p string, e fs.DirEntry, err error) error {
+		if err == nil && !e.IsDir() && strings.EqualFold(p, name) {
+			rv = p
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return rv, rv != ""
+}
+
+func readPage(fsys fs.FS, dir string, mf *manifest) (*Page, error) {
+	actions := mf.Actions
+	for _, ctrl := range mf.Controllers {
+		if ctrl.Type == "" || ctrl.Type == "Keypad" {
+			actions = ctrl.Actions
+			break
+		}
+	}
+
+	rv := &Page{
+		Name: mf.Name,
+	}
+	for pos, act := range actions {
+		c, r, found := strings.Cut(pos, ",")
+		col, err1 := strconv.Atoi(c)
+		row, err2 := strconv.Atoi(r)
+		if !found || err1 != nil || err2 != nil || col < 0 || row < 0 {
+			return nil, fmt.Errorf("%w: key position: %q", ErrProfileInvalid, pos)
+		}
+
+		k := Key{
+			Row:            row,
+			Column:         col,
+			Action:         act.UUID,
+			Name:           act.Name,
+			TitleAlignment: streamdeck.TEXT_VERTICAL_ALIGNMENT_BOTTOM,
+		}
+
+		if act.State >= 0 && act.State < len(act.States) {
+			st := act.States[act.State]
+			if st.ShowTitle == nil || *st.ShowTitle {
+				k.Title = st.Title
+			}
+			k.TitleColor = parseColor(st.TitleColor)
+			k.TitleAlignment = parseAlignment(st.TitleAlignment)
+			k.TitleSize = parseNumber(st.FontSize)
+			if k.TitleSize == 0 {
+				k.TitleSize = parseNumber(st.FSize)
+			}
+
+			// legacy profiles store the custom images in a directory named
+			// after the key position, instead of referencing them.
+			img := path.Join(dir, pos, "CustomImages", "state"+strconv.Itoa(act.State)+".png")
+			if st.Image != "" {
+				img = path.Join(dir, st.Image)
+			}
+			if name, found := findFile(fsys, img); found {
+				data, err := fs.ReadFile(fsys, name)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %w", ErrProfileInvalid, err)
+				}
+				k.Image = data
+			}
+		}
+		rv.Keys = append(rv.Keys, k)
+	}
+
+	slices.SortFunc(rv.Keys, func(a Key, b Key) int {
+		if a.Row != b.Row {
+			return a.Row - b.Row
+		}
+		return a.Column - b.Column
+	})
+	return rv, nil
+}
+
+// Read reads an Elgato Stream Deck profile from an io.ReaderAt, with the
+// contents of a .streamDeckProfile file, that is a zip archive.
+func Read(r io.ReaderAt, size int64) (*Profile, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrProfileInvalid, err)
+	}
+
+	// the profile manifest is the outermost one, and the manifests of the
+	// pages are in its Profiles directory.
+	top := ""
+	for _, f := range zr.File {
+		if path.Base(f.Name) != "manifest.json" {
+			continue
+		}
+		if top == "" || strings.Count(f.Name, "/") < strings.Count(top, "/") {
+			top = f.Name
+		}
+	}
+	if top == "" {
+		return nil, fmt.Errorf("%w: manifest not found", ErrProfileInvalid)
+	}
+
+	mf, err := readManifest(zr, top)
+	if err != nil {
+		return nil, err
+	}
+
+	rv := &Profile{
+		Name:        mf.Name,
+		DeviceModel: mf.DeviceModel,
+	}
+	if rv.DeviceModel == "" {
+		rv.DeviceModel = mf.Device.Model
+	}
+
+	dir := path.Dir(top)
+	if len(mf.Pages.Pages) == 0 {
+		pg, err := readPage(zr, dir, mf)
+		if err != nil {
+			return nil, err
+		}
+		pg.Name = ""
+		rv.Pages = append(rv.Pages, pg)
+		return rv, nil
+	}
+
+	for i, id := range mf.Pages.Pages {
+		if strings.EqualFold(id, mf.Pages.Current) {
+			rv.Current = i
+		}
+
+		pdir := path.Join(dir, "Profiles", id)
+		name, found := findFile(zr, path.Join(pdir, "manifest.json"))
+		if !found {
+			return nil, fmt.Errorf("%w: page not found: %s", ErrProfileInvalid, id)
+		}
+
+		pmf, err := readManifest(zr, name)
+		if err != nil {
+			return nil, err
+		}
+
+		pg, err := readPage(zr, path.Dir(name), pmf)
+		if err != nil {
+			return nil, err
+		}
+		rv.Pages = append(rv.Pages, pg)
+	}
+	return rv, nil
+}
+
+// ReadFile reads an Elgato Stream Deck profile from a .streamDeckProfile
+// file.
+func ReadFile(name string) (*Profile, error) {
+	fp, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	st, err := fp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return Read(fp, st.Size())
+}
+
+func (k *Key) decodeImage(size image.Point) (image.Image, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(k.Image), []byte("<")) {
+		return streamdeck.RasterizeSVG(bytes.NewReader(k.Image), size)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(k.Image))
+	if err != nil {
+		return nil, fmt.Errorf("%w: key image: %w", ErrProfileInvalid, err)
+	}
+	return img, nil
+}
+
+func (k *Key) apply(dev *streamdeck.Device, key streamdeck.KeyID) error {
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		return err
+	}
+
+	opts := streamdeck.TextOptions{
+		Foreground:        k.TitleColor,
+		VerticalAlignment: k.TitleAlignment,
+		Size:              k.TitleSize * float64(rect.Dx()) / titleFontScale,
+	}
+
+	if k.Image == nil {
+		if k.Title == "" {
+			return dev.ClearKey(key)
+		}
+		return dev.SetKeyTextWithOptions(key, k.Title, opts)
+	}
+
+	img, err := k.decodeImage(rect.Size())
+	if err != nil {
+		return err
+	}
+	if err := dev.SetKeyImage(key, img); err != nil {
+		return err
+	}
+	if k.Title == "" {
+		return nil
+	}
+	return dev.SetKeyOverlayText(key, k.Title, opts)
+}
+
+// Apply draws the key images and titles of the page to an Elgato Stream Deck
+// device. Titles are rendered over the images, as key overlays. Keys outside
+// the key grid of the device, e.g. from profiles created for larger models,
+// are ignored, and keys not defined by the page are cleared.
+func (pg *Page) Apply(dev *streamdeck.Device) error {
+	if dev == nil {
+		return fmt.Errorf("%w: device is nil", ErrProfileInvalid)
+	}
+
+	if !dev.GetKeyDisplaySupported() {
+		return nil
+	}
+
+	defined := map[streamdeck.KeyID]bool{}
+	for _, k := range pg.Keys {
+		key, err := dev.KeyAt(k.Row, k.Column)
+		if err != nil {
+			continue
+		}
+		defined[key] = true
+
+		if err := k.apply(dev, key); err != nil {
+			return err
+		}
+	}
+
+	return dev.ForEachKey(func(key streamdeck.KeyID) error {
+		if defined[key] {
+			return nil
+		}
+		return dev.ClearKey(key)
+	})
+}
+
+// Apply draws the key images and titles of the current page of the profile
+// to an Elgato Stream Deck device. See Page.Apply for details.
+func (p *Profile) Apply(dev *streamdeck.Device) error {
+	if p.Current < 0 || p.Current >= len(p.Pages) {
+		return fmt.Errorf("%w: page is not valid: %d", ErrProfileInvalid, p.Current)
+	}
+	return p.Pages[p.Current].Apply(dev)
+}
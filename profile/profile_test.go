@@ -0,0 +1,218 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package profile
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func encodePNG(t *testing.T, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 144, 144))
+	draw.Draw(img, img.Rect, image.NewUniform(c), image.Point{}, draw.Src)
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func archive(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	for name, data := range files {
+		fp, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fp.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadLegacy(t *testing.T) {
+	r := bytes.NewReader(archive(t, map[string][]byte{
+		"ABC.sdProfile/manifest.json": []byte(`{
+			"Name": "Legacy",
+			"DeviceModel": "20GAA9901",
+			"Actions": {
+				"1,0": {"Name": "Open", "UUID": "com.elgato.streamdeck.system.open", "State": 0, "States": [{"Title": "Docs", "TitleColor": "#ff0000", "TitleAlignment": "top", "FSize": "12"}]},
+				"0,0": {"Name": "Hotkey", "UUID": "com.elgato.streamdeck.system.hotkey", "State": 0, "States": [{"Title": ""}]}
+			}
+		}`),
+		"ABC.sdProfile/0,0/CustomImages/state0.png": encodePNG(t, color.RGBA{B: 0xff, A: 0xff}),
+	}))
+
+	p, err := Read(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Legacy" || p.DeviceModel != "20GAA9901" || len(p.Pages) != 1 {
+		t.Fatalf("bad profile: %+v", p)
+	}
+
+	keys := p.Pages[0].Keys
+	if len(keys) != 2 || keys[0].Column != 0 || keys[1].Column != 1 {
+		t.Fatalf("bad keys: %+v", keys)
+	}
+	if keys[0].Action != "com.elgato.streamdeck.system.hotkey" || keys[0].Image == nil {
+		t.Errorf("bad key: %+v", keys[0])
+	}
+	if k := keys[1]; k.Title != "Docs" || k.TitleSize != 12 || k.TitleAlignment != streamdeck.TEXT_VERTICAL_ALIGNMENT_TOP || k.TitleColor != (color.RGBA{R: 0xff, A: 0xff}) || k.Image != nil {
+		t.Errorf("bad key: %+v", k)
+	}
+}
+
+func TestRead(t *testing.T) {
+	r := bytes.NewReader(archive(t, map[string][]byte{
+		"ABC.sdProfile/manifest.json": []byte(`{
+			"Name": "Pages",
+			"Device": {"Model": "20GBA9901"},
+			"Pages": {"Current": "page-2", "Pages": ["page-1", "page-2"]},
+			"Version": "2.0"
+		}`),
+		"ABC.sdProfile/Profiles/PAGE-1/manifest.json": []byte(`{
+			"Name": "First",
+			"Controllers": [{"Type": "Keypad", "Actions": {
+				"0,0": {"UUID": "com.elgato.streamdeck.page.next", "State": 0, "States": [{"Title": "Next", "ShowTitle": false}]}
+			}}]
+		}`),
+		"ABC.sdProfile/Profiles/PAGE-2/manifest.json": []byte(`{
+			"Name": "Second",
+			"Controllers": [{"Type": "Keypad", "Actions": {
+				"2,1": {"UUID": "com.elgato.streamdeck.system.website", "State": 1, "States": [{}, {"Image": "Images/red.png", "Title": "Web", "FontSize": 16}]}
+			}}]
+		}`),
+		"ABC.sdProfile/Profiles/PAGE-2/Images/red.png": encodePNG(t, color.RGBA{R: 0xff, A: 0xff}),
+	}))
+
+	p, err := Read(r, r.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "Pages" || p.DeviceModel != "20GBA9901" || len(p.Pages) != 2 || p.Current != 1 {
+		t.Fatalf("bad profile: %+v", p)
+	}
+	if pg := p.Pages[0]; pg.Name != "First" || len(pg.Keys) != 1 || pg.Keys[0].Title != "" {
+		t.Errorf("bad page: %+v", pg)
+	}
+	pg := p.Pages[1]
+	if len(pg.Keys) != 1 {
+		t.Fatalf("bad page: %+v", pg)
+	}
+	if k := pg.Keys[0]; k.Row != 1 || k.Column != 2 || k.Title != "Web" || k.TitleSize != 16 || k.TitleAlignment != streamdeck.TEXT_VERTICAL_ALIGNMENT_BOTTOM || k.Image == nil {
+		t.Errorf("bad key: %+v", k)
+	}
+
+	for _, files := range []map[string][]byte{
+		{},
+		{"ABC.sdProfile/manifest.json": []byte(`bola`)},
+		{"ABC.sdProfile/manifest.json": []byte(`{"Actions": {"a,b": {}}}`)},
+		{"ABC.sdProfile/manifest.json": []byte(`{"Pages": {"Pages": ["foo"]}}`)},
+	} {
+		r := bytes.NewReader(archive(t, files))
+		if _, err := Read(r, r.Size()); !errors.Is(err, ErrProfileInvalid) {
+			t.Errorf("%v: unexpected error: %v", files, err)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	data := archive(t, map[string][]byte{
+		"ABC.sdProfile/manifest.json": []byte(`{
+			"Name": "Legacy",
+			"Actions": {
+				"0,0": {"State": 0, "States": [{"Title": "B", "TitleColor": "#ff0000", "TitleAlignment": "top"}]},
+				"1,0": {"State": 0, "States": [{"Title": "Docs"}]},
+				"2,0": {"State": 0, "States": [{}]},
+				"7,0": {"State": 0, "States": [{"Title": "Outside"}]}
+			}
+		}`),
+		"ABC.sdProfile/0,0/CustomImages/state0.png": encodePNG(t, color.RGBA{B: 0xff, A: 0xff}),
+		"ABC.sdProfile/2,0/CustomImages/state0.png": encodePNG(t, color.RGBA{G: 0xff, A: 0xff}),
+	})
+
+	name := filepath.Join(t.TempDir(), "Legacy.streamDeckProfile")
+	if err := os.WriteFile(name, data, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetKeyColor(streamdeck.KEY_6, color.White); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(dev); err != nil {
+		t.Fatal(err)
+	}
+
+	// the title is drawn over the top of the image
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 36, 70, color.RGBA{B: 0xff})
+	found := false
+	img := m.KeyImage(streamdeck.KEY_1)
+	for y := 0; y < 36 && !found; y++ {
+		for x := 0; x < 72 && !found; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			found = r>>8 > 0xc0 && g>>8 < 0x40 && b>>8 < 0xc0
+		}
+	}
+	if !found {
+		t.Error("title not found")
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{})
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 36, 36, color.RGBA{G: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_6), 36, 36, color.RGBA{})
+
+	p.Current = 1
+	if err := p.Apply(dev); !errors.Is(err, ErrProfileInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := ReadFile(filepath.Join(t.TempDir(), "bola")); err == nil {
+		t.Error("unexpected success")
+	}
+}
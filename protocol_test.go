@@ -0,0 +1,175 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var updateProtocol = flag.Bool("update-protocol", false, "update the protocol trace golden files")
+
+// traceDevice is a fake HIDDevice that records the reports sent to it, with
+// the report lengths of the real hardware.
+type traceDevice struct {
+	HIDDevice
+	outputLength  uint16
+	featureLength uint16
+	trace         bytes.Buffer
+}
+
+// record writes the length and the checksum of a report to the trace, with
+// its first bytes only, to keep the golden files readable.
+func (d *traceDevice) record(kind string, id byte, data []byte) {
+	head := data[:min(len(data), 24)]
+	fmt.Fprintf(&d.trace, "%s %d len=%d crc32=%08x: %s\n", kind, id, len(data), crc32.ChecksumIEEE(data), hex.EncodeToString(head))
+}
+
+func (d *traceDevice) SetOutputReport(id byte, data []byte) error {
+	d.record("output", id, data)
+	return nil
+}
+
+func (d *traceDevice) SetFeatureReport(id byte, data []byte) error {
+	d.record("feature", id, data)
+	return nil
+}
+
+func (d *traceDevice) GetOutputReportLength() uint16 {
+	return d.outputLength
+}
+
+func (d *traceDevice) GetFeatureReportLength() uint16 {
+	return d.featureLength
+}
+
+// tracePayload returns a deterministic payload, without zero bytes, long
+// enough to span two reports.
+func tracePayload(n int) []byte {
+	rv := make([]byte, n)
+	for i := range rv {
+		rv[i] = byte(i%251) + 1
+	}
+	return rv
+}
+
+func TestProtocolTraces(t *testing.T) {
+	for _, tt := range []struct {
+		pid           uint16
+		outputLength  uint16
+		featureLength uint16
+	}{
+		{0x0060, 8190, 16},
+		{0x0063, 1023, 16},
+		{0x006c, 1023, 31},
+		{0x0080, 1023, 31},
+		{0x0084, 1023, 31},
+		{0x0086, 1023, 31},
+		{0x009a, 1023, 31},
+		{0x00aa, 1023, 31},
+	} {
+		md := models[tt.pid]
+		t.Run(md.id, func(t *testing.T) {
+			dev := &traceDevice{
+				outputLength:  tt.outputLength,
+				featureLength: tt.featureLength,
+			}
+			payload := tracePayload(int(tt.outputLength) + 100)
+
+			step := func(name string, fn func() error) {
+				t.Helper()
+				fmt.Fprintf(&dev.trace, "# %s\n", name)
+				if err := fn(); err != nil {
+					t.Fatalf("%s: %s", name, err)
+				}
+			}
+
+			if md.keyImageSend != nil {
+				for _, key := range []KeyID{KEY_1, KEY_1 + KeyID(md.keyCount) - 1} {
+					step("key image "+key.String(), func() error {
+						return md.keyImageSend(dev, key, payload)
+					})
+				}
+			}
+			if md.infoBarImageSend != nil {
+				step("info bar image", func() error {
+					return md.infoBarImageSend(dev, payload)
+				})
+			}
+			if md.touchPointColorSend != nil {
+				for tp := TOUCH_POINT_1; tp < TOUCH_POINT_1+TouchPointID(md.touchPointCount); tp++ {
+					step("touch point color "+tp.String(), func() error {
+						return md.touchPointColorSend(dev, tp, color.RGBA{R: 0x12, G: 0x34, B: 0x56, A: 0xff})
+					})
+				}
+			}
+			if md.touchStripImageSend != nil {
+				step("touch strip image", func() error {
+					return md.touchStripImageSend(dev, payload, image.Rect(200, 0, 400, 100))
+				})
+			}
+			if md.brightness != nil {
+				step("brightness 50", func() error {
+					return md.brightness(dev, 50)
+				})
+			}
+			step("reset", func() error {
+				return md.reset(dev)
+			})
+
+			fn := filepath.Join("testdata", "protocol", md.id+".txt")
+			if *updateProtocol {
+				if err := os.MkdirAll(filepath.Dir(fn), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(fn, dev.trace.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(fn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := dev.trace.String(); got != string(want) {
+				t.Errorf("trace differs from %s:\ngot:\n%s", fn, got)
+			}
+		})
+	}
+}
+
+// discardDevice is a fake HIDDevice that discards the output reports.
+type discardDevice struct {
+	HIDDevice
+}
+
+func (discardDevice) SetOutputReport(id byte, data []byte) error {
+	return nil
+}
+
+func (discardDevice) GetOutputReportLength() uint16 {
+	return 1023
+}
+
+func BenchmarkImageSend(b *testing.B) {
+	md := models[0x0084]
+	payload := tracePayload(20000)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := md.touchStripImageSend(discardDevice{}, payload, md.touchStripImageRect); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pulseaudio binds Elgato Stream Deck dials to the volume of
+// PulseAudio or PipeWire sinks and sources, on Linux.
+//
+// Rotating a bound dial changes the volume, and pressing it toggles the mute
+// state. The touch strip segment above the dial displays a label, the volume
+// percentage and a level bar, following the live audio server state, so
+// changes made by other applications are reflected too.
+//
+// The audio server is controlled with the pactl command, that must be
+// installed. PipeWire is supported through its PulseAudio compatibility
+// server, pipewire-pulse.
+package pulseaudio
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the pulseaudio package.
+var (
+	ErrDeviceTypeInvalid = errors.New("pulseaudio: device type is not valid")
+	ErrOutputInvalid     = errors.New("pulseaudio: pactl output is not valid")
+)
+
+// DeviceType represents the type of an audio server device.
+type DeviceType byte
+
+// Audio server device types.
+const (
+	DEVICE_TYPE_SINK DeviceType = iota + 1
+	DEVICE_TYPE_SOURCE
+)
+
+// String returns a string representation of the DeviceType.
+func (t DeviceType) String() string {
+	switch t {
+	case DEVICE_TYPE_SINK:
+		return "sink"
+	case DEVICE_TYPE_SOURCE:
+		return "source"
+	default:
+		return ""
+	}
+}
+
+var volumeRe = regexp.MustCompile(`(\d+)%`)
+
+// VolumeOptions defines the behavior and rendering of a Volume.
+type VolumeOptions struct {
+	// Label is the text displayed above the volume. If empty, defaults to
+	// "Volume" for sinks and "Mic" for sources.
+	Label string
+
+	// Step is the volume change, in percent, for each logical rotation step
+	// of the dial. If zero, defaults to 2.
+	Step int
+
+	// Max is the maximum volume, in percent. Volumes above 100% are
+	// amplified by the audio server. If zero, defaults to 100.
+	Max int
+
+	// Foreground is the color of the level bar. If nil, a green color is
+	// used. The bar is gray while the device is muted.
+	Foreground color.Color
+}
+
+// Volume represents the volume of an audio server sink or source, bound to
+// an Elgato Stream Deck dial. Failures to change the volume or to refresh the
+// dial are reported with Device.ReportError.
+type Volume struct {
+	device *streamdeck.Device
+	dial   streamdeck.DialID
+	typ    DeviceType
+	name   string
+	opts   VolumeOptions
+	regs   []*streamdeck.HandlerRegistration
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mtx    sync.Mutex
+	volume int
+	muted  bool
+}
+
+// BindDial binds the volume of a sink or source to the given dial. The name
+// is the name or index of the device, as listed by "pactl list short sinks"
+// or "pactl list short sources". If empty, the default device is used.
+func BindDial(dev *streamdeck.Device, di streamdeck.DialID, typ DeviceType, name string, opts VolumeOptions) (*Volume, error) {
+	if typ.String() == "" {
+		return nil, fmt.Errorf("%w: %d", ErrDeviceTypeInvalid, typ)
+	}
+
+	if name == "" {
+		name = "@DEFAULT_" + strings.ToUpper(typ.String()) + "@"
+	}
+	if opts.Label == "" {
+		opts.Label = "Volume"
+		if typ == DEVICE_TYPE_SOURCE {
+			opts.Label = "Mic"
+		}
+	}
+	if opts.Step <= 0 {
+		opts.Step = 2
+	}
+	if opts.Max <= 0 {
+		opts.Max = 100
+	}
+
+	rv := &Volume{
+		device: dev,
+		dial:   di,
+		typ:    typ,
+		name:   name,
+		opts:   opts,
+		done:   make(chan struct{}),
+	}
+	if err := rv.refresh(); err != nil {
+		return nil, err
+	}
+
+	reg, err := dev.AddDialRotationHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, r streamdeck.DialRotation) error {
+		return rv.rotate(r.Delta)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rv.regs = append(rv.regs, reg)
+
+	reg, err = dev.AddDialPressHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial) error {
+		return rv.ToggleMute()
+	})
+	if err != nil {
+		rv.regs[0].Remove()
+		return nil, err
+	}
+	rv.regs = append(rv.regs, reg)
+
+	if err := rv.render(); err != nil {
+		for _, reg := range rv.regs {
+			reg.Remove()
+		}
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rv.cancel = cancel
+	go rv.subscribe(ctx)
+	return rv, nil
+}
+
+func pactl(ctx context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "pactl", args...).Output()
+	if err != nil {
+		ee := &exec.ExitError{}
+		if errors.As(err, &ee) && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("pulseaudio: pactl %s: %s", args[0], strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("pulseaudio: pactl %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+func (v *Volume) refresh() error {
+	out, err := pactl(context.Background(), "get-"+v.typ.String()+"-volume", v.name)
+	if err != nil {
+		return err
+	}
+
+	// the volume is reported for each channel, use the average
+	m := volumeRe.FindAllStringSubmatch(out, -1)
+	if len(m) == 0 {
+		return fmt.Errorf("%w: %q", ErrOutputInvalid, out)
+	}
+	volume := 0
+	for _, c := range m {
+		p, err := strconv.Atoi(c[1])
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrOutputInvalid, out)
+		}
+		volume += p
+	}
+	volume /= len(m)
+
+	out, err = pactl(context.Background(), "get-"+v.typ.String()+"-mute", v.name)
+	if err != nil {
+		return err
+	}
+	s, found := strings.CutPrefix(strings.TrimSpace(out), "Mute:")
+	if !found {
+		return fmt.Errorf("%w: %q", ErrOutputInvalid, out)
+	}
+
+	v.mtx.Lock()
+	v.volume = volume
+	v.muted = strings.TrimSpace(s) == "yes"
+	v.mtx.Unlock()
+	return nil
+}
+
+func (v *Volume) subscribe(ctx context.Context) {
+	defer close(v.done)
+
+	cmd := exec.CommandContext(ctx, "pactl", "subscribe")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		v.logError(err)
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		v.logError(err)
+		return
+	}
+	defer cmd.Wait()
+
+	// events look like "Event 'change' on sink #56". Changes to the server
+	// may switch the default devices.
+	s := bufio.NewScanner(stdout)
+	for s.Scan() {
+		line := s.Text()
+		if !strings.Contains(line, " on "+v.typ.String()+" #") && !strings.Contains(line, " on server") {
+			continue
+		}
+
+		if err := v.refresh(); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			v.logError(err)
+			continue
+		}
+		if err := v.render(); err != nil {
+			v.logError(err)
+		}
+	}
+}
+
+func (v *Volume) logError(err error) {
+	v.device.ReportError("pulseaudio: failed to update volume", fmt.Errorf("pulseaudio: %s: %w", v.dial, err))
+}
+
+func (v *Volume) rotate(steps int) error {
+	v.mtx.Lock()
+	volume := min(max(v.volume+steps*v.opts.Step, 0), v.opts.Max)
+	changed := volume != v.volume
+	v.mtx.Unlock()
+
+	if !changed {
+		return nil
+	}
+	return v.SetVolume(volume)
+}
+
+func (v *Volume) render() error {
+	if !v.device.GetTouchStripSupported() {
+		return nil
+	}
+
+	rect, err := v.device.GetTouchStripSegmentRectangle(v.dial)
+	if err != nil {
+		return err
+	}
+
+	volume, muted := v.Get()
+	text := fmt.Sprintf("%s\n%d%%", v.opts.Label, volume)
+	fg := v.opts.Foreground
+	if muted {
+		text = v.opts.Label + "\nMuted"
+		fg = color.RGBA{R: 0x60, G: 0x60, B: 0x60, A: 0xff}
+	}
+
+	textRect := rect
+	textRect.Max.Y = rect.Max.Y - rect.Dy()/4
+	if err := v.device.SetTouchStripTextWithRectangle(text, streamdeck.TextOptions{}, textRect); err != nil {
+		return err
+	}
+
+	barRect := image.Rect(rect.Min.X+rect.Dx()/10, textRect.Max.Y, rect.Max.X-rect.Dx()/10, rect.Max.Y-rect.Dy()/10)
+	m := &streamdeck.LevelMeter{
+		Feed:       v,
+		Foreground: fg,
+		Background: color.RGBA{R: 0x20, G: 0x20, B: 0x20, A: 0xff},
+	}
+	return v.device.SetTouchStripImageWithRectangle(m.Render(barRect), barRect)
+}
+
+// Get returns the current volume, in percent, and mute state.
+func (v *Volume) Get() (int, bool) {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return v.volume, v.muted
+}
+
+// Level returns the current volume normalized to the [0, 1] range, relative
+// to VolumeOptions.Max, so that the Volume can be used as the LevelFeed of a
+// LevelMeter.
+func (v *Volume) Level() float64 {
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	return float64(v.volume) / float64(v.opts.Max)
+}
+
+// SetVolume sets the volume, in percent, limited to VolumeOptions.Max.
+func (v *Volume) SetVolume(volume int) error {
+	volume = min(max(volume, 0), v.opts.Max)
+	if _, err := pactl(context.Background(), "set-"+v.typ.String()+"-volume", v.name, strconv.Itoa(volume)+"%"); err != nil {
+		return err
+	}
+
+	v.mtx.Lock()
+	v.volume = volume
+	v.mtx.Unlock()
+	return v.render()
+}
+
+// ToggleMute toggles the mute state.
+func (v *Volume) ToggleMute() error {
+	if _, err := pactl(context.Background(), "set-"+v.typ.String()+"-mute", v.name, "toggle"); err != nil {
+		return err
+	}
+
+	v.mtx.Lock()
+	v.muted = !v.muted
+	v.mtx.Unlock()
+	return v.render()
+}
+
+// Close unbinds the volume from its dial, and stops following the audio
+// server state. The touch strip segment is not cleared.
+func (v *Volume) Close() error {
+	for _, reg := range v.regs {
+		reg.Remove()
+	}
+	v.cancel()
+	<-v.done
+	return nil
+}
+
+var _ streamdeck.LevelFeed = (*Volume)(nil)
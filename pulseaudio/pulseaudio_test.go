@@ -0,0 +1,183 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pulseaudio
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+const fakePactl = `#!/bin/sh
+d="$PACTL_DIR"
+if [ "$2" = bola ]; then
+	echo "Failure: No such entity" >&2
+	exit 1
+fi
+case "$1" in
+get-sink-volume)
+	v="$(cat "$d/volume")"
+	echo "Volume: front-left: 26214 / $v% / -23.88 dB,   front-right: 26214 / $v% / -23.88 dB"
+	echo "        balance 0.00"
+	;;
+get-sink-mute)
+	echo "Mute: $(cat "$d/mute")"
+	;;
+set-sink-volume)
+	echo "$3" | tr -d % > "$d/volume"
+	;;
+set-sink-mute)
+	if [ "$(cat "$d/mute")" = yes ]; then echo no > "$d/mute"; else echo yes > "$d/mute"; fi
+	;;
+subscribe)
+	exec tail -f "$d/events"
+	;;
+*)
+	echo "No valid command specified." >&2
+	exit 1
+	;;
+esac
+`
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func setup(t *testing.T) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("fake pactl requires a unix shell")
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"pactl":  fakePactl,
+		"volume": "40\n",
+		"mute":   "no\n",
+		"events": "",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("PACTL_DIR", dir)
+	return dir
+}
+
+func read(t *testing.T, dir string, name string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func wait(t *testing.T, fn func() bool) {
+	t.Helper()
+
+	for range 100 {
+		if fn() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("timeout waiting for condition")
+}
+
+func TestBindDial(t *testing.T) {
+	dir := setup(t)
+
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if _, err := BindDial(dev, streamdeck.DIAL_1, 0, "", VolumeOptions{}); !errors.Is(err, ErrDeviceTypeInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := BindDial(dev, streamdeck.DIAL_1, DEVICE_TYPE_SINK, "bola", VolumeOptions{}); err == nil || !strings.Contains(err.Error(), "No such entity") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	v, err := BindDial(dev, streamdeck.DIAL_2, DEVICE_TYPE_SINK, "", VolumeOptions{Step: 5, Max: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer v.Close()
+
+	if volume, muted := v.Get(); volume != 40 || muted {
+		t.Errorf("unexpected state: %d %t", volume, muted)
+	}
+	if l := v.Level(); l != 0.8 {
+		t.Errorf("unexpected level: %g", l)
+	}
+
+	rect, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	barY := rect.Max.Y - rect.Dy()/8
+	assertColor(t, m.TouchStripImage(), rect.Min.X+rect.Dx()/5, barY, color.RGBA{0x00, 0xc8, 0x53, 0xff})
+	assertColor(t, m.TouchStripImage(), rect.Max.X-rect.Dx()/10-2, barY, color.RGBA{0x20, 0x20, 0x20, 0xff})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	// clamped to the maximum volume
+	if err := m.RotateDial(streamdeck.DIAL_2, 3); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, func() bool { return read(t, dir, "volume") == "50" })
+
+	if err := m.PressDial(streamdeck.DIAL_2); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, func() bool { return read(t, dir, "mute") == "yes" })
+	wait(t, func() bool { _, muted := v.Get(); return muted })
+	assertColor(t, m.TouchStripImage(), rect.Min.X+rect.Dx()/5, barY, color.RGBA{0x60, 0x60, 0x60, 0xff})
+
+	// changes done by other applications
+	if err := os.WriteFile(filepath.Join(dir, "volume"), []byte("10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fp, err := os.OpenFile(filepath.Join(dir, "events"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fp.WriteString("Event 'change' on sink #56\n"); err != nil {
+		t.Fatal(err)
+	}
+	fp.Close()
+	wait(t, func() bool { volume, _ := v.Get(); return volume == 10 })
+
+	if err := v.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	"golang.org/x/image/bmp"
+)
+
+func (f imageFormat) String() string {
+	switch f {
+	case imageFormatBMP:
+		return "image/bmp"
+	case imageFormatJPEG:
+		return "image/jpeg"
+	default:
+		return ""
+	}
+}
+
+// validateRawImage checks if a pre-encoded image payload uses the given
+// image format and matches the dimensions of the given rectangle.
+func validateRawImage(data []byte, rect image.Rectangle, ifmt imageFormat) error {
+	var (
+		cfg image.Config
+		err error
+	)
+	switch ifmt {
+	case imageFormatBMP:
+		cfg, err = bmp.DecodeConfig(bytes.NewReader(data))
+	case imageFormatJPEG:
+		cfg, err = jpeg.DecodeConfig(bytes.NewReader(data))
+	default:
+		return fmt.Errorf("streamdeck: %w: invalid image format", ErrImageInvalid)
+	}
+	if err != nil {
+		return fmt.Errorf("streamdeck: %w: expected %s: %w", ErrImageInvalid, ifmt, err)
+	}
+
+	if cfg.Width != rect.Dx() || cfg.Height != rect.Dy() {
+		return fmt.Errorf("streamdeck: %w: unexpected image size: %dx%d, expected %dx%d", ErrImageInvalid, cfg.Width, cfg.Height, rect.Dx(), rect.Dy())
+	}
+	return nil
+}
+
+// GetKeyImageFormat returns the MIME type of the image format natively used
+// by the Elgato Stream Deck key background displays.
+func (d *Device) GetKeyImageFormat() (string, error) {
+	if err := d.validateKeyDisplay(); err != nil {
+		return "", err
+	}
+	return d.model.keyImageFormat.String(), nil
+}
+
+// GetInfoBarImageFormat returns the MIME type of the image format natively
+// used by the info bar display available on some Elgato Stream Deck models.
+func (d *Device) GetInfoBarImageFormat() (string, error) {
+	if err := d.validateInfoBar(); err != nil {
+		return "", err
+	}
+	return d.model.infoBarImageFormat.String(), nil
+}
+
+// GetTouchStripImageFormat returns the MIME type of the image format natively
+// used by the touch strip display available on some Elgato Stream Deck
+// models.
+func (d *Device) GetTouchStripImageFormat() (string, error) {
+	if err := d.validateTouchStrip(); err != nil {
+		return "", err
+	}
+	return d.model.touchStripImageFormat.String(), nil
+}
+
+// SetKeyImageRaw sends pre-encoded image data to an Elgato Stream Deck key
+// background display, without decoding, scaling or re-encoding it.
+//
+// The data must be encoded in the format returned by GetKeyImageFormat, with
+// the exact size returned by GetKeyImageRectangle, and oriented as expected
+// by the device hardware, that may require the image to be flipped or
+// rotated.
+func (d *Device) SetKeyImageRaw(key KeyID, data []byte) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if err := validateRawImage(data, d.model.keyImageRect, d.model.keyImageFormat); err != nil {
+		return err
+	}
+
+	return d.sendKeyImage(key, data)
+}
+
+// SetInfoBarImageRaw sends pre-encoded image data to the info bar display
+// available on some Elgato Stream Deck models, without decoding, scaling or
+// re-encoding it.
+//
+// The data must be encoded in the format returned by GetInfoBarImageFormat,
+// with the exact size returned by GetInfoBarImageRectangle, and oriented as
+// expected by the device hardware.
+func (d *Device) SetInfoBarImageRaw(data []byte) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateInfoBar(); err != nil {
+		return err
+	}
+
+	if err := validateRawImage(data, d.model.infoBarImageRect, d.model.infoBarImageFormat); err != nil {
+		return err
+	}
+
+	return d.sendInfoBarImage(data)
+}
+
+// SetTouchStripImageRaw sends pre-encoded image data to the whole touch strip
+// display available on some Elgato Stream Deck models, without decoding,
+// scaling or re-encoding it.
+//
+// The data must be encoded in the format returned by
+// GetTouchStripImageFormat, with the exact size returned by
+// GetTouchStripImageRectangle, and oriented as expected by the device
+// hardware.
+func (d *Device) SetTouchStripImageRaw(data []byte) error {
+	return d.SetTouchStripImageRawWithRectangle(data, d.model.touchStripImageRect)
+}
+
+// SetTouchStripImageRawWithRectangle sends pre-encoded image data to a
+// rectangle of the touch strip display available on some Elgato Stream Deck
+// models, without decoding, scaling or re-encoding it. The data must have the
+// exact size of the provided rectangle.
+func (d *Device) SetTouchStripImageRawWithRectangle(data []byte, rect image.Rectangle) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	if err := validateRawImage(data, rect, d.model.touchStripImageFormat); err != nil {
+		return err
+	}
+
+	return d.sendTouchStripImage(data, rect)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestKeyImageRaw(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if f, err := dev.GetKeyImageFormat(); err != nil || f != "image/jpeg" {
+		t.Fatalf("bad key image format: %q, %v", f, err)
+	}
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img := image.NewRGBA(rect)
+	draw.Draw(img, rect, image.NewUniform(color.RGBA{G: 0xff, A: 0xff}), image.Point{}, draw.Src)
+	buf := bytes.Buffer{}
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.SetKeyImageRaw(streamdeck.KEY_2, buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 2, 2, color.RGBA{G: 0xff})
+
+	if err := dev.SetKeyImageRaw(streamdeck.KEY_2, []byte("bola")); !errors.Is(err, streamdeck.ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w := m.Writes(); len(w) != 1 {
+		t.Errorf("bad writes: %+v", w)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// recordingHeader is the first line of an input recording, identifying the
+// model the input reports were read from.
+type recordingHeader struct {
+	Model string `json:"model"`
+}
+
+// recordingEvent is an input report of an input recording, with the time
+// elapsed since the recording started.
+type recordingEvent struct {
+	Time   time.Duration `json:"time"`
+	Report []byte        `json:"report"`
+}
+
+type inputRecorder struct {
+	mtx   sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+	err   error
+}
+
+func (r *inputRecorder) record(buf []byte) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(recordingEvent{
+		Time:   time.Since(r.start),
+		Report: buf,
+	})
+}
+
+// StartInputRecording starts recording the input events handled by the
+// Elgato Stream Deck listeners to an io.Writer, e.g. a file, with the time
+// they were received, until StopInputRecording is called. The recording is
+// written as JSON lines, and can be replayed into the handlers of a device of
+// the same model with ReplayInput, to reproduce bug reports, to test
+// applications or to demo them without hardware, along with the mock package.
+func (d *Device) StartInputRecording(w io.Writer) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if w == nil {
+		return wrapErr(ErrInputRecordingInvalid)
+	}
+
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if d.recorder != nil {
+		return fmt.Errorf("streamdeck: %w: already recording", ErrInputRecordingInvalid)
+	}
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(recordingHeader{Model: d.model.id}); err != nil {
+		return wrapErr(err)
+	}
+	d.recorder = &inputRecorder{
+		enc:   enc,
+		start: time.Now(),
+	}
+	return nil
+}
+
+// StopInputRecording stops recording the input events of the Elgato Stream
+// Deck device, and returns the first error writing the recording, if any.
+// The io.Writer is not closed.
+func (d *Device) StopInputRecording() error {
+	d.mtx.Lock()
+	r := d.recorder
+	d.recorder = nil
+	d.mtx.Unlock()
+
+	if r == nil {
+		return fmt.Errorf("streamdeck: %w: not recording", ErrInputRecordingInvalid)
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return wrapErr(r.err)
+}
+
+func (d *Device) recordInput(buf []byte) {
+	d.mtx.Lock()
+	r := d.recorder
+	d.mtx.Unlock()
+
+	if r != nil {
+		r.record(buf)
+	}
+}
+
+// ReplayInput replays an input recording created by StartInputRecording
+// into the listener of the Elgato Stream Deck device, keeping the original
+// timing between the events, as if they were read from the hardware. The
+// events are delivered to handlers only while Listen or ListenContext is
+// running. It blocks until the recording is fully replayed, or the context
+// is cancelled. The recording must have been created from a device of the
+// same model.
+func (d *Device) ReplayInput(ctx context.Context, r io.Reader) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if r == nil {
+		return wrapErr(ErrInputRecordingInvalid)
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1024*1024)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return wrapErr(err)
+		}
+		return fmt.Errorf("streamdeck: %w: recording is empty", ErrInputRecordingInvalid)
+	}
+
+	hdr := recordingHeader{}
+	if err := json.Unmarshal(sc.Bytes(), &hdr); err != nil {
+		return fmt.Errorf("streamdeck: %w: %w", ErrInputRecordingInvalid, err)
+	}
+	if hdr.Model != d.model.id {
+		return fmt.Errorf("streamdeck: %w: recorded from model %q, device is %q", ErrInputRecordingInvalid, hdr.Model, d.model.id)
+	}
+
+	d.mtx.Lock()
+	done := d.done
+	d.mtx.Unlock()
+
+	start := time.Now()
+	reports := d.inputReports()
+	for sc.Scan() {
+		ev := recordingEvent{}
+		if err := json.Unmarshal(sc.Bytes(), &ev); err != nil {
+			return fmt.Errorf("streamdeck: %w: %w", ErrInputRecordingInvalid, err)
+		}
+
+		if wait := time.Until(start.Add(ev.Time)); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-done:
+				return wrapErr(ErrDeviceIsClosed)
+			case <-time.After(wait):
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-done:
+			return wrapErr(ErrDeviceIsClosed)
+		case reports <- inputReport{id: 1, buf: ev.Report}:
+		}
+	}
+	return wrapErr(sc.Err())
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestInputRecording(t *testing.T) {
+	handlers := func(t *testing.T, dev *streamdeck.Device, events chan string) {
+		t.Helper()
+
+		if _, err := dev.AddKeyHandler(streamdeck.KEY_3, func(d *streamdeck.Device, k *streamdeck.Key) error {
+			events <- "key"
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := dev.AddDialRotateHandler(streamdeck.DIAL_2, func(d *streamdeck.Device, di *streamdeck.Dial, delta int8) error {
+			events <- fmt.Sprintf("rotate %d", delta)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wait := func(t *testing.T, events chan string, want string) {
+		t.Helper()
+
+		select {
+		case ev := <-events:
+			if ev != want {
+				t.Errorf("unexpected event: %q", ev)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for event: %q", want)
+		}
+	}
+
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	events := make(chan string, 10)
+	handlers(t, dev, events)
+	go dev.Listen(nil)
+
+	buf := &bytes.Buffer{}
+	if err := dev.StartInputRecording(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.StartInputRecording(buf); !errors.Is(err, streamdeck.ErrInputRecordingInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := m.PressKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.ReleaseKey(streamdeck.KEY_3); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, events, "key")
+	time.Sleep(50 * time.Millisecond)
+	if err := m.RotateDial(streamdeck.DIAL_2, -2); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, events, "rotate -2")
+
+	if err := dev.StopInputRecording(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.StopInputRecording(); !errors.Is(err, streamdeck.ErrInputRecordingInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	recording := buf.String()
+	if n := strings.Count(recording, "\n"); n != 4 {
+		t.Fatalf("unexpected recording: %d lines", n)
+	}
+
+	// replayed into a device with no hardware input, keeping the timing
+	replay, _, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replay.Close()
+
+	events = make(chan string, 10)
+	handlers(t, replay, events)
+	go replay.Listen(nil)
+
+	start := time.Now()
+	if err := replay.ReplayInput(context.Background(), strings.NewReader(recording)); err != nil {
+		t.Fatal(err)
+	}
+	wait(t, events, "key")
+	wait(t, events, "rotate -2")
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("replayed too fast: %s", d)
+	}
+
+	mk2, _, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mk2.Close()
+
+	for _, r := range []string{"", "bola\n", recording} {
+		if err := mk2.ReplayInput(context.Background(), strings.NewReader(r)); !errors.Is(err, streamdeck.ErrInputRecordingInvalid) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const journalInterval = time.Second
+
+var journalDir = ""
+
+// CrashRecoveryMode represents how the Elgato Stream Deck device displays are
+// recovered when the previous process using the device died without closing
+// it.
+type CrashRecoveryMode byte
+
+// String returns a string representation of the CrashRecoveryMode.
+func (m CrashRecoveryMode) String() string {
+	switch m {
+	case CRASH_RECOVERY_MODE_CLEAR:
+		return "CRASH_RECOVERY_MODE_CLEAR"
+	case CRASH_RECOVERY_MODE_RESTORE:
+		return "CRASH_RECOVERY_MODE_RESTORE"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck crash recovery modes. These constants represent what is
+// displayed after recovering from a process that died without closing the
+// device.
+const (
+	CRASH_RECOVERY_MODE_CLEAR CrashRecoveryMode = iota + 1
+	CRASH_RECOVERY_MODE_RESTORE
+)
+
+type stateJournal struct {
+	file    string
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func journalFile(id string) (string, error) {
+	dir := journalDir
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			cache = os.TempDir()
+		}
+		dir = filepath.Join(cache, "streamdeck")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id+".state"), nil
+}
+
+// EnableCrashRecovery enables an opt-in watchdog that persists the current
+// Elgato Stream Deck device display state to a journal file, removed when the
+// device is closed. It must be called after opening the device.
+//
+// If a journal file is found when this function is called, the previous
+// process using the device died without closing it, and the device displays
+// are recovered using the provided mode: cleared to a neutral state, or
+// restored to the layout saved in the journal. The returned boolean reports
+// if a recovery happened.
+func (d *Device) EnableCrashRecovery(mode CrashRecoveryMode) (bool, error) {
+	if err := d.validateOpen(); err != nil {
+		return false, err
+	}
+
+	if d.journal != nil {
+		return false, errors.New("streamdeck: crash recovery already enabled")
+	}
+
+	name, err := journalFile(d.instanceID())
+	if err != nil {
+		return false, fmt.Errorf("streamdeck: failed to create journal: %w", err)
+	}
+
+	recovered := false
+	if data, err := os.ReadFile(name); err == nil {
+		recovered = true
+
+		st := &savedState{}
+		if mode == CRASH_RECOVERY_MODE_RESTORE && json.Unmarshal(data, st) == nil && st.Model == d.model.id {
+			if err := d.restoreState(st); err != nil {
+				return false, err
+			}
+		} else if err := d.closeDisplays(); err != nil {
+			return false, wrapErr(err)
+		}
+	}
+
+	if err := writeStateFile(name, d.saveState()); err != nil {
+		return false, fmt.Errorf("streamdeck: failed to write journal: %w", err)
+	}
+
+	d.journal = &stateJournal{
+		file:    name,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go d.runJournal(d.journal, d.state.generation())
+	return recovered, nil
+}
+
+func (d *Device) runJournal(j *stateJournal, gen uint64) {
+	defer close(j.stopped)
+
+	ticker := time.NewTicker(journalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-j.stop:
+			return
+		case <-ticker.C:
+		}
+
+		if g := d.state.generation(); g != gen {
+			if err := writeStateFile(j.file, d.saveState()); err != nil {
+				d.logError("streamdeck: journal write failed", fmt.Errorf("streamdeck: failed to write journal: %w", err))
+				continue
+			}
+			gen = g
+		}
+	}
+}
+
+func (d *Device) stopJournal() error {
+	if d.journal == nil {
+		return nil
+	}
+
+	close(d.journal.stop)
+	<-d.journal.stopped
+
+	err := os.Remove(d.journal.file)
+	d.journal = nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("streamdeck: failed to remove journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+)
+
+const schedulePollInterval = time.Second
+
+// ScheduleAction represents a callback function that is called by a Schedule
+// to change the content displayed by the Elgato Stream Deck device. It
+// receives the Device instance as parameter.
+type ScheduleAction func(d *Device) error
+
+// ScheduleRule represents a rule of a Schedule. A rule is active during a
+// time window, in the schedule location. While active, its actions are
+// rotated at the rule interval.
+type ScheduleRule struct {
+	// Weekdays lists the days of the week when the rule is active. If empty,
+	// the rule is active every day.
+	Weekdays []time.Weekday
+
+	// Start is the time of day when the rule becomes active, as an offset
+	// from midnight.
+	Start time.Duration
+
+	// End is the time of day when the rule stops being active, as an offset
+	// from midnight. If End is before Start, the time window crosses
+	// midnight. If End is equal to Start, the rule is active all day.
+	End time.Duration
+
+	// Interval is the duration each action is displayed before rotating to
+	// the next one. If zero, only the first action is called, when the rule
+	// becomes active.
+	Interval time.Duration
+
+	// Page is the name of the page, managed by Device.Pages, activated when
+	// the rule becomes active, before calling its first action. If empty,
+	// the active page is not changed.
+	Page string
+
+	// Actions lists the actions called while the rule is active.
+	Actions []ScheduleAction
+}
+
+func (r *ScheduleRule) match(t time.Time) bool {
+	if len(r.Weekdays) > 0 && !slices.Contains(r.Weekdays, t.Weekday()) {
+		return false
+	}
+
+	h, m, s := t.Clock()
+	tod := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+
+	switch {
+	case r.Start == r.End:
+		return true
+	case r.Start < r.End:
+		return tod >= r.Start && tod < r.End
+	default:
+		return tod >= r.Start || tod < r.End
+	}
+}
+
+// Schedule represents a set of rules to rotate the content displayed by an
+// Elgato Stream Deck device based on timers and time windows. Rules are
+// evaluated in order, and the first matching rule is the active one.
+type Schedule struct {
+	// Location is the time zone used to evaluate rules time windows. If nil,
+	// the local time zone is used.
+	Location *time.Location
+
+	// Rules lists the schedule rules, in order of priority.
+	Rules []*ScheduleRule
+}
+
+func (s *Schedule) active(t time.Time) int {
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+
+	t = t.In(loc)
+	for i, r := range s.Rules {
+		if r != nil && (r.Page != "" || len(r.Actions) > 0) && r.match(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SwitchPage returns a ScheduleAction that activates the page with the given
+// name, managed by Device.Pages. It allows rotating pages at the rule
+// interval.
+func SwitchPage(name string) ScheduleAction {
+	return func(d *Device) error {
+		return d.Pages().Switch(name)
+	}
+}
+
+// scheduleRunner tracks the active rule and action of a Schedule.
+type scheduleRunner struct {
+	schedule *Schedule
+	current  int
+	action   int
+	changed  time.Time
+}
+
+// step calls the actions required by the schedule at the given time.
+func (sr *scheduleRunner) step(d *Device, now time.Time) error {
+	s := sr.schedule
+	if i := s.active(now); i != sr.current {
+		sr.current = i
+		sr.action = 0
+		sr.changed = now
+		if sr.current < 0 {
+			return nil
+		}
+
+		r := s.Rules[sr.current]
+		if r.Page != "" {
+			if err := d.Pages().Switch(r.Page); err != nil {
+				return err
+			}
+		}
+		if len(r.Actions) > 0 {
+			return r.Actions[sr.action](d)
+		}
+		return nil
+	}
+
+	if sr.current < 0 {
+		return nil
+	}
+	r := s.Rules[sr.current]
+	if r.Interval > 0 && len(r.Actions) > 1 && now.Sub(sr.changed) >= r.Interval {
+		sr.action = (sr.action + 1) % len(r.Actions)
+		sr.changed = now
+		return r.Actions[sr.action](d)
+	}
+	return nil
+}
+
+// RunSchedule runs a Schedule on the Elgato Stream Deck device, switching to
+// the page of the active rule and calling its actions as required. It blocks
+// until the context is cancelled or an action returns an error.
+func (d *Device) RunSchedule(ctx context.Context, s *Schedule) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if s == nil {
+		return errors.New("streamdeck: schedule is nil")
+	}
+
+	ticker := time.NewTicker(schedulePollInterval)
+	defer ticker.Stop()
+
+	sr := &scheduleRunner{
+		schedule: s,
+		current:  -1,
+	}
+	for {
+		if err := sr.step(d, time.Now()); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
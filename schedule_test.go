@@ -0,0 +1,132 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleRule_Match(t *testing.T) {
+	day := &ScheduleRule{
+		Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Start:    9 * time.Hour,
+		End:      17 * time.Hour,
+	}
+	night := &ScheduleRule{
+		Start: 22 * time.Hour,
+		End:   6 * time.Hour,
+	}
+	all := &ScheduleRule{}
+
+	tests := []struct {
+		rule *ScheduleRule
+		t    time.Time
+		want bool
+	}{
+		{day, time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), true},    // monday
+		{day, time.Date(2025, 6, 2, 16, 59, 59, 0, time.UTC), true}, // monday
+		{day, time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC), false},  // monday
+		{day, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), false},  // sunday
+		{night, time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC), true},
+		{night, time.Date(2025, 6, 1, 5, 59, 0, 0, time.UTC), true},
+		{night, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), false},
+		{all, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rule.match(tt.t); got != tt.want {
+			t.Errorf("match(%s) = %t, want %t", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestSchedule_Active(t *testing.T) {
+	loc := time.FixedZone("UTC-3", -3*60*60)
+	action := func(d *Device) error { return nil }
+
+	s := &Schedule{
+		Location: loc,
+		Rules: []*ScheduleRule{
+			{Start: 9 * time.Hour, End: 17 * time.Hour, Actions: []ScheduleAction{action}},
+			{Start: 10 * time.Hour, End: 11 * time.Hour}, // no actions, ignored
+			{Actions: []ScheduleAction{action}},
+		},
+	}
+
+	// 13:00 UTC is 10:00 in the schedule location
+	if i := s.active(time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC)); i != 0 {
+		t.Errorf("expected rule 0, got %d", i)
+	}
+
+	// 11:00 UTC is 08:00 in the schedule location
+	if i := s.active(time.Date(2025, 6, 2, 11, 0, 0, 0, time.UTC)); i != 2 {
+		t.Errorf("expected rule 2, got %d", i)
+	}
+}
+
+func TestScheduleRunner_Page(t *testing.T) {
+	d, err := NewDevice(&reportDevice{productID: 0x0086})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.open = true
+
+	pages := d.Pages()
+	for _, name := range []string{"work", "home"} {
+		if _, err := pages.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calls := 0
+	sr := &scheduleRunner{
+		schedule: &Schedule{
+			Location: time.UTC,
+			Rules: []*ScheduleRule{
+				{Start: 9 * time.Hour, End: 17 * time.Hour, Page: "work"},
+				{Page: "home", Actions: []ScheduleAction{func(d *Device) error {
+					calls++
+					return nil
+				}}},
+			},
+		},
+		current: -1,
+	}
+
+	for _, tt := range []struct {
+		t     time.Time
+		page  string
+		calls int
+	}{
+		{time.Date(2025, 6, 2, 8, 59, 59, 0, time.UTC), "home", 1},
+		{time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), "work", 1},
+		{time.Date(2025, 6, 2, 16, 59, 59, 0, time.UTC), "work", 1},
+		{time.Date(2025, 6, 2, 17, 0, 0, 0, time.UTC), "home", 2},
+	} {
+		if err := sr.step(d, tt.t); err != nil {
+			t.Fatal(err)
+		}
+		if pg := pages.Current(); pg == nil || pg.Name() != tt.page {
+			t.Errorf("%s: expected page %q, got %v", tt.t, tt.page, pg)
+		}
+		if calls != tt.calls {
+			t.Errorf("%s: expected %d action calls, got %d", tt.t, tt.calls, calls)
+		}
+	}
+
+	if err := SwitchPage("work")(d); err != nil {
+		t.Fatal(err)
+	}
+	if pg := pages.Current(); pg.Name() != "work" {
+		t.Errorf("expected page %q, got %q", "work", pg.Name())
+	}
+
+	sr.schedule.Rules[0].Page = "invalid"
+	if err := sr.step(d, time.Date(2025, 6, 3, 9, 0, 0, 0, time.UTC)); !errors.Is(err, ErrPageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"strings"
+)
+
+type selector struct {
+	filters  []func(d *Device) bool
+	index    int
+	hasIndex bool
+	desc     []string
+	err      error
+}
+
+// SelectorOption represents a criterion used by GetDeviceWith to select an
+// Elgato Stream Deck device connected to the computer.
+type SelectorOption func(s *selector)
+
+// WithSerialNumber selects the devices with the given serial number, as
+// reported by the USB string descriptor.
+func WithSerialNumber(serialNumber string) SelectorOption {
+	return func(s *selector) {
+		s.desc = append(s.desc, fmt.Sprintf("serial=%q", serialNumber))
+		s.filters = append(s.filters, func(d *Device) bool {
+			return d.dev.SerialNumber() == serialNumber
+		})
+	}
+}
+
+// WithModel selects the devices of the given model, identified by the
+// identifier returned by Device.GetModelID, like "plus" or "xl".
+func WithModel(id string) SelectorOption {
+	return func(s *selector) {
+		s.desc = append(s.desc, fmt.Sprintf("model=%q", id))
+		s.filters = append(s.filters, func(d *Device) bool {
+			return d.model.id == id
+		})
+	}
+}
+
+// WithPath selects the device with the given USB HID path, as returned by
+// Device.GetPath.
+func WithPath(path string) SelectorOption {
+	return func(s *selector) {
+		s.desc = append(s.desc, fmt.Sprintf("path=%q", path))
+		s.filters = append(s.filters, func(d *Device) bool {
+			return d.dev.Path() == path
+		})
+	}
+}
+
+// WithFunc selects the devices for which the given predicate returns true.
+// The devices are not open when the predicate is called.
+func WithFunc(fn func(d *Device) bool) SelectorOption {
+	return func(s *selector) {
+		if fn == nil {
+			s.err = fmt.Errorf("%w: predicate is nil", ErrSelectorInvalid)
+			return
+		}
+		s.desc = append(s.desc, "func")
+		s.filters = append(s.filters, fn)
+	}
+}
+
+// WithIndex selects the device at the given zero-based index among the
+// devices matching all the other criteria, in the order returned by
+// Enumerate. Combined with WithModel, it selects e.g. the first device of a
+// model, even when several devices are connected.
+func WithIndex(index int) SelectorOption {
+	return func(s *selector) {
+		if index < 0 {
+			s.err = fmt.Errorf("%w: index is negative: %d", ErrSelectorInvalid, index)
+			return
+		}
+		s.desc = append(s.desc, fmt.Sprintf("index=%d", index))
+		s.index = index
+		s.hasIndex = true
+	}
+}
+
+func selectDevice(devices []*Device, opts ...SelectorOption) (*Device, error) {
+	s := &selector{}
+	for _, opt := range opts {
+		if opt == nil {
+			return nil, wrapErr(ErrSelectorInvalid)
+		}
+		opt(s)
+		if s.err != nil {
+			return nil, wrapErr(s.err)
+		}
+	}
+
+	matches := []*Device{}
+	for _, dev := range devices {
+		found := true
+		for _, f := range s.filters {
+			if !f(dev) {
+				found = false
+				break
+			}
+		}
+		if found {
+			matches = append(matches, dev)
+		}
+	}
+
+	desc := strings.Join(s.desc, " ")
+	if s.hasIndex {
+		if s.index >= len(matches) {
+			return nil, fmt.Errorf("streamdeck: %w [%s]", ErrNoDeviceFound, desc)
+		}
+		return matches[s.index], nil
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("streamdeck: %w [%s]", ErrNoDeviceFound, desc)
+	case 1:
+		return matches[0], nil
+	}
+
+	sn := []string{}
+	for _, dev := range matches {
+		sn = append(sn, dev.dev.SerialNumber())
+	}
+	return nil, fmt.Errorf("streamdeck: %w %q", ErrMoreThanOneDeviceFound, sn)
+}
+
+// GetDeviceWith returns the Elgato Stream Deck device connected to the
+// computer that matches all the given criteria. Without WithIndex, an error
+// is returned if more than one device matches, like GetDevice. Without
+// criteria, the only device connected is returned.
+//
+//	dev, err := streamdeck.GetDeviceWith(streamdeck.WithModel("xl"), streamdeck.WithIndex(0))
+func GetDeviceWith(opts ...SelectorOption) (*Device, error) {
+	devices, err := Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	return selectDevice(devices, opts...)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"testing"
+)
+
+// selectorDevice is a fake HIDDevice with an USB path and serial number.
+type selectorDevice struct {
+	HIDDevice
+	productID uint16
+	path      string
+	serial    string
+}
+
+func (s *selectorDevice) IsOpen() bool {
+	return false
+}
+
+func (s *selectorDevice) VendorId() uint16 {
+	return elgatoVendorID
+}
+
+func (s *selectorDevice) ProductId() uint16 {
+	return s.productID
+}
+
+func (s *selectorDevice) Path() string {
+	return s.path
+}
+
+func (s *selectorDevice) SerialNumber() string {
+	return s.serial
+}
+
+func TestSelectDevice(t *testing.T) {
+	devices := []*Device{}
+	for _, d := range []*selectorDevice{
+		{productID: 0x0080, path: "/dev/hidraw0", serial: "MK2A"},
+		{productID: 0x006c, path: "/dev/hidraw1", serial: "XLA"},
+		{productID: 0x006c, path: "/dev/hidraw2", serial: "XLB"},
+		{productID: 0x0084, path: "/dev/hidraw3", serial: "XLB"},
+	} {
+		dev, err := NewDevice(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		devices = append(devices, dev)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opts []SelectorOption
+		want int
+		err  error
+	}{
+		{"none", nil, -1, ErrMoreThanOneDeviceFound},
+		{"index", []SelectorOption{WithIndex(3)}, 3, nil},
+		{"index-out-of-range", []SelectorOption{WithIndex(4)}, -1, ErrNoDeviceFound},
+		{"serial", []SelectorOption{WithSerialNumber("XLA")}, 1, nil},
+		{"serial-duplicated", []SelectorOption{WithSerialNumber("XLB")}, -1, ErrMoreThanOneDeviceFound},
+		{"serial-model", []SelectorOption{WithSerialNumber("XLB"), WithModel("plus")}, 3, nil},
+		{"model", []SelectorOption{WithModel("xl")}, -1, ErrMoreThanOneDeviceFound},
+		{"model-index", []SelectorOption{WithModel("xl"), WithIndex(0)}, 1, nil},
+		{"index-model", []SelectorOption{WithIndex(1), WithModel("xl")}, 2, nil},
+		{"model-not-found", []SelectorOption{WithModel("neo")}, -1, ErrNoDeviceFound},
+		{"path", []SelectorOption{WithPath("/dev/hidraw2")}, 2, nil},
+		{"func", []SelectorOption{WithFunc(func(d *Device) bool { return d.GetKeyCount() == 15 })}, 0, nil},
+		{"func-nil", []SelectorOption{WithFunc(nil)}, -1, ErrSelectorInvalid},
+		{"index-negative", []SelectorOption{WithIndex(-1)}, -1, ErrSelectorInvalid},
+		{"option-nil", []SelectorOption{nil}, -1, ErrSelectorInvalid},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dev, err := selectDevice(devices, tc.opts...)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dev != devices[tc.want] {
+				t.Errorf("unexpected device: %s", dev.GetPath())
+			}
+		})
+	}
+
+	if _, err := selectDevice(nil); !errors.Is(err, ErrNoDeviceFound) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
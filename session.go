@@ -0,0 +1,114 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"time"
+)
+
+const sessionLockDimBrightness = 10
+
+var (
+	sessionLockPollInterval = time.Second
+
+	// sessionLockProbe returns the desktop session lock state. It is
+	// replaced in tests.
+	sessionLockProbe = sessionLocked
+)
+
+// SessionLockAction represents the action performed to the Elgato Stream
+// Deck device displays while the desktop session is locked.
+type SessionLockAction byte
+
+// String returns a string representation of the SessionLockAction.
+func (a SessionLockAction) String() string {
+	switch a {
+	case SESSION_LOCK_ACTION_BLANK:
+		return "SESSION_LOCK_ACTION_BLANK"
+	case SESSION_LOCK_ACTION_DIM:
+		return "SESSION_LOCK_ACTION_DIM"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck session lock actions. These constants represent what
+// happens to the device displays while the desktop session is locked.
+const (
+	SESSION_LOCK_ACTION_BLANK SessionLockAction = iota + 1
+	SESSION_LOCK_ACTION_DIM
+)
+
+func (d *Device) sessionLockBrightness(action SessionLockAction) byte {
+	if action == SESSION_LOCK_ACTION_DIM {
+		if b := d.lastBrightness(); b < sessionLockDimBrightness {
+			return b
+		}
+		return sessionLockDimBrightness
+	}
+	return 0
+}
+
+func (d *Device) setSessionLocked(locked bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.sessionLocked = locked
+}
+
+// ListenSessionLock monitors the desktop session lock state and blanks or
+// dims the Elgato Stream Deck device displays while the session is locked,
+// restoring the brightness set with SetBrightness when it is unlocked. It
+// blocks until the context is cancelled, restoring the brightness before
+// returning.
+//
+// This is currently only supported on Linux, polling the LockedHint property
+// of the session with the loginctl command of systemd-logind every second.
+// Systems without loginctl, or without a logind session, return
+// ErrSessionLockNotSupported, describing the failure, as do other platforms.
+func (d *Device) ListenSessionLock(ctx context.Context, action SessionLockAction) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if _, err := sessionLockProbe(ctx); err != nil {
+		return wrapErr(err)
+	}
+
+	ticker := time.NewTicker(sessionLockPollInterval)
+	defer ticker.Stop()
+
+	locked := false
+	for {
+		l, err := sessionLockProbe(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.setSessionLocked(false)
+			return wrapErr(err)
+		}
+
+		if err == nil && l != locked {
+			b := d.lastBrightness()
+			if l {
+				b = d.sessionLockBrightness(action)
+			}
+			d.setSessionLocked(l)
+			if err := d.applyBrightness(b); err != nil {
+				d.setSessionLocked(false)
+				return err
+			}
+			locked = l
+		}
+
+		select {
+		case <-ctx.Done():
+			if locked {
+				d.setSessionLocked(false)
+				return d.applyBrightness(d.lastBrightness())
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+func sessionLocked(ctx context.Context) (bool, error) {
+	id := os.Getenv("XDG_SESSION_ID")
+	if id == "" {
+		id = "auto"
+	}
+
+	out, err := exec.CommandContext(ctx, "loginctl", "show-session", id, "--property=LockedHint", "--value").Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return false, fmt.Errorf("%w: loginctl command not found, systemd-logind is required: %w", ErrSessionLockNotSupported, err)
+		}
+		ee := &exec.ExitError{}
+		if errors.As(err, &ee) && len(ee.Stderr) > 0 {
+			return false, fmt.Errorf("%w: loginctl: %s: %w", ErrSessionLockNotSupported, bytes.TrimSpace(ee.Stderr), err)
+		}
+		return false, fmt.Errorf("%w: loginctl: %w", ErrSessionLockNotSupported, err)
+	}
+
+	switch v := string(bytes.TrimSpace(out)); v {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: unexpected LockedHint value: %q", ErrSessionLockNotSupported, v)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package streamdeck
+
+import (
+	"context"
+)
+
+func sessionLocked(ctx context.Context) (bool, error) {
+	return false, ErrSessionLockNotSupported
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// brightnessDevice is a fake HIDDevice of a Stream Deck MK.2 that records
+// the brightness written to it.
+type brightnessDevice struct {
+	HIDDevice
+	mtx        sync.Mutex
+	brightness []byte
+}
+
+func (b *brightnessDevice) IsOpen() bool {
+	return true
+}
+
+func (b *brightnessDevice) VendorId() uint16 {
+	return elgatoVendorID
+}
+
+func (b *brightnessDevice) ProductId() uint16 {
+	return 0x0080
+}
+
+func (b *brightnessDevice) GetFeatureReportLength() uint16 {
+	return 31
+}
+
+func (b *brightnessDevice) SetFeatureReport(id byte, data []byte) error {
+	if id == 3 && data[0] == 0x08 {
+		b.mtx.Lock()
+		b.brightness = append(b.brightness, data[1])
+		b.mtx.Unlock()
+	}
+	return nil
+}
+
+func (b *brightnessDevice) last() (byte, bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if len(b.brightness) == 0 {
+		return 0, false
+	}
+	return b.brightness[len(b.brightness)-1], true
+}
+
+func TestListenSessionLock(t *testing.T) {
+	interval, probe := sessionLockPollInterval, sessionLockProbe
+	t.Cleanup(func() {
+		sessionLockPollInterval, sessionLockProbe = interval, probe
+	})
+	sessionLockPollInterval = time.Millisecond
+
+	for _, tt := range []struct {
+		action SessionLockAction
+		want   byte
+	}{
+		{SESSION_LOCK_ACTION_BLANK, 0},
+		{SESSION_LOCK_ACTION_DIM, sessionLockDimBrightness},
+	} {
+		t.Run(tt.action.String(), func(t *testing.T) {
+			bd := &brightnessDevice{}
+			d, err := NewDevice(bd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d.open = true
+
+			if err := d.SetBrightness(60); err != nil {
+				t.Fatal(err)
+			}
+
+			mtx := sync.Mutex{}
+			locked := false
+			sessionLockProbe = func(ctx context.Context) (bool, error) {
+				mtx.Lock()
+				defer mtx.Unlock()
+				return locked, nil
+			}
+			setLocked := func(l bool) {
+				mtx.Lock()
+				locked = l
+				mtx.Unlock()
+			}
+			waitBrightness := func(want byte) {
+				t.Helper()
+
+				deadline := time.Now().Add(time.Second)
+				for time.Now().Before(deadline) {
+					if b, ok := bd.last(); ok && b == want {
+						return
+					}
+					time.Sleep(time.Millisecond)
+				}
+				b, _ := bd.last()
+				t.Fatalf("unexpected brightness: got %d, want %d", b, want)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			errs := make(chan error, 1)
+			go func() {
+				errs <- d.ListenSessionLock(ctx, tt.action)
+			}()
+
+			setLocked(true)
+			waitBrightness(tt.want)
+
+			setLocked(false)
+			waitBrightness(60)
+
+			setLocked(true)
+			waitBrightness(tt.want)
+
+			// the brightness is restored when the listener returns locked
+			cancel()
+			if err := <-errs; err != nil {
+				t.Fatal(err)
+			}
+			waitBrightness(60)
+			if b, err := d.GetBrightness(); err != nil || b != 60 {
+				t.Errorf("unexpected cached brightness: %d, %v", b, err)
+			}
+		})
+	}
+}
+
+func TestListenSessionLock_NotSupported(t *testing.T) {
+	probe := sessionLockProbe
+	t.Cleanup(func() {
+		sessionLockProbe = probe
+	})
+
+	d, err := NewDevice(&brightnessDevice{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.open = true
+
+	sessionLockProbe = func(ctx context.Context) (bool, error) {
+		return false, ErrSessionLockNotSupported
+	}
+	if err := d.ListenSessionLock(context.Background(), SESSION_LOCK_ACTION_BLANK); !errors.Is(err, ErrSessionLockNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestListenSessionLock_BrightnessSource(t *testing.T) {
+	interval, probe := sessionLockPollInterval, sessionLockProbe
+	t.Cleanup(func() {
+		sessionLockPollInterval, sessionLockProbe = interval, probe
+	})
+	sessionLockPollInterval = time.Millisecond
+
+	bd := &brightnessDevice{}
+	d, err := NewDevice(bd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.open = true
+
+	locked := atomic.Bool{}
+	sessionLockProbe = func(ctx context.Context) (bool, error) {
+		return locked.Load(), nil
+	}
+	waitBrightness := func(want byte) {
+		t.Helper()
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if b, ok := bd.last(); ok && b == want {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		b, _ := bd.last()
+		t.Fatalf("unexpected brightness: got %d, want %d", b, want)
+	}
+
+	sample := atomic.Uint32{}
+	sample.Store(60)
+	if err := d.BindBrightnessSource(func() byte { return byte(sample.Load()) }, 2*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	defer d.UnbindBrightnessSource()
+	waitBrightness(60)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- d.ListenSessionLock(ctx, SESSION_LOCK_ACTION_BLANK)
+	}()
+
+	// the samples recorded while locked are restored when unlocked
+	locked.Store(true)
+	waitBrightness(0)
+	sample.Store(80)
+	time.Sleep(20 * time.Millisecond)
+	if b, _ := bd.last(); b != 0 {
+		t.Errorf("session lock overridden by the brightness source: %d", b)
+	}
+	locked.Store(false)
+	waitBrightness(80)
+
+	cancel()
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+}
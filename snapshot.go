@@ -0,0 +1,150 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// snapshotPadding is the space around and between the displays in a
+// snapshot, in display pixels.
+const snapshotPadding = 24
+
+// snapshotTouchPointWidth is the width of the touch points in a snapshot, in
+// display pixels.
+const snapshotTouchPointWidth = 16
+
+// snapshotBackground is the color of the device frame in a snapshot.
+var snapshotBackground = color.RGBA{0x20, 0x20, 0x20, 0xff}
+
+// RenderSnapshot composes the images currently shown by the Elgato Stream
+// Deck device into a single image.Image, with the key displays laid out as
+// in the hardware, followed by the info bar and the touch points, or the
+// touch strip. Displays that were not drawn to since the device was opened
+// are rendered black.
+//
+// The images are decoded from the last payloads sent to the device, so they
+// include any scaling and compression artifacts, and are not affected by
+// the brightness.
+func (d *Device) RenderSnapshot() (image.Image, error) {
+	if d.model.keyImageSend == nil && d.model.infoBarImageSend == nil && d.model.touchStripImageSend == nil {
+		return nil, wrapErr(ErrDeviceKeyDisplayNotSupported)
+	}
+
+	d.state.mtx.Lock()
+	keys := make(map[KeyID][]byte, len(d.state.keys))
+	for k, v := range d.state.keys {
+		keys[k] = v
+	}
+	infoBar := d.state.infoBar
+	touchStrip := append([]touchStripState{}, d.state.touchStrip...)
+	touchPoints := make(map[TouchPointID]color.RGBA, len(d.state.touchPoints))
+	for tp, c := range d.state.touchPoints {
+		touchPoints[tp] = c
+	}
+	d.state.mtx.Unlock()
+
+	// rows of displays, stacked vertically and centered horizontally
+	var (
+		deckRect image.Rectangle
+		barRect  image.Rectangle
+	)
+	width := 0
+	if d.model.keyImageSend != nil {
+		cols := int(d.model.keyColumns)
+		rows := d.model.keyRows()
+		deckRect = image.Rect(0, 0,
+			cols*d.model.keyImageRect.Dx()+(cols-1)*d.model.keyImageGap.X,
+			rows*d.model.keyImageRect.Dy()+(rows-1)*d.model.keyImageGap.Y,
+		)
+		width = deckRect.Dx()
+	}
+	if d.model.infoBarImageSend != nil {
+		barRect = image.Rect(0, 0, d.model.infoBarImageRect.Dx(), d.model.infoBarImageRect.Dy())
+		if d.model.touchPointCount > 0 {
+			barRect.Max.X += 2 * (snapshotTouchPointWidth + snapshotPadding)
+		}
+	} else if d.model.touchStripImageSend != nil {
+		barRect = image.Rect(0, 0, d.model.touchStripImageRect.Dx(), d.model.touchStripImageRect.Dy())
+	}
+	width = max(width, barRect.Dx())
+
+	height := snapshotPadding
+	if !deckRect.Empty() {
+		height += deckRect.Dy() + snapshotPadding
+	}
+	if !barRect.Empty() {
+		height += barRect.Dy() + snapshotPadding
+	}
+
+	rv := image.NewRGBA(image.Rect(0, 0, width+2*snapshotPadding, height))
+	draw.Draw(rv, rv.Rect, image.NewUniform(snapshotBackground), image.Point{}, draw.Src)
+
+	y := snapshotPadding
+	if !deckRect.Empty() {
+		origin := image.Pt(snapshotPadding+(width-deckRect.Dx())/2, y)
+		for key := KEY_1; key < KEY_1+KeyID(d.model.keyCount); key++ {
+			r := d.model.deckKeyRect(key).Add(origin)
+			img := decodeSnapshotImage(keys[key], d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform)
+			draw.Draw(rv, r, img, img.Rect.Min, draw.Src)
+		}
+		y += deckRect.Dy() + snapshotPadding
+	}
+
+	if barRect.Empty() {
+		return rv, nil
+	}
+
+	origin := image.Pt(snapshotPadding+(width-barRect.Dx())/2, y)
+	if d.model.infoBarImageSend != nil {
+		x := origin.X
+		if d.model.touchPointCount > 0 {
+			x += snapshotTouchPointWidth + snapshotPadding
+		}
+		r := image.Rect(x, y, x+d.model.infoBarImageRect.Dx(), y+d.model.infoBarImageRect.Dy())
+		img := decodeSnapshotImage(infoBar, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform)
+		draw.Draw(rv, r, img, img.Rect.Min, draw.Src)
+
+		// touch points are rendered to the sides of the info bar, as in the
+		// hardware
+		for tp := TOUCH_POINT_1; tp < TOUCH_POINT_1+TouchPointID(d.model.touchPointCount); tp++ {
+			tx := origin.X
+			if tp > TOUCH_POINT_1 {
+				tx = r.Max.X + snapshotPadding
+			}
+			c, found := touchPoints[tp]
+			if !found {
+				c = color.RGBA{A: 0xff}
+			}
+			tr := image.Rect(tx, y, tx+snapshotTouchPointWidth, r.Max.Y)
+			draw.Draw(rv, tr, image.NewUniform(c), image.Point{}, draw.Src)
+		}
+		return rv, nil
+	}
+
+	draw.Draw(rv, barRect.Add(origin), image.Black, image.Point{}, draw.Src)
+	for _, ts := range touchStrip {
+		if img, err := decodeImage(ts.Data, ts.Rect, d.model.touchStripImageFormat, d.model.touchStripImageTransform); err == nil {
+			draw.Draw(rv, ts.Rect.Add(origin), img, ts.Rect.Min, draw.Src)
+		}
+	}
+	return rv, nil
+}
+
+// decodeSnapshotImage decodes a payload sent to a display, or returns a black
+// image if the payload is missing or invalid.
+func decodeSnapshotImage(data []byte, rect image.Rectangle, ifmt imageFormat, transform imageTransform) *image.RGBA {
+	if data != nil {
+		if img, err := decodeImage(data, rect, ifmt, transform); err == nil {
+			return img
+		}
+	}
+
+	rv := image.NewRGBA(rect)
+	draw.Draw(rv, rv.Rect, image.Black, image.Point{}, draw.Src)
+	return rv
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func assertSnapshotColor(t *testing.T, img image.Image, p image.Point, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(p.X, p.Y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at %s channel %d: got %d, want %d", p, i, v[0], v[1])
+		}
+	}
+}
+
+func snapshotPayload(t *testing.T, c color.Color, rect image.Rectangle, ifmt imageFormat, transform imageTransform) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(rect)
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+	data, err := genImage(img, rect, ifmt, transform, ImageOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRenderSnapshot(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	d := &Device{model: models[0x0084]}
+	d.state.setKey(KEY_6, snapshotPayload(t, red, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform))
+
+	seg := image.Rect(200, 0, 400, 100)
+	d.state.setTouchStrip(seg, snapshotPayload(t, blue, image.Rect(0, 0, seg.Dx(), seg.Dy()), d.model.touchStripImageFormat, d.model.touchStripImageTransform))
+
+	img, err := d.RenderSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 4x2 keys of 120px with 40px gaps, above the 800x100 touch strip
+	if b := img.Bounds(); b.Dx() != 800+2*snapshotPadding || b.Dy() != 280+100+3*snapshotPadding {
+		t.Errorf("bad bounds: %s", b)
+	}
+	origin := image.Pt(snapshotPadding+(800-620)/2, snapshotPadding)
+	assertSnapshotColor(t, img, image.Pt(2, 2), snapshotBackground)
+	assertSnapshotColor(t, img, origin.Add(image.Pt(60, 60)), color.RGBA{})
+	assertSnapshotColor(t, img, origin.Add(image.Pt(160+60, 160+60)), red)
+	assertSnapshotColor(t, img, origin.Add(image.Pt(140, 60)), snapshotBackground)
+
+	stripY := 2*snapshotPadding + 280
+	assertSnapshotColor(t, img, image.Pt(snapshotPadding+100, stripY+50), color.RGBA{})
+	assertSnapshotColor(t, img, image.Pt(snapshotPadding+300, stripY+50), blue)
+}
+
+func TestRenderSnapshot_InfoBar(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	d := &Device{model: models[0x009a]}
+	d.state.setInfoBar(snapshotPayload(t, red, d.model.infoBarImageRect, d.model.infoBarImageFormat, d.model.infoBarImageTransform))
+	d.state.setTouchPoint(TOUCH_POINT_2, color.RGBA{G: 0xff, A: 0xff})
+
+	img, err := d.RenderSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the info bar and touch points are narrower than the 4x2 keys
+	y := 2*snapshotPadding + 224 + 29
+	left := snapshotPadding + (480-(248+2*(snapshotTouchPointWidth+snapshotPadding)))/2
+	assertSnapshotColor(t, img, image.Pt(left+snapshotTouchPointWidth/2, y), color.RGBA{})
+	assertSnapshotColor(t, img, image.Pt(left+snapshotTouchPointWidth+snapshotPadding+124, y), red)
+	assertSnapshotColor(t, img, image.Pt(left+snapshotTouchPointWidth+2*snapshotPadding+248+snapshotTouchPointWidth/2, y), color.RGBA{G: 0xff})
+}
+
+func TestRenderSnapshot_NotSupported(t *testing.T) {
+	d := &Device{model: models[0x0086]}
+	if _, err := d.RenderSnapshot(); !errors.Is(err, ErrDeviceKeyDisplayNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"os"
+	"sync"
+)
+
+// displayState tracks the encoded payloads last sent to each of the Elgato
+// Stream Deck device displays, so that they can be restored later without
+// re-encoding.
+type displayState struct {
+	mtx         sync.Mutex
+	keys        map[KeyID][]byte
+	infoBar     []byte
+	touchStrip  []touchStripState
+	touchPoints map[TouchPointID]color.RGBA
+	changed     uint64
+}
+
+type touchStripState struct {
+	Rect image.Rectangle
+	Data []byte
+}
+
+// savedState is the serializable representation of a displayState.
+type savedState struct {
+	Model       string
+	Brightness  *byte                    `json:",omitempty"`
+	Keys        map[KeyID][]byte         `json:",omitempty"`
+	InfoBar     []byte                   `json:",omitempty"`
+	TouchStrip  []touchStripState        `json:",omitempty"`
+	TouchPoints map[TouchPointID][4]byte `json:",omitempty"`
+}
+
+func (s *displayState) setKey(key KeyID, data []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.keys == nil {
+		s.keys = map[KeyID][]byte{}
+	}
+	s.keys[key] = data
+	s.changed++
+}
+
+func (s *displayState) setInfoBar(data []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.infoBar = data
+	s.changed++
+}
+
+func (s *displayState) setTouchStrip(rect image.Rectangle, data []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// writes completely covered by the new one are not needed anymore
+	ts := []touchStripState{}
+	for _, t := range s.touchStrip {
+		if !t.Rect.In(rect) {
+			ts = append(ts, t)
+		}
+	}
+	s.touchStrip = append(ts, touchStripState{
+		Rect: rect,
+		Data: data,
+	})
+	s.changed++
+}
+
+func (s *displayState) setTouchPoint(tp TouchPointID, c color.Color) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.touchPoints == nil {
+		s.touchPoints = map[TouchPointID]color.RGBA{}
+	}
+	s.touchPoints[tp] = color.RGBAModel.Convert(c).(color.RGBA)
+	s.changed++
+}
+
+func (s *displayState) generation() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.changed
+}
+
+func (d *Device) saveState() *savedState {
+	d.state.mtx.Lock()
+	defer d.state.mtx.Unlock()
+
+	rv := &savedState{
+		Model:      d.model.id,
+		InfoBar:    d.state.infoBar,
+		TouchStrip: append([]touchStripState{}, d.state.touchStrip...),
+	}
+
+	d.mtx.Lock()
+	if d.brightnessValid {
+		b := d.brightness
+		rv.Brightness = &b
+	}
+	d.mtx.Unlock()
+
+	if len(d.state.keys) > 0 {
+		rv.Keys = map[KeyID][]byte{}
+		for k, v := range d.state.keys {
+			rv.Keys[k] = v
+		}
+	}
+
+	if len(d.state.touchPoints) > 0 {
+		rv.TouchPoints = map[TouchPointID][4]byte{}
+		for tp, c := range d.state.touchPoints {
+			rv.TouchPoints[tp] = [4]byte{c.R, c.G, c.B, c.A}
+		}
+	}
+	return rv
+}
+
+func (d *Device) restoreState(st *savedState) error {
+	if st.Model != d.model.id {
+		return fmt.Errorf("streamdeck: %w: saved for a different model: %s", ErrStateInvalid, st.Model)
+	}
+
+	if st.Brightness != nil {
+		if err := d.SetBrightness(*st.Brightness); err != nil {
+			return err
+		}
+	}
+
+	for key, data := range st.Keys {
+		if err := d.validateKey(key); err != nil {
+			return err
+		}
+		if err := d.validateKeyDisplay(); err != nil {
+			return err
+		}
+		if err := d.sendKeyImage(key, data); err != nil {
+			return err
+		}
+	}
+
+	if st.InfoBar != nil && d.model.infoBarImageSend != nil {
+		if err := d.sendInfoBarImage(st.InfoBar); err != nil {
+			return err
+		}
+	}
+
+	if d.model.touchStripImageSend != nil {
+		for _, ts := range st.TouchStrip {
+			if err := d.validateTouchStripRectangle(ts.Rect); err != nil {
+				return err
+			}
+			if err := d.sendTouchStripImage(ts.Data, ts.Rect); err != nil {
+				return err
+			}
+		}
+	}
+
+	for tp, c := range st.TouchPoints {
+		if err := d.SetTouchPointColor(tp, color.RGBA{c[0], c[1], c[2], c[3]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeStateFile(name string, st *savedState) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
+
+// SaveState writes the current visual state of the Elgato Stream Deck device
+// to an io.Writer, including the key, info bar and touch strip images, the
+// touch point colors and the brightness. Images are stored already encoded,
+// so that restoring them with LoadState is fast.
+func (d *Device) SaveState(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(d.saveState()); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// SaveStateToFile writes the current visual state of the Elgato Stream Deck
+// device to a file, as done by SaveState. The file is replaced atomically.
+func (d *Device) SaveStateToFile(name string) error {
+	if err := writeStateFile(name, d.saveState()); err != nil {
+		return wrapErr(err)
+	}
+	return nil
+}
+
+// LoadState restores a visual state of the Elgato Stream Deck device from an
+// io.Reader, as written by SaveState. The state must have been saved from a
+// device of the same model. Displays not included in the state are kept
+// untouched.
+func (d *Device) LoadState(r io.Reader) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	st := &savedState{}
+	if err := json.NewDecoder(r).Decode(st); err != nil {
+		return fmt.Errorf("streamdeck: %w: %w", ErrStateInvalid, err)
+	}
+	return d.restoreState(st)
+}
+
+// LoadStateFromFile restores a visual state of the Elgato Stream Deck device
+// from a file, as written by SaveStateToFile.
+func (d *Device) LoadStateFromFile(name string) error {
+	fp, err := os.Open(name)
+	if err != nil {
+		return wrapErr(err)
+	}
+	defer fp.Close()
+
+	return d.LoadState(fp)
+}
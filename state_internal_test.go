@@ -0,0 +1,63 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"encoding/json"
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestDisplayState_TouchStripPruning(t *testing.T) {
+	s := displayState{}
+	s.setTouchStrip(image.Rect(0, 0, 200, 100), []byte{1})
+	s.setTouchStrip(image.Rect(200, 0, 400, 100), []byte{2})
+	s.setTouchStrip(image.Rect(0, 0, 300, 100), []byte{3})
+
+	if l := len(s.touchStrip); l != 2 {
+		t.Fatalf("expected 2 touch strip writes, got %d", l)
+	}
+	if s.touchStrip[0].Data[0] != 2 || s.touchStrip[1].Data[0] != 3 {
+		t.Error("unexpected touch strip writes order")
+	}
+
+	s.setTouchStrip(image.Rect(0, 0, 800, 100), []byte{4})
+	if l := len(s.touchStrip); l != 1 {
+		t.Fatalf("expected 1 touch strip write, got %d", l)
+	}
+}
+
+func TestSavedState_JSON(t *testing.T) {
+	b := byte(50)
+	st := &savedState{
+		Model:      "mk2",
+		Brightness: &b,
+		Keys: map[KeyID][]byte{
+			KEY_1:  {1, 2, 3},
+			KEY_15: {4, 5, 6},
+		},
+		TouchStrip: []touchStripState{
+			{Rect: image.Rect(0, 0, 10, 10), Data: []byte{7}},
+		},
+		TouchPoints: map[TouchPointID][4]byte{
+			TOUCH_POINT_2: {255, 0, 0, 255},
+		},
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		t.Fatalf("failed to marshal state: %v", err)
+	}
+
+	st2 := &savedState{}
+	if err := json.Unmarshal(data, st2); err != nil {
+		t.Fatalf("failed to unmarshal state: %v", err)
+	}
+
+	if !reflect.DeepEqual(st, st2) {
+		t.Errorf("state doesn't match after round trip: %+v != %+v", st, st2)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestSaveLoadState(t *testing.T) {
+	dev, _, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetKeyImage(streamdeck.KEY_3, testImage(rect)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetInfoBarColor(color.RGBA{G: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetTouchPointColor(streamdeck.TOUCH_POINT_1, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetBrightness(40); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := bytes.Buffer{}
+	if err := dev.SaveState(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	other, m, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	if err := other.LoadState(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_3), 2, 2, color.RGBA{R: 0xff, B: 0xff})
+	assertColor(t, m.InfoBarImage(), 2, 2, color.RGBA{G: 0xff})
+	if c := m.TouchPointColor(streamdeck.TOUCH_POINT_1); c != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("bad touch point color: %v", c)
+	}
+	if b := m.Brightness(); b != 40 {
+		t.Errorf("bad brightness: %d", b)
+	}
+
+	mk2, _, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mk2.Close()
+
+	if err := mk2.LoadState(bytes.NewReader(buf.Bytes())); !errors.Is(err, streamdeck.ErrStateInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mk2.LoadState(bytes.NewReader([]byte("bola"))); !errors.Is(err, streamdeck.ErrStateInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package streamdecktest provides utilities to regression-test the rendering
+// of applications built with the streamdeck package, comparing the images
+// generated for Elgato Stream Deck displays against golden files.
+//
+// Golden files are PNG images stored in the testdata directory of the
+// package being tested. Setting the STREAMDECKTEST_UPDATE environment
+// variable to a non-empty value makes AssertGolden write the golden files
+// instead of comparing against them.
+//
+// The Assert*Golden helpers compare the contents of the displays of a fake
+// device from the mock package, that decodes the images sent by the
+// streamdeck package exactly as a real device would receive them.
+package streamdecktest
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+// UpdateEnv is the name of the environment variable that enables updating
+// golden files.
+const UpdateEnv = "STREAMDECKTEST_UPDATE"
+
+// Tolerance represents how much two images may differ and still be
+// considered equal. The zero value requires the images to be identical.
+//
+// Lossy encoding formats (like JPEG, used by most Elgato Stream Deck models)
+// produce small differences between encoder implementations and versions,
+// and comparisons of images decoded from device payloads should allow some
+// tolerance.
+type Tolerance struct {
+	// Channel is the maximum difference allowed for each color channel of
+	// each pixel, in the [0, 255] range.
+	Channel uint8
+
+	// Pixels is the fraction of pixels, in the [0, 1] range, allowed to
+	// differ more than the channel tolerance.
+	Pixels float64
+}
+
+// DefaultTolerance is a Tolerance suitable for images decoded from JPEG
+// payloads.
+var DefaultTolerance = Tolerance{
+	Channel: 16,
+	Pixels:  0.01,
+}
+
+// Compare compares two images with the given tolerance, returning an error
+// describing the differences if they are not considered equal.
+func Compare(got image.Image, want image.Image, tol Tolerance) error {
+	if got == nil || want == nil {
+		return errors.New("streamdecktest: image is nil")
+	}
+
+	gb := got.Bounds()
+	wb := want.Bounds()
+	if gb.Dx() != wb.Dx() || gb.Dy() != wb.Dy() {
+		return fmt.Errorf("streamdecktest: image sizes differ: got %dx%d, want %dx%d", gb.Dx(), gb.Dy(), wb.Dx(), wb.Dy())
+	}
+
+	limit := uint32(tol.Channel) * 0x101
+	diff := 0
+	for y := 0; y < gb.Dy(); y++ {
+		for x := 0; x < gb.Dx(); x++ {
+			r1, g1, b1, a1 := got.At(gb.Min.X+x, gb.Min.Y+y).RGBA()
+			r2, g2, b2, a2 := want.At(wb.Min.X+x, wb.Min.Y+y).RGBA()
+			if absDiff(r1, r2) > limit || absDiff(g1, g2) > limit || absDiff(b1, b2) > limit || absDiff(a1, a2) > limit {
+				diff++
+			}
+		}
+	}
+
+	if total := gb.Dx() * gb.Dy(); float64(diff) > tol.Pixels*float64(total) {
+		return fmt.Errorf("streamdecktest: %d of %d pixels differ", diff, total)
+	}
+	return nil
+}
+
+func absDiff(a uint32, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// GoldenFile returns the path of the golden file with the given name.
+func GoldenFile(name string) string {
+	return filepath.Join("testdata", name+".png")
+}
+
+// ReadGolden reads the golden file with the given name.
+func ReadGolden(name string) (image.Image, error) {
+	fp, err := os.Open(GoldenFile(name))
+	if err != nil {
+		return nil, err
+	}
+	defer fp.Close()
+
+	return png.Decode(fp)
+}
+
+// WriteGolden writes an image to the golden file with the given name.
+func WriteGolden(name string, img image.Image) error {
+	fn := GoldenFile(name)
+	if err := os.MkdirAll(filepath.Dir(fn), 0o755); err != nil {
+		return err
+	}
+
+	fp, err := os.Create(fn)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(fp, img); err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+// AssertGolden compares an image against the golden file with the given
+// name, failing the test if they differ more than the provided tolerance. If
+// the STREAMDECKTEST_UPDATE environment variable is set, the golden file is
+// written instead.
+func AssertGolden(t testing.TB, name string, got image.Image, tol Tolerance) {
+	t.Helper()
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := WriteGolden(name, got); err != nil {
+			t.Fatalf("streamdecktest: failed to write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := ReadGolden(name)
+	if err != nil {
+		t.Fatalf("streamdecktest: failed to read golden file (set %s=1 to create it): %v", UpdateEnv, err)
+	}
+
+	if err := Compare(got, want, tol); err != nil {
+		t.Errorf("%s: %v", GoldenFile(name), err)
+	}
+}
+
+func assertSurfaceGolden(t testing.TB, name string, surface string, got image.Image, tol Tolerance) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("streamdecktest: nothing was drawn to the %s display", surface)
+	}
+	AssertGolden(t, name, got, tol)
+}
+
+// AssertKeyGolden compares the image currently drawn to a key display of a
+// fake device against the golden file with the given name.
+func AssertKeyGolden(t testing.TB, name string, dev *mock.Device, key streamdeck.KeyID, tol Tolerance) {
+	t.Helper()
+	assertSurfaceGolden(t, name, key.String(), dev.KeyImage(key), tol)
+}
+
+// AssertInfoBarGolden compares the image currently drawn to the info bar
+// display of a fake device against the golden file with the given name.
+func AssertInfoBarGolden(t testing.TB, name string, dev *mock.Device, tol Tolerance) {
+	t.Helper()
+	assertSurfaceGolden(t, name, "info bar", dev.InfoBarImage(), tol)
+}
+
+// AssertTouchStripGolden compares the contents of the touch strip display of
+// a fake device against the golden file with the given name.
+func AssertTouchStripGolden(t testing.TB, name string, dev *mock.Device, tol Tolerance) {
+	t.Helper()
+	assertSurfaceGolden(t, name, "touch strip", dev.TouchStripImage(), tol)
+}
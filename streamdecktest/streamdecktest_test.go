@@ -0,0 +1,73 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdecktest
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"testing"
+)
+
+func createTestImage(rect image.Rectangle, c color.Color) *image.RGBA {
+	img := image.NewRGBA(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	a := createTestImage(image.Rect(0, 0, 10, 10), color.RGBA{100, 100, 100, 255})
+	b := createTestImage(image.Rect(10, 10, 20, 20), color.RGBA{110, 100, 100, 255})
+
+	if err := Compare(a, a, Tolerance{}); err != nil {
+		t.Errorf("identical images differ: %v", err)
+	}
+	if err := Compare(a, b, Tolerance{}); err == nil {
+		t.Error("different images are equal")
+	}
+	if err := Compare(a, b, Tolerance{Channel: 10}); err != nil {
+		t.Errorf("images should be equal with channel tolerance: %v", err)
+	}
+
+	b.Set(15, 15, color.White)
+	if err := Compare(a, b, Tolerance{Channel: 10}); err == nil {
+		t.Error("images should differ")
+	}
+	if err := Compare(a, b, Tolerance{Channel: 10, Pixels: 0.01}); err != nil {
+		t.Errorf("images should be equal with pixel tolerance: %v", err)
+	}
+}
+
+func TestCompare_Size(t *testing.T) {
+	a := createTestImage(image.Rect(0, 0, 10, 10), color.Black)
+	b := createTestImage(image.Rect(0, 0, 10, 11), color.Black)
+
+	if err := Compare(a, b, DefaultTolerance); err == nil {
+		t.Error("images with different sizes should differ")
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	img := createTestImage(image.Rect(0, 0, 10, 10), color.RGBA{255, 0, 0, 255})
+
+	t.Setenv(UpdateEnv, "1")
+	AssertGolden(t, "red", img, Tolerance{})
+
+	t.Setenv(UpdateEnv, "")
+	AssertGolden(t, "red", img, Tolerance{})
+}
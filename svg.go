@@ -0,0 +1,161 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"sync"
+)
+
+// SVGRasterizer rasterizes an SVG document from an io.Reader to an image of
+// the given size. The document is scaled to fit the image, keeping its aspect
+// ratio, and the remaining area is transparent.
+type SVGRasterizer func(r io.Reader, size image.Point) (*image.RGBA, error)
+
+var (
+	svgRasterizerMtx sync.RWMutex
+	svgRasterizer    SVGRasterizer
+)
+
+// RegisterSVGRasterizer sets the rasterizer used to draw SVG documents and
+// icons. This module does not include a rasterizer, to keep its dependencies
+// to a minimum, and ErrSVGNotSupported is returned until one is registered.
+// The one provided by the rafaelmartins.com/p/streamdeck/svg module is
+// registered by importing it:
+//
+//	import _ "rafaelmartins.com/p/streamdeck/svg"
+func RegisterSVGRasterizer(fn SVGRasterizer) {
+	svgRasterizerMtx.Lock()
+	defer svgRasterizerMtx.Unlock()
+
+	svgRasterizer = fn
+}
+
+// renderSVG rasterizes an SVG document to an image with the size of the given
+// rectangle, with the registered rasterizer.
+func renderSVG(r io.Reader, rect image.Rectangle) (*image.RGBA, error) {
+	svgRasterizerMtx.RLock()
+	fn := svgRasterizer
+	svgRasterizerMtx.RUnlock()
+	if fn == nil {
+		return nil, ErrSVGNotSupported
+	}
+
+	rv, err := fn(r, rect.Size())
+	if err != nil {
+		if errors.Is(err, ErrImageInvalid) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrImageInvalid, err)
+	}
+	if rv == nil || rv.Rect.Size() != rect.Size() {
+		return nil, fmt.Errorf("%w: svg rasterized with wrong size", ErrImageInvalid)
+	}
+	return rv, nil
+}
+
+// RasterizeSVG rasterizes an SVG document from an io.Reader to an image of
+// the given size, with the rasterizer set with RegisterSVGRasterizer.
+func RasterizeSVG(r io.Reader, size image.Point) (*image.RGBA, error) {
+	if r == nil || size.X <= 0 || size.Y <= 0 {
+		return nil, wrapErr(ErrImageInvalid)
+	}
+
+	rv, err := renderSVG(r, image.Rectangle{Max: size})
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return rv, nil
+}
+
+// SetKeyImageFromSVG draws an SVG document from an io.Reader to an Elgato
+// Stream Deck key background display. The document is rasterized at the
+// native resolution of the display, keeping its aspect ratio.
+func (d *Device) SetKeyImageFromSVG(key KeyID, r io.Reader) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if r == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	img, err := renderSVG(r, d.model.keyImageRect)
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setKeyImage(key, img)
+}
+
+// SetInfoBarImageFromSVG draws an SVG document from an io.Reader to the info
+// bar display available on some Elgato Stream Deck models. The document is
+// rasterized at the native resolution of the display, keeping its aspect
+// ratio.
+func (d *Device) SetInfoBarImageFromSVG(r io.Reader) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateInfoBar(); err != nil {
+		return err
+	}
+
+	if r == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	img, err := renderSVG(r, d.model.infoBarImageRect)
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setInfoBarImage(img)
+}
+
+// SetTouchStripImageFromSVG draws an SVG document from an io.Reader to the
+// touch strip display available on some Elgato Stream Deck models. The
+// document is rasterized at the native resolution of the display, keeping its
+// aspect ratio.
+func (d *Device) SetTouchStripImageFromSVG(r io.Reader) error {
+	return d.SetTouchStripImageFromSVGWithRectangle(r, d.model.touchStripImageRect)
+}
+
+// SetTouchStripImageFromSVGWithRectangle draws an SVG document from an
+// io.Reader to a rectangle of the touch strip display available on some
+// Elgato Stream Deck models. The document is rasterized at the size of the
+// provided rectangle, keeping its aspect ratio.
+func (d *Device) SetTouchStripImageFromSVGWithRectangle(r io.Reader, rect image.Rectangle) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	if r == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	img, err := renderSVG(r, rect)
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setTouchStripImage(img, &rect)
+}
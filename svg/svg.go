@@ -0,0 +1,60 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package svg rasterizes SVG documents for Elgato Stream Deck displays, and
+// registers itself with streamdeck.RegisterSVGRasterizer, enabling the
+// RasterizeSVG, RasterizeIcon and Device *FromSVG and key icon functions of
+// the streamdeck package. It is usually imported for its side effects:
+//
+//	import _ "rafaelmartins.com/p/streamdeck/svg"
+//
+// It lives in its own module, to keep the dependencies of the streamdeck
+// module to a minimum.
+package svg
+
+import (
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+func init() {
+	streamdeck.RegisterSVGRasterizer(Rasterize)
+}
+
+// Rasterize rasterizes an SVG document from an io.Reader to an image of the
+// given size. The document is scaled to fit the image, keeping its aspect
+// ratio, and the remaining area is transparent.
+func Rasterize(r io.Reader, size image.Point) (*image.RGBA, error) {
+	if r == nil || size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("svg: %w", streamdeck.ErrImageInvalid)
+	}
+
+	icon, err := oksvg.ReadIconStream(r, oksvg.IgnoreErrorMode)
+	if err != nil {
+		return nil, fmt.Errorf("svg: %w: %w", streamdeck.ErrImageInvalid, err)
+	}
+
+	vb := icon.ViewBox
+	if vb.W <= 0 || vb.H <= 0 {
+		return nil, fmt.Errorf("svg: %w: svg without dimensions", streamdeck.ErrImageInvalid)
+	}
+
+	w, h := float64(size.X), float64(size.Y)
+	if vb.W/vb.H > w/h {
+		h = w * vb.H / vb.W
+	} else {
+		w = h * vb.W / vb.H
+	}
+	icon.SetTarget((float64(size.X)-w)/2, (float64(size.Y)-h)/2, w, h)
+
+	rv := image.NewRGBA(image.Rectangle{Max: size})
+	scanner := rasterx.NewScannerGV(size.X, size.Y, rv, rv.Bounds())
+	icon.Draw(rasterx.NewDasher(size.X, size.Y, scanner), 1)
+	return rv, nil
+}
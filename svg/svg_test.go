@@ -0,0 +1,128 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package svg
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+const testSVG = `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 20 10">
+	<rect x="0" y="0" width="10" height="10" fill="#ff0000"/>
+	<rect x="10" y="0" width="10" height="10" fill="#0000ff"/>
+</svg>`
+
+func countPixels(img *image.RGBA, rect image.Rectangle, c color.Color) int {
+	rv := 0
+	r1, g1, b1, _ := c.RGBA()
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r2, g2, b2, _ := img.At(x, y).RGBA()
+			if r1 == r2 && g1 == g2 && b1 == b2 {
+				rv++
+			}
+		}
+	}
+	return rv
+}
+
+func TestRasterize(t *testing.T) {
+	img, err := Rasterize(strings.NewReader(testSVG), image.Pt(100, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 100, 100) {
+		t.Fatalf("bad bounds: %s", img.Bounds())
+	}
+
+	// 2:1 document centered vertically
+	if countPixels(img, image.Rect(0, 0, 100, 20), color.Black) != 100*20 {
+		t.Error("top area should be empty")
+	}
+	if countPixels(img, image.Rect(5, 30, 45, 70), color.RGBA{R: 0xff, A: 0xff}) != 40*40 {
+		t.Error("left half should be red")
+	}
+	if countPixels(img, image.Rect(55, 30, 95, 70), color.RGBA{B: 0xff, A: 0xff}) != 40*40 {
+		t.Error("right half should be blue")
+	}
+
+	if _, err := Rasterize(strings.NewReader("bola"), image.Pt(100, 100)); !errors.Is(err, streamdeck.ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := Rasterize(strings.NewReader(testSVG), image.Point{}); !errors.Is(err, streamdeck.ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	img, err := streamdeck.RasterizeSVG(strings.NewReader(testSVG), image.Pt(100, 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countPixels(img, image.Rect(0, 0, 50, 50), color.RGBA{R: 0xff, A: 0xff}) != 50*50 {
+		t.Error("left half should be red")
+	}
+
+	dev, m, err := mock.Open("neo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetKeyImageFromSVG(streamdeck.KEY_1, strings.NewReader(testSVG)); err != nil {
+		t.Fatal(err)
+	}
+	if err := dev.SetInfoBarImageFromSVG(strings.NewReader(testSVG)); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 || w[0].Key != streamdeck.KEY_1 || w[1].Surface != mock.SURFACE_INFO_BAR {
+		t.Errorf("bad writes: %+v", w)
+	}
+}
+
+func TestKeyIcon(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	if err := dev.SetKeyIcon(streamdeck.KEY_1, "builtin:stop", color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	img := m.KeyImage(streamdeck.KEY_1)
+	for _, p := range []struct {
+		x    int
+		y    int
+		want color.RGBA
+	}{
+		{36, 36, color.RGBA{R: 0xff, A: 0xff}},
+		{2, 2, color.RGBA{A: 0xff}},
+	} {
+		r, g, b, _ := img.At(p.x, p.y).RGBA()
+		for i, v := range [][2]uint32{{r >> 8, uint32(p.want.R)}, {g >> 8, uint32(p.want.G)}, {b >> 8, uint32(p.want.B)}} {
+			if d := int(v[0]) - int(v[1]); d < -16 || d > 16 {
+				t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", p.x, p.y, i, v[0], v[1])
+			}
+		}
+	}
+
+	if err := dev.SetKeyIconWithLabel(streamdeck.KEY_2, "builtin:play", nil, "Play", streamdeck.TextOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 || w[1].Key != streamdeck.KEY_2 {
+		t.Errorf("bad writes: %+v", w)
+	}
+
+	if err := dev.SetKeyIcon(streamdeck.KEY_1, "mdi:volume-high", nil); !errors.Is(err, streamdeck.ErrIconInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"strings"
+	"testing"
+)
+
+// testSVGRasterizer ignores the document, and paints a white square in the
+// middle of the image.
+func testSVGRasterizer(r io.Reader, size image.Point) (*image.RGBA, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(string(data), "<svg") {
+		return nil, errors.New("not a svg document")
+	}
+
+	rv := image.NewRGBA(image.Rectangle{Max: size})
+	draw.Draw(rv, image.Rect(size.X/4, size.Y/4, size.X*3/4, size.Y*3/4), image.White, image.Point{}, draw.Src)
+	return rv, nil
+}
+
+func setSVGRasterizer(t *testing.T, fn SVGRasterizer) {
+	t.Helper()
+
+	svgRasterizerMtx.Lock()
+	old := svgRasterizer
+	svgRasterizer = fn
+	svgRasterizerMtx.Unlock()
+
+	t.Cleanup(func() {
+		RegisterSVGRasterizer(old)
+	})
+}
+
+func TestRenderSVG(t *testing.T) {
+	rect := image.Rect(10, 10, 110, 60)
+
+	setSVGRasterizer(t, nil)
+	if _, err := renderSVG(strings.NewReader("<svg/>"), rect); !errors.Is(err, ErrSVGNotSupported) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	RegisterSVGRasterizer(testSVGRasterizer)
+	img, err := renderSVG(strings.NewReader("<svg/>"), rect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 100, 50) {
+		t.Fatalf("bad bounds: %s", img.Bounds())
+	}
+	if countPixels(img, image.Rect(25, 12, 75, 37), color.White) != 50*25 {
+		t.Error("middle area should be painted")
+	}
+
+	if _, err := renderSVG(strings.NewReader("bola"), rect); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	RegisterSVGRasterizer(func(r io.Reader, size image.Point) (*image.RGBA, error) {
+		return image.NewRGBA(image.Rect(0, 0, 1, 1)), nil
+	})
+	if _, err := renderSVG(strings.NewReader("<svg/>"), rect); !errors.Is(err, ErrImageInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
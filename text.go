@@ -0,0 +1,391 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// TextAlignment represents the horizontal alignment of text lines.
+type TextAlignment byte
+
+// Text horizontal alignments.
+const (
+	TEXT_ALIGNMENT_CENTER TextAlignment = iota
+	TEXT_ALIGNMENT_LEFT
+	TEXT_ALIGNMENT_RIGHT
+)
+
+// TextVerticalAlignment represents the vertical alignment of a block of text.
+type TextVerticalAlignment byte
+
+// Text vertical alignments.
+const (
+	TEXT_VERTICAL_ALIGNMENT_MIDDLE TextVerticalAlignment = iota
+	TEXT_VERTICAL_ALIGNMENT_TOP
+	TEXT_VERTICAL_ALIGNMENT_BOTTOM
+)
+
+// TextOptions represents the options used to render text to Elgato Stream
+// Deck displays. The zero value renders white text, centered and scaled to
+// fit, over a black background, using the default font.
+type TextOptions struct {
+	// Fonts are the fonts used to render the text, in order of preference.
+	// Glyphs missing from a font are rendered with the next one including
+	// them, and the bundled default font is always used as the last
+	// fallback. If empty, the fonts set with Device.SetFonts are used.
+	Fonts []*opentype.Font
+
+	// Size is the font size, in pixels. If zero, the largest size between
+	// MinSize and MaxSize that fits the display is used.
+	Size float64
+
+	// MinSize and MaxSize are the bounds used to fit the text. If zero,
+	// defaults to 8 pixels and half the height of the text area,
+	// respectively.
+	MinSize float64
+	MaxSize float64
+
+	// Foreground and Background are the text and background colors. If nil,
+	// defaults to white and black, respectively.
+	Foreground color.Color
+	Background color.Color
+
+	// Alignment and VerticalAlignment define how the text is positioned in
+	// the text area.
+	Alignment         TextAlignment
+	VerticalAlignment TextVerticalAlignment
+
+	// Padding is the space, in pixels, kept empty around the text area.
+	Padding int
+
+	// LineSpacing is a factor applied to the height of the text lines. If
+	// zero, defaults to 1.
+	LineSpacing float64
+
+	// Icon is an optional image rendered above the text, scaled to fit a
+	// fraction of the display height defined by IconRatio (defaults to 0.6).
+	// If the text is empty, the icon fills the whole display.
+	Icon      image.Image
+	IconRatio float64
+}
+
+func (o *TextOptions) newFace(size float64) (font.Face, error) {
+	fonts := o.Fonts
+	if len(fonts) == 0 || fonts[len(fonts)-1] != defaultFont {
+		fonts = append(fonts[:len(fonts):len(fonts)], defaultFont)
+	}
+
+	faces := []font.Face{}
+	for _, f := range fonts {
+		if f == nil {
+			continue
+		}
+
+		face, err := opentype.NewFace(f, &opentype.FaceOptions{
+			Size:    size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		if err != nil {
+			for _, face := range faces {
+				face.Close()
+			}
+			return nil, err
+		}
+		faces = append(faces, face)
+	}
+
+	if len(faces) == 1 {
+		return faces[0], nil
+	}
+	return NewFallbackFace(faces...)
+}
+
+// wrapText breaks text into lines that fit the given width. Explicit line
+// breaks are preserved. If breakWords is false and a single word does not fit
+// the width, false is returned, otherwise the word is broken.
+func wrapText(face font.Face, text string, width fixed.Int26_6, breakWords bool) ([]string, bool) {
+	rv := []string{}
+	for _, paragraph := range strings.Split(text, "\n") {
+		line := ""
+		for _, word := range strings.FieldsFunc(paragraph, unicode.IsSpace) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if font.MeasureString(face, candidate) <= width {
+				line = candidate
+				continue
+			}
+
+			if line != "" {
+				rv = append(rv, line)
+				line = ""
+			}
+
+			if font.MeasureString(face, word) <= width {
+				line = word
+				continue
+			}
+			if !breakWords {
+				return nil, false
+			}
+
+			for _, r := range word {
+				if line != "" && font.MeasureString(face, line+string(r)) > width {
+					rv = append(rv, line)
+					line = ""
+				}
+				line += string(r)
+			}
+		}
+		rv = append(rv, line)
+	}
+	return rv, true
+}
+
+func lineHeight(face font.Face, spacing float64) fixed.Int26_6 {
+	if spacing <= 0 {
+		spacing = 1
+	}
+	return fixed.Int26_6(float64(face.Metrics().Height) * spacing)
+}
+
+// layoutText selects the font face and breaks the text into lines fitting
+// the given rectangle. If size is zero, the largest size fitting the
+// rectangle, but not smaller than minSize, is used.
+func (o *TextOptions) layoutText(text string, rect image.Rectangle, size float64, minSize float64) (font.Face, []string, error) {
+	width := fixed.I(rect.Dx())
+	height := fixed.I(rect.Dy())
+
+	if size > 0 {
+		face, err := o.newFace(size)
+		if err != nil {
+			return nil, nil, err
+		}
+		lines, _ := wrapText(face, text, width, true)
+		return face, lines, nil
+	}
+
+	maxSize := o.MaxSize
+	if maxSize <= 0 {
+		maxSize = float64(rect.Dy()) / 2
+	}
+	maxSize = max(maxSize, minSize)
+
+	for s := maxSize; s > minSize; s-- {
+		face, err := o.newFace(s)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if lines, ok := wrapText(face, text, width, false); ok && fixed.Int26_6(len(lines))*lineHeight(face, o.LineSpacing) <= height {
+			return face, lines, nil
+		}
+		face.Close()
+	}
+
+	face, err := o.newFace(minSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines, _ := wrapText(face, text, width, true)
+	return face, lines, nil
+}
+
+func renderText(rect image.Rectangle, text string, opts TextOptions, acc AccessibilityOptions) (*image.RGBA, error) {
+	fg := opts.Foreground
+	if fg == nil {
+		fg = color.White
+	}
+	bg := opts.Background
+	if bg == nil {
+		bg = color.Black
+	}
+	fg, bg = acc.colors(fg, bg)
+
+	rv := image.NewRGBA(rect)
+	draw.Draw(rv, rect, image.NewUniform(bg), image.Point{}, draw.Src)
+
+	area := rect.Inset(opts.Padding)
+	if area.Empty() {
+		return rv, nil
+	}
+
+	if opts.Icon != nil {
+		iconArea := area
+		if text != "" {
+			ratio := opts.IconRatio
+			if ratio <= 0 || ratio >= 1 {
+				ratio = 0.6
+			}
+			iconArea.Max.Y = area.Min.Y + int(float64(area.Dy())*ratio)
+			area.Min.Y = iconArea.Max.Y
+		}
+
+		ib := opts.Icon.Bounds()
+		if !ib.Empty() && !iconArea.Empty() {
+			draw.BiLinear.Scale(rv, getScaledRect(ib, iconArea), opts.Icon, ib, draw.Over, nil)
+		}
+	}
+
+	if text == "" || area.Empty() {
+		return rv, nil
+	}
+
+	size := opts.Size
+	if size > 0 {
+		size = acc.textSize(size)
+	}
+	minSize := opts.MinSize
+	if minSize <= 0 {
+		minSize = 8
+	}
+
+	face, lines, err := opts.layoutText(text, area, size, acc.textSize(minSize))
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	lh := lineHeight(face, opts.LineSpacing)
+	blockHeight := fixed.Int26_6(len(lines)) * lh
+
+	var y fixed.Int26_6
+	switch opts.VerticalAlignment {
+	case TEXT_VERTICAL_ALIGNMENT_TOP:
+		y = fixed.I(area.Min.Y)
+	case TEXT_VERTICAL_ALIGNMENT_BOTTOM:
+		y = fixed.I(area.Max.Y) - blockHeight
+	default:
+		y = fixed.I(area.Min.Y) + (fixed.I(area.Dy())-blockHeight)/2
+	}
+
+	// center the glyphs vertically in the line height
+	m := face.Metrics()
+	y += (lh-m.Ascent-m.Descent)/2 + m.Ascent
+
+	dst := rv.SubImage(area).(*image.RGBA)
+	dr := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(fg),
+		Face: face,
+	}
+
+	for _, line := range lines {
+		w := dr.MeasureString(line)
+
+		var x fixed.Int26_6
+		switch opts.Alignment {
+		case TEXT_ALIGNMENT_LEFT:
+			x = fixed.I(area.Min.X)
+		case TEXT_ALIGNMENT_RIGHT:
+			x = fixed.I(area.Max.X) - w
+		default:
+			x = fixed.I(area.Min.X) + (fixed.I(area.Dx())-w)/2
+		}
+
+		dr.Dot = fixed.Point26_6{X: x, Y: y}
+		dr.DrawString(line)
+		y += lh
+	}
+	return rv, nil
+}
+
+// SetKeyText draws text to an Elgato Stream Deck key background display,
+// using the default TextOptions.
+func (d *Device) SetKeyText(key KeyID, text string) error {
+	return d.SetKeyTextWithOptions(key, text, TextOptions{})
+}
+
+// SetKeyTextWithOptions draws text to an Elgato Stream Deck key background
+// display, using the given TextOptions. The text is word wrapped as needed.
+func (d *Device) SetKeyTextWithOptions(key KeyID, text string, opts TextOptions) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	img, err := renderText(d.model.keyImageRect, text, d.textOptions(opts), d.GetAccessibilityOptions())
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setKeyImage(key, img)
+}
+
+// SetInfoBarText draws text to the info bar display available on some
+// Elgato Stream Deck models, using the default TextOptions.
+func (d *Device) SetInfoBarText(text string) error {
+	return d.SetInfoBarTextWithOptions(text, TextOptions{})
+}
+
+// SetInfoBarTextWithOptions draws text to the info bar display available on
+// some Elgato Stream Deck models, using the given TextOptions.
+func (d *Device) SetInfoBarTextWithOptions(text string, opts TextOptions) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateInfoBar(); err != nil {
+		return err
+	}
+
+	img, err := renderText(d.model.infoBarImageRect, text, d.textOptions(opts), d.GetAccessibilityOptions())
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setInfoBarImage(img)
+}
+
+// SetTouchStripText draws text to the touch strip display available on some
+// Elgato Stream Deck models, using the default TextOptions.
+func (d *Device) SetTouchStripText(text string) error {
+	return d.SetTouchStripTextWithOptions(text, TextOptions{})
+}
+
+// SetTouchStripTextWithOptions draws text to the touch strip display
+// available on some Elgato Stream Deck models, using the given TextOptions.
+func (d *Device) SetTouchStripTextWithOptions(text string, opts TextOptions) error {
+	return d.SetTouchStripTextWithRectangle(text, opts, d.model.touchStripImageRect)
+}
+
+// SetTouchStripTextWithRectangle draws text to a rectangle of the touch strip
+// display available on some Elgato Stream Deck models, using the given
+// TextOptions.
+func (d *Device) SetTouchStripTextWithRectangle(text string, opts TextOptions, rect image.Rectangle) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStripRectangle(rect); err != nil {
+		return err
+	}
+
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+
+	img, err := renderText(image.Rect(0, 0, rect.Dx(), rect.Dy()), text, d.textOptions(opts), d.GetAccessibilityOptions())
+	if err != nil {
+		return wrapErr(err)
+	}
+	return d.setTouchStripImage(img, &rect)
+}
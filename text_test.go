@@ -0,0 +1,167 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestWrapText(t *testing.T) {
+	opts := TextOptions{}
+	face, err := opts.newFace(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer face.Close()
+
+	width := fixed.I(60)
+
+	lines, ok := wrapText(face, "hello world foo", width, false)
+	if !ok {
+		t.Fatal("text should fit")
+	}
+	for _, l := range lines {
+		if w := font.MeasureString(face, l); w > width {
+			t.Errorf("line %q too wide: %s", l, w)
+		}
+	}
+	if len(lines) < 2 {
+		t.Errorf("text should be wrapped: %q", lines)
+	}
+
+	if lines, _ := wrapText(face, "a\nb", width, false); !slices.Equal(lines, []string{"a", "b"}) {
+		t.Errorf("line breaks not preserved: %q", lines)
+	}
+
+	if _, ok := wrapText(face, "supercalifragilistic", width, false); ok {
+		t.Error("long word should not fit")
+	}
+	lines, ok = wrapText(face, "supercalifragilistic", width, true)
+	if !ok || len(lines) < 2 {
+		t.Errorf("long word should be broken: %q", lines)
+	}
+}
+
+func countPixels(img *image.RGBA, rect image.Rectangle, c color.Color) int {
+	rv := 0
+	r1, g1, b1, _ := c.RGBA()
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			r2, g2, b2, _ := img.At(x, y).RGBA()
+			if r1 == r2 && g1 == g2 && b1 == b2 {
+				rv++
+			}
+		}
+	}
+	return rv
+}
+
+func TestRenderText(t *testing.T) {
+	rect := image.Rect(0, 0, 72, 72)
+
+	img, err := renderText(rect, "Hi", TextOptions{
+		Background: color.RGBA{0, 0, 0xff, 0xff},
+	}, AccessibilityOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != rect {
+		t.Fatalf("bad bounds: %s", img.Bounds())
+	}
+	if countPixels(img, image.Rect(0, 0, 2, 2), color.RGBA{0, 0, 0xff, 0xff}) != 4 {
+		t.Error("background not drawn")
+	}
+	if countPixels(img, rect, color.White) == 0 {
+		t.Error("text not drawn")
+	}
+
+	img, err = renderText(rect, "Hi", TextOptions{
+		Alignment:         TEXT_ALIGNMENT_LEFT,
+		VerticalAlignment: TEXT_VERTICAL_ALIGNMENT_TOP,
+	}, AccessibilityOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countPixels(img, image.Rect(36, 36, 72, 72), color.White) != 0 {
+		t.Error("text should be aligned to the top left")
+	}
+}
+
+func TestRenderText_HighContrast(t *testing.T) {
+	rect := image.Rect(0, 0, 72, 72)
+
+	img, err := renderText(rect, "Hi", TextOptions{
+		Foreground: color.RGBA{0x10, 0x10, 0x10, 0xff},
+		Background: color.RGBA{0x20, 0x20, 0x20, 0xff},
+	}, AccessibilityOptions{HighContrast: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countPixels(img, image.Rect(0, 0, 2, 2), color.Black) != 4 {
+		t.Error("high contrast background not used")
+	}
+	if countPixels(img, rect, color.White) == 0 {
+		t.Error("high contrast foreground not used")
+	}
+}
+
+func TestLayoutText_AutoFit(t *testing.T) {
+	opts := TextOptions{}
+	rect := image.Rect(0, 0, 72, 72)
+
+	short, _, err := opts.layoutText("Hi", rect, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer short.Close()
+
+	long, lines, err := opts.layoutText("a much longer label for this key", rect, 0, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer long.Close()
+
+	if long.Metrics().Height >= short.Metrics().Height {
+		t.Errorf("long text should use a smaller font: %s >= %s", long.Metrics().Height, short.Metrics().Height)
+	}
+	if len(lines) < 2 {
+		t.Errorf("long text should be wrapped: %q", lines)
+	}
+
+	minFace, _, err := opts.layoutText("Hi", rect, 0, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer minFace.Close()
+
+	if minFace.Metrics().Height < short.Metrics().Height {
+		t.Error("minimum size not enforced")
+	}
+}
+
+func TestRenderText_Icon(t *testing.T) {
+	rect := image.Rect(0, 0, 100, 100)
+	icon := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	for i := range icon.Pix {
+		icon.Pix[i] = 0xff
+	}
+
+	img, err := renderText(rect, "label", TextOptions{Icon: icon}, AccessibilityOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if countPixels(img, image.Rect(40, 0, 60, 60), color.White) != 20*60 {
+		t.Error("icon not drawn above the label")
+	}
+	if countPixels(img, image.Rect(0, 60, 100, 100), color.White) == 0 {
+		t.Error("label not drawn below the icon")
+	}
+}
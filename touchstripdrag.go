@@ -0,0 +1,113 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"fmt"
+	"image"
+)
+
+// TouchStripDragPhase represents the phase of a touch strip drag gesture.
+type TouchStripDragPhase byte
+
+// Elgato Stream Deck touch strip drag phases.
+const (
+	TOUCH_STRIP_DRAG_PHASE_BEGIN TouchStripDragPhase = iota + 1
+	TOUCH_STRIP_DRAG_PHASE_MOVE
+	TOUCH_STRIP_DRAG_PHASE_END
+)
+
+// String returns a string representation of the TouchStripDragPhase.
+func (p TouchStripDragPhase) String() string {
+	switch p {
+	case TOUCH_STRIP_DRAG_PHASE_BEGIN:
+		return "TOUCH_STRIP_DRAG_PHASE_BEGIN"
+	case TOUCH_STRIP_DRAG_PHASE_MOVE:
+		return "TOUCH_STRIP_DRAG_PHASE_MOVE"
+	case TOUCH_STRIP_DRAG_PHASE_END:
+		return "TOUCH_STRIP_DRAG_PHASE_END"
+	default:
+		return ""
+	}
+}
+
+// TouchStripDragHandlerError represents an error returned by a touch strip
+// drag handler including the drag phase and point.
+type TouchStripDragHandlerError struct {
+	Phase TouchStripDragPhase
+	Point image.Point
+	Err   error
+}
+
+// Error returns a string representation of a touch strip drag handler error.
+func (b TouchStripDragHandlerError) Error() string {
+	return fmt.Sprintf("%s [%s: %s]", b.Err, b.Phase, b.Point)
+}
+
+// Unwrap returns the underlying touch strip drag handler error.
+func (b TouchStripDragHandlerError) Unwrap() error {
+	return b.Err
+}
+
+// TouchStripDragHandler represents a callback function that is called for
+// each phase of a touch strip drag gesture. It receives the Device instance,
+// the drag phase and the point of the finger as parameters.
+type TouchStripDragHandler func(d *Device, phase TouchStripDragPhase, p image.Point) error
+
+func (t *touchStrip) addDragHandler(h TouchStripDragHandler) *HandlerRegistration {
+	if h == nil || t.input == nil {
+		return nil
+	}
+	return addHandler(&t.input.mtx, &t.dragHandlers, h)
+}
+
+// AddTouchStripDragHandler registers a TouchStripDragHandler callback to be
+// called for the phases of drag and hold gestures on the touch strip. The
+// callbacks are called in order, one at a time. The returned
+// HandlerRegistration can be used to unregister the callback.
+//
+// The device firmware reports touch strip gestures only after they complete,
+// without intermediate positions: a swipe is delivered as a begin at its
+// origin, a move to its destination and an end at its destination, and a long
+// touch as a begin and an end at the touched point. Short touches are not
+// reported as drags.
+func (d *Device) AddTouchStripDragHandler(fn TouchStripDragHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchStrip(); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchStripHandlerInvalid)
+	}
+
+	d.initInputs()
+
+	return d.touchStripInput.touchStrip.addDragHandler(fn), nil
+}
+
+type touchStripDragEvent struct {
+	phase TouchStripDragPhase
+	point image.Point
+}
+
+// dispatchDrag dispatches the phases of a drag gesture to the
+// TouchStripDragHandler callbacks. It must be called with the input mutex
+// held.
+func (in *input) dispatchDrag(events []touchStripDragEvent, errCh chan error) {
+	if len(in.touchStrip.dragHandlers) == 0 {
+		return
+	}
+
+	hnds := append([]handler[TouchStripDragHandler]{}, in.touchStrip.dragHandlers...)
+	in.dispatch(func() {
+		for _, ev := range events {
+			for _, h := range hnds {
+				if err := callHandler(func() error { return h.fn(in.device, ev.phase, ev.point) }); err != nil {
+					in.device.sendHandlerError(errCh, TouchStripDragHandlerError{Phase: ev.phase, Point: ev.point, Err: err})
+				}
+			}
+		}
+	})
+}
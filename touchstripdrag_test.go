@@ -0,0 +1,63 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestTouchStripDrag(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	type event struct {
+		phase streamdeck.TouchStripDragPhase
+		point image.Point
+	}
+	events := make(chan event, 10)
+	if _, err := dev.AddTouchStripDragHandler(func(d *streamdeck.Device, phase streamdeck.TouchStripDragPhase, p image.Point) error {
+		events <- event{phase, p}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT, image.Pt(10, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SwipeTouchStrip(image.Pt(100, 50), image.Pt(500, 60)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, image.Pt(700, 20)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []event{
+		{streamdeck.TOUCH_STRIP_DRAG_PHASE_BEGIN, image.Pt(100, 50)},
+		{streamdeck.TOUCH_STRIP_DRAG_PHASE_MOVE, image.Pt(500, 60)},
+		{streamdeck.TOUCH_STRIP_DRAG_PHASE_END, image.Pt(500, 60)},
+		{streamdeck.TOUCH_STRIP_DRAG_PHASE_BEGIN, image.Pt(700, 20)},
+		{streamdeck.TOUCH_STRIP_DRAG_PHASE_END, image.Pt(700, 20)},
+	} {
+		select {
+		case e := <-events:
+			if e != want {
+				t.Fatalf("unexpected event: got %s %s, want %s %s", e.phase, e.point, want.phase, want.point)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for %s", want.phase)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+)
+
+// TouchStripSegmentTouchHandler represents a callback function that is called
+// when the touch strip segment above a dial is touched. It receives the
+// Device instance, the dial identifier, and the touch strip touch type and
+// point as parameters. The point is relative to the whole touch strip.
+type TouchStripSegmentTouchHandler func(d *Device, di DialID, t TouchStripTouchType, p image.Point) error
+
+// TouchStripSegmentSwipeHandler represents a callback function that is called
+// when a swipe starts at the touch strip segment above a dial. It receives
+// the Device instance, the dial identifier, the origin point and the
+// destination point as parameters. The points are relative to the whole
+// touch strip.
+type TouchStripSegmentSwipeHandler func(d *Device, di DialID, origin image.Point, destination image.Point) error
+
+// touchStripDialRect returns the area of the touch strip display above the
+// given dial, assuming dials evenly distributed along the touch strip.
+func (d *Device) touchStripDialRect(di DialID) image.Rectangle {
+	r := d.model.touchStripImageRect
+	if d.model.dialCount == 0 {
+		return r
+	}
+
+	w := r.Dx() / int(d.model.dialCount)
+	x := r.Min.X + w*int(di-DIAL_1)
+	return image.Rect(x, r.Min.Y, x+w, r.Max.Y)
+}
+
+// touchStripDial returns the dial below the given touch strip point.
+func (d *Device) touchStripDial(p image.Point) DialID {
+	r := d.model.touchStripImageRect
+	if d.model.dialCount == 0 || r.Dx() == 0 {
+		return DIAL_1
+	}
+
+	i := (p.X - r.Min.X) * int(d.model.dialCount) / r.Dx()
+	return DIAL_1 + DialID(min(max(i, 0), int(d.model.dialCount)-1))
+}
+
+func (d *Device) validateTouchStripSegment(di DialID) error {
+	if err := d.validateTouchStrip(); err != nil {
+		return err
+	}
+	return d.validateDial(di)
+}
+
+// GetTouchStripSegmentRectangle returns an image.Rectangle representing the
+// geometry of the segment of the touch strip display above the given dial,
+// available on some Elgato Stream Deck models.
+func (d *Device) GetTouchStripSegmentRectangle(di DialID) (image.Rectangle, error) {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return image.Rectangle{}, err
+	}
+	return d.touchStripDialRect(di), nil
+}
+
+// SetTouchStripSegmentImage draws a given image.Image to the segment of the
+// touch strip display above the given dial, available on some Elgato Stream
+// Deck models. The image is scaled as needed.
+func (d *Device) SetTouchStripSegmentImage(di DialID, img image.Image) error {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return err
+	}
+	return d.SetTouchStripImageWithRectangle(img, d.touchStripDialRect(di))
+}
+
+// SetTouchStripSegmentColor sets a color to the segment of the touch strip
+// display above the given dial, available on some Elgato Stream Deck models.
+func (d *Device) SetTouchStripSegmentColor(di DialID, c color.Color) error {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return err
+	}
+	return d.SetTouchStripColorWithRectangle(c, d.touchStripDialRect(di))
+}
+
+// SetTouchStripSegmentText draws text to the segment of the touch strip
+// display above the given dial, available on some Elgato Stream Deck models,
+// using the given TextOptions.
+func (d *Device) SetTouchStripSegmentText(di DialID, text string, opts TextOptions) error {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return err
+	}
+	return d.SetTouchStripTextWithRectangle(text, opts, d.touchStripDialRect(di))
+}
+
+// ClearTouchStripSegment clears the segment of the touch strip display above
+// the given dial, available on some Elgato Stream Deck models.
+func (d *Device) ClearTouchStripSegment(di DialID) error {
+	return d.SetTouchStripSegmentColor(di, color.Black)
+}
+
+// AddTouchStripSegmentTouchHandler registers a TouchStripSegmentTouchHandler
+// callback to be called whenever the touch strip segment above the given dial
+// is touched. The returned HandlerRegistration can be used to unregister the
+// callback, and RemoveTouchStripHandlers unregisters it as well.
+func (d *Device) AddTouchStripSegmentTouchHandler(di DialID, fn TouchStripSegmentTouchHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchStripHandlerInvalid)
+	}
+
+	return d.AddTouchStripTouchHandler(func(d *Device, t TouchStripTouchType, p image.Point) error {
+		if d.touchStripDial(p) != di {
+			return nil
+		}
+		return fn(d, di, t, p)
+	})
+}
+
+// AddTouchStripSegmentSwipeHandler registers a TouchStripSegmentSwipeHandler
+// callback to be called whenever a swipe starts at the touch strip segment
+// above the given dial. The returned HandlerRegistration can be used to
+// unregister the callback, and RemoveTouchStripHandlers unregisters it as
+// well.
+func (d *Device) AddTouchStripSegmentSwipeHandler(di DialID, fn TouchStripSegmentSwipeHandler) (*HandlerRegistration, error) {
+	if err := d.validateTouchStripSegment(di); err != nil {
+		return nil, err
+	}
+
+	if fn == nil {
+		return nil, wrapErr(ErrTouchStripHandlerInvalid)
+	}
+
+	return d.AddTouchStripSwipeHandler(func(d *Device, origin image.Point, destination image.Point) error {
+		if d.touchStripDial(origin) != di {
+			return nil
+		}
+		return fn(d, di, origin, destination)
+	})
+}
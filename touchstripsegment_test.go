@@ -0,0 +1,94 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestTouchStripSegments(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rect != image.Rect(400, 0, 600, 100) {
+		t.Fatalf("bad segment rectangle: %s", rect)
+	}
+	if _, err := dev.GetTouchStripSegmentRectangle(streamdeck.DIAL_4 + 1); !errors.Is(err, streamdeck.ErrDialInvalid) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := dev.SetTouchStripSegmentColor(streamdeck.DIAL_3, color.RGBA{R: 0xff, A: 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	assertColor(t, m.TouchStripImage(), 399, 50, color.RGBA{})
+	assertColor(t, m.TouchStripImage(), 400, 50, color.RGBA{R: 0xff})
+	assertColor(t, m.TouchStripImage(), 599, 50, color.RGBA{R: 0xff})
+	assertColor(t, m.TouchStripImage(), 600, 50, color.RGBA{})
+
+	events := make(chan string, 10)
+	if _, err := dev.AddTouchStripSegmentTouchHandler(streamdeck.DIAL_2, func(d *streamdeck.Device, di streamdeck.DialID, tt streamdeck.TouchStripTouchType, p image.Point) error {
+		events <- "touch " + di.String()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dev.AddTouchStripSegmentSwipeHandler(streamdeck.DIAL_4, func(d *streamdeck.Device, di streamdeck.DialID, origin image.Point, destination image.Point) error {
+		events <- "swipe " + di.String()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	go dev.Listen(nil)
+
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT, image.Pt(100, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.TouchStrip(streamdeck.TOUCH_STRIP_TOUCH_TYPE_SHORT, image.Pt(300, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SwipeTouchStrip(image.Pt(300, 50), image.Pt(700, 50)); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SwipeTouchStrip(image.Pt(700, 50), image.Pt(300, 50)); err != nil {
+		t.Fatal(err)
+	}
+
+	// touch strip handlers are not called in order
+	got := []string{}
+	for range 2 {
+		select {
+		case e := <-events:
+			got = append(got, e)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for events: %q", got)
+		}
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"swipe DIAL_4", "touch DIAL_2"}) {
+		t.Fatalf("unexpected events: %q", got)
+	}
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event: %q", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/draw"
+	"time"
+)
+
+// Transition represents an effect used when changing the image of an Elgato
+// Stream Deck key background display.
+type Transition byte
+
+// Elgato Stream Deck key image transitions. Slide transitions push the
+// current image out in the given direction, and wipe transitions reveal the
+// new image over the current one in the given direction.
+const (
+	TRANSITION_NONE Transition = iota
+	TRANSITION_FADE
+	TRANSITION_SLIDE_LEFT
+	TRANSITION_SLIDE_RIGHT
+	TRANSITION_SLIDE_UP
+	TRANSITION_SLIDE_DOWN
+	TRANSITION_WIPE_LEFT
+	TRANSITION_WIPE_RIGHT
+	TRANSITION_WIPE_UP
+	TRANSITION_WIPE_DOWN
+)
+
+// String returns a string representation of the Transition.
+func (t Transition) String() string {
+	switch t {
+	case TRANSITION_NONE:
+		return "TRANSITION_NONE"
+	case TRANSITION_FADE:
+		return "TRANSITION_FADE"
+	case TRANSITION_SLIDE_LEFT:
+		return "TRANSITION_SLIDE_LEFT"
+	case TRANSITION_SLIDE_RIGHT:
+		return "TRANSITION_SLIDE_RIGHT"
+	case TRANSITION_SLIDE_UP:
+		return "TRANSITION_SLIDE_UP"
+	case TRANSITION_SLIDE_DOWN:
+		return "TRANSITION_SLIDE_DOWN"
+	case TRANSITION_WIPE_LEFT:
+		return "TRANSITION_WIPE_LEFT"
+	case TRANSITION_WIPE_RIGHT:
+		return "TRANSITION_WIPE_RIGHT"
+	case TRANSITION_WIPE_UP:
+		return "TRANSITION_WIPE_UP"
+	case TRANSITION_WIPE_DOWN:
+		return "TRANSITION_WIPE_DOWN"
+	default:
+		return ""
+	}
+}
+
+const transitionFrameInterval = 33 * time.Millisecond
+
+// transitionFrame renders the frame of a transition between two images with
+// the same bounds, at progress p, in the [0, 1] range.
+func transitionFrame(t Transition, from *image.RGBA, to *image.RGBA, p float64) *image.RGBA {
+	b := to.Bounds()
+	rv := image.NewRGBA(b)
+
+	dx := int(float64(b.Dx()) * p)
+	dy := int(float64(b.Dy()) * p)
+
+	switch t {
+	case TRANSITION_FADE:
+		for i := range rv.Pix {
+			rv.Pix[i] = byte(float64(from.Pix[i])*(1-p) + float64(to.Pix[i])*p + 0.5)
+		}
+
+	case TRANSITION_SLIDE_LEFT:
+		draw.Draw(rv, b, from, b.Min.Add(image.Pt(dx, 0)), draw.Src)
+		draw.Draw(rv, b.Add(image.Pt(b.Dx()-dx, 0)), to, b.Min, draw.Src)
+
+	case TRANSITION_SLIDE_RIGHT:
+		draw.Draw(rv, b.Add(image.Pt(dx, 0)), from, b.Min, draw.Src)
+		draw.Draw(rv, b, to, b.Min.Add(image.Pt(b.Dx()-dx, 0)), draw.Src)
+
+	case TRANSITION_SLIDE_UP:
+		draw.Draw(rv, b, from, b.Min.Add(image.Pt(0, dy)), draw.Src)
+		draw.Draw(rv, b.Add(image.Pt(0, b.Dy()-dy)), to, b.Min, draw.Src)
+
+	case TRANSITION_SLIDE_DOWN:
+		draw.Draw(rv, b.Add(image.Pt(0, dy)), from, b.Min, draw.Src)
+		draw.Draw(rv, b, to, b.Min.Add(image.Pt(0, b.Dy()-dy)), draw.Src)
+
+	case TRANSITION_WIPE_LEFT:
+		draw.Draw(rv, b, from, b.Min, draw.Src)
+		r := image.Rect(b.Max.X-dx, b.Min.Y, b.Max.X, b.Max.Y)
+		draw.Draw(rv, r, to, r.Min, draw.Src)
+
+	case TRANSITION_WIPE_RIGHT:
+		draw.Draw(rv, b, from, b.Min, draw.Src)
+		r := image.Rect(b.Min.X, b.Min.Y, b.Min.X+dx, b.Max.Y)
+		draw.Draw(rv, r, to, r.Min, draw.Src)
+
+	case TRANSITION_WIPE_UP:
+		draw.Draw(rv, b, from, b.Min, draw.Src)
+		r := image.Rect(b.Min.X, b.Max.Y-dy, b.Max.X, b.Max.Y)
+		draw.Draw(rv, r, to, r.Min, draw.Src)
+
+	case TRANSITION_WIPE_DOWN:
+		draw.Draw(rv, b, from, b.Min, draw.Src)
+		r := image.Rect(b.Min.X, b.Min.Y, b.Max.X, b.Min.Y+dy)
+		draw.Draw(rv, r, to, r.Min, draw.Src)
+
+	default:
+		draw.Draw(rv, b, to, b.Min, draw.Src)
+	}
+	return rv
+}
+
+// keyImage returns the image currently drawn to a key display, decoded from
+// the last payload sent to it, or a black image.
+func (d *Device) keyImage(key KeyID) *image.RGBA {
+	d.state.mtx.Lock()
+	data := d.state.keys[key]
+	d.state.mtx.Unlock()
+
+	if data != nil {
+		if img, err := decodeImage(data, d.model.keyImageRect, d.model.keyImageFormat, d.model.keyImageTransform); err == nil {
+			return img
+		}
+	}
+
+	rv := image.NewRGBA(d.model.keyImageRect)
+	draw.Draw(rv, rv.Bounds(), image.Black, image.Point{}, draw.Src)
+	return rv
+}
+
+// SetKeyImageWithTransition draws a given image.Image to an Elgato Stream
+// Deck key background display, animating the change from the current image
+// with the given transition effect. The image is scaled as needed. It blocks
+// until the transition completes.
+//
+// The duration of the transition is affected by the AnimationScale
+// accessibility option.
+func (d *Device) SetKeyImageWithTransition(key KeyID, img image.Image, t Transition, duration time.Duration) error {
+	if err := d.validateOpen(); err != nil {
+		return err
+	}
+
+	if err := d.validateKey(key); err != nil {
+		return err
+	}
+
+	if err := d.validateKeyDisplay(); err != nil {
+		return err
+	}
+
+	if img == nil {
+		return wrapErr(ErrImageInvalid)
+	}
+
+	duration = d.GetAccessibilityOptions().duration(duration)
+	if t == TRANSITION_NONE || duration < transitionFrameInterval {
+		return d.setKeyImage(key, img)
+	}
+
+	from := d.keyImage(key)
+	to := scaleImage(img, d.model.keyImageRect, d.GetImageOptions())
+
+	start := time.Now()
+	ticker := time.NewTicker(transitionFrameInterval)
+	defer ticker.Stop()
+
+	for {
+		elapsed := time.Since(start)
+		if elapsed >= duration {
+			break
+		}
+
+		if err := d.setKeyImage(key, transitionFrame(t, from, to, float64(elapsed)/float64(duration))); err != nil {
+			return err
+		}
+		<-ticker.C
+	}
+	return d.setKeyImage(key, to)
+}
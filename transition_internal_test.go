@@ -0,0 +1,57 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+func solidImage(rect image.Rectangle, c color.Color) *image.RGBA {
+	rv := image.NewRGBA(rect)
+	draw.Draw(rv, rect, image.NewUniform(c), image.Point{}, draw.Src)
+	return rv
+}
+
+func TestTransitionFrame(t *testing.T) {
+	rect := image.Rect(0, 0, 10, 10)
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	from := solidImage(rect, red)
+	to := solidImage(rect, blue)
+
+	for tr := TRANSITION_FADE; tr <= TRANSITION_WIPE_DOWN; tr++ {
+		t.Run(tr.String(), func(t *testing.T) {
+			if c := transitionFrame(tr, from, to, 0).RGBAAt(5, 5); c != red {
+				t.Errorf("bad color at start: %v", c)
+			}
+			if c := transitionFrame(tr, from, to, 1).RGBAAt(5, 5); c != blue {
+				t.Errorf("bad color at end: %v", c)
+			}
+		})
+	}
+
+	if c := transitionFrame(TRANSITION_FADE, from, to, 0.5).RGBAAt(5, 5); c != (color.RGBA{0x80, 0, 0x80, 0xff}) {
+		t.Errorf("bad fade color: %v", c)
+	}
+
+	f := transitionFrame(TRANSITION_SLIDE_LEFT, from, to, 0.3)
+	if c := f.RGBAAt(0, 5); c != red {
+		t.Errorf("bad slide color at left: %v", c)
+	}
+	if c := f.RGBAAt(9, 5); c != blue {
+		t.Errorf("bad slide color at right: %v", c)
+	}
+
+	f = transitionFrame(TRANSITION_WIPE_DOWN, from, to, 0.3)
+	if c := f.RGBAAt(5, 0); c != blue {
+		t.Errorf("bad wipe color at top: %v", c)
+	}
+	if c := f.RGBAAt(5, 9); c != red {
+		t.Errorf("bad wipe color at bottom: %v", c)
+	}
+}
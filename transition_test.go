@@ -0,0 +1,36 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestKeyImageWithTransition(t *testing.T) {
+	dev, m, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	rect, err := dev.GetKeyImageRectangle()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := dev.SetKeyImageWithTransition(streamdeck.KEY_1, testImage(rect), streamdeck.TRANSITION_FADE, 150*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	if w := m.Writes(); len(w) < 3 {
+		t.Errorf("not enough frames written: %d", len(w))
+	}
+	assertColor(t, m.KeyImage(streamdeck.KEY_1), 2, 2, color.RGBA{R: 0xff, B: 0xff})
+}
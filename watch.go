@@ -0,0 +1,144 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"context"
+	"time"
+
+	"rafaelmartins.com/p/usbhid"
+)
+
+const watchInterval = 500 * time.Millisecond
+
+// DeviceEventType represents the type of a DeviceEvent.
+type DeviceEventType byte
+
+// String returns a string representation of the DeviceEventType.
+func (t DeviceEventType) String() string {
+	switch t {
+	case DEVICE_EVENT_TYPE_CONNECTED:
+		return "DEVICE_EVENT_TYPE_CONNECTED"
+	case DEVICE_EVENT_TYPE_DISCONNECTED:
+		return "DEVICE_EVENT_TYPE_DISCONNECTED"
+	default:
+		return ""
+	}
+}
+
+// Elgato Stream Deck device event types. These constants represent the
+// changes reported by Watch.
+const (
+	DEVICE_EVENT_TYPE_CONNECTED DeviceEventType = iota + 1
+	DEVICE_EVENT_TYPE_DISCONNECTED
+)
+
+// DeviceEvent represents a supported Elgato Stream Deck device being
+// connected to or disconnected from the computer. Info describes the device
+// without requiring it to be open, as returned by EnumerateInfo.
+type DeviceEvent struct {
+	Type   DeviceEventType
+	Device *Device
+	Info   DeviceInfo
+}
+
+func watchKey(dev *usbhid.Device) string {
+	return dev.Path() + "\x00" + dev.SerialNumber()
+}
+
+// watchDiff compares the currently connected devices with the previously
+// known ones, returning the keys of the devices that were connected and
+// disconnected since then.
+func watchDiff(known map[string]*Device, current map[string]*usbhid.Device) ([]string, []string) {
+	connected := []string{}
+	for k := range current {
+		if _, found := known[k]; !found {
+			connected = append(connected, k)
+		}
+	}
+
+	disconnected := []string{}
+	for k := range known {
+		if _, found := current[k]; !found {
+			disconnected = append(disconnected, k)
+		}
+	}
+	return connected, disconnected
+}
+
+// Watch monitors the supported Elgato Stream Deck devices connected to the
+// computer, calling the provided callback function whenever a device is
+// connected or disconnected. Devices already connected when Watch is called
+// are reported as connected. It blocks until the context is cancelled.
+//
+// Devices reported as connected are not open. Devices reported as
+// disconnected are the same instances previously reported as connected, and
+// should be closed by the caller if they are open.
+//
+// Device changes are detected by periodically enumerating the devices, so
+// devices disconnected and reconnected between enumerations with the same
+// path and serial number are not reported.
+func Watch(ctx context.Context, fn func(event DeviceEvent)) error {
+	if fn == nil {
+		return wrapErr(ErrDeviceEventHandlerInvalid)
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	known := map[string]*Device{}
+	infos := map[string]DeviceInfo{}
+	first := true
+	for {
+		devices, err := usbhid.Enumerate(enumerateFunc)
+		if err != nil && first {
+			return wrapErr(err)
+		}
+		first = false
+
+		if err == nil {
+			current := map[string]*usbhid.Device{}
+			for _, dev := range devices {
+				current[watchKey(dev)] = dev
+			}
+
+			connected, disconnected := watchDiff(known, current)
+			for _, k := range disconnected {
+				dev, info := known[k], infos[k]
+				delete(known, k)
+				delete(infos, k)
+				fn(DeviceEvent{
+					Type:   DEVICE_EVENT_TYPE_DISCONNECTED,
+					Device: dev,
+					Info:   info,
+				})
+			}
+			for _, k := range connected {
+				model, err := getModel(current[k])
+				if err != nil {
+					continue
+				}
+
+				dev := &Device{
+					dev:   current[k],
+					model: model,
+				}
+				known[k] = dev
+				infos[k] = newDeviceInfo(current[k], model)
+				fn(DeviceEvent{
+					Type:   DEVICE_EVENT_TYPE_CONNECTED,
+					Device: dev,
+					Info:   infos[k],
+				})
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"slices"
+	"testing"
+
+	"rafaelmartins.com/p/usbhid"
+)
+
+func TestWatchDiff(t *testing.T) {
+	known := map[string]*Device{
+		"a": {},
+		"b": {},
+	}
+	current := map[string]*usbhid.Device{
+		"b": nil,
+		"c": nil,
+		"d": nil,
+	}
+
+	connected, disconnected := watchDiff(known, current)
+	slices.Sort(connected)
+
+	if !slices.Equal(connected, []string{"c", "d"}) {
+		t.Errorf("unexpected connected devices: %q", connected)
+	}
+	if !slices.Equal(disconnected, []string{"a"}) {
+		t.Errorf("unexpected disconnected devices: %q", disconnected)
+	}
+}
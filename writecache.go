@@ -0,0 +1,106 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck
+
+import (
+	"hash/maphash"
+	"image"
+	"sync"
+)
+
+// writeCache remembers hashes of the last payloads written to the displays
+// since the device was opened, to skip writing identical payloads again.
+type writeCache struct {
+	mtx        sync.Mutex
+	seed       maphash.Seed
+	keys       map[KeyID]uint64
+	infoBar    *uint64
+	touchStrip map[image.Rectangle]uint64
+}
+
+func (c *writeCache) reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.seed = maphash.MakeSeed()
+	c.keys = map[KeyID]uint64{}
+	c.infoBar = nil
+	c.touchStrip = map[image.Rectangle]uint64{}
+}
+
+func (c *writeCache) hash(data []byte) uint64 {
+	return maphash.Bytes(c.seed, data)
+}
+
+// key returns the hash of the payload, and true if it is the last payload
+// written to the key.
+func (c *writeCache) key(key KeyID, data []byte) (uint64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	h := c.hash(data)
+	prev, found := c.keys[key]
+	return h, found && prev == h
+}
+
+func (c *writeCache) setKey(key KeyID, h uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.keys != nil {
+		c.keys[key] = h
+	}
+}
+
+func (c *writeCache) infoBarImage(data []byte) (uint64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	h := c.hash(data)
+	return h, c.infoBar != nil && *c.infoBar == h
+}
+
+func (c *writeCache) setInfoBar(h uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.infoBar = &h
+}
+
+func (c *writeCache) touchStripImage(rect image.Rectangle, data []byte) (uint64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	h := c.hash(data)
+	prev, found := c.touchStrip[rect]
+	return h, found && prev == h
+}
+
+func (c *writeCache) setTouchStrip(rect image.Rectangle, h uint64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.touchStrip == nil {
+		return
+	}
+
+	// overlapping areas were (partially) overwritten
+	for r := range c.touchStrip {
+		if r.Overlaps(rect) {
+			delete(c.touchStrip, r)
+		}
+	}
+	c.touchStrip[rect] = h
+}
+
+// InvalidateImageCache makes the next image written to each of the Elgato
+// Stream Deck device displays to be sent, even if identical to the last one.
+//
+// Images identical to the last image written to a display since the device
+// was opened are not sent again, to save USB bandwidth. This is only needed
+// if the displays were changed by other means, like a firmware reset.
+func (d *Device) InvalidateImageCache() {
+	d.writeCache.reset()
+}
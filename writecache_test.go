@@ -0,0 +1,60 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamdeck_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func TestWriteCache(t *testing.T) {
+	dev, m, err := mock.Open("plus")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	red := color.RGBA{R: 0xff, A: 0xff}
+	for range 2 {
+		if err := dev.SetKeyColor(streamdeck.KEY_1, red); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := m.Writes(); len(w) != 1 {
+		t.Fatalf("identical image written again: %+v", w)
+	}
+
+	dev.InvalidateImageCache()
+	if err := dev.SetKeyColor(streamdeck.KEY_1, red); err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Writes(); len(w) != 2 {
+		t.Fatalf("image not written after invalidating cache: %+v", w)
+	}
+
+	m.ClearWrites()
+	rect := image.Rect(0, 0, 200, 100)
+	for _, step := range []struct {
+		rect image.Rectangle
+		c    color.Color
+	}{
+		{rect, red},
+		{rect, red},
+		{image.Rect(0, 0, 800, 100), color.Black},
+		{rect, red},
+	} {
+		if err := dev.SetTouchStripColorWithRectangle(step.c, step.rect); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := m.Writes(); len(w) != 3 {
+		t.Fatalf("bad touch strip writes: %+v", w)
+	}
+	assertColor(t, m.TouchStripImage(), 10, 10, color.RGBA{R: 0xff})
+}
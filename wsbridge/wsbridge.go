@@ -0,0 +1,396 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package wsbridge exposes an Elgato Stream Deck device through a WebSocket
+// endpoint, to drive it from browser-based dashboards.
+//
+// The Bridge implements http.Handler. Each connection receives the input
+// events of the device as JSON messages:
+//
+//	{"type": "key_press", "key": 2}
+//	{"type": "dial_rotate", "dial": 1, "delta": -3}
+//
+// and may send commands as JSON messages, each answered with a result
+// message carrying the command identifier:
+//
+//	{"id": 1, "command": "setImage", "key": 2, "image": "<base64 PNG>"}
+//	{"id": 2, "command": "setColor", "key": 3, "color": "#ff8000"}
+//	{"id": 3, "command": "setBrightness", "brightness": 60}
+//
+//	{"type": "result", "id": 1}
+//	{"type": "result", "id": 2, "error": "..."}
+//
+// Keys are numbered starting from 1 for the top left key. Browser
+// connections are only accepted from pages served by the same host as the
+// Bridge, unless other origins are allowed with Options.AllowedOrigins.
+//
+// The Bridge does not listen to the device input. Events are only reported
+// while the application calls Device.Listen.
+package wsbridge
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+)
+
+// Errors returned by the wsbridge package.
+var (
+	ErrColorInvalid   = errors.New("wsbridge: color is not valid")
+	ErrCommandInvalid = errors.New("wsbridge: command is not valid")
+	ErrOriginInvalid  = errors.New("wsbridge: origin is not allowed")
+)
+
+// connBuffer is the number of messages buffered for each connection. Events
+// are dropped for connections that do not keep up.
+const connBuffer = 16
+
+// Point represents a point of the touch strip display.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Message represents a message sent to the connections: an input event of
+// the Elgato Stream Deck device, or the result of a command. Only the fields
+// relevant to the message type are set.
+type Message struct {
+	// Type is the message type, one of key_press, key_release,
+	// touch_point_press, touch_point_release, dial_press, dial_release,
+	// dial_rotate, touch_strip_touch, touch_strip_swipe and result.
+	Type string `json:"type"`
+
+	Key        streamdeck.KeyID        `json:"key,omitempty"`
+	TouchPoint streamdeck.TouchPointID `json:"touch_point,omitempty"`
+	Dial       streamdeck.DialID       `json:"dial,omitempty"`
+
+	// Duration is the time the input was held, in milliseconds, for release
+	// events.
+	Duration int64 `json:"duration,omitempty"`
+
+	// Delta is the rotation delta, for dial rotate events.
+	Delta int8 `json:"delta,omitempty"`
+
+	// Long is set for long touches, for touch strip touch events.
+	Long bool `json:"long,omitempty"`
+
+	// Point is the touched point, for touch strip touch events, or the
+	// origin point, for touch strip swipe events.
+	Point *Point `json:"point,omitempty"`
+
+	// Destination is the destination point, for touch strip swipe events.
+	Destination *Point `json:"destination,omitempty"`
+
+	// ID is the identifier of the command, for results.
+	ID int64 `json:"id,omitempty"`
+
+	// Error is the error returned by the command, for results of failed
+	// commands.
+	Error string `json:"error,omitempty"`
+}
+
+// Command represents a command received from the connections.
+type Command struct {
+	// ID is an identifier chosen by the client, echoed in the result.
+	ID int64 `json:"id"`
+
+	// Command is the command name, one of setImage, setColor and
+	// setBrightness.
+	Command string `json:"command"`
+
+	// Key is the key to draw to, for setImage and setColor.
+	Key streamdeck.KeyID `json:"key"`
+
+	// Image is the base64 encoded image, in any of the formats supported
+	// by Device.SetKeyImageFromReader, for setImage.
+	Image string `json:"image"`
+
+	// Color is the color, in the #rgb or #rrggbb formats, for setColor.
+	Color string `json:"color"`
+
+	// Brightness is the brightness, in percent, for setBrightness.
+	Brightness byte `json:"brightness"`
+}
+
+// Options represents the settings of a Bridge created with NewWithOptions.
+type Options struct {
+	// AllowedOrigins is the list of origins, like
+	// "https://dashboard.example.com", allowed to connect in addition to the
+	// same host as the Bridge, or "*" to allow any origin. Connections
+	// without an Origin header, that are not opened by browsers, are always
+	// accepted.
+	AllowedOrigins []string
+}
+
+// Bridge is an http.Handler exposing an Elgato Stream Deck device through a
+// WebSocket endpoint.
+type Bridge struct {
+	device  *streamdeck.Device
+	server  websocket.Server
+	origins []string
+	regs    []*streamdeck.HandlerRegistration
+	done    chan struct{}
+	once    sync.Once
+
+	mtx   sync.Mutex
+	conns map[chan Message]struct{}
+}
+
+// New creates a Bridge bound to an open Elgato Stream Deck device, and
+// registers the input handlers used to report events. Browser connections
+// are only accepted from the same host as the Bridge.
+func New(dev *streamdeck.Device) (*Bridge, error) {
+	return NewWithOptions(dev, Options{})
+}
+
+// NewWithOptions creates a Bridge bound to an open Elgato Stream Deck device,
+// with the given Options, and registers the input handlers used to report
+// events.
+func NewWithOptions(dev *streamdeck.Device, opts Options) (*Bridge, error) {
+	if dev == nil || !dev.IsOpen() {
+		return nil, fmt.Errorf("wsbridge: %w", streamdeck.ErrDeviceIsClosed)
+	}
+
+	rv := &Bridge{
+		device: dev,
+		done:   make(chan struct{}),
+		conns:  map[chan Message]struct{}{},
+	}
+	for _, o := range opts.AllowedOrigins {
+		rv.origins = append(rv.origins, strings.ToLower(strings.TrimSuffix(o, "/")))
+	}
+	rv.server = websocket.Server{Handler: rv.serve, Handshake: rv.handshake}
+
+	if err := rv.register(); err != nil {
+		rv.Close()
+		return nil, err
+	}
+	return rv, nil
+}
+
+func (b *Bridge) register() error {
+	dev := b.device
+	add := func(reg *streamdeck.HandlerRegistration, err error) error {
+		if err != nil {
+			return err
+		}
+		b.regs = append(b.regs, reg)
+		return nil
+	}
+
+	if err := dev.ForEachKey(func(k streamdeck.KeyID) error {
+		if err := add(dev.AddKeyPressHandler(k, func(d *streamdeck.Device, key *streamdeck.Key) error {
+			b.publish(Message{Type: "key_press", Key: key.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddKeyReleaseHandler(k, func(d *streamdeck.Device, key *streamdeck.Key, duration time.Duration) error {
+			b.publish(Message{Type: "key_release", Key: key.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachTouchPoint(func(tp streamdeck.TouchPointID) error {
+		if err := add(dev.AddTouchPointPressHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint) error {
+			b.publish(Message{Type: "touch_point_press", TouchPoint: t.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddTouchPointReleaseHandler(tp, func(d *streamdeck.Device, t *streamdeck.TouchPoint, duration time.Duration) error {
+			b.publish(Message{Type: "touch_point_release", TouchPoint: t.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if err := dev.ForEachDial(func(di streamdeck.DialID) error {
+		if err := add(dev.AddDialPressHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial) error {
+			b.publish(Message{Type: "dial_press", Dial: dial.GetID()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddDialReleaseHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, duration time.Duration) error {
+			b.publish(Message{Type: "dial_release", Dial: dial.GetID(), Duration: duration.Milliseconds()})
+			return nil
+		})); err != nil {
+			return err
+		}
+		return add(dev.AddDialRotateHandler(di, func(d *streamdeck.Device, dial *streamdeck.Dial, delta int8) error {
+			b.publish(Message{Type: "dial_rotate", Dial: dial.GetID(), Delta: delta})
+			return nil
+		}))
+	}); err != nil {
+		return err
+	}
+
+	if dev.GetTouchStripSupported() {
+		if err := add(dev.AddTouchStripTouchHandler(func(d *streamdeck.Device, t streamdeck.TouchStripTouchType, p image.Point) error {
+			b.publish(Message{Type: "touch_strip_touch", Long: t == streamdeck.TOUCH_STRIP_TOUCH_TYPE_LONG, Point: &Point{X: p.X, Y: p.Y}})
+			return nil
+		})); err != nil {
+			return err
+		}
+		if err := add(dev.AddTouchStripSwipeHandler(func(d *streamdeck.Device, origin image.Point, destination image.Point) error {
+			b.publish(Message{Type: "touch_strip_swipe", Point: &Point{X: origin.X, Y: origin.Y}, Destination: &Point{X: destination.X, Y: destination.Y}})
+			return nil
+		})); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *Bridge) publish(m Message) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	for ch := range b.conns {
+		select {
+		case ch <- m:
+		default:
+		}
+	}
+}
+
+func (b *Bridge) handshake(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrOriginInvalid, err)
+	}
+	config.Origin = origin
+	if origin == nil || strings.EqualFold(origin.Host, req.Host) || slices.Contains(b.origins, "*") {
+		return nil
+	}
+	o := (&url.URL{Scheme: origin.Scheme, Host: origin.Host}).String()
+	if slices.Contains(b.origins, strings.ToLower(o)) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrOriginInvalid, o)
+}
+
+// ServeHTTP handles the WebSocket connections.
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.server.ServeHTTP(w, r)
+}
+
+// Close unregisters the input handlers of the Bridge and closes the
+// connections. The device is not closed.
+func (b *Bridge) Close() error {
+	b.once.Do(func() {
+		for _, reg := range b.regs {
+			reg.Remove()
+		}
+		close(b.done)
+	})
+	return nil
+}
+
+func (b *Bridge) serve(ws *websocket.Conn) {
+	out := make(chan Message, connBuffer)
+
+	b.mtx.Lock()
+	b.conns[out] = struct{}{}
+	b.mtx.Unlock()
+
+	defer func() {
+		b.mtx.Lock()
+		delete(b.conns, out)
+		b.mtx.Unlock()
+	}()
+
+	received := make(chan struct{})
+	go func() {
+		defer close(received)
+
+		for {
+			cmd := Command{}
+			if err := websocket.JSON.Receive(ws, &cmd); err != nil {
+				return
+			}
+
+			res := Message{Type: "result", ID: cmd.ID}
+			if err := b.exec(cmd); err != nil {
+				res.Error = err.Error()
+			}
+
+			select {
+			case out <- res:
+			case <-b.done:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case m := <-out:
+			if err := websocket.JSON.Send(ws, m); err != nil {
+				return
+			}
+
+		case <-received:
+			return
+
+		case <-b.done:
+			return
+		}
+	}
+}
+
+func (b *Bridge) exec(cmd Command) error {
+	switch cmd.Command {
+	case "setImage":
+		data, err := base64.StdEncoding.DecodeString(cmd.Image)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrCommandInvalid, err)
+		}
+		return b.device.SetKeyImageFromReader(cmd.Key, bytes.NewReader(data))
+
+	case "setColor":
+		c, err := parseColor(cmd.Color)
+		if err != nil {
+			return err
+		}
+		return b.device.SetKeyColor(cmd.Key, c)
+
+	case "setBrightness":
+		return b.device.SetBrightness(cmd.Brightness)
+	}
+	return fmt.Errorf("%w: %q", ErrCommandInvalid, cmd.Command)
+}
+
+func parseColor(s string) (color.Color, error) {
+	h, ok := strings.CutPrefix(s, "#")
+	if ok && len(h) == 3 {
+		h = string([]byte{h[0], h[0], h[1], h[1], h[2], h[2]})
+	}
+	if !ok || len(h) != 6 {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrColorInvalid, s)
+	}
+	return color.RGBA{R: byte(v >> 16), G: byte(v >> 8), B: byte(v), A: 0xff}, nil
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Rafael G. Martins. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package wsbridge
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"rafaelmartins.com/p/streamdeck"
+	"rafaelmartins.com/p/streamdeck/mock"
+)
+
+func assertColor(t *testing.T, img image.Image, x int, y int, want color.RGBA) {
+	t.Helper()
+
+	r, g, b, _ := img.At(x, y).RGBA()
+	for i, v := range [][2]uint32{{r >> 8, uint32(want.R)}, {g >> 8, uint32(want.G)}, {b >> 8, uint32(want.B)}} {
+		d := int(v[0]) - int(v[1])
+		if d < -16 || d > 16 {
+			t.Errorf("bad color at (%d, %d) channel %d: got %d, want %d", x, y, i, v[0], v[1])
+		}
+	}
+}
+
+func dial(t *testing.T, modelID string) (*streamdeck.Device, *mock.Device, *websocket.Conn) {
+	t.Helper()
+
+	dev, m, err := mock.Open(modelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dev.Close() })
+
+	b, err := New(dev)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(b)
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { b.Close() })
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), "", ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	return dev, m, ws
+}
+
+func receive(t *testing.T, ws *websocket.Conn) Message {
+	t.Helper()
+
+	if err := ws.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	rv := Message{}
+	if err := websocket.JSON.Receive(ws, &rv); err != nil {
+		t.Fatal(err)
+	}
+	return rv
+}
+
+func TestCommands(t *testing.T) {
+	_, m, ws := dial(t, "mk2")
+
+	img := image.NewRGBA(image.Rect(0, 0, 20, 20))
+	for y := range 20 {
+		for x := range 20 {
+			img.Set(x, y, color.RGBA{G: 0xff, A: 0xff})
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, cmd := range []Command{
+		{ID: 1, Command: "setImage", Key: streamdeck.KEY_2, Image: base64.StdEncoding.EncodeToString(buf.Bytes())},
+		{ID: 2, Command: "setColor", Key: streamdeck.KEY_5, Color: "#f80"},
+		{ID: 3, Command: "setBrightness", Brightness: 33},
+	} {
+		if err := websocket.JSON.Send(ws, cmd); err != nil {
+			t.Fatal(err)
+		}
+		if res := receive(t, ws); res.Type != "result" || res.ID != cmd.ID || res.Error != "" {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	}
+
+	assertColor(t, m.KeyImage(streamdeck.KEY_2), 36, 36, color.RGBA{G: 0xff, A: 0xff})
+	assertColor(t, m.KeyImage(streamdeck.KEY_5), 36, 36, color.RGBA{R: 0xff, G: 0x88, A: 0xff})
+	if m.Brightness() != 33 {
+		t.Errorf("unexpected brightness: %d", m.Brightness())
+	}
+
+	for _, cmd := range []Command{
+		{ID: 4, Command: "setImage", Key: streamdeck.KEY_2, Image: "!!!"},
+		{ID: 5, Command: "setColor", Key: streamdeck.KEY_2, Color: "red"},
+		{ID: 6, Command: "setColor", Key: 16, Color: "#fff"},
+		{ID: 7, Command: "bola"},
+	} {
+		if err := websocket.JSON.Send(ws, cmd); err != nil {
+			t.Fatal(err)
+		}
+		if res := receive(t, ws); res.Type != "result" || res.ID != cmd.ID || res.Error == "" {
+			t.Errorf("unexpected result: %+v", res)
+		}
+	}
+}
+
+func TestEvents(t *testing.T) {
+	dev, m, ws := dial(t, "plus")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go dev.ListenContext(ctx, nil)
+
+	if err := m.PressKey(streamdeck.KEY_7); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, ws); e.Type != "key_press" || e.Key != streamdeck.KEY_7 {
+		t.Errorf("unexpected event: %+v", e)
+	}
+
+	if err := m.SwipeTouchStrip(image.Pt(10, 20), image.Pt(300, 40)); err != nil {
+		t.Fatal(err)
+	}
+	if e := receive(t, ws); e.Type != "touch_strip_swipe" || e.Point == nil || *e.Point != (Point{X: 10, Y: 20}) || e.Destination == nil || *e.Destination != (Point{X: 300, Y: 40}) {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	dev, _, err := mock.Open("mk2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Close()
+
+	for _, tc := range []struct {
+		origins []string
+		origin  string
+		allowed bool
+	}{
+		{nil, "", true},
+		{nil, "http://evil.example.com", false},
+		{[]string{"https://Dashboard.example.com/"}, "https://dashboard.example.com", true},
+		{[]string{"https://dashboard.example.com"}, "http://dashboard.example.com", false},
+		{[]string{"*"}, "http://evil.example.com", true},
+	} {
+		b, err := NewWithOptions(dev, Options{AllowedOrigins: tc.origins})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := httptest.NewServer(b)
+
+		origin := tc.origin
+		if origin == "" {
+			origin = ts.URL
+		}
+		ws, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), "", origin)
+		if tc.allowed && err != nil {
+			t.Errorf("%v: %s: unexpected error: %v", tc.origins, origin, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Errorf("%v: %s: connection not rejected", tc.origins, origin)
+		}
+		if ws != nil {
+			ws.Close()
+		}
+		ts.Close()
+		b.Close()
+	}
+}